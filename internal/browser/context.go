@@ -6,26 +6,118 @@ import (
 
 	"browser-tools-go/internal/config"
 
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/target"
 	"github.com/chromedp/chromedp"
 )
 
+// PersistentOptions controls which tab of the persistent browser a context drives.
+type PersistentOptions struct {
+	// ReuseTab drives the browser's first open tab, typically the one the
+	// user is looking at, instead of the dedicated automation tab.
+	ReuseTab bool
+	// Target drives the first existing tab it matches instead, e.g. an
+	// already signed-in app. It is never opened when nothing matches.
+	Target *TabMatcher
+}
+
 // NewPersistentContext creates a new browser context connected to a persistent, remote browser instance.
-func NewPersistentContext() (context.Context, context.CancelFunc, error) {
+// Commands share a dedicated automation tab, opened by the first command of
+// the session and left open for the next ones, so the tabs the user browses
+// in are never navigated away.
+func NewPersistentContext(opts PersistentOptions) (context.Context, context.CancelFunc, error) {
 	info, err := config.LoadWsInfo()
 	if err != nil {
 		return nil, nil, fmt.Errorf("could not load browser session, is it running? Error: %w", err)
 	}
+	if err := CheckSession(info); err != nil {
+		return nil, nil, err
+	}
+	if !IsBrowserWebSocketURL(info.Url) {
+		// Sessions saved before the URL was resolved at start hold a bare ws://host:port.
+		resolved, err := ResolveWebSocketURL(context.Background(), info.Url)
+		if err != nil {
+			return nil, nil, err
+		}
+		info.Url = resolved
+		if err := config.WriteWsInfo(info); err != nil {
+			return nil, nil, fmt.Errorf("failed to save session info: %w", err)
+		}
+	}
 
 	allocCtx, cancel1 := chromedp.NewRemoteAllocator(context.Background(), info.Url)
-	ctx, cancel2 := chromedp.NewContext(allocCtx)
+	// Never run, so it connects to the browser without opening a tab.
+	browserCtx, cancel2 := chromedp.NewContext(allocCtx)
+	fail := func(err error) (context.Context, context.CancelFunc, error) {
+		cancel2()
+		cancel1()
+		return nil, nil, err
+	}
+
+	targets, err := chromedp.Targets(browserCtx)
+	if err != nil {
+		return fail(fmt.Errorf("failed to list tabs: %w", err))
+	}
+	var id target.ID
+	var ok bool
+	if opts.Target != nil {
+		tab, found := opts.Target.FindTab(targets)
+		if !found {
+			return fail(fmt.Errorf("no open tab matches %q", opts.Target))
+		}
+		id, ok = tab.TargetID, true
+	} else {
+		id, ok = selectTab(targets, target.ID(info.AutomationTab), opts.ReuseTab)
+	}
+	if !ok {
+		c := chromedp.FromContext(browserCtx)
+		id, err = target.CreateTarget("about:blank").Do(cdp.WithExecutor(browserCtx, c.Browser))
+		if err != nil {
+			return fail(fmt.Errorf("failed to open the automation tab: %w", err))
+		}
+		info.AutomationTab = string(id)
+		if err := config.WriteWsInfo(info); err != nil {
+			return fail(fmt.Errorf("failed to save the automation tab: %w", err))
+		}
+	}
+
+	ctx, release := AttachTab(browserCtx, id)
+	// Attach now: the tab's event loop lives as long as the context of the first Run.
+	if err := chromedp.Run(ctx); err != nil {
+		release()
+		return fail(fmt.Errorf("failed to attach to tab %s: %w", id, err))
+	}
 
 	cancel := func() {
+		release()
 		cancel2()
 		cancel1()
 	}
 	return ctx, cancel, nil
 }
 
+// selectTab picks the tab to drive among the browser's targets: the first
+// page with reuseTab, otherwise the automation tab. It returns false when
+// the tab has to be opened.
+func selectTab(targets []*target.Info, automationTab target.ID, reuseTab bool) (target.ID, bool) {
+	for _, t := range targets {
+		if t.Type != "page" {
+			continue
+		}
+		if reuseTab && t.TargetID != automationTab {
+			return t.TargetID, true
+		}
+		if !reuseTab && t.TargetID == automationTab {
+			return t.TargetID, true
+		}
+	}
+	if reuseTab && automationTab != "" {
+		// The automation tab is the only one left.
+		return selectTab(targets, automationTab, false)
+	}
+	return "", false
+}
+
 // NewTemporaryContext creates a new browser context with its own temporary browser instance.
 func NewTemporaryContext(headless bool) (context.Context, context.CancelFunc, error) {
 	opts := append(chromedp.DefaultExecAllocatorOptions[:],
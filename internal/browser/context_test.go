@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"browser-tools-go/internal/config"
+
+	"github.com/chromedp/cdproto/target"
 )
 
 // TestNewPersistentContext_NoSession はセッションが存在しない場合のエラーをテストします。
@@ -13,7 +15,7 @@ func TestNewPersistentContext_NoSession(t *testing.T) {
 	// 既存のセッションファイルがあれば削除
 	_ = config.RemoveWsInfo()
 
-	_, _, err := NewPersistentContext()
+	_, _, err := NewPersistentContext(PersistentOptions{})
 	if err == nil {
 		t.Error("Expected error when no session is running, got nil")
 	}
@@ -74,4 +76,37 @@ func TestWaitForWS_CancelledContext(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for cancelled context, got nil")
 	}
-}
\ No newline at end of file
+}
+// TestSelectTab は操作対象タブの選択をテストします。
+func TestSelectTab(t *testing.T) {
+	targets := []*target.Info{
+		{TargetID: "worker", Type: "service_worker"},
+		{TargetID: "user", Type: "page"},
+		{TargetID: "automation", Type: "page"},
+	}
+
+	tests := []struct {
+		name          string
+		targets       []*target.Info
+		automationTab target.ID
+		reuseTab      bool
+		want          target.ID
+		wantOK        bool
+	}{
+		{"automation tab open", targets, "automation", false, "automation", true},
+		{"automation tab closed", targets, "gone", false, "", false},
+		{"no automation tab yet", targets, "", false, "", false},
+		{"reuse first page", targets, "automation", true, "user", true},
+		{"reuse without automation tab", targets, "", true, "user", true},
+		{"reuse with only the automation tab", targets[2:], "automation", true, "automation", true},
+		{"reuse without pages", targets[:1], "", true, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := selectTab(tt.targets, tt.automationTab, tt.reuseTab)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("selectTab() = %q, %v; want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
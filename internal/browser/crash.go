@@ -0,0 +1,139 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"browser-tools-go/internal/config"
+
+	"github.com/chromedp/cdproto"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/inspector"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+)
+
+// ErrTabCrashed is matched by the error of a tab whose renderer crashed or
+// ran out of memory.
+var ErrTabCrashed = errors.New("tab crashed")
+
+// CrashError reports the crash of a tab.
+type CrashError struct {
+	TargetID target.ID
+	// Status is the termination status reported by the browser, such as
+	// "crashed" or "oom"; it is empty when only the tab itself reported it.
+	Status string
+}
+
+func (e *CrashError) Error() string {
+	if e.Status == "" {
+		return fmt.Sprintf("tab %s crashed", e.TargetID)
+	}
+	return fmt.Sprintf("tab %s crashed (%s)", e.TargetID, e.Status)
+}
+
+// Is makes errors.Is(err, ErrTabCrashed) true for a CrashError.
+func (e *CrashError) Is(target error) bool {
+	return target == ErrTabCrashed
+}
+
+// WatchCrash returns a context derived from ctx, which must be attached to a
+// tab, that is cancelled with a *CrashError as its cause when the tab crashes.
+// A crashed tab never answers again, so without it commands would hang until
+// their timeout; with it they fail at once and context.Cause tells why.
+//
+// The tab reports the crash of its renderer with Inspector.targetCrashed, and
+// the browser with Target.targetCrashed on its own session, along with the
+// termination status such as "oom". When the main frame starts loading, the
+// renderer is also probed, as one that dies while loading a document doesn't
+// always report it on the tab's session.
+func WatchCrash(ctx context.Context) (context.Context, context.CancelFunc) {
+	watched, cancel := context.WithCancelCause(ctx)
+	c := chromedp.FromContext(ctx)
+	if c == nil || c.Target == nil {
+		return watched, func() { cancel(context.Canceled) }
+	}
+	id := c.Target.TargetID
+	chromedp.ListenTarget(watched, func(ev interface{}) {
+		switch ev := ev.(type) {
+		case *inspector.EventTargetCrashed:
+			cancel(&CrashError{TargetID: id})
+		case *page.EventFrameStartedLoading:
+			// The main frame of a tab has the id of its target.
+			if ev.FrameID == cdp.FrameID(id) {
+				go probeRenderer(watched, c.Target, id, cancel)
+			}
+		}
+	})
+	chromedp.ListenBrowser(watched, func(ev interface{}) {
+		if ev, ok := ev.(*target.EventTargetCrashed); ok && ev.TargetID == id {
+			cancel(&CrashError{TargetID: id, Status: ev.Status})
+		}
+	})
+	return watched, func() { cancel(context.Canceled) }
+}
+
+// rendererProbeTimeout bounds probeRenderer; a renderer busy loading a page
+// that doesn't answer in time isn't taken for a crashed one.
+const rendererProbeTimeout = 10 * time.Second
+
+// probeRenderer evaluates an expression in the tab and cancels with a
+// CrashError when the browser answers that the target crashed.
+func probeRenderer(ctx context.Context, executor cdp.Executor, id target.ID, cancel context.CancelCauseFunc) {
+	probeCtx, stop := context.WithTimeout(ctx, rendererProbeTimeout)
+	defer stop()
+	_, _, err := runtime.Evaluate("1").Do(cdp.WithExecutor(probeCtx, executor))
+	if isTargetCrashedError(err) {
+		cancel(&CrashError{TargetID: id})
+	}
+}
+
+// isTargetCrashedError reports whether err is the answer of the browser to a
+// command sent to a crashed target.
+func isTargetCrashedError(err error) bool {
+	var cdpErr *cdproto.Error
+	return errors.As(err, &cdpErr) && strings.EqualFold(cdpErr.Message, "Target crashed")
+}
+
+// TabCrash returns the crash that cancelled a context returned by WatchCrash,
+// or nil when its tab didn't crash.
+func TabCrash(ctx context.Context) *CrashError {
+	var crash *CrashError
+	if errors.As(context.Cause(ctx), &crash) {
+		return crash
+	}
+	return nil
+}
+
+// DiscardCrashedTab closes the crashed automation tab of the session, so that
+// the next command opens a new one in its place. Other tabs belong to the user
+// and are left for them to reload.
+func DiscardCrashedTab(id target.ID) error {
+	info, err := config.LoadWsInfo()
+	if err != nil {
+		return fmt.Errorf("browser is not running")
+	}
+	if info.AutomationTab != string(id) {
+		return fmt.Errorf("tab %s isn't the automation tab; reload it to recover it", id)
+	}
+	ctx, cancel, err := connectBrowser(info.Url, resolveTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to browser: %w", err)
+	}
+	defer cancel()
+
+	c := chromedp.FromContext(ctx)
+	if err := target.CloseTarget(id).Do(cdp.WithExecutor(ctx, c.Browser)); err != nil {
+		return fmt.Errorf("failed to close the crashed tab: %w", err)
+	}
+	info.AutomationTab = ""
+	if err := config.WriteWsInfo(info); err != nil {
+		return fmt.Errorf("failed to save session info: %w", err)
+	}
+	return nil
+}
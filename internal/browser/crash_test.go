@@ -0,0 +1,54 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/chromedp/cdproto"
+)
+
+// TestCrashError はクラッシュエラーの表示と照合をテストします。
+func TestCrashError(t *testing.T) {
+	crash := &CrashError{TargetID: "ABC123", Status: "oom"}
+	if got := crash.Error(); got != "tab ABC123 crashed (oom)" {
+		t.Errorf("Error() = %q", got)
+	}
+	if got := (&CrashError{TargetID: "ABC123"}).Error(); got != "tab ABC123 crashed" {
+		t.Errorf("Error() without a status = %q", got)
+	}
+	if !errors.Is(fmt.Errorf("failed to navigate: %w", crash), ErrTabCrashed) {
+		t.Error("Expected a wrapped CrashError to match ErrTabCrashed")
+	}
+}
+
+// TestTabCrash はコンテキストのキャンセル理由からのクラッシュ取得をテストします。
+func TestTabCrash(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	if crash := TabCrash(ctx); crash != nil {
+		t.Errorf("Expected no crash while the context is alive, got %v", crash)
+	}
+	cancel(&CrashError{TargetID: "ABC123", Status: "crashed"})
+	if crash := TabCrash(ctx); crash == nil || crash.Status != "crashed" {
+		t.Errorf("Expected the crash, got %v", crash)
+	}
+
+	ctx, cancel = context.WithCancelCause(context.Background())
+	cancel(context.Canceled)
+	if crash := TabCrash(ctx); crash != nil {
+		t.Errorf("Expected no crash for a cancelled context, got %v", crash)
+	}
+}
+
+// TestIsTargetCrashedError はクラッシュしたターゲットへのコマンドのエラー判定をテストします。
+func TestIsTargetCrashedError(t *testing.T) {
+	if !isTargetCrashedError(fmt.Errorf("evaluate: %w", &cdproto.Error{Code: -32000, Message: "Target crashed"})) {
+		t.Error("Expected the crashed target error to be detected")
+	}
+	for _, err := range []error{nil, &cdproto.Error{Code: -32000, Message: "Execution context was destroyed."}, context.DeadlineExceeded} {
+		if isTargetCrashedError(err) {
+			t.Errorf("Expected %v not to be taken for a crash", err)
+		}
+	}
+}
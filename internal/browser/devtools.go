@@ -0,0 +1,199 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/models"
+
+	cdpbrowser "github.com/chromedp/cdproto/browser"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+)
+
+// resolveTimeout bounds asking the DevTools HTTP endpoint for the WebSocket URL.
+const resolveTimeout = 5 * time.Second
+
+// browserPathPrefix starts the path of browser-level DevTools WebSocket URLs.
+const browserPathPrefix = "/devtools/browser/"
+
+// IsBrowserWebSocketURL reports whether wsURL is a full browser WebSocket
+// URL, as opposed to a bare ws://host:port.
+func IsBrowserWebSocketURL(wsURL string) bool {
+	u, err := url.Parse(wsURL)
+	return err == nil && strings.HasPrefix(u.Path, browserPathPrefix)
+}
+
+// devtoolsHTTPURL returns the URL of path on the DevTools HTTP endpoint of
+// the browser at wsURL, e.g. http://127.0.0.1:9222/json/version.
+func devtoolsHTTPURL(wsURL, path string) (string, error) {
+	u, err := url.Parse(wsURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid DevTools URL %q", wsURL)
+	}
+	scheme := "http"
+	if u.Scheme == "wss" || u.Scheme == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + u.Host + path, nil
+}
+
+// ResolveWebSocketURL asks the DevTools HTTP endpoint of the browser at
+// wsURL (/json/version) for its actual WebSocket URL, including the
+// /devtools/browser/<id> path.
+func ResolveWebSocketURL(ctx context.Context, wsURL string) (string, error) {
+	endpoint, err := devtoolsHTTPURL(wsURL, "/json/version")
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s returned %s", endpoint, resp.Status)
+	}
+
+	var version struct {
+		WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return "", fmt.Errorf("invalid response from %s: %w", endpoint, err)
+	}
+	if !IsBrowserWebSocketURL(version.WebSocketDebuggerURL) {
+		return "", fmt.Errorf("%s returned no browser WebSocket URL", endpoint)
+	}
+	return version.WebSocketDebuggerURL, nil
+}
+
+// connectBrowser connects to the browser at wsURL without opening or
+// attaching to a tab, for browser-level commands. timeout bounds the
+// returned context.
+func connectBrowser(wsURL string, timeout time.Duration) (context.Context, context.CancelFunc, error) {
+	timeoutCtx, cancelTimeout := context.WithTimeout(context.Background(), timeout)
+	allocCtx, cancelAlloc := chromedp.NewRemoteAllocator(timeoutCtx, wsURL)
+	// Never run, so it connects to the browser without opening a tab.
+	ctx, cancelBrowser := chromedp.NewContext(allocCtx)
+	cancel := func() {
+		cancelBrowser()
+		cancelAlloc()
+		cancelTimeout()
+	}
+	if _, err := chromedp.Targets(ctx); err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return ctx, cancel, nil
+}
+
+// BrowserVersion asks the browser of the current session for its version.
+func BrowserVersion() (*models.BrowserInfo, error) {
+	info, err := config.LoadWsInfo()
+	if err != nil {
+		return nil, fmt.Errorf("browser is not running")
+	}
+	if err := CheckSession(info); err != nil {
+		return nil, err
+	}
+	ctx, cancel, err := connectBrowser(info.Url, resolveTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to browser: %w", err)
+	}
+	defer cancel()
+
+	c := chromedp.FromContext(ctx)
+	protocol, product, revision, userAgent, jsVersion, err := cdpbrowser.GetVersion().Do(cdp.WithExecutor(ctx, c.Browser))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get browser version: %w", err)
+	}
+	return &models.BrowserInfo{
+		Product:         product,
+		Revision:        revision,
+		ProtocolVersion: protocol,
+		UserAgent:       userAgent,
+		JSVersion:       jsVersion,
+	}, nil
+}
+
+// SessionTabs returns the open tabs of the browser of the current session,
+// without attaching to any of them.
+func SessionTabs() ([]*target.Info, error) {
+	info, err := config.LoadWsInfo()
+	if err != nil {
+		return nil, fmt.Errorf("browser is not running")
+	}
+	if err := CheckSession(info); err != nil {
+		return nil, err
+	}
+	ctx, cancel, err := connectBrowser(info.Url, resolveTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to browser: %w", err)
+	}
+	defer cancel()
+
+	targets, err := chromedp.Targets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tabs: %w", err)
+	}
+	tabs := make([]*target.Info, 0, len(targets))
+	for _, t := range targets {
+		if t.Type == "page" {
+			tabs = append(tabs, t)
+		}
+	}
+	return tabs, nil
+}
+
+// Ping checks that the browser of the current session answers: it connects
+// at the browser level, without touching any tab, and round-trips
+// Browser.getVersion, the cheapest DevTools call. Both steps are timed; the
+// whole check takes at most timeout.
+func Ping(timeout time.Duration) (*models.PingResult, error) {
+	info, err := config.LoadWsInfo()
+	if err != nil {
+		return nil, fmt.Errorf("browser is not running")
+	}
+	if err := CheckSession(info); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	ctx, cancel, err := connectBrowser(info.Url, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to browser: %w", err)
+	}
+	defer cancel()
+	connected := time.Now()
+
+	c := chromedp.FromContext(ctx)
+	_, product, _, _, _, err := cdpbrowser.GetVersion().Do(cdp.WithExecutor(ctx, c.Browser))
+	if err != nil {
+		return nil, fmt.Errorf("browser did not answer: %w", err)
+	}
+	return &models.PingResult{
+		OK:        true,
+		Product:   product,
+		ConnectMs: milliseconds(connected.Sub(start)),
+		LatencyMs: milliseconds(time.Since(connected)),
+		CheckedAt: start.UTC(),
+	}, nil
+}
+
+// milliseconds returns d in fractional milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,89 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestResolveWebSocketURL は/json/versionからWebSocket URLを取得できることをテストします。
+func TestResolveWebSocketURL(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/version" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "browser url",
+			body: `{"Browser":"Chrome/131.0.0.0","webSocketDebuggerUrl":"ws://` + host + `/devtools/browser/0c4f1d2e-guid"}`,
+			want: "ws://" + host + "/devtools/browser/0c4f1d2e-guid",
+		},
+		{name: "missing url", body: `{"Browser":"Chrome/131.0.0.0"}`, wantErr: true},
+		{name: "invalid json", body: `not json`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body = tt.body
+			got, err := ResolveWebSocketURL(context.Background(), "ws://"+host)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveWebSocketURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveWebSocketURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestIsBrowserWebSocketURL はWebSocket URLの判定をテストします。
+func TestIsBrowserWebSocketURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"ws://127.0.0.1:9222/devtools/browser/abc", true},
+		{"wss://remote.example.com/devtools/browser/abc", true},
+		{"ws://127.0.0.1:9222", false},
+		{"ws://127.0.0.1:9222/devtools/page/abc", false},
+	}
+	for _, tt := range tests {
+		if got := IsBrowserWebSocketURL(tt.url); got != tt.want {
+			t.Errorf("IsBrowserWebSocketURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+// TestDevtoolsHTTPURL はWebSocket URLからHTTPエンドポイントを導けることをテストします。
+func TestDevtoolsHTTPURL(t *testing.T) {
+	tests := []struct {
+		wsURL   string
+		want    string
+		wantErr bool
+	}{
+		{"ws://127.0.0.1:9222", "http://127.0.0.1:9222/json/version", false},
+		{"ws://127.0.0.1:9222/devtools/browser/abc", "http://127.0.0.1:9222/json/version", false},
+		{"wss://remote.example.com/devtools/browser/abc", "https://remote.example.com/json/version", false},
+		{"not a url", "", true},
+	}
+	for _, tt := range tests {
+		got, err := devtoolsHTTPURL(tt.wsURL, "/json/version")
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("devtoolsHTTPURL(%q) = %q, %v; want %q", tt.wsURL, got, err, tt.want)
+		}
+	}
+}
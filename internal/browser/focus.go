@@ -0,0 +1,52 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	cdpbrowser "github.com/chromedp/cdproto/browser"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+)
+
+// ErrHeadless is returned by FocusTab when the browser has no window to show.
+var ErrHeadless = errors.New("the browser runs headless and has no window; start it without --headless")
+
+// FocusTab shows the tab of ctx to the user: its window is restored when
+// minimized and raised, and the tab is brought to the front, so that an
+// operator can take over.
+func FocusTab(ctx context.Context) error {
+	if err := chromedp.Run(ctx); err != nil {
+		return err
+	}
+	c := chromedp.FromContext(ctx)
+	if c == nil || c.Target == nil {
+		return fmt.Errorf("no tab to focus")
+	}
+	browserCtx := cdp.WithExecutor(ctx, c.Browser)
+	_, product, _, userAgent, _, err := cdpbrowser.GetVersion().Do(browserCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get the browser version: %w", err)
+	}
+	if strings.Contains(product, "Headless") || strings.Contains(userAgent, "Headless") {
+		return ErrHeadless
+	}
+
+	id := c.Target.TargetID
+	windowID, _, err := cdpbrowser.GetWindowForTarget().WithTargetID(id).Do(browserCtx)
+	if err != nil {
+		return fmt.Errorf("failed to find the window of the tab: %w", err)
+	}
+	// A minimized window must be restored before it can be raised.
+	if err := cdpbrowser.SetWindowBounds(windowID, &cdpbrowser.Bounds{WindowState: cdpbrowser.WindowStateNormal}).Do(browserCtx); err != nil {
+		return fmt.Errorf("failed to restore the window: %w", err)
+	}
+	if err := target.ActivateTarget(id).Do(browserCtx); err != nil {
+		return fmt.Errorf("failed to activate the tab: %w", err)
+	}
+	return chromedp.Run(ctx, page.BringToFront())
+}
@@ -0,0 +1,307 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/utils"
+
+	cdpbrowser "github.com/chromedp/cdproto/browser"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/chromedp"
+)
+
+// closeTimeout bounds waiting for the browser to exit after asking it to close.
+const closeTimeout = 5 * time.Second
+
+// chromeExecutables are the Chrome executables looked up in PATH.
+var chromeExecutables = []string{"google-chrome", "chrome", "chromium"}
+
+// findChrome returns the path of the Chrome executable to launch: the first
+// of chromeExecutables found in PATH, otherwise the first existing path of
+// the platform's usual install locations.
+func findChrome() (string, error) {
+	for _, executable := range chromeExecutables {
+		if path, err := exec.LookPath(executable); err == nil {
+			return path, nil
+		}
+	}
+	for _, path := range chromeInstallPaths() {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("could not find Chrome installation")
+}
+
+// StartOptions configures Start.
+type StartOptions struct {
+	Port     int
+	Headless bool
+	// UserDataDir is the Chrome profile directory; empty means user-data in
+	// the config directory. Separate directories keep isolated profiles
+	// that can be backed up on their own.
+	UserDataDir string
+	// ProfileDirectory picks a profile inside UserDataDir, such as
+	// "Profile 1"; empty means Chrome's default one.
+	ProfileDirectory string
+}
+
+// userDataDir returns the absolute user data directory of the options.
+func (o StartOptions) userDataDir() (string, error) {
+	if o.UserDataDir == "" {
+		configDir, err := config.GetConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("could not determine config directory: %w", err)
+		}
+		return filepath.Join(configDir, "user-data"), nil
+	}
+	path, err := utils.GetSafeAbsolutePath(o.UserDataDir, "")
+	if err != nil {
+		return "", fmt.Errorf("invalid user data directory %q: %w", o.UserDataDir, err)
+	}
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		return "", fmt.Errorf("user data directory %s is not a directory", path)
+	}
+	return path, nil
+}
+
+// validateProfileDirectory checks the name of a profile directory, which
+// must be a single directory name inside the user data directory.
+func validateProfileDirectory(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := utils.ValidateFilePath(name, false, ""); err != nil || name == "." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid profile directory %q: expected a directory name such as \"Profile 1\"", name)
+	}
+	return nil
+}
+
+// Start launches a new persistent Chrome instance.
+func Start(opts StartOptions) error {
+	if info, err := config.LoadWsInfo(); err == nil {
+		if err := CheckSession(info); err != nil {
+			return err
+		}
+		return fmt.Errorf("browser is already running. Use 'close' to stop it first")
+	}
+	userDataDir, err := opts.userDataDir()
+	if err != nil {
+		return err
+	}
+	if err := validateProfileDirectory(opts.ProfileDirectory); err != nil {
+		return err
+	}
+
+	chromePath, err := findChrome()
+	if err != nil {
+		return err
+	}
+
+	chromeArgs := []string{
+		fmt.Sprintf("--remote-debugging-port=%d", opts.Port),
+		fmt.Sprintf("--user-data-dir=%s", userDataDir),
+	}
+	if opts.ProfileDirectory != "" {
+		chromeArgs = append(chromeArgs, fmt.Sprintf("--profile-directory=%s", opts.ProfileDirectory))
+	}
+	if opts.Headless {
+		chromeArgs = append(chromeArgs, "--headless=new")
+	}
+
+	proc := exec.Command(chromePath, chromeArgs...)
+	configureProcess(proc)
+
+	if err := proc.Start(); err != nil {
+		return fmt.Errorf("failed to start Chrome: %w", err)
+	}
+
+	wsURL := fmt.Sprintf("ws://127.0.0.1:%d", opts.Port)
+	i18n.Printf("⏳ Waiting for browser to be ready at %s...", wsURL)
+	if err := WaitForWS(context.Background(), wsURL, 5*time.Second); err != nil {
+		_ = proc.Process.Kill()
+		return fmt.Errorf("error waiting for browser: %w", err)
+	}
+	wsURL, err = ResolveWebSocketURL(context.Background(), wsURL)
+	if err != nil {
+		_ = proc.Process.Kill()
+		return fmt.Errorf("error resolving the browser WebSocket URL: %w", err)
+	}
+
+	if err := config.SaveWsInfo(wsURL, proc.Process.Pid); err != nil {
+		_ = proc.Process.Kill()
+		return fmt.Errorf("failed to save session info: %w", err)
+	}
+
+	i18n.Printf("✅ Browser started successfully with PID %d.", proc.Process.Pid)
+	return nil
+}
+
+// Close terminates the persistent Chrome instance. It asks the browser to
+// close over the DevTools connection first, so the profile is written out,
+// and terminates the process only when that fails or takes too long. The
+// session file is only removed once the process has exited and its debugging
+// port is released, so that a browser still shutting down isn't mistaken for
+// a closed one; the process is never killed outright, as that may corrupt
+// the user data directory.
+//
+// A session whose debugging port nobody listens on is stale: its browser is
+// gone and the PID may have been reused by another process, so the session
+// file is removed without signalling the process.
+//
+// With force, a browser that doesn't exit when asked to terminate is killed
+// and the session file is removed whatever state the browser is in, which
+// cleans up stale sessions; failing to kill the process, e.g. one of another
+// user, is only a warning.
+func Close(force bool) error {
+	info, err := config.LoadWsInfo()
+	if err != nil {
+		return fmt.Errorf("browser is not running")
+	}
+
+	if !listening(info.Url) {
+		i18n.Printf("⚠️ Nothing listens at %s; removing the stale session without signalling process %d.", info.Url, info.Pid)
+		return removeSession()
+	}
+
+	i18n.Printf("🛑 Closing browser with PID %d...", info.Pid)
+	exited := false
+	if err := closeViaDevTools(info.Url); err != nil {
+		i18n.Printf("⚠️ Could not close the browser over DevTools: %v. Terminating the process.", err)
+	} else if exited = waitForExit(info.Pid, closeTimeout); !exited {
+		i18n.Printf("⚠️ Browser still running after %v. Terminating the process.", closeTimeout)
+	}
+	if !exited && processAlive(info.Pid) {
+		if err := stopProcess(info.Pid, force); err != nil {
+			if !force {
+				return err
+			}
+			i18n.Printf("⚠️ %v. Removing the session anyway.", err)
+		}
+	}
+	if err := waitForPortRelease(info.Url, closeTimeout); err != nil {
+		if !force {
+			return err
+		}
+		i18n.Printf("⚠️ %v. Removing the session anyway.", err)
+	}
+	return removeSession()
+}
+
+// removeSession removes the session file of a closed browser.
+func removeSession() error {
+	if err := config.RemoveWsInfo(); err != nil {
+		return fmt.Errorf("failed to remove session file: %w", err)
+	}
+	i18n.Println("✅ Browser session closed and cleaned up.")
+	return nil
+}
+
+// stopProcess terminates the process pid and waits until it exits. With
+// force, a process that doesn't exit is killed.
+func stopProcess(pid int, force bool) error {
+	err := terminateProcess(pid)
+	if err == nil && waitForExit(pid, closeTimeout) {
+		return nil
+	}
+	if !force {
+		if err != nil {
+			return fmt.Errorf("failed to terminate the browser process %d: %w", pid, err)
+		}
+		return fmt.Errorf("browser process %d is still running %v after it was asked to terminate; use 'close --force' to kill it", pid, closeTimeout)
+	}
+	i18n.Printf("⚠️ Killing browser process %d.", pid)
+	if err := killProcess(pid); err != nil {
+		return fmt.Errorf("failed to kill the browser process %d: %w", pid, err)
+	}
+	if !waitForExit(pid, closeTimeout) {
+		return fmt.Errorf("browser process %d is still running after it was killed", pid)
+	}
+	return nil
+}
+
+// ErrStaleSession is matched by the error of CheckSession for a session whose
+// browser is gone.
+var ErrStaleSession = errors.New("stale browser session")
+
+// CheckSession reports a stale session: one whose browser process is no
+// longer running, or doesn't listen on its debugging port, e.g. after a crash
+// or a reboot. Commands would only fail to connect to it.
+//
+// The debugging port is checked first: a browser that doesn't listen on it is
+// gone even when a process with its PID, possibly another one, is running.
+// 'close' removes such a session without signalling the process.
+func CheckSession(info *config.WsInfo) error {
+	if !listening(info.Url) {
+		return fmt.Errorf("%w: nothing listens at %s; clean it up with 'browser-tools-go close'", ErrStaleSession, info.Url)
+	}
+	if info.Pid > 0 && !processAlive(info.Pid) {
+		return fmt.Errorf("%w: browser process %d is not running; clean it up with 'browser-tools-go close --force'", ErrStaleSession, info.Pid)
+	}
+	return nil
+}
+
+// closeViaDevTools sends Browser.close to the browser at wsURL.
+func closeViaDevTools(wsURL string) error {
+	ctx, cancel, err := connectBrowser(wsURL, closeTimeout)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	c := chromedp.FromContext(ctx)
+	return cdpbrowser.Close().Do(cdp.WithExecutor(ctx, c.Browser))
+}
+
+// waitForExit polls until the process pid exits or timeout passes, and
+// reports whether it exited.
+func waitForExit(pid int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		if !processAlive(pid) {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// waitForPortRelease polls until nothing listens on the host and port of
+// wsURL any more, for at most timeout.
+func waitForPortRelease(wsURL string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for listening(wsURL) {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the debugging port of %s is still in use %v after the browser exited", wsURL, timeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return nil
+}
+
+// listening reports whether something accepts connections on the host and
+// port of wsURL.
+func listening(wsURL string) bool {
+	u, err := url.Parse(wsURL)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	conn, err := net.DialTimeout("tcp", u.Host, 200*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
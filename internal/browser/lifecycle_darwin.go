@@ -0,0 +1,37 @@
+//go:build darwin
+
+package browser
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// macApps are the Chromium-based browsers start can drive on macOS, in
+// order of preference, with the executable inside their app bundle.
+var macApps = []struct {
+	bundle     string
+	executable string
+}{
+	{"Google Chrome.app", "Google Chrome"},
+	{"Chromium.app", "Chromium"},
+	{"Microsoft Edge.app", "Microsoft Edge"},
+	{"Google Chrome Canary.app", "Google Chrome Canary"},
+}
+
+// chromeInstallPaths returns where Chrome is installed outside PATH: the
+// app bundles in /Applications, then in ~/Applications.
+func chromeInstallPaths() []string {
+	dirs := []string{"/Applications"}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, "Applications"))
+	}
+
+	var paths []string
+	for _, app := range macApps {
+		for _, dir := range dirs {
+			paths = append(paths, filepath.Join(dir, app.bundle, "Contents", "MacOS", app.executable))
+		}
+	}
+	return paths
+}
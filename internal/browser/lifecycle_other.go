@@ -0,0 +1,9 @@
+//go:build !windows && !darwin
+
+package browser
+
+// chromeInstallPaths returns where Chrome is installed outside PATH; on
+// Linux and the BSDs it is always found in PATH.
+func chromeInstallPaths() []string {
+	return nil
+}
@@ -0,0 +1,200 @@
+package browser
+
+import (
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"browser-tools-go/internal/config"
+)
+
+// TestProcessAlive はプロセスの生存確認をテストします。
+func TestProcessAlive(t *testing.T) {
+	if !processAlive(os.Getpid()) {
+		t.Error("Expected the test process to be alive")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("os.Executable() error = %v", err)
+	}
+	// 何もせず終了するテストバイナリを子プロセスとして起動
+	child := exec.Command(exe, "-test.run=^$")
+	if err := child.Run(); err != nil {
+		t.Fatalf("Failed to run child process: %v", err)
+	}
+	if processAlive(child.Process.Pid) {
+		t.Errorf("Expected exited process %d not to be alive", child.Process.Pid)
+	}
+}
+
+// TestWaitForExit は終了待ちのタイムアウトをテストします。
+func TestWaitForExit(t *testing.T) {
+	start := time.Now()
+	if waitForExit(os.Getpid(), 200*time.Millisecond) {
+		t.Error("Expected waiting for the running test process to time out")
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Expected waitForExit to wait for the timeout, returned after %v", elapsed)
+	}
+}
+
+// TestChromeInstallPaths はWindowsでの標準インストール先をテストします。
+func TestChromeInstallPaths(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("install paths are only listed on Windows")
+	}
+	t.Setenv("LocalAppData", `C:\Users\test\AppData\Local`)
+	found := false
+	for _, path := range chromeInstallPaths() {
+		if path == `C:\Users\test\AppData\Local\Google\Chrome\Application\chrome.exe` {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the per-user install path, got %v", chromeInstallPaths())
+	}
+}
+
+// TestChromeInstallPaths_Darwin はmacOSでのアプリバンドルの探索順をテストします。
+func TestChromeInstallPaths_Darwin(t *testing.T) {
+	if runtime.GOOS != "darwin" {
+		t.Skip("app bundles are only listed on macOS")
+	}
+	t.Setenv("HOME", "/Users/test")
+	paths := chromeInstallPaths()
+	want := []string{
+		"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+		"/Users/test/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+		"/Applications/Chromium.app/Contents/MacOS/Chromium",
+	}
+	if len(paths) < len(want) {
+		t.Fatalf("Expected at least %d paths, got %v", len(want), paths)
+	}
+	for i, path := range want {
+		if paths[i] != path {
+			t.Errorf("paths[%d] = %q, want %q", i, paths[i], path)
+		}
+	}
+	found := false
+	for _, path := range paths {
+		if path == "/Applications/Microsoft Edge.app/Contents/MacOS/Microsoft Edge" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected Microsoft Edge to be looked up")
+	}
+}
+
+// TestWaitForPortRelease はデバッグポートの解放待ちをテストします。
+func TestWaitForPortRelease(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	wsURL := "ws://" + ln.Addr().String() + "/devtools/browser/abc"
+	if err := waitForPortRelease(wsURL, 200*time.Millisecond); err == nil {
+		t.Error("Expected an error while the port is still in use")
+	}
+
+	ln.Close()
+	if err := waitForPortRelease(wsURL, 200*time.Millisecond); err != nil {
+		t.Errorf("Expected the released port to be detected, got %v", err)
+	}
+}
+
+// TestCheckSession は古いセッションの検出をテストします。
+func TestCheckSession(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer ln.Close()
+	wsURL := "ws://" + ln.Addr().String() + "/devtools/browser/abc"
+
+	if err := CheckSession(&config.WsInfo{Url: wsURL, Pid: os.Getpid()}); err != nil {
+		t.Errorf("Expected a live session, got %v", err)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("os.Executable() error = %v", err)
+	}
+	child := exec.Command(exe, "-test.run=^$")
+	if err := child.Run(); err != nil {
+		t.Fatalf("Failed to run child process: %v", err)
+	}
+	if err := CheckSession(&config.WsInfo{Url: wsURL, Pid: child.Process.Pid}); !errors.Is(err, ErrStaleSession) {
+		t.Errorf("Expected a stale session for an exited process, got %v", err)
+	}
+	if err := CheckSession(&config.WsInfo{Url: "ws://127.0.0.1:1/devtools/browser/abc", Pid: os.Getpid()}); !errors.Is(err, ErrStaleSession) {
+		t.Errorf("Expected a stale session for a closed port, got %v", err)
+	}
+}
+
+// TestClose_StaleSession は待ち受けていないセッションをプロセスにシグナルを送らずに削除することをテストします。
+func TestClose_StaleSession(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	// テストプロセス自身のPIDを記録し、シグナルが送られないことを確認
+	if err := config.SaveWsInfo("ws://127.0.0.1:1/devtools/browser/abc", os.Getpid()); err != nil {
+		t.Fatalf("SaveWsInfo() error = %v", err)
+	}
+
+	if err := Close(false); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := config.LoadWsInfo(); err == nil {
+		t.Error("Expected the stale session file to be removed")
+	}
+	if !processAlive(os.Getpid()) {
+		t.Error("Expected the recorded process not to be signalled")
+	}
+}
+
+// TestStartOptions_UserDataDir はユーザーデータディレクトリの解決をテストします。
+func TestStartOptions_UserDataDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir, err := StartOptions{}.userDataDir()
+	if err != nil || dir != filepath.Join(home, ".browser-tools-go", "user-data") {
+		t.Errorf("Expected the default directory, got %q, %v", dir, err)
+	}
+
+	custom := filepath.Join(t.TempDir(), "work")
+	if dir, err := (StartOptions{UserDataDir: custom}).userDataDir(); err != nil || dir != custom {
+		t.Errorf("Expected %q, got %q, %v", custom, dir, err)
+	}
+	for _, path := range []string{"../profiles", "~/profiles"} {
+		if _, err := (StartOptions{UserDataDir: path}).userDataDir(); err == nil {
+			t.Errorf("Expected %q to be rejected", path)
+		}
+	}
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (StartOptions{UserDataDir: file}).userDataDir(); err == nil {
+		t.Error("Expected a file to be rejected")
+	}
+}
+
+// TestValidateProfileDirectory はプロファイルディレクトリ名の検証をテストします。
+func TestValidateProfileDirectory(t *testing.T) {
+	for _, name := range []string{"", "Default", "Profile 1"} {
+		if err := validateProfileDirectory(name); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", name, err)
+		}
+	}
+	for _, name := range []string{".", "..", "../Profile 1", "Profile/1", `Profile\1`, "/tmp/profile"} {
+		if err := validateProfileDirectory(name); err == nil {
+			t.Errorf("Expected %q to be rejected", name)
+		}
+	}
+}
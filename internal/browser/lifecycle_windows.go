@@ -3,107 +3,62 @@
 package browser
 
 import (
-	"context"
 	"fmt"
-	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"syscall"
-	"time"
-
-	"browser-tools-go/internal/config"
 )
 
-func mustGetConfigPath() string {
-	path, err := config.GetConfigPath()
-	if err != nil {
-		log.Fatalf("Could not determine config path: %v", err)
-	}
-	return path
-}
-
-// Start launches a new persistent Chrome instance.
-func Start(port int, headless bool) error {
-	if _, err := config.LoadWsInfo(); err == nil {
-		return fmt.Errorf("browser is already running. Use 'close' to stop it first")
-	}
-
-	var chromePath string
-	for _, executable := range []string{"google-chrome", "chrome", "chromium"} {
-		path, err := exec.LookPath(executable)
-		if err == nil {
-			chromePath = path
-			break
-		}
-	}
+// createNewProcessGroup keeps Ctrl+C in the console that ran start from reaching Chrome.
+const createNewProcessGroup = 0x00000200
 
-	if chromePath == "" {
-		for _, path := range []string{
-			"C:\\Program Files\\Google\\Chrome\\Application\\chrome.exe",
-			"C:\\Program Files (x86)\\Google\\Chrome\\Application\\chrome.exe",
-		} {
-			if _, err := os.Stat(path); err == nil {
-				chromePath = path
-				break
-			}
+// chromeInstallPaths returns where Chrome is installed outside PATH: the
+// system-wide and per-user install locations.
+func chromeInstallPaths() []string {
+	var paths []string
+	for _, env := range []string{"ProgramFiles", "ProgramFiles(x86)", "LocalAppData"} {
+		if dir := os.Getenv(env); dir != "" {
+			paths = append(paths, filepath.Join(dir, "Google", "Chrome", "Application", "chrome.exe"))
 		}
 	}
+	return paths
+}
 
-	if chromePath == "" {
-		return fmt.Errorf("could not find Chrome installation")
-	}
-
-	userDataDir := strings.Replace(mustGetConfigPath(), "ws.json", "user-data", 1)
-	chromeArgs := []string{
-		fmt.Sprintf("--remote-debugging-port=%d", port),
-		fmt.Sprintf("--user-data-dir=%s", userDataDir),
-	}
-	if headless {
-		chromeArgs = append(chromeArgs, "--headless=new")
-	}
-
-	proc := exec.Command(chromePath, chromeArgs...)
-	proc.SysProcAttr = &syscall.SysProcAttr{CreationFlags: 0x00000200} // CREATE_NEW_PROCESS_GROUP
-
-	if err := proc.Start(); err != nil {
-		return fmt.Errorf("failed to start Chrome: %w", err)
-	}
-
-	wsURL := fmt.Sprintf("ws://127.0.0.1:%d", port)
-	log.Printf("⏳ Waiting for browser to be ready at %s...", wsURL)
-	if err := WaitForWS(context.Background(), wsURL, 5*time.Second); err != nil {
-		_ = proc.Process.Kill()
-		return fmt.Errorf("error waiting for browser: %w", err)
-	}
-
-	if err := config.SaveWsInfo(wsURL, proc.Process.Pid); err != nil {
-		_ = proc.Process.Kill()
-		return fmt.Errorf("failed to save session info: %w", err)
-	}
-
-	log.Printf("✅ Browser started successfully with PID %d.", proc.Process.Pid)
-	return nil
+// configureProcess prepares the Chrome process before it starts.
+func configureProcess(proc *exec.Cmd) {
+	proc.SysProcAttr = &syscall.SysProcAttr{CreationFlags: createNewProcessGroup}
 }
 
-// Close terminates the persistent Chrome instance.
-func Close() error {
-	info, err := config.LoadWsInfo()
+// processAlive reports whether the process pid is running.
+func processAlive(pid int) bool {
+	h, err := syscall.OpenProcess(syscall.SYNCHRONIZE, false, uint32(pid))
 	if err != nil {
-		return fmt.Errorf("browser is not running")
+		return false
 	}
+	defer syscall.CloseHandle(h)
+	event, err := syscall.WaitForSingleObject(h, 0)
+	return err == nil && event == syscall.WAIT_TIMEOUT
+}
 
-	log.Printf("🛑 Closing browser with PID %d...", info.Pid)
-	err = exec.Command("taskkill", "/F", "/T", "/PID", strconv.Itoa(info.Pid)).Run()
+// terminateProcess asks the process pid to exit with taskkill, which closes
+// its windows the way the user would, rather than killing it. Chrome's other
+// processes exit along with the browser process.
+func terminateProcess(pid int) error {
+	out, err := exec.Command("taskkill", "/PID", strconv.Itoa(pid)).CombinedOutput()
 	if err != nil {
-		log.Printf("⚠️ Failed to terminate process: %v. Attempting cleanup anyway.", err)
+		return fmt.Errorf("taskkill: %w: %s", err, strings.TrimSpace(string(out)))
 	}
+	return nil
+}
 
-	if err := config.RemoveWsInfo(); err != nil {
-		return fmt.Errorf("failed to remove session file: %w", err)
+// killProcess forcibly ends the process pid and its child processes.
+func killProcess(pid int) error {
+	out, err := exec.Command("taskkill", "/F", "/T", "/PID", strconv.Itoa(pid)).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("taskkill: %w: %s", err, strings.TrimSpace(string(out)))
 	}
-
-	log.Println("✅ Browser session closed and cleaned up.")
 	return nil
 }
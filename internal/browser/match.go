@@ -0,0 +1,68 @@
+package browser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/chromedp/cdproto/target"
+)
+
+// TabMatcher selects existing tabs by URL, title or id. See ParseTabMatcher.
+type TabMatcher struct {
+	expr  string
+	field string // url, title or id
+	exact string
+	re    *regexp.Regexp
+}
+
+// ParseTabMatcher parses a tab selection expression: "url~=<regexp>" and
+// "title~=<regexp>" match part of the tab's URL or title, "url=<url>",
+// "title=<title>" and "id=<target id>" match it exactly.
+func ParseTabMatcher(expr string) (*TabMatcher, error) {
+	for _, field := range []string{"url", "title", "id"} {
+		if pattern, ok := strings.CutPrefix(expr, field+"~="); ok && field != "id" {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid target pattern %q: %w", pattern, err)
+			}
+			return &TabMatcher{expr: expr, field: field, re: re}, nil
+		}
+		if value, ok := strings.CutPrefix(expr, field+"="); ok {
+			return &TabMatcher{expr: expr, field: field, exact: value}, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid target %q (expected url~=, url=, title~=, title= or id=)", expr)
+}
+
+// String returns the expression m was parsed from.
+func (m *TabMatcher) String() string {
+	return m.expr
+}
+
+// Match reports whether the tab t matches.
+func (m *TabMatcher) Match(t *target.Info) bool {
+	var value string
+	switch m.field {
+	case "url":
+		value = t.URL
+	case "title":
+		value = t.Title
+	case "id":
+		value = string(t.TargetID)
+	}
+	if m.re != nil {
+		return m.re.MatchString(value)
+	}
+	return value == m.exact
+}
+
+// FindTab returns the first page among targets that m matches.
+func (m *TabMatcher) FindTab(targets []*target.Info) (*target.Info, bool) {
+	for _, t := range targets {
+		if t.Type == "page" && m.Match(t) {
+			return t, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,73 @@
+package browser
+
+import (
+	"testing"
+
+	"github.com/chromedp/cdproto/target"
+)
+
+// TestParseTabMatcher はタブ選択式の解析と照合をテストします。
+func TestParseTabMatcher(t *testing.T) {
+	tab := &target.Info{TargetID: "ABC123", Type: "page", URL: "https://app.example.com/dashboard?tab=1", Title: "Dashboard - Example"}
+
+	tests := []struct {
+		expr      string
+		wantErr   bool
+		wantMatch bool
+	}{
+		{"url~=dashboard", false, true},
+		{"url~=^https://app\\.example\\.com/", false, true},
+		{"url~=settings", false, false},
+		{"title~=(?i)^dashboard", false, true},
+		{"title=Dashboard - Example", false, true},
+		{"title=Dashboard", false, false},
+		{"url=https://app.example.com/dashboard?tab=1", false, true},
+		{"id=ABC123", false, true},
+		{"id=XYZ", false, false},
+		{"url~=(", true, false},
+		{"id~=ABC", true, false},
+		{"dashboard", true, false},
+		{"host=example.com", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			m, err := ParseTabMatcher(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTabMatcher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := m.Match(tab); got != tt.wantMatch {
+				t.Errorf("Match() = %v, want %v", got, tt.wantMatch)
+			}
+			if m.String() != tt.expr {
+				t.Errorf("String() = %q, want %q", m.String(), tt.expr)
+			}
+		})
+	}
+}
+
+// TestTabMatcher_FindTab はページ以外を除いた最初の一致タブが選ばれることをテストします。
+func TestTabMatcher_FindTab(t *testing.T) {
+	targets := []*target.Info{
+		{TargetID: "sw", Type: "service_worker", URL: "https://app.example.com/sw.js"},
+		{TargetID: "other", Type: "page", URL: "https://news.example.com/"},
+		{TargetID: "first", Type: "page", URL: "https://app.example.com/a"},
+		{TargetID: "second", Type: "page", URL: "https://app.example.com/b"},
+	}
+
+	m, err := ParseTabMatcher("url~=app\\.example\\.com")
+	if err != nil {
+		t.Fatalf("ParseTabMatcher() error = %v", err)
+	}
+	tab, ok := m.FindTab(targets)
+	if !ok || tab.TargetID != "first" {
+		t.Errorf("Expected the first matching page, got %v", tab)
+	}
+
+	m, _ = ParseTabMatcher("title~=nothing")
+	if _, ok := m.FindTab(targets); ok {
+		t.Error("Expected no tab to match")
+	}
+}
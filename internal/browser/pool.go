@@ -0,0 +1,163 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/chromedp/chromedp"
+)
+
+// ErrPoolClosed is returned by TabPool.Get after the pool was closed.
+var ErrPoolClosed = errors.New("tab pool is closed")
+
+// Tab is a tab checked out of a TabPool.
+type Tab struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Context returns the chromedp context of the tab.
+func (t *Tab) Context() context.Context {
+	return t.ctx
+}
+
+// TabPool hands out isolated tabs of the browser connected to its parent
+// context, so several goroutines can drive the browser at once without
+// sharing a tab. At most size tabs are checked out at a time; Get waits for a
+// free one. Returned tabs are reused, and closed by Close.
+type TabPool struct {
+	// Setup, when set, prepares each tab the pool opens before it is handed
+	// out; a tab it fails on is closed.
+	Setup func(ctx context.Context) error
+
+	parent  context.Context
+	openTab func(parent context.Context) (context.Context, context.CancelFunc, error)
+	slots   chan struct{}
+
+	mu     sync.Mutex
+	idle   []*Tab
+	closed bool
+}
+
+// NewTabPool returns a pool of at most size tabs opened in the browser of
+// parent. Sizes below 1 are raised to 1.
+func NewTabPool(parent context.Context, size int) *TabPool {
+	if size < 1 {
+		size = 1
+	}
+	return &TabPool{
+		parent:  parent,
+		openTab: openTab,
+		slots:   make(chan struct{}, size),
+	}
+}
+
+// openTab opens a new tab in the browser of parent.
+func openTab(parent context.Context) (context.Context, context.CancelFunc, error) {
+	ctx, cancel := chromedp.NewContext(parent)
+	// Open the tab now: its event loop lives as long as the context of the first Run.
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to open tab: %w", err)
+	}
+	return ctx, cancel, nil
+}
+
+// Size returns the maximum number of tabs checked out at a time.
+func (p *TabPool) Size() int {
+	return cap(p.slots)
+}
+
+// Get checks out a tab, reusing an idle one or opening a new one. It waits
+// while size tabs are checked out, until one is returned or ctx ends. The
+// tab must be handed back with Put or Discard.
+func (p *TabPool) Get(ctx context.Context) (*Tab, error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		<-p.slots
+		return nil, ErrPoolClosed
+	}
+	if n := len(p.idle); n > 0 {
+		tab := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mu.Unlock()
+		return tab, nil
+	}
+	p.mu.Unlock()
+
+	tabCtx, cancel, err := p.openTab(p.parent)
+	if err == nil && p.Setup != nil {
+		if err = p.Setup(tabCtx); err != nil {
+			cancel()
+		}
+	}
+	if err != nil {
+		<-p.slots
+		return nil, err
+	}
+	return &Tab{ctx: tabCtx, cancel: cancel}, nil
+}
+
+// Put returns a tab for reuse. Tabs whose context already ended, e.g. because
+// the tab crashed, are discarded.
+func (p *TabPool) Put(tab *Tab) {
+	p.mu.Lock()
+	if p.closed || tab.ctx.Err() != nil {
+		p.mu.Unlock()
+		tab.cancel()
+	} else {
+		p.idle = append(p.idle, tab)
+		p.mu.Unlock()
+	}
+	<-p.slots
+}
+
+// Discard closes a checked out tab instead of returning it, for tabs left in
+// an unknown state.
+func (p *TabPool) Discard(tab *Tab) {
+	tab.cancel()
+	<-p.slots
+}
+
+// Do runs fn on a tab checked out for the duration of the call. The context
+// passed to fn is the tab's, cancelled as well when ctx ends. A tab whose run
+// was cut short by ctx is discarded, as it may be left mid-navigation.
+func (p *TabPool) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	tab, err := p.Get(ctx)
+	if err != nil {
+		return err
+	}
+	runCtx, cancel := context.WithCancel(tab.ctx)
+	stop := context.AfterFunc(ctx, cancel)
+	err = fn(runCtx)
+	stop()
+	cancel()
+	if ctx.Err() != nil {
+		p.Discard(tab)
+	} else {
+		p.Put(tab)
+	}
+	return err
+}
+
+// Close closes the idle tabs. Tabs still checked out are closed when they are
+// returned, and Get fails from now on.
+func (p *TabPool) Close() {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.closed = true
+	p.mu.Unlock()
+	for _, tab := range idle {
+		tab.cancel()
+	}
+}
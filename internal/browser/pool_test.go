@@ -0,0 +1,224 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestPool はChromeの代わりにキャンセル可能なコンテキストをタブとして開くプールを返します。
+func newTestPool(size int) (*TabPool, *atomic.Int32) {
+	opened := &atomic.Int32{}
+	pool := NewTabPool(context.Background(), size)
+	pool.openTab = func(parent context.Context) (context.Context, context.CancelFunc, error) {
+		opened.Add(1)
+		ctx, cancel := context.WithCancel(parent)
+		return ctx, cancel, nil
+	}
+	return pool, opened
+}
+
+// TestTabPool_WaitsWhenFull は上限までタブを貸し出すと次のGetが待機することをテストします。
+func TestTabPool_WaitsWhenFull(t *testing.T) {
+	pool, _ := newTestPool(2)
+	defer pool.Close()
+
+	first, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if _, err := pool.Get(context.Background()); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Get(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected Get() on a full pool to wait until the deadline, got %v", err)
+	}
+
+	got := make(chan *Tab)
+	go func() {
+		tab, _ := pool.Get(context.Background())
+		got <- tab
+	}()
+	pool.Put(first)
+	select {
+	case tab := <-got:
+		if tab != first {
+			t.Error("Expected the returned tab to be reused")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected Get() to proceed once a tab was returned")
+	}
+}
+
+// TestTabPool_Discard は破棄したタブが閉じられ、再利用されないことをテストします。
+func TestTabPool_Discard(t *testing.T) {
+	pool, opened := newTestPool(1)
+	defer pool.Close()
+
+	tab, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	pool.Discard(tab)
+	if tab.Context().Err() == nil {
+		t.Error("Expected a discarded tab to be closed")
+	}
+
+	next, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if next == tab || opened.Load() != 2 {
+		t.Errorf("Expected a new tab after Discard, opened %d tabs", opened.Load())
+	}
+}
+
+// TestTabPool_PutClosedTab は終了済みのタブが返却時に捨てられることをテストします。
+func TestTabPool_PutClosedTab(t *testing.T) {
+	pool, opened := newTestPool(1)
+	defer pool.Close()
+
+	tab, _ := pool.Get(context.Background())
+	tab.cancel()
+	pool.Put(tab)
+
+	next, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if next == tab || opened.Load() != 2 {
+		t.Error("Expected a closed tab not to be reused")
+	}
+}
+
+// TestTabPool_Close はClose後にタブが閉じられ、Getが失敗することをテストします。
+func TestTabPool_Close(t *testing.T) {
+	pool, _ := newTestPool(2)
+
+	idle, _ := pool.Get(context.Background())
+	busy, _ := pool.Get(context.Background())
+	pool.Put(idle)
+	pool.Close()
+
+	if idle.Context().Err() == nil {
+		t.Error("Expected idle tabs to be closed by Close")
+	}
+	if busy.Context().Err() != nil {
+		t.Error("Expected checked out tabs to stay open until returned")
+	}
+	pool.Put(busy)
+	if busy.Context().Err() == nil {
+		t.Error("Expected tabs returned after Close to be closed")
+	}
+	if _, err := pool.Get(context.Background()); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Expected ErrPoolClosed, got %v", err)
+	}
+}
+
+// TestTabPool_Do は並列実行時に同時使用タブ数が上限を超えないことをテストします。
+func TestTabPool_Do(t *testing.T) {
+	pool, opened := newTestPool(3)
+	defer pool.Close()
+
+	var active, peak atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := pool.Do(context.Background(), func(ctx context.Context) error {
+				n := active.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				active.Add(-1)
+				return nil
+			})
+			if err != nil {
+				t.Errorf("Do() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak.Load() > 3 {
+		t.Errorf("Expected at most 3 tabs in use at once, got %d", peak.Load())
+	}
+	if opened.Load() > 3 {
+		t.Errorf("Expected at most 3 tabs to be opened, got %d", opened.Load())
+	}
+}
+
+// TestTabPool_DoCancel は呼び出し元のキャンセルがタブ上の処理に伝わり、そのタブが破棄されることをテストします。
+func TestTabPool_DoCancel(t *testing.T) {
+	pool, opened := newTestPool(1)
+	defer pool.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := pool.Do(ctx, func(tabCtx context.Context) error {
+		cancel()
+		<-tabCtx.Done()
+		return tabCtx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the tab context to be cancelled with the caller's, got %v", err)
+	}
+
+	tab, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if tab.Context().Err() != nil {
+		t.Error("Expected a usable tab after the call was cancelled")
+	}
+	// 中断されたタブはナビゲーション途中かもしれないので再利用されない
+	if n := opened.Load(); n != 2 {
+		t.Errorf("Expected the interrupted tab to be replaced, opened %d tabs", n)
+	}
+}
+
+// TestTabPool_Setup は開いたタブが準備され、準備に失敗したタブが閉じられることをテストします。
+func TestTabPool_Setup(t *testing.T) {
+	pool, opened := newTestPool(1)
+	defer pool.Close()
+
+	var prepared []context.Context
+	failing := errors.New("setup failed")
+	pool.Setup = func(ctx context.Context) error {
+		prepared = append(prepared, ctx)
+		if len(prepared) == 1 {
+			return failing
+		}
+		return nil
+	}
+
+	if _, err := pool.Get(context.Background()); !errors.Is(err, failing) {
+		t.Fatalf("Expected the setup error, got %v", err)
+	}
+	if prepared[0].Err() == nil {
+		t.Error("Expected the tab the setup failed on to be closed")
+	}
+	tab, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if tab.Context() != prepared[1] || opened.Load() != 2 {
+		t.Errorf("Expected a new prepared tab, got %d tabs opened", opened.Load())
+	}
+
+	// 再利用されるタブは再度準備されない
+	pool.Put(tab)
+	if _, err := pool.Get(context.Background()); err != nil || len(prepared) != 2 {
+		t.Errorf("Expected the reused tab not to be prepared again, got %d, %v", len(prepared), err)
+	}
+}
@@ -0,0 +1,50 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+)
+
+// ListTabs returns the page targets (tabs) of the browser connected to ctx,
+// excluding the tab ctx itself is attached to.
+func ListTabs(ctx context.Context) ([]*target.Info, error) {
+	targets, err := chromedp.Targets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tabs: %w", err)
+	}
+	var own target.ID
+	if c := chromedp.FromContext(ctx); c != nil && c.Target != nil {
+		own = c.Target.TargetID
+	}
+
+	tabs := make([]*target.Info, 0, len(targets))
+	for _, t := range targets {
+		if t.Type == "page" && t.TargetID != own {
+			tabs = append(tabs, t)
+		}
+	}
+	return tabs, nil
+}
+
+// AttachTab returns a context attached to an existing tab of the browser
+// connected to parent. chromedp closes tabs when their context is cancelled;
+// the returned release function detaches instead, leaving the tab open. It
+// must be called before parent is cancelled.
+func AttachTab(parent context.Context, id target.ID) (context.Context, func()) {
+	ctx, cancel := chromedp.NewContext(parent, chromedp.WithTargetID(id))
+	release := func() {
+		if c := chromedp.FromContext(ctx); c != nil && c.Target != nil {
+			detachCtx, detachCancel := context.WithTimeout(context.Background(), time.Second)
+			_ = target.DetachFromTarget().WithSessionID(c.Target.SessionID).Do(cdp.WithExecutor(detachCtx, c.Browser))
+			detachCancel()
+			c.Target = nil
+		}
+		cancel()
+	}
+	return ctx, release
+}
@@ -3,15 +3,20 @@ package browser
 import (
 	"context"
 	"fmt"
-	"log"
 	"net"
-	"strings"
+	"net/url"
 	"time"
+
+	"browser-tools-go/internal/i18n"
 )
 
 // WaitForWS polls a WebSocket URL until it becomes available or the timeout is reached.
-func WaitForWS(ctx context.Context, url string, maxWait time.Duration) error {
-	addr := strings.TrimPrefix(url, "ws://")
+func WaitForWS(ctx context.Context, wsURL string, maxWait time.Duration) error {
+	u, err := url.Parse(wsURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid WebSocket URL %q", wsURL)
+	}
+	addr := u.Host
 
 	dialer := net.Dialer{
 		Timeout: time.Second, // Timeout for each individual dial attempt
@@ -22,7 +27,7 @@ func WaitForWS(ctx context.Context, url string, maxWait time.Duration) error {
 		conn, err := dialer.DialContext(ctx, "tcp", addr)
 		if err == nil {
 			_ = conn.Close()
-			log.Println("✅ Browser WebSocket is ready.")
+			i18n.Println("✅ Browser WebSocket is ready.")
 			return nil
 		}
 		time.Sleep(100 * time.Millisecond) // Wait before retrying
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func newSaveMHTMLCmd() *cobra.Command {
+	var url string
+	var upload uploadFlags
+
+	cmd := &cobra.Command{
+		Use:   "save-mhtml [path]",
+		Short: "Save the rendered page as a single-file MHTML archive",
+		Long: `Saves the fully rendered page, including its stylesheets, images and frames, as a
+single-file MHTML archive (default: page.mhtml) that Chrome can open offline.`,
+		Args:              cobra.MaximumNArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			filePath := ""
+			if len(args) > 0 {
+				filePath = args[0]
+			}
+			if url != "" {
+				i18n.Printf("🚀 Navigating to %s...", url)
+			}
+			i18n.Println("🗄️ Capturing MHTML snapshot...")
+
+			savedPath, err := logic.SaveMHTML(bc.ctx, url, filePath)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("✅ MHTML snapshot saved to: %s", savedPath)
+			upload.upload(bc.ctx, savedPath)
+		},
+	}
+
+	cmd.Flags().StringVar(&url, "url", "", "URL to navigate to first")
+	upload.register(cmd)
+	return cmd
+}
+
+func newSavePageCmd() *cobra.Command {
+	var url string
+	var upload uploadFlags
+
+	cmd := &cobra.Command{
+		Use:   "save-page <dir>",
+		Short: "Save the rendered page with its resources for offline viewing",
+		Long: `Saves the rendered HTML as <dir>/index.html together with the stylesheets, scripts,
+images and fonts it references, downloaded through the browser session into
+<dir>/resources/. Links in the HTML and in the stylesheets are rewritten to the
+local copies so the page can be opened from disk. With --upload, the directory is
+also uploaded file by file.`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			if url != "" {
+				i18n.Printf("🚀 Navigating to %s...", url)
+			}
+			i18n.Println("🗄️ Saving page and resources...")
+
+			saved, err := logic.SavePage(bc.ctx, url, args[0])
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			for _, skipped := range saved.Skipped {
+				i18n.Printf("⚠️ Could not save %s", skipped)
+			}
+			i18n.Printf("✅ Page saved to: %s (%d resources)", saved.Index, len(saved.Resources))
+			upload.uploadDir(bc.ctx, filepath.Dir(saved.Index))
+			prettyPrintResults(saved)
+		},
+	}
+
+	cmd.Flags().StringVar(&url, "url", "", "URL to navigate to first")
+	upload.register(cmd)
+	return cmd
+}
+
+func newArchiveCmd() *cobra.Command {
+	var warcPath string
+	var wait time.Duration
+	var upload uploadFlags
+
+	cmd := &cobra.Command{
+		Use:   "archive [url]",
+		Short: "Record the page's requests and responses into a WARC file",
+		Long: `Records every HTTP request/response pair made by the tab into a WARC file that
+standard web-archiving tools can replay. When [url] is given it is loaded first;
+recording continues for --wait after the page has loaded. A --warc path ending in
+.gz is written gzip-compressed, one member per record. With --upload, the WARC
+file is also uploaded once recording stops.`,
+		Args:              cobra.MaximumNArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			recorder, closeFile, err := openWARCRecorder(bc.ctx, warcPath, false)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			if len(args) > 0 {
+				i18n.Printf("🚀 Navigating to %s...", args[0])
+				if _, err := logic.Navigate(bc.ctx, args[0], logic.NavigateOptions{}); err != nil {
+					recorder.Stop()
+					closeFile()
+					fatalf("✗ %v", err)
+				}
+			}
+			i18n.Printf("🗄️ Recording for %s...", wait)
+			time.Sleep(wait)
+
+			records := recorder.Stop()
+			closeFile()
+			i18n.Printf("✅ Archived %d requests to: %s", records, warcPath)
+			upload.upload(bc.ctx, warcPath)
+		},
+	}
+
+	cmd.Flags().StringVar(&warcPath, "warc", "", "WARC file to write (.warc or .warc.gz)")
+	cmd.Flags().DurationVar(&wait, "wait", 2*time.Second, "How long to keep recording after the page has loaded")
+	_ = cmd.MarkFlagRequired("warc")
+	upload.register(cmd)
+	return cmd
+}
+
+// openWARCRecorder validates path, opens it (appending when resuming) and starts
+// recording the tab's traffic into it. The returned function closes the file
+// and must be called after the recorder is stopped.
+func openWARCRecorder(ctx context.Context, path string, appendToFile bool) (*logic.WARCRecorder, func(), error) {
+	validatedPath, err := utils.ValidateFilePath(path, false, ".")
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid WARC file path: %w", err)
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendToFile {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(validatedPath, flags, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open WARC file: %w", err)
+	}
+	recorder, err := logic.OpenWARC(ctx, file, logic.IsCompressedWARCPath(validatedPath), redactor)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	closeFile := func() {
+		if err := file.Close(); err != nil {
+			i18n.Printf("⚠️ Failed to close WARC file: %v", err)
+		}
+	}
+	return recorder, closeFile, nil
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"testing"
+)
+
+// TestNewSaveMHTMLCmd はsave-mhtmlコマンドの定義、フラグと引数検証をテストします。
+func TestNewSaveMHTMLCmd(t *testing.T) {
+	cmd := newSaveMHTMLCmd()
+
+	if cmd.Use != "save-mhtml [path]" {
+		t.Errorf("Expected Use to be 'save-mhtml [path]', got %s", cmd.Use)
+	}
+	for _, name := range []string{"url", "upload"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected '%s' flag to exist", name)
+		}
+	}
+	if err := cmd.Args(cmd, []string{"a.mhtml", "b.mhtml"}); err == nil {
+		t.Error("Expected an error for more than one path")
+	}
+}
+
+// TestNewArchiveCmd はarchiveコマンドの定義とフラグをテストします。
+func TestNewArchiveCmd(t *testing.T) {
+	cmd := newArchiveCmd()
+
+	if cmd.Use != "archive [url]" {
+		t.Errorf("Expected Use to be 'archive [url]', got %s", cmd.Use)
+	}
+	warcFlag := cmd.Flags().Lookup("warc")
+	if warcFlag == nil {
+		t.Fatal("Expected 'warc' flag to exist")
+	}
+	if _, ok := warcFlag.Annotations["cobra_annotation_bash_completion_one_required_flag"]; !ok {
+		t.Error("Expected 'warc' flag to be required")
+	}
+	waitFlag := cmd.Flags().Lookup("wait")
+	if waitFlag == nil || waitFlag.DefValue != "2s" {
+		t.Error("Expected 'wait' flag with default 2s")
+	}
+	if cmd.Flags().Lookup("upload") == nil {
+		t.Error("Expected 'upload' flag to exist")
+	}
+}
+
+// TestNewSavePageCmd はsave-pageコマンドの定義と引数検証をテストします。
+func TestNewSavePageCmd(t *testing.T) {
+	cmd := newSavePageCmd()
+
+	if cmd.Use != "save-page <dir>" {
+		t.Errorf("Expected Use to be 'save-page <dir>', got %s", cmd.Use)
+	}
+	for _, name := range []string{"url", "upload"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected '%s' flag to exist", name)
+		}
+	}
+	if err := cmd.Args(cmd, []string{}); err == nil {
+		t.Error("Expected an error when no directory is given")
+	}
+	if err := cmd.Args(cmd, []string{"offline"}); err != nil {
+		t.Errorf("Expected no error for one directory, got %v", err)
+	}
+}
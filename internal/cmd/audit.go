@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+func newAuditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Audit the security of a page",
+	}
+	cmd.AddCommand(newAuditHeadersCmd(), newAuditMixedContentCmd(), newAuditAssetsCmd(), newAuditThirdPartyCmd())
+	return cmd
+}
+
+func newAuditHeadersCmd() *cobra.Command {
+	var minScore int
+
+	cmd := &cobra.Command{
+		Use:   "headers <url>",
+		Short: "Score the security headers of a page",
+		Long: `Loads <url> and scores the security headers of its main document response out
+of 100, with a grade from A to F:
+
+  Content-Security-Policy       25  no 'unsafe-inline', 'unsafe-eval' or wildcard script sources
+  Strict-Transport-Security     20  max-age of at least 180 days and includeSubDomains
+  X-Frame-Options               15  DENY or SAMEORIGIN, or a CSP frame-ancestors directive
+  X-Content-Type-Options        10  nosniff
+  Referrer-Policy               10  no-referrer, same-origin or a strict-origin policy
+  Cross-Origin-Opener-Policy    10  same-origin
+  Permissions-Policy             5  present
+  Cross-Origin-Embedder-Policy   5  require-corp or credentialless
+
+The report lists the value of each header and notes why points were withheld.
+With --min-score, the command exits with status 2 when the page scores less.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if minScore < 0 || minScore > 100 {
+				return fmt.Errorf("--min-score must be between 0 and 100")
+			}
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			return logic.ValidateAbsoluteURL(args[0])
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Printf("🛡️ Auditing the security headers of %s...", args[0])
+			audit, err := logic.AuditHeaders(bc.ctx, args[0])
+			if err != nil {
+				fatalf("✗ Audit failed: %v", err)
+			}
+			prettyPrintResults(audit)
+			i18n.Printf("📋 Score %d/%d (grade %s)", audit.Score, audit.MaxScore, audit.Grade)
+			if audit.Score < minScore {
+				bc.cancel()
+				exitCommand(ExitFalse, fmt.Sprintf("%s scored %d, below %d", args[0], audit.Score, minScore))
+			}
+		},
+	}
+
+	cmd.Flags().IntVar(&minScore, "min-score", 0, "Exit with status 2 when the page scores less than this")
+	return cmd
+}
+
+func newAuditMixedContentCmd() *cobra.Command {
+	var waitUntil string
+
+	cmd := &cobra.Command{
+		Use:   "mixed-content <url>",
+		Short: "List the HTTP subresources of an HTTPS page",
+		Long: `Loads the HTTPS page <url> and lists the subresources it requested over HTTP
+(mixed content), with what Chrome did with each of them: "loaded" insecurely,
+"blocked", or "upgraded" to HTTPS. The report also gives the security state
+Chrome shows for the page and the reasons it isn't secure.
+
+Exits with status 2 when the page has mixed content, upgraded requests
+included, so that the check can gate a deployment.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := logic.ValidateWaitUntil(waitUntil); err != nil {
+				return err
+			}
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			if !strings.HasPrefix(args[0], "https://") {
+				return fmt.Errorf("invalid URL %q: mixed content only exists on https:// pages", args[0])
+			}
+			return nil
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Printf("🛡️ Auditing the mixed content of %s...", args[0])
+			audit, err := logic.AuditMixedContent(bc.ctx, args[0], waitUntil)
+			if err != nil {
+				fatalf("✗ Audit failed: %v", err)
+			}
+			prettyPrintResults(audit)
+			if n := len(audit.Requests); n > 0 {
+				i18n.Printf("⚠️ %d insecure request(s): %d loaded, %d blocked, %d upgraded", n, audit.Loaded, audit.Blocked, audit.Upgraded)
+				bc.cancel()
+				exitCommand(ExitFalse, fmt.Sprintf("%s has %d insecure request(s)", args[0], n))
+			}
+			i18n.Println("✅ No mixed content.")
+		},
+	}
+
+	cmd.Flags().StringVar(&waitUntil, "wait-until", logic.WaitUntilLoad, "When the load is complete: load, domcontentloaded or networkidle")
+	return cmd
+}
+
+func newAuditAssetsCmd() *cobra.Command {
+	var waitUntil string
+
+	cmd := &cobra.Command{
+		Use:   "assets <url>",
+		Short: "List the missing and broken assets of a page",
+		Long: `Loads <url> and lists, grouped by resource type (Image, Script, Stylesheet,
+Font, ...), the subresources that responded with an HTTP status of 400 or more
+or failed to load, and the images that loaded without pixels (naturalWidth 0),
+so that visual breakage is caught without looking at screenshots.
+
+Exits with status 2 when any asset is broken.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := logic.ValidateWaitUntil(waitUntil); err != nil {
+				return err
+			}
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			return logic.ValidateAbsoluteURL(args[0])
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Printf("🛡️ Auditing the assets of %s...", args[0])
+			audit, err := logic.AuditAssets(bc.ctx, args[0], waitUntil)
+			if err != nil {
+				fatalf("✗ Audit failed: %v", err)
+			}
+			prettyPrintResults(audit)
+			if audit.Broken > 0 {
+				i18n.Printf("⚠️ %d of %d asset(s) are broken", audit.Broken, audit.Requests)
+				bc.cancel()
+				exitCommand(ExitFalse, fmt.Sprintf("%s has %d broken asset(s)", args[0], audit.Broken))
+			}
+			i18n.Println("✅ No broken assets.")
+		},
+	}
+
+	cmd.Flags().StringVar(&waitUntil, "wait-until", logic.WaitUntilLoad, "When the load is complete: load, domcontentloaded or networkidle")
+	return cmd
+}
+
+func newAuditThirdPartyCmd() *cobra.Command {
+	var waitUntil string
+
+	cmd := &cobra.Command{
+		Use:   "third-party <url>",
+		Short: "Inventory the third parties a page loads from",
+		Long: `Loads <url> with the browser cache disabled and groups its requests by site,
+the registrable domain (eTLD+1) of their host: the site of the page itself (the
+first party) and every third party, with their hosts, number of requests,
+transferred bytes and main-thread blocking time, the time their scripts ran in
+long animation frames. Third parties are listed most blocking first.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := logic.ValidateWaitUntil(waitUntil); err != nil {
+				return err
+			}
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			return logic.ValidateAbsoluteURL(args[0])
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Printf("🛡️ Auditing the third parties of %s...", args[0])
+			audit, err := logic.AuditThirdParties(bc.ctx, args[0], waitUntil)
+			if err != nil {
+				fatalf("✗ Audit failed: %v", err)
+			}
+			i18n.Printf("📋 %d third part(ies) besides %s", len(audit.ThirdParties), audit.FirstParty.Domain)
+			prettyPrintResults(audit)
+		},
+	}
+
+	cmd.Flags().StringVar(&waitUntil, "wait-until", logic.WaitUntilNetworkIdle, "When the load is complete: load, domcontentloaded or networkidle")
+	return cmd
+}
+
+func newCertCmd() *cobra.Command {
+	var warnDays int
+
+	cmd := &cobra.Command{
+		Use:   "cert <url>",
+		Short: "Show the TLS certificate of a page",
+		Long: `Loads the HTTPS page <url> and prints the TLS certificate and connection of its
+main document as JSON: subject, issuer, SANs, validity dates, days until expiry,
+protocol, cipher, key exchange and Certificate Transparency compliance.
+
+With --warn-days, the command exits with status 2 when the certificate expires
+in fewer days, so that expiry can be monitored like content.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if warnDays < 0 {
+				return fmt.Errorf("--warn-days must not be negative")
+			}
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			if !strings.HasPrefix(args[0], "https://") {
+				return fmt.Errorf("invalid URL %q: only https:// pages have a certificate", args[0])
+			}
+			return nil
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Printf("🔒 Retrieving the certificate of %s...", args[0])
+			info, err := logic.Certificate(bc.ctx, args[0])
+			if err != nil {
+				fatalf("✗ Failed to retrieve the certificate: %v", err)
+			}
+			prettyPrintResults(info)
+			if info.DaysUntilExpiry < warnDays {
+				i18n.Printf("⚠️ The certificate expires in %d day(s), on %s", info.DaysUntilExpiry, info.ValidTo.Format("2006-01-02"))
+				bc.cancel()
+				exitCommand(ExitFalse, fmt.Sprintf("the certificate of %s expires in %d day(s)", args[0], info.DaysUntilExpiry))
+			}
+		},
+	}
+
+	cmd.Flags().IntVar(&warnDays, "warn-days", 0, "Exit with status 2 when the certificate expires in fewer days than this")
+	return cmd
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import "testing"
+
+// TestNewAuditHeadersCmd_Args はaudit headersコマンドの引数とフラグの検証をテストします。
+func TestNewAuditHeadersCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{"url", []string{"https://a.example"}, nil, false},
+		{"min score", []string{"https://a.example"}, map[string]string{"min-score": "75"}, false},
+		{"no url", nil, nil, true},
+		{"relative url", []string{"a.example"}, nil, true},
+		{"min score above 100", []string{"https://a.example"}, map[string]string{"min-score": "101"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newAuditHeadersCmd()
+			for name, value := range tt.flags {
+				if err := cmd.Flags().Set(name, value); err != nil {
+					t.Fatalf("Failed to set %s flag: %v", name, err)
+				}
+			}
+			err := cmd.Args(cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNewAuditMixedContentCmd_Args はaudit mixed-contentコマンドの引数とフラグの検証をテストします。
+func TestNewAuditMixedContentCmd_Args(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		waitUntil string
+		wantErr   bool
+	}{
+		{"https url", []string{"https://a.example"}, "", false},
+		{"network idle", []string{"https://a.example"}, "networkidle", false},
+		{"http url", []string{"http://a.example"}, "", true},
+		{"no url", nil, "", true},
+		{"invalid wait condition", []string{"https://a.example"}, "idle", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newAuditMixedContentCmd()
+			if tt.waitUntil != "" {
+				if err := cmd.Flags().Set("wait-until", tt.waitUntil); err != nil {
+					t.Fatalf("Failed to set wait-until flag: %v", err)
+				}
+			}
+			err := cmd.Args(cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNewCertCmd_Args はcertコマンドの引数とフラグの検証をテストします。
+func TestNewCertCmd_Args(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		warnDays string
+		wantErr  bool
+	}{
+		{"https url", []string{"https://a.example"}, "", false},
+		{"warn days", []string{"https://a.example"}, "30", false},
+		{"http url", []string{"http://a.example"}, "", true},
+		{"no url", nil, "", true},
+		{"negative warn days", []string{"https://a.example"}, "-1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newCertCmd()
+			if tt.warnDays != "" {
+				if err := cmd.Flags().Set("warn-days", tt.warnDays); err != nil {
+					t.Fatalf("Failed to set warn-days flag: %v", err)
+				}
+			}
+			err := cmd.Args(cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNewAuditAssetsCmd_Args はaudit assetsコマンドの引数の検証をテストします。
+func TestNewAuditAssetsCmd_Args(t *testing.T) {
+	cmd := newAuditAssetsCmd()
+	if err := cmd.Args(cmd, []string{"http://a.example"}); err != nil {
+		t.Errorf("Expected an absolute URL to be accepted, got %v", err)
+	}
+	if err := cmd.Args(cmd, []string{"a.example"}); err == nil {
+		t.Error("Expected a relative URL to be rejected")
+	}
+	if err := cmd.Flags().Set("wait-until", "idle"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Args(cmd, []string{"http://a.example"}); err == nil {
+		t.Error("Expected an invalid wait condition to be rejected")
+	}
+}
+
+// TestNewAuditThirdPartyCmd_Args はaudit third-partyコマンドの引数の検証をテストします。
+func TestNewAuditThirdPartyCmd_Args(t *testing.T) {
+	cmd := newAuditThirdPartyCmd()
+	if err := cmd.Args(cmd, []string{"https://a.example"}); err != nil {
+		t.Errorf("Expected an absolute URL to be accepted, got %v", err)
+	}
+	if err := cmd.Args(cmd, []string{"a.example"}); err == nil {
+		t.Error("Expected a relative URL to be rejected")
+	}
+	if err := cmd.Args(cmd, nil); err == nil {
+		t.Error("Expected a missing URL to be rejected")
+	}
+}
@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"browser-tools-go/internal/browser"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// Values of batch --on-error.
+const (
+	onErrorStop     = "stop"
+	onErrorContinue = "continue"
+)
+
+// inProcessExcluded are the commands that manage their own browser connection
+// and can't run inside batch or pipe-line.
+var inProcessExcluded = map[string]bool{
+	"batch":     true,
+	"pipe-line": true,
+	"run":       true,
+}
+
+// commandFailure aborts a command run in-process; see runCommandLine.
+type commandFailure string
+
+func (f commandFailure) Error() string { return string(f) }
+
+// splitCommandLine splits a command line into arguments like a POSIX shell
+// would, honoring single quotes, double quotes and backslash escapes. A
+// leading "browser-tools-go" is dropped.
+func splitCommandLine(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	if len(args) > 0 && args[0] == "browser-tools-go" {
+		args = args[1:]
+	}
+	return args, nil
+}
+
+// validateInProcess rejects empty and nested connection-managing commands.
+func validateInProcess(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("empty command")
+	}
+	if inProcessExcluded[args[0]] {
+		return fmt.Errorf("%q can't run inside batch or pipe-line", args[0])
+	}
+	return nil
+}
+
+// runCommandLine executes a CLI command in this process, on the browser
+// connection of bc, and returns what it printed to stdout. A failure that
+// would exit the process (fatalf) only aborts the command.
+func runCommandLine(bc *browserCtx, args []string) (string, error) {
+	return captureStdout(func() error {
+		return executeCommandLine(bc, args)
+	})
+}
+
+// executeCommandLine is runCommandLine with the output of the command going
+// to stdout.
+func executeCommandLine(bc *browserCtx, args []string) (err error) {
+	root := NewRootCmd()
+	found, _, err := root.Find(args)
+	if err != nil || found == root {
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	root.SetArgs(args)
+	root.SilenceUsage = true
+	root.SilenceErrors = true
+	// The commands cancel their browser context when done; keep the shared one alive.
+	shared := &browserCtx{ctx: bc.ctx, cancel: func() {}}
+	ctx := context.WithValue(context.Background(), browserCtxKey, shared)
+
+	previous := fatalf
+	fatalf = func(format string, v ...interface{}) {
+		msg := fmt.Sprintf(format, v...)
+		if tabCrash() != nil {
+			msg = crashMessage(msg)
+			format, v = "%s", []interface{}{msg}
+		}
+		i18n.Printf(format, v...)
+		saveFailureArtifacts(commandName(found), msg)
+		panic(commandFailure(strings.TrimSpace(strings.TrimPrefix(msg, "✗"))))
+	}
+	previousExit := exitCommand
+	exitCommand = func(code int, message string) {
+		panic(commandFailure(message))
+	}
+	// The step sets up its own result format and redaction; the caller's are
+	// restored afterwards.
+	previousFormat, previousRedactor := outputFormat, redactor
+	outputFormat = resultFormat{}
+	defer func() {
+		fatalf, exitCommand = previous, previousExit
+		outputFormat = previousFormat
+		useRedactor(previousRedactor)
+	}()
+
+	defer func() {
+		if r := recover(); r != nil {
+			failure, ok := r.(commandFailure)
+			if !ok {
+				panic(r)
+			}
+			err = failure
+		}
+	}()
+	return root.ExecuteContext(ctx)
+}
+
+// captureStdout runs fn with os.Stdout redirected and returns what it wrote.
+func captureStdout(fn func() error) (string, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	original := os.Stdout
+	os.Stdout = w
+	captured := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		r.Close()
+		captured <- data
+	}()
+
+	err = fn()
+	os.Stdout = original
+	w.Close()
+	return string(<-captured), err
+}
+
+// parseCommandOutput turns a command's stdout into a JSON value for reports:
+// the value itself when it is JSON, an array for JSON lines, text otherwise.
+func parseCommandOutput(output string) interface{} {
+	trimmed := strings.TrimSpace(output)
+	if trimmed == "" {
+		return nil
+	}
+	if json.Valid([]byte(trimmed)) {
+		return json.RawMessage(trimmed)
+	}
+	var lines []json.RawMessage
+	scanner := bufio.NewScanner(strings.NewReader(trimmed))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if !json.Valid(line) {
+			return output
+		}
+		lines = append(lines, json.RawMessage(append([]byte(nil), line...)))
+	}
+	return lines
+}
+
+// readCommandFile reads the commands of a batch file, one per line; blank
+// lines and lines starting with # are skipped.
+func readCommandFile(path string) ([]models.BatchStep, error) {
+	data, err := readInputFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var steps []models.BatchStep
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		steps = append(steps, models.BatchStep{Line: i + 1, Command: line})
+	}
+	return steps, nil
+}
+
+func newBatchCmd() *cobra.Command {
+	var onError string
+	var newTab bool
+
+	cmd := &cobra.Command{
+		Use:   "batch <file>",
+		Short: "Run a file of commands over one browser connection",
+		Long: `Runs the commands listed in <file> (one per line, "-" for stdin) in order over a
+single shared browser connection, instead of connecting once per command as a
+shell loop does. Lines are split like a shell would; blank lines and lines
+starting with # are skipped.
+
+Prints a JSON report with each command's output (parsed as JSON when possible)
+or error. With --on-error stop (the default) the first failure ends the batch;
+with --on-error continue the remaining commands still run. Exits with an error
+when any command failed.
+
+With --new-tab, the commands run on a tab of their own checked out of a tab
+pool, rather than on the automation tab, and the tab is closed when the batch
+ends. A tab that crashes or is left unusable by a command is replaced by a new
+one for the next command.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if onError != onErrorStop && onError != onErrorContinue {
+				return fmt.Errorf("invalid --on-error %q (expected stop or continue)", onError)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			// recoverSession replaces bc.cancel when the tab crashes.
+			defer func() { bc.cancel() }()
+
+			steps, err := readCommandFile(args[0])
+			if err != nil {
+				fatalf("✗ Failed to read commands: %v", err)
+			}
+
+			// Commands share the process's stdout and failure handlers, so they run
+			// one at a time on a pool of a single tab.
+			var pool *browser.TabPool
+			if newTab {
+				pool = logic.NewTabPool(bc.ctx, 1)
+				defer pool.Close()
+			}
+
+			report := models.BatchReport{Steps: []models.BatchStep{}}
+			start := time.Now()
+			for i, step := range steps {
+				i18n.Printf("▶️ [%d/%d] %s", i+1, len(steps), step.Command)
+				stepStart := time.Now()
+				stepArgs, err := splitCommandLine(step.Command)
+				if err == nil {
+					err = validateInProcess(stepArgs)
+				}
+				if err == nil {
+					var output string
+					output, err = runBatchStep(bc, pool, stepArgs)
+					step.Output = parseCommandOutput(output)
+				}
+				step.DurationMs = float64(time.Since(stepStart).Microseconds()) / 1000
+				if err != nil {
+					step.Error = err.Error()
+					report.Failed++
+					recoverSession(bc)
+				} else {
+					step.OK = true
+					report.Succeeded++
+				}
+				report.Steps = append(report.Steps, step)
+				if err != nil && onError == onErrorStop {
+					report.Skipped = len(steps) - i - 1
+					i18n.Printf("⏹️ Stopping after line %d failed: %v", step.Line, err)
+					break
+				}
+			}
+			report.DurationMs = float64(time.Since(start).Microseconds()) / 1000
+
+			prettyPrintResults(report)
+			if report.Failed > 0 {
+				bc.cancel()
+				finishCommand(false, fmt.Sprintf("%d of %d commands failed", report.Failed, len(steps)))
+				os.Exit(ExitError)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&onError, "on-error", onErrorStop, "What to do when a command fails: stop or continue")
+	cmd.Flags().BoolVar(&newTab, "new-tab", false, "Run the commands on a tab of their own instead of the automation tab")
+	return cmd
+}
+
+// runBatchStep runs a command of a batch with runCommandLine, on the tab of
+// bc, or on a tab checked out of pool when it is set. The tab goes back to the
+// pool, which discards it when its context has ended, e.g. after a crash.
+func runBatchStep(bc *browserCtx, pool *browser.TabPool, args []string) (string, error) {
+	if pool == nil {
+		return runCommandLine(bc, args)
+	}
+	tab, err := pool.Get(bc.ctx)
+	if err != nil {
+		return "", err
+	}
+	defer pool.Put(tab)
+	return runCommandLine(&browserCtx{ctx: tab.Context(), cancel: func() {}}, args)
+}
+
+func newPipeLineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pipe-line <command>...",
+		Short: "Run several commands in one process on the same tab",
+		Long: `Runs each argument as a command, in order, in this process and on the same tab,
+and prints the output of the last one. The first failure stops the pipeline.
+This avoids connecting to the browser once per command, e.g.
+
+  browser-tools-go pipe-line 'navigate https://example.com' 'wait --selector main' 'content --format markdown'`,
+		Args:              cobra.MinimumNArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			var output string
+			for i, line := range args {
+				i18n.Printf("▶️ [%d/%d] %s", i+1, len(args), line)
+				stepArgs, err := splitCommandLine(line)
+				if err == nil {
+					err = validateInProcess(stepArgs)
+				}
+				if err == nil {
+					output, err = runCommandLine(bc, stepArgs)
+				}
+				if err != nil {
+					fatalf("✗ Step %d (%s) failed: %v", i+1, line, err)
+				}
+			}
+			fmt.Print(output)
+		},
+	}
+	return cmd
+}
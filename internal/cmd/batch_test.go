@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// TestSplitCommandLine はシェル風のコマンド行分割をテストします。
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    []string
+		wantErr bool
+	}{
+		{"navigate https://example.com", []string{"navigate", "https://example.com"}, false},
+		{"browser-tools-go eval 'document.title'", []string{"eval", "document.title"}, false},
+		{`eval "a \"b\" c"  --await`, []string{"eval", `a "b" c`, "--await"}, false},
+		{`pick 'a[title="x y"]'`, []string{"pick", `a[title="x y"]`}, false},
+		{`eval ''`, []string{"eval", ""}, false},
+		{`content \'x`, []string{"content", "'x"}, false},
+		{"eval 'unterminated", nil, true},
+		{`eval trailing\`, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := splitCommandLine(tt.line)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("splitCommandLine(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitCommandLine(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+// TestValidateInProcess は入れ子にできないコマンドの拒否をテストします。
+func TestValidateInProcess(t *testing.T) {
+	if err := validateInProcess([]string{"navigate", "https://example.com"}); err != nil {
+		t.Errorf("Expected navigate to be allowed, got %v", err)
+	}
+	for _, args := range [][]string{{}, {"batch", "x.txt"}, {"run", "eval", "1"}, {"pipe-line", "eval 1"}} {
+		if err := validateInProcess(args); err == nil {
+			t.Errorf("Expected %q to be rejected", args)
+		}
+	}
+}
+
+// TestParseCommandOutput は出力のJSON解釈をテストします。
+func TestParseCommandOutput(t *testing.T) {
+	marshal := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	if got := parseCommandOutput("  \n"); got != nil {
+		t.Errorf("Expected nil for empty output, got %v", got)
+	}
+	if got := marshal(parseCommandOutput("{\n  \"a\": 1\n}\n")); got != `{"a":1}` {
+		t.Errorf("Unexpected JSON output: %s", got)
+	}
+	if got := marshal(parseCommandOutput("{\"a\":1}\n{\"a\":2}\n")); got != `[{"a":1},{"a":2}]` {
+		t.Errorf("Unexpected JSON lines output: %s", got)
+	}
+	if got := parseCommandOutput("<html></html>\n"); got != "<html></html>\n" {
+		t.Errorf("Expected text output to be kept, got %v", got)
+	}
+}
+
+// TestRunCommandLine はプロセス内でのコマンド実行と失敗時の中断をテストします。
+func TestRunCommandLine(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	bc := &browserCtx{ctx: context.Background(), cancel: func() {}}
+
+	output, err := runCommandLine(bc, []string{"history", "--all"})
+	if err != nil {
+		t.Fatalf("Expected history --all to succeed, got %v", err)
+	}
+	if got := parseCommandOutput(output); got == nil {
+		t.Errorf("Expected JSON output, got %q", output)
+	}
+
+	// セッションが無い場合historyはfatalfで失敗するが、プロセスは終了しない
+	if _, err := runCommandLine(bc, []string{"history"}); err == nil {
+		t.Error("Expected history without a session to fail")
+	}
+	if _, err := runCommandLine(bc, []string{"no-such-command"}); err == nil {
+		t.Error("Expected an unknown command to fail")
+	}
+}
+
+// TestNewBatchCmd はbatchコマンドの引数と--on-errorの検証をテストします。
+func TestNewBatchCmd(t *testing.T) {
+	cmd := newBatchCmd()
+
+	if err := cmd.Args(cmd, []string{"commands.txt"}); err != nil {
+		t.Errorf("Expected one file to be accepted, got %v", err)
+	}
+	if err := cmd.Args(cmd, []string{}); err == nil {
+		t.Error("Expected error without a file")
+	}
+	if flag := cmd.Flags().Lookup("new-tab"); flag == nil || flag.DefValue != "false" {
+		t.Error("Expected 'new-tab' flag defaulting to false")
+	}
+	if err := cmd.Flags().Set("on-error", "ignore"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Args(cmd, []string{"commands.txt"}); err == nil {
+		t.Error("Expected error for an invalid --on-error value")
+	}
+}
+
+// TestNewPipeLineCmd はpipe-lineコマンドの引数検証をテストします。
+func TestNewPipeLineCmd(t *testing.T) {
+	cmd := newPipeLineCmd()
+
+	if err := cmd.Args(cmd, []string{}); err == nil {
+		t.Error("Expected error without commands")
+	}
+	if err := cmd.Args(cmd, []string{"navigate https://example.com", "content"}); err != nil {
+		t.Errorf("Expected commands to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// loadBenchBaseline reads a report stored with bench --save-baseline.
+func loadBenchBaseline(path string) (*models.BenchReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	var baseline models.BenchReport
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if len(baseline.Metrics) == 0 {
+		return nil, fmt.Errorf("baseline %s has no metrics", path)
+	}
+	return &baseline, nil
+}
+
+func newBenchCmd() *cobra.Command {
+	var runs int
+	var cold, warm bool
+	var waitUntil string
+	var baselinePath, savePath string
+	var threshold float64
+
+	cmd := &cobra.Command{
+		Use:   "bench <url>",
+		Short: "Load a page repeatedly and report percentiles of its load timings",
+		Long: `Loads <url> --runs times, each time starting from about:blank, and prints the
+navigation and paint timings of every run with min, max, mean, standard deviation,
+p50 and p95 per metric.
+
+With --cold (the default) the browser cache is disabled, so every run loads the
+page from the network. With --warm an uncounted first load primes the cache.
+
+--save-baseline stores the report; --baseline compares the medians with a stored
+report and fails when a metric got slower by more than --threshold percent.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if runs < 1 {
+				return fmt.Errorf("--runs must be at least 1")
+			}
+			if threshold < 0 {
+				return fmt.Errorf("--threshold must not be negative")
+			}
+			if err := logic.ValidateWaitUntil(waitUntil); err != nil {
+				return err
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			var baseline *models.BenchReport
+			if baselinePath != "" {
+				var err error
+				if baseline, err = loadBenchBaseline(baselinePath); err != nil {
+					fatalf("✗ %v", err)
+				}
+			}
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			mode := logic.BenchCold
+			if warm {
+				mode = logic.BenchWarm
+			}
+			i18n.Printf("⏱️ Benchmarking %s (%d %s runs)...", args[0], runs, mode)
+			report, err := logic.Bench(bc.ctx, args[0], logic.BenchOptions{Runs: runs, Mode: mode, WaitUntil: waitUntil}, func(sample models.BenchSample) {
+				i18n.Printf("⏱️ Run %d/%d: %.0f ms", sample.Run, runs, sample.TotalMs)
+			})
+			if err != nil {
+				fatalf("✗ Benchmark failed: %v", err)
+			}
+
+			if baseline != nil {
+				report.Baseline = baselinePath
+				logic.CompareBench(report, baseline, threshold)
+			}
+			if savePath != "" {
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					fatalf("✗ Failed to encode baseline: %v", err)
+				}
+				if err := utils.SecureWriteFile(savePath, data, 0644, "."); err != nil {
+					fatalf("✗ Failed to write baseline to %s: %v", savePath, err)
+				}
+				i18n.Printf("💾 Baseline saved to %s", savePath)
+			}
+
+			prettyPrintResults(report)
+			if report.Regressions > 0 {
+				bc.cancel()
+				i18n.Printf("✗ %d metric(s) regressed by more than %g%% against %s", report.Regressions, threshold, baselinePath)
+				exitCommand(ExitError, fmt.Sprintf("%d metric(s) regressed", report.Regressions))
+			}
+		},
+	}
+
+	cmd.Flags().IntVar(&runs, "runs", 10, "Number of measured page loads")
+	cmd.Flags().BoolVar(&cold, "cold", false, "Disable the browser cache so every run loads from the network (default)")
+	cmd.Flags().BoolVar(&warm, "warm", false, "Prime the browser cache with an uncounted first load")
+	cmd.Flags().StringVar(&waitUntil, "wait-until", logic.WaitUntilLoad, "When a load is complete: load, domcontentloaded or networkidle")
+	cmd.Flags().StringVar(&baselinePath, "baseline", "", "Compare against a report stored with --save-baseline")
+	cmd.Flags().StringVar(&savePath, "save-baseline", "", "Store the report as a baseline for later runs")
+	cmd.Flags().Float64Var(&threshold, "threshold", 10, "Percentage by which a median may grow over the baseline before it counts as a regression")
+	cmd.MarkFlagsMutuallyExclusive("cold", "warm")
+	return cmd
+}
+
+func newWeighCmd() *cobra.Command {
+	var opts logic.WeighOptions
+
+	cmd := &cobra.Command{
+		Use:   "weigh <url>",
+		Short: "Break down the bytes a page loads by resource type and domain",
+		Long: `Loads <url> with the browser cache disabled and prints the bytes its requests
+transferred over the network and decoded, in total, by resource type and by
+domain, heaviest first, with the --top largest resources, to see what bloats a
+page.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.Top < 0 {
+				return fmt.Errorf("--top must not be negative")
+			}
+			if err := logic.ValidateWaitUntil(opts.WaitUntil); err != nil {
+				return err
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Printf("⚖️ Weighing %s...", args[0])
+			weight, err := logic.Weigh(bc.ctx, args[0], opts)
+			if err != nil {
+				fatalf("✗ Failed to weigh the page: %v", err)
+			}
+			i18n.Printf("📦 %d requests, %d bytes transferred, %d bytes decoded", weight.Requests, weight.TransferBytes, weight.DecodedBytes)
+			prettyPrintResults(weight)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Top, "top", 10, "Number of largest resources to list")
+	cmd.Flags().StringVar(&opts.WaitUntil, "wait-until", logic.WaitUntilNetworkIdle, "When the load is complete: load, domcontentloaded or networkidle")
+	return cmd
+}
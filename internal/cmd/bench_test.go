@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestNewBenchCmd_Args はbenchコマンドの引数とフラグの検証をテストします。
+func TestNewBenchCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{"default", nil, false},
+		{"warm", map[string]string{"warm": "true", "runs": "3"}, false},
+		{"zero runs", map[string]string{"runs": "0"}, true},
+		{"negative threshold", map[string]string{"threshold": "-1"}, true},
+		{"invalid wait condition", map[string]string{"wait-until": "idle"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newBenchCmd()
+			for name, value := range tt.flags {
+				if err := cmd.Flags().Set(name, value); err != nil {
+					t.Fatalf("Failed to set %s flag: %v", name, err)
+				}
+			}
+			err := cmd.Args(cmd, []string{"https://example.com"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	cmd := newBenchCmd()
+	cmd.Flags().Set("cold", "true")
+	cmd.Flags().Set("warm", "true")
+	if err := cmd.ValidateFlagGroups(); err == nil {
+		t.Error("Expected --cold and --warm to be mutually exclusive")
+	}
+}
+
+// TestLoadBenchBaseline は保存済みベースラインの読み込みをテストします。
+func TestLoadBenchBaseline(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "baseline.json")
+	os.WriteFile(valid, []byte(`{"url":"https://example.com","metrics":{"loadMs":{"samples":3,"p50":120}}}`), 0644)
+	empty := filepath.Join(dir, "empty.json")
+	os.WriteFile(empty, []byte(`{"url":"https://example.com"}`), 0644)
+
+	baseline, err := loadBenchBaseline(valid)
+	if err != nil {
+		t.Fatalf("Failed to load baseline: %v", err)
+	}
+	if baseline.Metrics["loadMs"].P50 != 120 {
+		t.Errorf("Expected loadMs p50 120, got %+v", baseline.Metrics)
+	}
+	if _, err := loadBenchBaseline(empty); err == nil {
+		t.Error("Expected error for a baseline without metrics")
+	}
+	if _, err := loadBenchBaseline(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected error for a missing baseline")
+	}
+}
+
+// TestNewWeighCmd_Args はweighコマンドの引数とフラグの検証をテストします。
+func TestNewWeighCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{"default", nil, false},
+		{"top", map[string]string{"top": "3", "wait-until": "load"}, false},
+		{"negative top", map[string]string{"top": "-1"}, true},
+		{"invalid wait condition", map[string]string{"wait-until": "idle"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newWeighCmd()
+			for name, value := range tt.flags {
+				if err := cmd.Flags().Set(name, value); err != nil {
+					t.Fatalf("Failed to set %s flag: %v", name, err)
+				}
+			}
+			err := cmd.Args(cmd, []string{"https://example.com"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
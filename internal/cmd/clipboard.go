@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+func newClipboardCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clipboard",
+		Short: "Read and write the clipboard through the current page",
+		Long: `Reads and writes the system clipboard with navigator.clipboard in the current
+page, e.g. to verify what a copy button copied. The page must have an http(s)
+origin; it is granted the clipboard permissions automatically.`,
+	}
+	cmd.AddCommand(newClipboardReadCmd(), newClipboardWriteCmd())
+	return cmd
+}
+
+func newClipboardReadCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "read",
+		Short:             "Print the text on the clipboard",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			text, err := logic.ReadClipboard(bc.ctx)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			fmt.Println(text)
+		},
+	}
+}
+
+func newClipboardWriteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "write <text | ->",
+		Short:             "Put text on the clipboard (\"-\" reads it from stdin)",
+		Args:              cobra.MinimumNArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			text := strings.Join(args, " ")
+			if len(args) == 1 && args[0] == "-" {
+				data, err := readInputFile("-")
+				if err != nil {
+					fatalf("✗ Failed to read stdin: %v", err)
+				}
+				text = string(data)
+			}
+			if err := logic.WriteClipboard(bc.ctx, text); err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("📋 Copied %d characters to the clipboard.", len([]rune(text)))
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import "testing"
+
+// TestNewClipboardCmd_Subcommands はclipboardコマンドのサブコマンドと引数の検証をテストします。
+func TestNewClipboardCmd_Subcommands(t *testing.T) {
+	cmd := newClipboardCmd()
+	for _, name := range []string{"read", "write"} {
+		sub, _, err := cmd.Find([]string{name})
+		if err != nil || sub.Name() != name {
+			t.Errorf("Expected subcommand %s, got %v (err: %v)", name, sub, err)
+		}
+	}
+
+	read := newClipboardReadCmd()
+	if err := read.Args(read, []string{"extra"}); err == nil {
+		t.Error("Expected read to reject arguments")
+	}
+	write := newClipboardWriteCmd()
+	if err := write.Args(write, []string{}); err == nil {
+		t.Error("Expected write to require text")
+	}
+	if err := write.Args(write, []string{"hello", "world"}); err != nil {
+		t.Errorf("Expected write to accept text, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+func newCompareCmd() *cobra.Command {
+	var runs int
+	var format, selector string
+	var screenshotDir string
+	var fullPage bool
+	var waitUntil string
+
+	cmd := &cobra.Command{
+		Use:   "compare <url-a> <url-b>",
+		Short: "Compare two pages side by side: load timings, content, console errors and screenshots",
+		Long: `Loads <url-a> and then <url-b> in the tab with the browser cache disabled and
+prints a side-by-side report of both pages: the median navigation and paint timings
+over --runs loads with their differences, a diff of the extracted content, the
+console errors each page reported, the errors only <url-b> reported, and the
+percentage of screenshot pixels that differ.
+
+Screenshots are saved as a.png and b.png in --screenshots; pass "" to skip them.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if runs < 1 {
+				return fmt.Errorf("--runs must be at least 1")
+			}
+			if values := formatValues["compare"]; !slices.Contains(values, format) {
+				return fmt.Errorf("invalid --format %q (expected %s)", format, strings.Join(values, ", "))
+			}
+			if err := logic.ValidateWaitUntil(waitUntil); err != nil {
+				return err
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Printf("⚖️ Comparing %s with %s...", args[0], args[1])
+			report, err := logic.ComparePages(bc.ctx, args[0], args[1], logic.CompareOptions{
+				Runs:          runs,
+				WaitUntil:     waitUntil,
+				Format:        format,
+				Selector:      selector,
+				ScreenshotDir: screenshotDir,
+				FullPage:      fullPage,
+			})
+			if err != nil {
+				fatalf("✗ Comparison failed: %v", err)
+			}
+			prettyPrintResults(report)
+		},
+	}
+
+	cmd.Flags().IntVar(&runs, "runs", 1, "Number of loads of each page; timings are their medians")
+	cmd.Flags().StringVar(&format, "format", "markdown", "Format of the compared content (markdown, text, or html)")
+	cmd.Flags().StringVar(&selector, "selector", "", "CSS selector of the region whose content is compared")
+	cmd.Flags().StringVar(&screenshotDir, "screenshots", "compare", "Directory for the screenshots of both pages (\"\" to skip them)")
+	cmd.Flags().BoolVar(&fullPage, "full-page", false, "Capture full page screenshots")
+	cmd.Flags().StringVar(&waitUntil, "wait-until", logic.WaitUntilLoad, "When a load is complete: load, domcontentloaded or networkidle")
+	return cmd
+}
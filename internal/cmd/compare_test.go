@@ -0,0 +1,34 @@
+package cmd
+
+import "testing"
+
+// TestNewCompareCmd_Args はcompareコマンドの引数とフラグの検証をテストします。
+func TestNewCompareCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{"two urls", []string{"https://a.example", "https://b.example"}, nil, false},
+		{"text format", []string{"https://a.example", "https://b.example"}, map[string]string{"format": "text", "runs": "3"}, false},
+		{"one url", []string{"https://a.example"}, nil, true},
+		{"zero runs", []string{"https://a.example", "https://b.example"}, map[string]string{"runs": "0"}, true},
+		{"invalid format", []string{"https://a.example", "https://b.example"}, map[string]string{"format": "pdf"}, true},
+		{"invalid wait condition", []string{"https://a.example", "https://b.example"}, map[string]string{"wait-until": "idle"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newCompareCmd()
+			for name, value := range tt.flags {
+				if err := cmd.Flags().Set(name, value); err != nil {
+					t.Fatalf("Failed to set %s flag: %v", name, err)
+				}
+			}
+			err := cmd.Args(cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"browser-tools-go/internal/browser"
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/logic"
+
+	"github.com/chromedp/cdproto/target"
+	"github.com/spf13/cobra"
+)
+
+// formatValues are the values accepted by the --format flag of each command,
+// keyed by command path.
+var formatValues = map[string][]string{
+	"compare":        {"markdown", "text", "html"},
+	"content":        {"markdown", "text", "html"},
+	"crawl":          {"markdown", "text", "html"},
+	"cookies":        {"json", "header"},
+	"cookies export": {"json", "netscape"},
+	"version":        {"text", "json"},
+}
+
+// registerCompletions attaches the dynamic completions to the flags of the
+// command tree rooted at root.
+func registerCompletions(root *cobra.Command) {
+	_ = root.RegisterFlagCompletionFunc("target", completeTargets)
+
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		path := strings.TrimPrefix(c.CommandPath(), root.Name()+" ")
+		if values, ok := formatValues[path]; ok {
+			_ = c.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp))
+		}
+		switch path {
+		case "crawl":
+			_ = c.RegisterFlagCompletionFunc("resume", completeCrawlRuns)
+		case "search":
+			_ = c.RegisterFlagCompletionFunc("include", cobra.FixedCompletions(logic.SearchVerticals(), cobra.ShellCompDirectiveNoFileComp))
+		case "screenshot":
+			_ = c.RegisterFlagCompletionFunc("themes", cobra.FixedCompletions(logic.ColorSchemes, cobra.ShellCompDirectiveNoFileComp))
+		case "batch":
+			_ = c.RegisterFlagCompletionFunc("on-error", cobra.FixedCompletions([]string{onErrorStop, onErrorContinue}, cobra.ShellCompDirectiveNoFileComp))
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+}
+
+// completeTargets completes --target with the tabs open in the running
+// session, as id=, url= and title= expressions.
+func completeTargets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	tabs, err := browser.SessionTabs()
+	if err != nil {
+		cobra.CompDebugln(err.Error(), true)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return targetCompletions(tabs, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// targetCompletions returns the --target expressions selecting tabs that
+// start with toComplete, each described by the tab's title or URL.
+func targetCompletions(tabs []*target.Info, toComplete string) []string {
+	var completions []string
+	add := func(value, description string) {
+		if strings.HasPrefix(value, toComplete) {
+			completions = append(completions, value+"\t"+description)
+		}
+	}
+	for _, tab := range tabs {
+		add("id="+string(tab.TargetID), tab.Title)
+		add("url="+tab.URL, tab.Title)
+		if tab.Title != "" {
+			add("title="+tab.Title, tab.URL)
+		}
+	}
+	return completions
+}
+
+// completeCrawlRuns completes --resume with the crawl runs that haven't completed.
+func completeCrawlRuns(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	checkpoints, err := config.ListCrawlCheckpoints()
+	if err != nil {
+		cobra.CompDebugln(err.Error(), true)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	for _, checkpoint := range checkpoints {
+		if checkpoint.Completed || !strings.HasPrefix(checkpoint.RunID, toComplete) {
+			continue
+		}
+		completions = append(completions, fmt.Sprintf("%s\t%s (%d pages done)", checkpoint.RunID, checkpoint.StartURL, len(checkpoint.Visited)))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
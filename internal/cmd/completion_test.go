@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"browser-tools-go/internal/config"
+
+	"github.com/chromedp/cdproto/target"
+)
+
+// complete はシェル補完と同じ__completeコマンドを実行し、候補を返します。
+func complete(t *testing.T, args ...string) []string {
+	t.Helper()
+	root := NewRootCmd()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs(append([]string{"__complete"}, args...))
+	if err := root.Execute(); err != nil {
+		t.Fatalf("__complete %v failed: %v", args, err)
+	}
+	var completions []string
+	for _, line := range strings.Split(out.String(), "\n") {
+		if line == "" || strings.HasPrefix(line, ":") {
+			continue
+		}
+		completions = append(completions, line)
+	}
+	return completions
+}
+
+// TestCompletion_Format は--formatの値が補完されることをテストします。
+func TestCompletion_Format(t *testing.T) {
+	got := complete(t, "crawl", "--format", "")
+	if strings.Join(got, ",") != "markdown,text,html" {
+		t.Errorf("Expected content formats, got %v", got)
+	}
+	got = complete(t, "cookies", "export", "--format", "")
+	if strings.Join(got, ",") != "json,netscape" {
+		t.Errorf("Expected cookie formats, got %v", got)
+	}
+}
+
+// TestCompletion_CrawlResume は--resumeが未完了のクロールで補完されることをテストします。
+func TestCompletion_CrawlResume(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	runs := []*config.CrawlCheckpoint{
+		{RunID: "20260101-000000-aaaaaa", StartURL: "https://example.com/", Visited: []string{"https://example.com/"}},
+		{RunID: "20260102-000000-bbbbbb", StartURL: "https://example.org/", Completed: true},
+	}
+	for _, run := range runs {
+		if err := config.SaveCrawlCheckpoint(run); err != nil {
+			t.Fatalf("SaveCrawlCheckpoint() error = %v", err)
+		}
+	}
+
+	got := complete(t, "crawl", "--resume", "2026")
+	if len(got) != 1 || got[0] != "20260101-000000-aaaaaa\thttps://example.com/ (1 pages done)" {
+		t.Errorf("Expected only the unfinished run, got %v", got)
+	}
+}
+
+// TestTargetCompletions は開いているタブから--targetの候補を作れることをテストします。
+func TestTargetCompletions(t *testing.T) {
+	tabs := []*target.Info{
+		{TargetID: "AAA", Type: "page", URL: "https://app.example.com/dashboard", Title: "Dashboard"},
+		{TargetID: "BBB", Type: "page", URL: "about:blank"},
+	}
+
+	got := targetCompletions(tabs, "")
+	want := []string{
+		"id=AAA\tDashboard",
+		"url=https://app.example.com/dashboard\tDashboard",
+		"title=Dashboard\thttps://app.example.com/dashboard",
+		"id=BBB\t",
+		"url=about:blank\t",
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("targetCompletions() = %q, want %q", got, want)
+	}
+
+	got = targetCompletions(tabs, "url=https://app")
+	if len(got) != 1 || !strings.HasPrefix(got[0], "url=https://app.example.com/dashboard") {
+		t.Errorf("Expected completions filtered by prefix, got %q", got)
+	}
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func newCookiesCmd() *cobra.Command {
+	var filter logic.CookieFilter
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "cookies",
+		Short: "Display the cookies of the current browser context",
+		Long: `Displays the cookies of the browser as JSON. --url only lists the cookies the
+browser would send with a request to that URL; --domain and --name narrow the list
+further. --format header prints a Cookie header value instead.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			for _, u := range filter.URLs {
+				if err := logic.ValidateAbsoluteURL(u); err != nil {
+					return fmt.Errorf("--url: %w", err)
+				}
+			}
+			if values := formatValues["cookies"]; !slices.Contains(values, format) {
+				return fmt.Errorf("invalid --format %q (expected %s)", format, strings.Join(values, ", "))
+			}
+			return cobra.NoArgs(cmd, args)
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Println("🌐 Retrieving cookies...")
+
+			cookies, err := logic.ListCookies(bc.ctx, filter)
+			if err != nil {
+				fatalf("✗ Failed to get cookies: %v", err)
+			}
+			if format == "header" {
+				fmt.Println(logic.CookieHeader(cookies))
+				return
+			}
+			prettyPrintResults(cookies)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&filter.URLs, "url", nil, "Only list the cookies sent with requests to this URL (repeatable)")
+	cmd.Flags().StringVar(&filter.Domain, "domain", "", "Only list cookies of this domain and its subdomains")
+	cmd.Flags().StringVar(&filter.Name, "name", "", "Only list cookies with this name")
+	cmd.Flags().StringVar(&format, "format", "json", "Output format (json, or header for a Cookie header value)")
+	cmd.AddCommand(newCookiesSetCmd(), newCookiesDeleteCmd(), newCookiesClearCmd(), newCookiesExportCmd(), newCookiesImportCmd())
+	return cmd
+}
+
+func newCookiesSetCmd() *cobra.Command {
+	var params logic.CookieParams
+	var expires string
+
+	cmd := &cobra.Command{
+		Use:               "set",
+		Short:             "Create or replace a cookie",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			params.Expires, err = logic.ParseCookieExpiry(expires, time.Now())
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+
+			i18n.Printf("🍪 Setting cookie: %s", params.Name)
+			if err := logic.SetCookie(bc.ctx, params); err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Println("✅ Cookie set.")
+		},
+	}
+
+	cmd.Flags().StringVar(&params.Name, "name", "", "Cookie name")
+	cmd.Flags().StringVar(&params.Value, "value", "", "Cookie value")
+	cmd.Flags().StringVar(&params.Domain, "domain", "", "Cookie domain (default: host of the current page)")
+	cmd.Flags().StringVar(&params.Path, "path", "/", "Cookie path")
+	cmd.Flags().BoolVar(&params.Secure, "secure", false, "Only send the cookie over HTTPS")
+	cmd.Flags().BoolVar(&params.HTTPOnly, "http-only", false, "Hide the cookie from JavaScript")
+	cmd.Flags().StringVar(&params.SameSite, "same-site", "", "SameSite attribute (Strict, Lax or None)")
+	cmd.Flags().StringVar(&expires, "expires", "", "Expiry as RFC 3339 time, Unix seconds or a duration like 720h (default: session cookie)")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func newCookiesDeleteCmd() *cobra.Command {
+	var name, domain string
+
+	cmd := &cobra.Command{
+		Use:               "delete",
+		Short:             "Delete cookies by name, optionally limited to a domain",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Printf("🗑️ Deleting cookie: %s", name)
+			deleted, err := logic.DeleteCookies(bc.ctx, name, domain)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("✅ Deleted %d cookie(s).", deleted)
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Cookie name")
+	cmd.Flags().StringVar(&domain, "domain", "", "Only delete cookies of this domain and its subdomains")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func newCookiesClearCmd() *cobra.Command {
+	var domain string
+
+	cmd := &cobra.Command{
+		Use:               "clear",
+		Short:             "Delete all cookies, optionally limited to a domain",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Println("🗑️ Clearing cookies...")
+			deleted, err := logic.DeleteCookies(bc.ctx, "", domain)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("✅ Deleted %d cookie(s).", deleted)
+		},
+	}
+
+	cmd.Flags().StringVar(&domain, "domain", "", "Only delete cookies of this domain and its subdomains")
+	return cmd
+}
+
+func newCookiesExportCmd() *cobra.Command {
+	var format, out string
+
+	cmd := &cobra.Command{
+		Use:               "export",
+		Short:             "Export all cookies as a Netscape cookie jar or JSON",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Printf("📤 Exporting cookies (format: %s)...", format)
+			data, err := logic.ExportCookies(bc.ctx, format)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+
+			if out == "" {
+				os.Stdout.Write(data)
+				return
+			}
+			if err := utils.SecureWriteFile(out, data, 0600, "."); err != nil {
+				fatalf("✗ Failed to write cookies to %s: %v", out, err)
+			}
+			i18n.Printf("✅ Cookies saved to %s", out)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "json", "Output format (json or netscape)")
+	cmd.Flags().StringVar(&out, "out", "", "Write to this file instead of stdout")
+	return cmd
+}
+
+func newCookiesImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import cookies from a Netscape cookie jar or JSON file",
+		Long: `Imports cookies from a Netscape cookie jar (as written by curl -c or wget
+--save-cookies) or a JSON array (as written by "cookies export" or browser
+cookie extensions). The format is detected from the file contents; use "-" to
+read from stdin.`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			var data []byte
+			if args[0] == "-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				fatalf("✗ Failed to read cookie file: %v", err)
+			}
+
+			cookies, err := logic.ParseCookieFile(data)
+			if err != nil {
+				fatalf("✗ Failed to parse cookie file: %v", err)
+			}
+
+			i18n.Printf("📥 Importing %d cookie(s)...", len(cookies))
+			if err := logic.ImportCookies(bc.ctx, cookies); err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Println("✅ Cookies imported.")
+		},
+	}
+	return cmd
+}
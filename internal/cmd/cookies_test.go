@@ -0,0 +1,58 @@
+package cmd
+
+import "testing"
+
+// TestNewCookiesCmd_Subcommands はcookiesコマンドのサブコマンド構成をテストします。
+func TestNewCookiesCmd_Subcommands(t *testing.T) {
+	cmd := newCookiesCmd()
+
+	for _, name := range []string{"set", "delete", "clear", "export", "import"} {
+		sub, _, err := cmd.Find([]string{name})
+		if err != nil || sub.Name() != name {
+			t.Errorf("Expected subcommand %s, got %v (err: %v)", name, sub, err)
+		}
+	}
+}
+
+// TestNewCookiesSetCmd_FlagDefaults はcookies setコマンドのフラグのデフォルト値をテストします。
+func TestNewCookiesSetCmd_FlagDefaults(t *testing.T) {
+	cmd := newCookiesSetCmd()
+
+	if path := cmd.Flags().Lookup("path"); path == nil || path.DefValue != "/" {
+		t.Errorf("Expected path flag with default '/', got %v", path)
+	}
+	for _, name := range []string{"name", "value", "domain", "secure", "http-only", "same-site", "expires"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag %s to be defined", name)
+		}
+	}
+}
+
+// TestNewCookiesCmd_Args はcookiesコマンドの絞り込みフラグの検証をテストします。
+func TestNewCookiesCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{"default", nil, false},
+		{"header for url", map[string]string{"url": "https://example.com/account", "format": "header"}, false},
+		{"domain and name", map[string]string{"domain": "example.com", "name": "session"}, false},
+		{"relative url", map[string]string{"url": "/account"}, true},
+		{"invalid format", map[string]string{"format": "netscape"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newCookiesCmd()
+			for name, value := range tt.flags {
+				if err := cmd.Flags().Set(name, value); err != nil {
+					t.Fatalf("Failed to set %s flag: %v", name, err)
+				}
+			}
+			err := cmd.Args(cmd, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"browser-tools-go/internal/browser"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+// crashWatch is the tab of this process watched for crashes; ctx is nil until
+// persistentPreRunE connects to it.
+var crashWatch struct {
+	ctx         context.Context
+	opts        browser.PersistentOptions
+	autoRecover bool
+}
+
+// watchCrash makes ctx fail as soon as its tab crashes, and remembers it so
+// that a failure can be reported as the crash rather than what it caused.
+func watchCrash(cmd *cobra.Command, ctx context.Context, opts browser.PersistentOptions) (context.Context, context.CancelFunc) {
+	ctx, stop := browser.WatchCrash(ctx)
+	crashWatch.ctx = ctx
+	crashWatch.opts = opts
+	crashWatch.autoRecover, _ = cmd.Flags().GetBool("auto-recover")
+	return ctx, stop
+}
+
+// tabCrash returns the crash of the tab of this process, or nil.
+func tabCrash() *browser.CrashError {
+	if crashWatch.ctx == nil {
+		return nil
+	}
+	return browser.TabCrash(crashWatch.ctx)
+}
+
+// crashMessage reports a failure caused by a tab crash as the crash, followed
+// by the failure itself; other failures are returned as they are.
+func crashMessage(message string) string {
+	crash := tabCrash()
+	if crash == nil {
+		return message
+	}
+	return "✗ " + crash.Error() + ": " + strings.TrimSpace(strings.TrimPrefix(message, "✗"))
+}
+
+// discardCrashedTab closes the crashed automation tab with --auto-recover, so
+// that the next command opens a new one. It reports whether it did.
+func discardCrashedTab(crash *browser.CrashError) bool {
+	if !crashWatch.autoRecover {
+		i18n.Println("💡 Run again with --auto-recover to replace the crashed tab")
+		return false
+	}
+	if err := browser.DiscardCrashedTab(crash.TargetID); err != nil {
+		i18n.Printf("⚠️ Failed to recover from the crash: %v", err)
+		return false
+	}
+	return true
+}
+
+// recoverSession replaces the crashed tab of bc, shared by the commands of
+// batch, with a new automation tab when --auto-recover is given, so that the
+// remaining commands run on it.
+func recoverSession(bc *browserCtx) {
+	crash := tabCrash()
+	if crash == nil || !discardCrashedTab(crash) {
+		return
+	}
+	ctx, cancel, err := browser.NewPersistentContext(crashWatch.opts)
+	if err != nil {
+		i18n.Printf("⚠️ Failed to recover from the crash: %v", err)
+		return
+	}
+	ctx, stop := browser.WatchCrash(ctx)
+	crashWatch.ctx = ctx
+	if failureArtifacts.ctx != nil {
+		failureArtifacts.ctx = ctx
+		failureArtifacts.console = logic.WatchConsole(ctx)
+	}
+	previous := bc.cancel
+	bc.ctx = withCommandSpan(ctx, bc.ctx)
+	bc.cancel = func() {
+		stop()
+		cancel()
+		previous()
+	}
+	i18n.Println("🔁 Opened a new automation tab in place of the crashed one")
+}
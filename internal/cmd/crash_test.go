@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"browser-tools-go/internal/browser"
+)
+
+// TestCrashMessage はタブのクラッシュによる失敗メッセージをテストします。
+func TestCrashMessage(t *testing.T) {
+	t.Cleanup(func() { crashWatch.ctx = nil })
+
+	message := "✗ Failed to navigate: context canceled"
+	if got := crashMessage(message); got != message {
+		t.Errorf("Expected the message as it is without a watched tab, got %q", got)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	crashWatch.ctx = ctx
+	if got := crashMessage(message); got != message {
+		t.Errorf("Expected the message as it is while the tab is alive, got %q", got)
+	}
+	cancel(&browser.CrashError{TargetID: "ABC123", Status: "oom"})
+	want := "✗ tab ABC123 crashed (oom): Failed to navigate: context canceled"
+	if got := crashMessage(message); got != want {
+		t.Errorf("crashMessage() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"time"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+// dedupeFlags holds the --dedupe-store options shared by scrapers and watch.
+type dedupeFlags struct {
+	path string
+	ttl  time.Duration
+}
+
+func (d *dedupeFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&d.path, "dedupe-store", "", "Database file remembering items seen in previous runs; seen items are skipped")
+	cmd.Flags().DurationVar(&d.ttl, "dedupe-ttl", 7*24*time.Hour, "How long a seen item is remembered (0 = forever)")
+}
+
+// open opens the dedupe store, or returns nil when --dedupe-store is not set.
+func (d *dedupeFlags) open() *store.DedupeStore {
+	if d.path == "" {
+		return nil
+	}
+	s, err := store.OpenDedupeStore(d.path, d.ttl)
+	if err != nil {
+		fatalf("✗ %v", err)
+	}
+	return s
+}
+
+// filterSeen drops items whose key was already seen in a previous run and marks
+// the remaining ones as seen. It returns items unchanged when s is nil.
+func filterSeen[T any](s *store.DedupeStore, namespace string, items []T, key func(T) string) []T {
+	if s == nil {
+		return items
+	}
+
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = key(item)
+	}
+	fresh, err := s.FilterUnseen(namespace, keys)
+	if err != nil {
+		fatalf("✗ %v", err)
+	}
+
+	filtered := make([]T, 0, len(fresh))
+	for _, i := range fresh {
+		filtered = append(filtered, items[i])
+	}
+	if skipped := len(items) - len(filtered); skipped > 0 {
+		i18n.Printf("⏭️ Skipped %d item(s) seen in previous runs.", skipped)
+	}
+	return filtered
+}
@@ -0,0 +1,462 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"browser-tools-go/internal/browser"
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Kinds of argument and flag values, telling --dry-run what a command does
+// with them and how to validate them.
+const (
+	valueVisit     = "visit"     // page the tab navigates to
+	valueRequest   = "request"   // URL requested from the page, may be relative
+	valueSelector  = "select"    // CSS selector
+	valueSelectors = "selectors" // CSS selector fallback list, "a||b"
+	valueRead      = "read"      // input file, "-" for stdin
+	valueWrite     = "write"     // output file or directory below the working directory
+	valueDatabase  = "database"  // database file, created when missing
+	valueSend      = "send"      // endpoint results are POSTed to
+	valueUpload    = "upload"    // s3:// destination
+)
+
+// stepOrder orders the steps of a plan by action.
+var stepOrder = []string{valueRead, valueVisit, valueRequest, valueSelector, valueWrite, valueSend, valueUpload}
+
+// dryRunSpec describes the values of a command's arguments and flags.
+type dryRunSpec struct {
+	args  []string          // kind of each positional argument; "" for other values
+	flags map[string]string // kind by flag name
+}
+
+// dryRunSpecs describe the commands, keyed by command path.
+var dryRunSpecs = map[string]dryRunSpec{
+	"archive":             {args: []string{valueVisit}, flags: map[string]string{"warc": valueWrite}},
+	"audit assets":        {args: []string{valueVisit}},
+	"audit headers":       {args: []string{valueVisit}},
+	"audit mixed-content": {args: []string{valueVisit}},
+	"audit third-party":   {args: []string{valueVisit}},
+	"batch":               {args: []string{valueRead}},
+	"bench":               {args: []string{valueVisit}, flags: map[string]string{"baseline": valueRead, "save-baseline": valueWrite}},
+	"bind":                {args: []string{"", valueVisit}, flags: map[string]string{"script": valueRead}},
+	"capture-api":         {args: []string{valueVisit}},
+	"cert":                {args: []string{valueVisit}},
+	"compare":             {args: []string{valueVisit, valueVisit}, flags: map[string]string{"selector": valueSelector, "screenshots": valueWrite}},
+	"content":             {args: []string{valueVisit}, flags: map[string]string{"selector": valueSelector, "strip": valueSelector, "out-dir": valueWrite}},
+	"count":               {args: []string{valueSelector}},
+	"cookies export":      {flags: map[string]string{"out": valueWrite}},
+	"cookies import":      {args: []string{valueRead}},
+	"crawl":               {flags: map[string]string{"warc": valueWrite, "out-dir": valueWrite}},
+	"diff":                {args: []string{valueRead, valueRead}, flags: map[string]string{"live": valueVisit}},
+	"download":            {args: []string{valueRequest, valueWrite}},
+	"eval":                {flags: map[string]string{"file": valueRead, "args-json": valueRead, "out": valueWrite}},
+	"exists":              {args: []string{valueSelector}},
+	"fetch":               {args: []string{valueRequest}},
+	"get":                 {args: []string{valueSelector}},
+	"graphql":             {args: []string{valueRequest}, flags: map[string]string{"query": valueRead, "variables": valueRead}},
+	"har to-curl":         {args: []string{valueRead}},
+	"highlight":           {args: []string{valueSelector}},
+	"navigate":            {args: []string{valueVisit}, flags: map[string]string{"budget": valueRead}},
+	"network":             {args: []string{valueVisit}},
+	"pick":                {args: []string{valueSelectors}, flags: map[string]string{"selector": valueSelectors}},
+	"pixel":               {flags: map[string]string{"selector": valueSelector}},
+	"qr decode":           {flags: map[string]string{"file": valueRead, "selector": valueSelector}},
+	"run":                 {flags: map[string]string{"state": valueRead}},
+	"save-mhtml":          {flags: map[string]string{"url": valueVisit}},
+	"save-page":           {args: []string{valueWrite}, flags: map[string]string{"url": valueVisit}},
+	"screenshot":          {flags: map[string]string{"url": valueVisit}},
+	"script":              {args: []string{valueRead}},
+	"set":                 {args: []string{valueSelector}},
+	"sites add":           {flags: map[string]string{"selector-config": valueRead}},
+	"source":              {args: []string{valueVisit}},
+	"state load":          {args: []string{valueRead}},
+	"state save":          {args: []string{valueWrite}},
+	"trace-redirects":     {args: []string{valueVisit}},
+	"type":                {args: []string{valueSelector}},
+	"wait":                {flags: map[string]string{"selector": valueSelectors}},
+	"watch":               {args: []string{valueVisit}, flags: map[string]string{"selector": valueSelector}},
+	"weigh":               {args: []string{valueVisit}},
+}
+
+// sharedFlagKinds are the kinds of flags shared by several commands.
+var sharedFlagKinds = map[string]string{
+	"dedupe-store":         valueDatabase,
+	"on-failure-artifacts": valueWrite,
+	"webhook":              valueSend,
+	"upload":               valueUpload,
+}
+
+// dryRun prints the plan of cmd instead of running it when --dry-run is
+// given, and reports whether it did. The plan is printed as JSON; the command
+// fails when the plan has problems.
+func dryRun(cmd *cobra.Command, args []string, session bool) bool {
+	if enabled, _ := cmd.Flags().GetBool("dry-run"); !enabled {
+		return false
+	}
+	plan := newPlan(cmd, args, session)
+	prettyPrintResults(plan)
+	// Cobra runs the fields as they are after the pre-run hooks.
+	cmd.PreRun, cmd.PreRunE, cmd.RunE, cmd.PostRun, cmd.PostRunE = nil, nil, nil, nil, nil
+	cmd.Run = func(*cobra.Command, []string) {}
+	if !plan.OK {
+		fatalf("✗ Dry run found %d problem(s)", len(plan.Problems))
+	}
+	return true
+}
+
+// newPlan returns the plan of cmd run with args, whose flags are parsed.
+// session tells whether cmd drives the browser session, which is then
+// checked to be reachable.
+func newPlan(cmd *cobra.Command, args []string, session bool) *models.DryRunPlan {
+	if args == nil {
+		args = []string{}
+	}
+	plan := &models.DryRunPlan{Command: commandName(cmd), Args: args, Steps: []models.DryRunStep{}}
+	policy, err := config.LoadPolicy()
+	p := &planner{plan: plan, policy: policy}
+	if err != nil {
+		p.problem("%v", err)
+	}
+	p.command(cmd, args)
+	if session {
+		plan.Browser = p.session(cmd)
+	}
+	sort.SliceStable(plan.Steps, func(i, j int) bool {
+		if plan.Steps[i].Line != plan.Steps[j].Line {
+			return plan.Steps[i].Line < plan.Steps[j].Line
+		}
+		return slices.Index(stepOrder, plan.Steps[i].Action) < slices.Index(stepOrder, plan.Steps[j].Action)
+	})
+	plan.OK = len(plan.Problems) == 0
+	return plan
+}
+
+// commandName returns the path of cmd below the root command.
+func commandName(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// planner collects the steps and problems of a plan. line numbers the
+// commands of batch and pipe-line.
+type planner struct {
+	plan   *models.DryRunPlan
+	line   int
+	policy *config.Policy // host policy the URLs to visit and request are checked against
+}
+
+func (p *planner) problem(format string, v ...interface{}) {
+	msg := fmt.Sprintf(format, v...)
+	if p.line > 0 {
+		msg = fmt.Sprintf("line %d: %s", p.line, msg)
+	}
+	p.plan.Problems = append(p.plan.Problems, msg)
+}
+
+// add validates a value of the given kind and adds the step using it.
+func (p *planner) add(kind, value, detail string) {
+	if value == "" {
+		return
+	}
+	action, target := kind, value
+	var err error
+	switch kind {
+	case valueVisit:
+		if err = logic.ValidateAbsoluteURL(value); err == nil {
+			err = p.policy.Allows(value)
+		}
+	case valueRequest:
+		err = p.policy.Allows(value)
+	case valueSend:
+		err = logic.ValidateAbsoluteURL(value)
+	case valueSelector:
+		err = logic.ValidateSelector(value)
+	case valueSelectors:
+		action = valueSelector
+		for _, candidate := range logic.SelectorCandidates(value) {
+			if err = logic.ValidateSelector(candidate); err != nil {
+				break
+			}
+		}
+	case valueRead:
+		err = checkInputFile(value)
+	case valueWrite:
+		target, err = utils.ValidateFilePath(value, false, ".")
+		if err != nil {
+			err = fmt.Errorf("invalid output path %q: %w", value, err)
+		}
+	case valueDatabase:
+		action = valueWrite
+		err = checkParentDir(value)
+	case valueUpload:
+		if _, err = logic.ParseS3URL(value); err == nil {
+			_, err = logic.AWSCredentialsFromEnv()
+		}
+	}
+	if err != nil {
+		if strings.HasPrefix(detail, "--") {
+			p.problem("%s: %v", detail, err)
+		} else {
+			p.problem("%v", err)
+		}
+		return
+	}
+	p.plan.Steps = append(p.plan.Steps, models.DryRunStep{Line: p.line, Action: action, Target: target, Detail: detail})
+}
+
+// command adds the steps of cmd run with args, whose flags are parsed.
+func (p *planner) command(cmd *cobra.Command, args []string) {
+	path := commandName(cmd)
+	spec := dryRunSpecs[path]
+	for i, arg := range args {
+		if i < len(spec.args) {
+			p.add(spec.args[i], arg, "")
+		}
+	}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		kind, ok := spec.flags[f.Name]
+		if !ok {
+			kind = sharedFlagKinds[f.Name]
+		}
+		if kind == "" {
+			return
+		}
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			for _, value := range slice.GetSlice() {
+				p.add(kind, value, "--"+f.Name)
+			}
+			return
+		}
+		p.add(kind, f.Value.String(), "--"+f.Name)
+	})
+	if values, ok := formatValues[path]; ok {
+		if format, _ := cmd.Flags().GetString("format"); !slices.Contains(values, format) {
+			p.problem("invalid --format %q (expected %s)", format, strings.Join(values, ", "))
+		}
+	}
+
+	switch path {
+	case "screenshot":
+		target := ""
+		if len(args) > 0 {
+			target = args[0]
+		}
+		targets := []string{target}
+		if themes, _ := cmd.Flags().GetStringSlice("themes"); len(themes) > 0 {
+			schemes, _ := logic.ParseColorSchemes(themes)
+			targets = nil
+			for _, scheme := range schemes {
+				targets = append(targets, themeScreenshotPath(target, scheme))
+			}
+		}
+		for _, target := range targets {
+			validated, err := utils.ValidateScreenshotPath(target, ".")
+			if err != nil {
+				p.problem("invalid screenshot path %q: %v", target, err)
+				return
+			}
+			p.add(valueWrite, validated, "")
+		}
+	case "save-mhtml":
+		target := ""
+		if len(args) > 0 {
+			target = args[0]
+		}
+		validated, err := logic.MHTMLPath(target)
+		if err != nil {
+			p.problem("invalid MHTML file path %q: %v", target, err)
+			return
+		}
+		p.add(valueWrite, validated, "")
+	case "crawl":
+		p.crawl(cmd, args)
+	case "login":
+		p.login(cmd)
+	case "batch":
+		steps, err := readCommandFile(args[0])
+		if err != nil {
+			return // reported by the read step
+		}
+		for _, step := range steps {
+			p.commandLine(step.Line, step.Command)
+		}
+	case "pipe-line":
+		for i, line := range args {
+			p.commandLine(i+1, line)
+		}
+	}
+}
+
+// crawl adds the pages a crawl starts from, with its limits.
+func (p *planner) crawl(cmd *cobra.Command, args []string) {
+	if resume, _ := cmd.Flags().GetString("resume"); resume != "" {
+		checkpoint, err := config.LoadCrawlCheckpoint(resume)
+		if err != nil {
+			p.problem("%v", err)
+			return
+		}
+		if checkpoint.Completed {
+			p.add(valueVisit, checkpoint.StartURL, fmt.Sprintf("run %s already completed, nothing to do", checkpoint.RunID))
+			return
+		}
+		p.add(valueVisit, checkpoint.StartURL, fmt.Sprintf("resume run %s: %d pages done, %d queued", checkpoint.RunID, len(checkpoint.Visited), len(checkpoint.Frontier)))
+		return
+	}
+
+	depth, _ := cmd.Flags().GetInt("depth")
+	maxPages, _ := cmd.Flags().GetInt("max-pages")
+	sameHost, _ := cmd.Flags().GetBool("same-host")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	detail := fmt.Sprintf("follow links up to %d hops", depth)
+	if maxPages > 0 {
+		detail += fmt.Sprintf(", at most %d pages", maxPages)
+	}
+	if sameHost {
+		detail += ", same host only"
+	}
+	if concurrency > 1 {
+		detail += fmt.Sprintf(", %d pages at a time", concurrency)
+	}
+	p.add(valueVisit, args[0], detail)
+}
+
+// login adds the login page of the recipe of --site, checking that its
+// credentials can be resolved.
+func (p *planner) login(cmd *cobra.Command) {
+	site, _ := cmd.Flags().GetString("site")
+	recipe, err := config.LoadLoginRecipe(site)
+	if err != nil {
+		p.problem("%v", err)
+		return
+	}
+	refs := []string{recipe.Username, recipe.Password}
+	if recipe.MFA != nil && recipe.MFA.Code != "" {
+		refs = append(refs, recipe.MFA.Code)
+	}
+	for _, ref := range refs {
+		if _, err := config.ResolveSecret(ref); err != nil {
+			p.problem("%v", err)
+		}
+	}
+	if recipe.MFA != nil && recipe.MFA.TOTPSecret != "" {
+		secret, err := config.ResolveSecret(recipe.MFA.TOTPSecret)
+		if err == nil {
+			_, _, err = logic.TOTP(secret, time.Now())
+		}
+		if err != nil {
+			p.problem("mfa.totpSecret: %v", err)
+		}
+	}
+	p.add(valueVisit, recipe.URL, "log in to "+site)
+}
+
+// commandLine adds the steps of a command line of batch or pipe-line,
+// validating it like the command would be.
+func (p *planner) commandLine(line int, commandLine string) {
+	outer := p.line
+	p.line = line
+	defer func() { p.line = outer }()
+
+	args, err := splitCommandLine(commandLine)
+	if err == nil {
+		err = validateInProcess(args)
+	}
+	if err != nil {
+		p.problem("%v", err)
+		return
+	}
+	root := NewRootCmd()
+	cmd, rest, err := root.Find(args)
+	if err != nil || cmd == root {
+		p.problem("unknown command %q", args[0])
+		return
+	}
+	if err := cmd.ParseFlags(rest); err != nil {
+		p.problem("%v", err)
+		return
+	}
+	args = cmd.Flags().Args()
+	for _, validate := range []func() error{
+		func() error { return cmd.ValidateArgs(args) },
+		cmd.ValidateRequiredFlags,
+		cmd.ValidateFlagGroups,
+	} {
+		if err := validate(); err != nil {
+			p.problem("%s: %v", commandName(cmd), err)
+			return
+		}
+	}
+	p.command(cmd, args)
+}
+
+// session checks that the browser session answers, and that a tab matches
+// --target.
+func (p *planner) session(cmd *cobra.Command) *models.DryRunBrowser {
+	opts, err := persistentOptions(cmd)
+	if err != nil {
+		p.problem("%v", err)
+	}
+	status := &models.DryRunBrowser{}
+	tabs, err := browser.SessionTabs()
+	if err != nil {
+		status.Error = err.Error()
+		if errors.Is(err, browser.ErrStaleSession) {
+			p.problem("%v", err)
+		} else {
+			p.problem("%v (start with 'browser-tools-go start')", err)
+		}
+		return status
+	}
+	status.Connected = true
+	status.OpenTabs = len(tabs)
+	if opts.Target != nil {
+		tab, ok := opts.Target.FindTab(tabs)
+		if !ok {
+			p.problem("no open tab matches %q", opts.Target.String())
+			return status
+		}
+		status.Tab = tab.URL
+	}
+	return status
+}
+
+// checkInputFile checks that path, unless "-" for stdin, is a readable file.
+func checkInputFile(path string) error {
+	if path == "-" {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+	return nil
+}
+
+// checkParentDir checks that the directory path is created in exists.
+func checkParentDir(path string) error {
+	dir := filepath.Dir(path)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
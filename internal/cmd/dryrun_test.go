@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"browser-tools-go/internal/models"
+)
+
+// planArgs はコマンドライン引数の計画を作成します。
+func planArgs(t *testing.T, args ...string) *models.DryRunPlan {
+	t.Helper()
+	root := NewRootCmd()
+	cmd, rest, err := root.Find(args)
+	if err != nil {
+		t.Fatalf("Failed to find command %v: %v", args, err)
+	}
+	if err := cmd.ParseFlags(rest); err != nil {
+		t.Fatalf("Failed to parse flags %v: %v", rest, err)
+	}
+	return newPlan(cmd, cmd.Flags().Args(), false)
+}
+
+// planLine はバッチファイルの1行目として、コマンドの計画を作成します。
+func planLine(t *testing.T, line string) *models.DryRunPlan {
+	t.Helper()
+	plan := &models.DryRunPlan{}
+	p := &planner{plan: plan}
+	p.commandLine(1, line)
+	return plan
+}
+
+// TestNewRootCmd_DryRunFlag はルートコマンドに--dry-runフラグがあることをテストします。
+func TestNewRootCmd_DryRunFlag(t *testing.T) {
+	flag := NewRootCmd().PersistentFlags().Lookup("dry-run")
+	if flag == nil {
+		t.Fatal("Expected persistent dry-run flag on root command")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("Expected dry-run to default to false, got %s", flag.DefValue)
+	}
+}
+
+// TestPlanner_Steps はURLやファイルが計画のステップになることをテストします。
+func TestPlanner_Steps(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	plan := planArgs(t, "crawl", "https://example.com", "--max-pages", "5", "--warc", "out/site.warc")
+	if len(plan.Problems) != 0 {
+		t.Fatalf("Unexpected problems: %v", plan.Problems)
+	}
+	if len(plan.Steps) != 2 {
+		t.Fatalf("Expected 2 steps, got %+v", plan.Steps)
+	}
+	visit := plan.Steps[0]
+	if visit.Action != valueVisit || visit.Target != "https://example.com" || !strings.Contains(visit.Detail, "at most 5 pages") {
+		t.Errorf("Unexpected visit step: %+v", visit)
+	}
+	write := plan.Steps[1]
+	if write.Action != valueWrite || write.Target != filepath.Join("out", "site.warc") || write.Detail != "--warc" {
+		t.Errorf("Unexpected write step: %+v", write)
+	}
+
+	plan = planArgs(t, "screenshot", "--url", "https://example.com", "shot")
+	if len(plan.Steps) != 2 || plan.Steps[1].Target != "shot.png" {
+		t.Errorf("Expected the screenshot to be written to shot.png, got %+v", plan.Steps)
+	}
+
+	plan = planArgs(t, "pick", "#price||.price")
+	if len(plan.Problems) != 0 || len(plan.Steps) != 1 || plan.Steps[0].Action != valueSelector {
+		t.Errorf("Expected a selector fallback list to be one select step, got %+v %v", plan.Steps, plan.Problems)
+	}
+
+	plan = planArgs(t, "screenshot", "shot.png", "--themes", "light,dark")
+	if len(plan.Steps) != 2 || plan.Steps[0].Target != "shot-light.png" || plan.Steps[1].Target != "shot-dark.png" {
+		t.Errorf("Expected one screenshot per theme, got %+v", plan.Steps)
+	}
+}
+
+// TestPlanner_Problems は不正な引数が問題として報告されることをテストします。
+func TestPlanner_Problems(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"navigate example.com", "scheme missing"},
+		{"pick 'div['", "invalid selector"},
+		{"pick 'main||div['", "invalid selector"},
+		{"wait --selector main --selector 'div['", "invalid selector"},
+		{"content --format xml", "invalid --format"},
+		{"state load missing.json", "missing.json"},
+		{"state save ../state.json", "invalid output path"},
+		{"search q --dedupe-store missing/seen.db", "--dedupe-store"},
+		{"navigate", "navigate: accepts 1 arg(s)"},
+		{"crawl https://example.com --concurrency 2 --warc a.warc", "can't be combined"},
+		{"no-such-command", "unknown command"},
+		{"batch x", "can't run inside batch"},
+	}
+	for _, tt := range tests {
+		plan := planLine(t, tt.line)
+		if len(plan.Problems) != 1 || !strings.Contains(plan.Problems[0], tt.want) {
+			t.Errorf("%s: expected one problem containing %q, got %v", tt.line, tt.want, plan.Problems)
+			continue
+		}
+		if !strings.HasPrefix(plan.Problems[0], "line 1: ") {
+			t.Errorf("%s: expected the problem to name the line, got %q", tt.line, plan.Problems[0])
+		}
+	}
+}
+
+// TestPlanner_Batch はバッチファイルの各行が計画に含まれることをテストします。
+func TestPlanner_Batch(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("cmds.txt", []byte("# comment\nnavigate https://a.test\n\nsave-page out --url https://b.test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := planArgs(t, "batch", "cmds.txt")
+	if len(plan.Problems) != 0 {
+		t.Fatalf("Unexpected problems: %v", plan.Problems)
+	}
+	var got []string
+	for _, step := range plan.Steps {
+		got = append(got, step.Action+" "+step.Target)
+	}
+	want := "read cmds.txt,visit https://a.test,visit https://b.test,write out"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Expected steps %s, got %s", want, strings.Join(got, ","))
+	}
+	if !plan.OK || plan.Steps[1].Line != 2 || plan.Steps[3].Line != 4 {
+		t.Errorf("Expected steps to carry their batch line, got %+v", plan.Steps)
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+func newZoomCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "zoom [factor|reset]",
+		Short: "Zoom the page for all following commands of the session",
+		Long: `Zooms the page by <factor> (e.g. 0.5 or 50%) for all following commands of the
+session, until "zoom reset". Zooming out fits more of a dense page into the
+viewport and shrinks full-page screenshots, which keeps them within Chrome's
+capture limits:
+  browser-tools-go zoom 50%
+  browser-tools-go screenshot --full-page dashboard.png
+Without arguments, prints the current zoom factor.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			info, err := config.LoadWsInfo()
+			if err != nil {
+				fatalf("✗ Browser is not running (start with 'browser-tools-go start'): %v", err)
+			}
+			if len(args) == 0 {
+				zoom := info.Zoom
+				if zoom == 0 {
+					zoom = 1
+				}
+				prettyPrintResults(map[string]float64{"zoom": zoom})
+				return
+			}
+
+			factor, err := logic.ParseZoomFactor(args[0])
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			info.Zoom = factor
+			if factor == 1 {
+				info.Zoom = 0
+			}
+			if err := config.WriteWsInfo(info); err != nil {
+				fatalf("✗ Failed to save session info: %v", err)
+			}
+			i18n.Printf("✅ Zoom set to %g%%.", factor*100)
+		},
+	}
+	return cmd
+}
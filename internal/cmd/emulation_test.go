@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"browser-tools-go/internal/config"
+)
+
+// TestNewZoomCmd はzoomコマンドがズーム倍率をセッション情報に保存することをテストします。
+func TestNewZoomCmd(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tempDir)
+	defer os.Setenv("HOME", originalHome)
+
+	if err := config.WriteWsInfo(&config.WsInfo{Url: "ws://localhost:9222/devtools/browser/test", Pid: 1}); err != nil {
+		t.Fatalf("Failed to write session info: %v", err)
+	}
+
+	for _, tt := range []struct {
+		arg  string
+		want float64
+	}{
+		{"50%", 0.5},
+		{"reset", 0},
+	} {
+		cmd := newZoomCmd()
+		cmd.SetArgs([]string{tt.arg})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("zoom %s failed: %v", tt.arg, err)
+		}
+		info, err := config.LoadWsInfo()
+		if err != nil {
+			t.Fatalf("Failed to load session info: %v", err)
+		}
+		if info.Zoom != tt.want {
+			t.Errorf("zoom %s: expected stored zoom %v, got %v", tt.arg, tt.want, info.Zoom)
+		}
+	}
+}
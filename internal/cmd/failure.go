@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// failureArtifacts is what --on-failure-artifacts needs to save the artifacts
+// of a failed command; dir is empty when the flag isn't given.
+var failureArtifacts struct {
+	dir     string
+	command string
+	ctx     context.Context
+	console *logic.ConsoleWatcher
+}
+
+// setupFailureArtifacts starts watching the console of the tab in ctx when
+// --on-failure-artifacts is given, so that a failure can report its tail.
+func setupFailureArtifacts(cmd *cobra.Command, ctx context.Context) error {
+	dir, _ := cmd.Flags().GetString("on-failure-artifacts")
+	if dir == "" {
+		return nil
+	}
+	if _, err := utils.ValidateFilePath(dir, false, "."); err != nil {
+		return fmt.Errorf("invalid --on-failure-artifacts directory: %w", err)
+	}
+	failureArtifacts.dir = dir
+	failureArtifacts.command = commandName(cmd)
+	failureArtifacts.ctx = ctx
+	failureArtifacts.console = logic.WatchConsole(ctx)
+	return nil
+}
+
+// saveFailureArtifacts saves the artifacts of a failed command, when
+// --on-failure-artifacts is given, in a directory of their own named after
+// the time and the command. message is the failure as reported to the user.
+func saveFailureArtifacts(command, message string) {
+	if failureArtifacts.dir == "" {
+		return
+	}
+	now := time.Now()
+	name := now.Format("20060102-150405.000") + "-" + strings.ReplaceAll(command, " ", "-")
+	report := &models.FailureReport{
+		Command: command,
+		Error:   redactor.String(strings.TrimSpace(strings.TrimPrefix(message, "✗"))),
+		Time:    now,
+		Console: failureArtifacts.console.Tail(),
+	}
+	for i, message := range report.Console {
+		report.Console[i] = redactor.ConsoleMessage(message)
+	}
+	path, err := logic.SaveFailureArtifacts(failureArtifacts.ctx, filepath.Join(failureArtifacts.dir, name), report)
+	if err != nil {
+		i18n.Printf("⚠️ Failed to save failure artifacts: %v", err)
+		return
+	}
+	i18n.Printf("🧾 Failure artifacts saved to %s", filepath.Dir(path))
+}
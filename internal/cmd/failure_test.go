@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+)
+
+// TestSetupFailureArtifacts は--on-failure-artifactsの検証をテストします。
+func TestSetupFailureArtifacts(t *testing.T) {
+	t.Cleanup(func() { failureArtifacts.dir = "" })
+
+	root := NewRootCmd()
+	cmd, _, err := root.Find([]string{"navigate"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := setupFailureArtifacts(cmd, context.Background()); err != nil || failureArtifacts.dir != "" {
+		t.Errorf("Expected nothing to be set up without the flag, got %v and %q", err, failureArtifacts.dir)
+	}
+	// Without the flag, failures save nothing.
+	saveFailureArtifacts("navigate", "✗ boom")
+
+	if err := cmd.ParseFlags([]string{"--on-failure-artifacts", "../artifacts"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := setupFailureArtifacts(cmd, context.Background()); err == nil {
+		t.Error("Expected a directory outside the working directory to be rejected")
+	}
+}
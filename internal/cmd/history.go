@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+// recordHistory appends the main-frame navigations made while connected to
+// the session's navigation history, read back by the history command.
+func recordHistory(ctx context.Context) {
+	session := 0
+	if info, err := config.LoadWsInfo(); err == nil {
+		session = info.Pid
+	}
+	logic.TrackNavigations(ctx, func(url, tabID string) {
+		entry := &config.HistoryEntry{URL: url, TabID: tabID, Session: session, VisitedAt: time.Now().UTC()}
+		if err := config.AppendHistory(entry); err != nil {
+			i18n.Printf("⚠️ Failed to record navigation history: %v", err)
+		}
+	})
+}
+
+// filterHistory returns the entries of the given session (all sessions when
+// session is 0) and tab, keeping only the last limit entries when limit > 0.
+func filterHistory(entries []config.HistoryEntry, session int, tabID string, limit int) []config.HistoryEntry {
+	filtered := []config.HistoryEntry{}
+	for _, entry := range entries {
+		if session != 0 && entry.Session != session {
+			continue
+		}
+		if tabID != "" && entry.TabID != tabID {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	if limit > 0 && len(filtered) > limit {
+		filtered = filtered[len(filtered)-limit:]
+	}
+	return filtered
+}
+
+func newHistoryCmd() *cobra.Command {
+	var all, clearHistory bool
+	var tabID string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the URLs visited during the browser session",
+		Long: `Lists the main-frame navigations observed by browser-tools-go commands during the
+current browser session, oldest first, with timestamps and tab IDs. Navigations
+made while no command was connected (e.g. by hand) are not recorded.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if clearHistory {
+				if err := config.ClearHistory(); err != nil {
+					fatalf("✗ Failed to clear history: %v", err)
+				}
+				i18n.Println("✅ Navigation history cleared.")
+				return
+			}
+
+			entries, err := config.LoadHistory()
+			if err != nil {
+				fatalf("✗ Failed to load history: %v", err)
+			}
+			session := 0
+			if !all {
+				info, err := config.LoadWsInfo()
+				if err != nil {
+					fatalf("✗ No browser session is running (use --all to list the history of past sessions)")
+				}
+				session = info.Pid
+			}
+			prettyPrintResults(filterHistory(entries, session, tabID, limit))
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Include navigations of earlier browser sessions")
+	cmd.Flags().StringVar(&tabID, "tab", "", "Only list navigations of this tab ID")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Only list the most recent n navigations (0 = all)")
+	cmd.Flags().BoolVar(&clearHistory, "clear", false, "Delete the recorded history")
+	return cmd
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"testing"
+
+	"browser-tools-go/internal/config"
+)
+
+// TestNewHistoryCmd はhistoryコマンドの定義とフラグをテストします。
+func TestNewHistoryCmd(t *testing.T) {
+	cmd := newHistoryCmd()
+
+	if cmd.Use != "history" {
+		t.Errorf("Expected Use to be 'history', got %s", cmd.Use)
+	}
+	for _, name := range []string{"all", "tab", "limit", "clear"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected '%s' flag to exist", name)
+		}
+	}
+	if cmd.PersistentPreRunE != nil {
+		t.Error("history should not connect to the browser")
+	}
+}
+
+// TestFilterHistory はセッション・タブ・件数による履歴の絞り込みをテストします。
+func TestFilterHistory(t *testing.T) {
+	entries := []config.HistoryEntry{
+		{URL: "https://old.example/", TabID: "A", Session: 1},
+		{URL: "https://a.example/1", TabID: "A", Session: 2},
+		{URL: "https://b.example/1", TabID: "B", Session: 2},
+		{URL: "https://a.example/2", TabID: "A", Session: 2},
+	}
+
+	tests := []struct {
+		name    string
+		session int
+		tabID   string
+		limit   int
+		want    []string
+	}{
+		{"all sessions", 0, "", 0, []string{"https://old.example/", "https://a.example/1", "https://b.example/1", "https://a.example/2"}},
+		{"current session", 2, "", 0, []string{"https://a.example/1", "https://b.example/1", "https://a.example/2"}},
+		{"one tab", 2, "A", 0, []string{"https://a.example/1", "https://a.example/2"}},
+		{"most recent", 2, "", 1, []string{"https://a.example/2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterHistory(entries, tt.session, tt.tabID, tt.limit)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d entries, got %d", len(tt.want), len(got))
+			}
+			for i, url := range tt.want {
+				if got[i].URL != url {
+					t.Errorf("Entry %d: expected %s, got %s", i, url, got[i].URL)
+				}
+			}
+		})
+	}
+}
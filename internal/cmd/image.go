@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+func newQRCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "qr",
+		Short: "Decode QR codes and barcodes",
+	}
+	cmd.AddCommand(newQRDecodeCmd())
+	return cmd
+}
+
+func newQRDecodeCmd() *cobra.Command {
+	var selector, file string
+
+	cmd := &cobra.Command{
+		Use:   "decode",
+		Short: "Decode the QR codes and barcodes shown in the page",
+		Long: `Captures the first element matching --selector (or the viewport) and decodes the
+QR codes and barcodes in it (QR, Data Matrix, Aztec, EAN/UPC, Code 128/39/93,
+Codabar, ITF). Prints the format and text of each code. With --file, decodes a
+local image instead and does not need a browser.`,
+		Args: cobra.NoArgs,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if file != "" {
+				dryRun(cmd, args, false)
+				return nil
+			}
+			return persistentPreRunE(cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			var data []byte
+			var err error
+			if file != "" {
+				data, err = readInputFile(file)
+			} else {
+				bc, bcErr := getBrowserCtx(cmd)
+				if bcErr != nil {
+					fatalf("✗ %v", bcErr)
+				}
+				defer bc.cancel()
+				i18n.Println("📷 Capturing the page for decoding...")
+				data, err = logic.CaptureForDecoding(bc.ctx, selector)
+			}
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+
+			codes, err := logic.DecodeCodes(data)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			if len(codes) == 0 {
+				i18n.Println("✅ No QR codes or barcodes found.")
+			}
+			prettyPrintResults(codes)
+		},
+	}
+
+	cmd.Flags().StringVar(&selector, "selector", "", "Capture only the first element matching this CSS selector (e.g. img.qr)")
+	cmd.Flags().StringVar(&file, "file", "", "Decode a local PNG, JPEG or GIF image (\"-\" for stdin) instead of the page")
+	cmd.MarkFlagsMutuallyExclusive("selector", "file")
+	return cmd
+}
+
+func newPixelCmd() *cobra.Command {
+	var selector, offset string
+
+	cmd := &cobra.Command{
+		Use:   "pixel [x,y]",
+		Short: "Print the rendered color at a point of the page",
+		Long: `Prints the rendered color at viewport point x,y (CSS pixels), or at the first
+element matching --selector: its center, or its top-left corner moved by
+--offset dx,dy. The element is scrolled into view first. Useful for cheap visual
+checks such as status indicators or theme colors:
+  browser-tools-go pixel --selector .status-led`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MaximumNArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			if (len(args) == 1) == (selector != "") {
+				return fmt.Errorf("requires either a point x,y or --selector")
+			}
+			if offset != "" && selector == "" {
+				return fmt.Errorf("--offset requires --selector")
+			}
+			return nil
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			var point logic.Point
+			if selector != "" {
+				var delta *logic.Point
+				if offset != "" {
+					p, err := logic.ParsePoint(offset)
+					if err != nil {
+						fatalf("✗ %v", err)
+					}
+					delta = &p
+				}
+				point, err = logic.ElementPoint(bc.ctx, selector, delta)
+			} else {
+				point, err = logic.ParsePoint(args[0])
+			}
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+
+			pixel, err := logic.SamplePixel(bc.ctx, point)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			prettyPrintResults(pixel)
+		},
+	}
+
+	cmd.Flags().StringVar(&selector, "selector", "", "Sample the first element matching this CSS selector")
+	cmd.Flags().StringVar(&offset, "offset", "", "Offset dx,dy from the element's top-left corner (default: its center)")
+	return cmd
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+)
+
+// TestNewQRCmd_Subcommands はqrコマンドのdecodeサブコマンドとそのフラグ、引数検証をテストします。
+func TestNewQRCmd_Subcommands(t *testing.T) {
+	cmd := newQRCmd()
+
+	decode, _, err := cmd.Find([]string{"decode"})
+	if err != nil || decode.Name() != "decode" {
+		t.Fatalf("Expected 'decode' subcommand, got %v (err: %v)", decode, err)
+	}
+	for _, name := range []string{"selector", "file"} {
+		if decode.Flags().Lookup(name) == nil {
+			t.Errorf("Expected '%s' flag to exist", name)
+		}
+	}
+	if err := decode.Args(decode, []string{"extra"}); err == nil {
+		t.Error("Expected an error for positional arguments")
+	}
+}
+
+// TestNewPixelCmd_Args はpixelコマンドの引数とフラグの組み合わせの検証をテストします。
+func TestNewPixelCmd_Args(t *testing.T) {
+	tests := []struct {
+		name      string
+		flags     map[string]string
+		args      []string
+		expectErr bool
+	}{
+		{"point", nil, []string{"10,20"}, false},
+		{"selector", map[string]string{"selector": ".led"}, nil, false},
+		{"selector with offset", map[string]string{"selector": ".led", "offset": "2,2"}, nil, false},
+		{"nothing", nil, nil, true},
+		{"point and selector", map[string]string{"selector": ".led"}, []string{"10,20"}, true},
+		{"offset without selector", map[string]string{"offset": "2,2"}, []string{"10,20"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newPixelCmd()
+			for name, value := range tt.flags {
+				cmd.Flags().Set(name, value)
+			}
+			err := cmd.Args(cmd, tt.args)
+			if tt.expectErr && err == nil {
+				t.Error("Expected an error but got none")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
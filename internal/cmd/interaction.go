@@ -1,36 +1,91 @@
 package cmd
 
 import (
-	"log"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
 	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/utils"
 
+	"github.com/chromedp/cdproto/target"
 	"github.com/spf13/cobra"
 )
 
 func newPickCmd() *cobra.Command {
-	var all bool
+	var all, allTabs, noAutoScroll bool
+	var selectors []string
 	cmd := &cobra.Command{
-		Use:               "pick <selector>",
-		Short:             "Pick and extract information about elements matching a CSS selector",
-		Args:              cobra.ExactArgs(1),
+		Use:   "pick <selector>",
+		Short: "Pick and extract information about elements matching a CSS selector",
+		Long: `Picks the first element matching <selector>, or all of them with --all, and
+prints its tag, text, attributes and rect.
+
+Several candidate selectors can be given as a fallback list, either separated
+by "||" or as repeated --selector flags:
+  pick "#price||.price||[itemprop=price]"
+  pick --selector "#price" --selector ".price"
+The first candidate that matches a visible element within 30s is used.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(selectors) > 0 {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		PersistentPreRunE: persistentPreRunE,
 		Run: func(cmd *cobra.Command, args []string) {
 			bc, err := getBrowserCtx(cmd)
 			if err != nil {
-				log.Fatalf("✗ %v", err)
+				fatalf("✗ %v", err)
 			}
 			defer bc.cancel()
 
-			log.Printf("🔍 Picking elements with selector: %s (all=%t)...", args[0], all)
+			candidates := logic.SelectorCandidates(append(args, selectors...)...)
+			if len(candidates) == 0 {
+				fatalf("✗ No selector given")
+			}
+			i18n.Printf("🔍 Picking elements with selector: %s (all=%t)...", strings.Join(candidates, logic.SelectorFallbackSeparator), all)
+
+			if allTabs {
+				results, err := runOnAllTabs(bc.ctx, func(ctx context.Context, _ *target.Info) (interface{}, error) {
+					selector, err := resolveSelector(ctx, candidates)
+					if err != nil {
+						return nil, err
+					}
+					elements, err := logic.PickElements(ctx, selector, all, !noAutoScroll)
+					if err != nil || len(elements) == 0 {
+						return nil, err
+					}
+					if all {
+						return elements, nil
+					}
+					return elements[0], nil
+				})
+				if err != nil {
+					fatalf("✗ Failed to pick elements: %v", err)
+				}
+				prettyPrintResults(results)
+				return
+			}
 
-			results, err := logic.PickElements(bc.ctx, args[0], all)
+			selector, err := resolveSelector(bc.ctx, candidates)
+			if err != nil {
+				fatalf("✗ Failed to pick elements: %v", err)
+			}
+			results, err := logic.PickElements(bc.ctx, selector, all, !noAutoScroll)
 			if err != nil {
-				log.Fatalf("✗ Failed to pick elements: %v", err)
+				fatalf("✗ Failed to pick elements: %v", err)
 			}
 			if len(results) == 0 {
-				log.Println("✅ No elements found.")
+				i18n.Println("✅ No elements found.")
 				return
 			}
 
@@ -42,56 +97,626 @@ func newPickCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVar(&all, "all", false, "Extract info from all matching elements")
+	cmd.Flags().BoolVar(&allTabs, "all-tabs", false, "Pick in every open tab and print the results keyed by tab")
+	cmd.Flags().BoolVar(&noAutoScroll, "no-auto-scroll", false, "Report the rects of elements where they are instead of scrolling them into view first")
+	cmd.Flags().StringArrayVar(&selectors, "selector", nil, "Candidate selector, tried in the order given (repeatable; instead of <selector>)")
 	return cmd
 }
 
-func newEvalCmd() *cobra.Command {
+// selectorFallbackTimeout bounds how long pick waits for one of several
+// candidate selectors to match.
+const selectorFallbackTimeout = 30 * time.Second
+
+// resolveSelector returns the selector to use from a fallback list: a single
+// candidate as it is, otherwise the first that matches a visible element.
+func resolveSelector(ctx context.Context, candidates []string) (string, error) {
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	return logic.WaitForAnySelector(ctx, candidates, selectorFallbackTimeout)
+}
+
+func newExistsCmd() *cobra.Command {
+	var visible bool
+
+	cmd := &cobra.Command{
+		Use:   "exists <selector>",
+		Short: "Print whether an element matches a selector, exiting with 2 if none does",
+		Long: `Prints true when an element of the current page matches <selector> and false
+otherwise. The exit status is 0 when an element matches and 2 when none does, so
+shell scripts can branch on page state:
+  if browser-tools-go exists '.login-form'; then ...; fi`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			count, err := logic.CountElements(bc.ctx, args[0], visible)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			prettyPrintResults(count > 0)
+			if count == 0 {
+				bc.cancel()
+				exitCommand(ExitFalse, fmt.Sprintf("no element matches %s", args[0]))
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&visible, "visible", false, "Only consider elements that are displayed")
+	return cmd
+}
+
+func newCountCmd() *cobra.Command {
+	var visible bool
+
+	cmd := &cobra.Command{
+		Use:   "count <selector>",
+		Short: "Print the number of elements matching a selector, exiting with 2 if there are none",
+		Long: `Prints the number of elements of the current page that match <selector>. The
+exit status is 0 when at least one element matches and 2 when none does.`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			count, err := logic.CountElements(bc.ctx, args[0], visible)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			prettyPrintResults(count)
+			if count == 0 {
+				bc.cancel()
+				exitCommand(ExitFalse, fmt.Sprintf("no element matches %s", args[0]))
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&visible, "visible", false, "Only count elements that are displayed")
+	return cmd
+}
+
+func newGetCmd() *cobra.Command {
+	var attr, prop string
+	var all, raw bool
+
 	cmd := &cobra.Command{
-		Use:               "eval <javascript>",
-		Short:             "Execute a JavaScript expression",
-		Args:              cobra.MinimumNArgs(1),
+		Use:   "get <selector>",
+		Short: "Print an attribute or property of the elements matching a selector",
+		Long: `Prints the attribute --attr or the DOM property --prop (such as value, checked
+or innerText) of the first element matching <selector> as JSON, or of every match
+as an array with --all. Missing attributes are null. With --raw, strings are printed
+without quotes, one per line. The exit status is 2 when no element matches.`,
+		Args:              cobra.ExactArgs(1),
 		PersistentPreRunE: persistentPreRunE,
 		Run: func(cmd *cobra.Command, args []string) {
 			bc, err := getBrowserCtx(cmd)
 			if err != nil {
-				log.Fatalf("✗ %v", err)
+				fatalf("✗ %v", err)
 			}
 			defer bc.cancel()
 
-			js := strings.Join(args, " ")
-			log.Printf("📝 Evaluating JavaScript: %s", js)
+			values, err := logic.GetElementValues(bc.ctx, args[0], attr, prop, all)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			if all {
+				printElementValues(values, raw)
+			} else if len(values) > 0 {
+				printElementValues(values[0], raw)
+			}
+			if len(values) == 0 {
+				bc.cancel()
+				exitCommand(ExitFalse, fmt.Sprintf("no element matches %s", args[0]))
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&attr, "attr", "", "Attribute to print, e.g. href")
+	cmd.Flags().StringVar(&prop, "prop", "", "DOM property to print, e.g. value, checked or innerText")
+	cmd.Flags().BoolVar(&all, "all", false, "Print the value of every matching element as an array")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print strings without JSON quoting, one per line")
+	cmd.MarkFlagsOneRequired("attr", "prop")
+	cmd.MarkFlagsMutuallyExclusive("attr", "prop")
+	return cmd
+}
+
+// printElementValues prints the result of get: as JSON, or with raw, strings
+// as they are and each value of a list on its own line.
+func printElementValues(value interface{}, raw bool) {
+	if !raw {
+		prettyPrintResults(value)
+		return
+	}
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			fmt.Println(s)
+		} else {
+			printJSONLine(v)
+		}
+	}
+}
+
+func newSetCmd() *cobra.Command {
+	var value string
+	var checked bool
+
+	cmd := &cobra.Command{
+		Use:   "set <selector>",
+		Short: "Set the value or checked state of a form control",
+		Long: `Sets the value (--value) or the checked state (--checked) of the form control
+matching <selector> and dispatches input and change events, as if the user had
+changed it. The native setters are used, so frameworks such as React pick up the
+change. Works with inputs, textareas, selects (by option value), checkboxes, radio
+buttons and contenteditable elements. Prints the resulting value.`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
 
-			result, err := logic.EvaluateJS(bc.ctx, js)
+			var checkedValue *bool
+			if cmd.Flags().Changed("checked") {
+				checkedValue = &checked
+			}
+			result, err := logic.SetElementValue(bc.ctx, args[0], value, checkedValue)
 			if err != nil {
-				log.Fatalf("✗ Failed to evaluate JavaScript: %v", err)
+				fatalf("✗ %v", err)
 			}
+			i18n.Printf("✅ %s set.", args[0])
 			prettyPrintResults(result)
 		},
 	}
+
+	cmd.Flags().StringVar(&value, "value", "", "Value to set")
+	cmd.Flags().BoolVar(&checked, "checked", false, "Checked state to set on a checkbox or radio button (--checked=false to uncheck)")
+	cmd.MarkFlagsOneRequired("value", "checked")
+	cmd.MarkFlagsMutuallyExclusive("value", "checked")
+	return cmd
+}
+
+func newTypeCmd() *cobra.Command {
+	var totpSecret string
+
+	cmd := &cobra.Command{
+		Use:   "type <selector> [text]",
+		Short: "Type text into an element",
+		Long: `Focuses the element matching <selector> once it is visible and types [text]
+into it key by key, as a user would. Unlike set, the page receives the key
+events, which some fields need.
+
+With --totp-secret, the current TOTP code of an authenticator app setup is
+typed instead: the flag takes a secret reference to the base32 key or
+otpauth:// URI, "keyring:ENTRY" for an entry of the OS keyring or "env:NAME"
+for an environment variable, so that the secret never lands in the shell
+history. The code is never printed.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.RangeArgs(1, 2)(cmd, args); err != nil {
+				return err
+			}
+			if (len(args) == 2) == (totpSecret != "") {
+				return fmt.Errorf("give either [text] or --totp-secret")
+			}
+			if totpSecret != "" && !config.IsSecretReference(totpSecret) {
+				return fmt.Errorf(`--totp-secret must be a secret reference such as "env:NAME" or "keyring:ENTRY"`)
+			}
+			return nil
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			var text string
+			if totpSecret != "" {
+				if text, err = totpCode(totpSecret); err != nil {
+					fatalf("✗ Failed to generate the TOTP code: %v", err)
+				}
+			} else {
+				text = args[1]
+			}
+			if err := logic.TypeText(bc.ctx, args[0], text); err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("✅ Typed into %s.", args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&totpSecret, "totp-secret", "", `Type the current TOTP code of this secret reference ("env:NAME" or "keyring:ENTRY") instead of [text]`)
+	return cmd
+}
+
+func newWaitCmd() *cobra.Command {
+	var selectors []string
+	var timeout, duration time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait for an element to appear or for a fixed time",
+		Long: `Waits until an element matching --selector is visible (failing after --timeout),
+or simply pauses for --duration. Useful between steps of batch and pipe-line.
+
+Several candidate selectors, separated by "||" or given as repeated --selector
+flags, wait for whichever matches first; the earliest candidate wins when
+several match at once.`,
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			if len(selectors) == 0 {
+				i18n.Printf("⏳ Waiting %s...", duration)
+				select {
+				case <-bc.ctx.Done():
+				case <-time.After(duration):
+				}
+				return
+			}
+			candidates := logic.SelectorCandidates(selectors...)
+			if len(candidates) == 0 {
+				fatalf("✗ No selector given")
+			}
+			i18n.Printf("⏳ Waiting for %s...", strings.Join(candidates, logic.SelectorFallbackSeparator))
+			selector, err := logic.WaitForAnySelector(bc.ctx, candidates, timeout)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("✅ %s is visible.", selector)
+		},
+	}
+	cmd.Flags().StringArrayVar(&selectors, "selector", nil, "CSS selector of the element to wait for (repeatable, or \"a||b\", to wait for the first of several)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "How long to wait for --selector")
+	cmd.Flags().DurationVar(&duration, "duration", 0, "Pause for this long instead of waiting for an element")
+	cmd.MarkFlagsOneRequired("selector", "duration")
+	cmd.MarkFlagsMutuallyExclusive("selector", "duration")
 	return cmd
 }
 
-func newCookiesCmd() *cobra.Command {
+func newPauseCmd() *cobra.Command {
+	var message string
+	var timeout time.Duration
+
 	cmd := &cobra.Command{
-		Use:               "cookies",
-		Short:             "Display all cookies for the current browser context",
+		Use:   "pause",
+		Short: "Hand the browser over to an operator until Enter is pressed",
+		Long: `Brings the browser window to the front, shows --message and waits until Enter
+is pressed, so that a person can do what automation can't, such as solving a
+CAPTCHA or approving a sign-in on their phone, before the next step of a batch
+or pipe-line runs. The browser must not run headless.
+
+Fails when stdin is closed, or after --timeout, instead of going on
+unconfirmed.`,
 		Args:              cobra.NoArgs,
 		PersistentPreRunE: persistentPreRunE,
 		Run: func(cmd *cobra.Command, args []string) {
 			bc, err := getBrowserCtx(cmd)
 			if err != nil {
-				log.Fatalf("✗ %v", err)
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			if err := logic.Pause(bc.ctx, message, os.Stdin, timeout); err != nil {
+				fatalf("✗ %v", err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&message, "message", "", `What the operator should do before pressing Enter (default "Press Enter to continue")`)
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Fail when Enter isn't pressed within this duration (0 = wait forever)")
+	return cmd
+}
+
+func newEvalCmd() *cobra.Command {
+	var file, argsFile string
+	var argPairs []string
+	var opts logic.EvalOptions
+	var allTabs bool
+	var out string
+	var watch time.Duration
+	cmd := &cobra.Command{
+		Use:   "eval [javascript | -]",
+		Short: "Execute a JavaScript expression",
+		Long: `Executes a JavaScript expression given as arguments, or a multi-line script
+read from --file or from stdin when the only argument is "-". Errors in scripts
+are reported with their line and column.
+
+Promise results are awaited and their resolved value is printed. --await also
+allows top-level await, e.g. eval --await 'const r = await fetch("/api"); await r.json()'.
+
+Values from --arg and --args-json are exposed as a frozen global "args" object,
+so user data never has to be interpolated into the script:
+  eval --arg q="it's" 'document.querySelector(args.q)'
+
+With --isolated, the code runs in an isolated world: it sees the page's DOM
+but not its JavaScript globals, so page scripts can't interfere with it.
+
+--out writes the result to a file: strings as they are, other values as JSON.
+With --decode-base64 the result is saved as binary data; it may be an ArrayBuffer,
+typed array, Blob, base64 string or data: URL:
+  eval --out chart.png --decode-base64 'document.querySelector("canvas").toDataURL()'
+
+--watch evaluates the code again at that interval until interrupted and prints
+each result that differs from the previous one as a JSON line, e.g. to follow
+an in-page counter during a load test, in the tab picked with --target:
+  eval --watch 2s --target "url~=dashboard" 'window.stats.activeUsers'
+
+When the policy file sets eval_script_dir, eval only runs the --file scripts
+of that directory whose SHA-256 is pinned in eval_script_hashes; inline
+JavaScript and scripts from stdin are refused.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.Binary && out == "" {
+				return fmt.Errorf("--decode-base64 requires --out")
+			}
+			if watch < 0 {
+				return fmt.Errorf("--watch must be positive")
+			}
+			if file != "" && len(args) > 0 {
+				return fmt.Errorf("cannot combine --file with an inline expression")
+			}
+			if file == "" && len(args) == 0 {
+				return fmt.Errorf("requires a javascript expression, \"-\" or --file")
+			}
+			return nil
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			var argsJSON []byte
+			if argsFile != "" {
+				if argsJSON, err = readInputFile(argsFile); err != nil {
+					fatalf("✗ Failed to read args: %v", err)
+				}
+			}
+			if opts.Args, err = logic.ParseEvalArgs(argPairs, argsJSON); err != nil {
+				fatalf("✗ %v", err)
+			}
+
+			var evaluate func(ctx context.Context) (interface{}, error)
+			if file != "" || (len(args) == 1 && args[0] == "-") {
+				path, sourceName := file, file
+				if path == "" {
+					path, sourceName = "-", "stdin"
+				}
+				source, err := logic.PolicyFrom(bc.ctx).ReadEvalScript(path)
+				if err != nil {
+					fatalf("✗ Failed to read script: %v", err)
+				}
+				i18n.Printf("📝 Evaluating script: %s", sourceName)
+				evaluate = func(ctx context.Context) (interface{}, error) {
+					return logic.EvaluateScript(ctx, string(source), sourceName, opts)
+				}
+			} else {
+				if err := logic.CheckEvalPolicy(bc.ctx); err != nil {
+					fatalf("✗ %v", err)
+				}
+				js := strings.Join(args, " ")
+				i18n.Printf("📝 Evaluating JavaScript: %s", js)
+				evaluate = func(ctx context.Context) (interface{}, error) {
+					return logic.EvaluateJS(ctx, js, opts)
+				}
+			}
+
+			if watch > 0 {
+				ctx, stop := signal.NotifyContext(bc.ctx, os.Interrupt, syscall.SIGTERM)
+				defer stop()
+				i18n.Printf("👀 Evaluating every %s, press Ctrl+C to stop...", watch)
+				err := logic.WatchEval(ctx, watch, evaluate, func(event *models.EvalWatchEvent) {
+					printJSONLine(event)
+				})
+				if err != nil {
+					fatalf("✗ Failed to evaluate JavaScript: %v", err)
+				}
+				return
+			}
+			if allTabs {
+				results, err := runOnAllTabs(bc.ctx, func(ctx context.Context, _ *target.Info) (interface{}, error) {
+					return evaluate(ctx)
+				})
+				if err != nil {
+					fatalf("✗ Failed to evaluate JavaScript: %v", err)
+				}
+				prettyPrintResults(results)
+				return
+			}
+			result, err := evaluate(bc.ctx)
+			if err != nil {
+				fatalf("✗ Failed to evaluate JavaScript: %v", err)
+			}
+			if out != "" {
+				data, err := evalResultBytes(result)
+				if err != nil {
+					fatalf("✗ %v", err)
+				}
+				if err := utils.SecureWriteFile(out, data, 0644, "."); err != nil {
+					fatalf("✗ Failed to write result to %s: %v", out, err)
+				}
+				i18n.Printf("✅ Result saved to %s (%d bytes)", out, len(data))
+				return
+			}
+			prettyPrintResults(result)
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Read the script from a file")
+	cmd.Flags().BoolVar(&opts.Await, "await", false, "Allow top-level await in the expression or script")
+	cmd.Flags().BoolVar(&opts.Isolated, "isolated", false, "Run in an isolated world that shares the DOM but not the page's globals")
+	cmd.Flags().StringArrayVar(&argPairs, "arg", nil, "Expose key=value as args.key (repeatable)")
+	cmd.Flags().StringVar(&argsFile, "args-json", "", "Expose the properties of a JSON object file as args (\"-\" for stdin)")
+	cmd.Flags().BoolVar(&allTabs, "all-tabs", false, "Evaluate in every open tab and print the results keyed by tab")
+	cmd.Flags().StringVar(&out, "out", "", "Write the result to this file instead of printing it")
+	cmd.Flags().BoolVar(&opts.Binary, "decode-base64", false, "Save the result as binary data: an ArrayBuffer, typed array, Blob, base64 string or data: URL")
+	cmd.Flags().DurationVar(&watch, "watch", 0, "Evaluate again at this interval until interrupted, printing each changed result as a JSON line")
+	cmd.MarkFlagsMutuallyExclusive("out", "all-tabs", "watch")
+	return cmd
+}
+
+// evalResultBytes returns the contents eval --out writes for result: binary
+// results and strings as they are, other values as JSON.
+func evalResultBytes(result interface{}) ([]byte, error) {
+	switch v := result.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+func newBindCmd() *cobra.Command {
+	var scriptFile string
+	var count int
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "bind <name> [url]",
+		Short: "Expose a function to page JavaScript and stream its calls as JSON lines",
+		Long: `Registers <name> as a global function in the page (and in pages loaded later)
+and prints one JSON line per call until --count calls, --timeout or Ctrl+C.
+Objects passed to the function are sent as JSON, which enables push-style
+extraction from inside the page:
+  bind __bt_emit https://example.com --script observe.js
+where observe.js calls __bt_emit({...}) whenever new data appears.`,
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
 			}
 			defer bc.cancel()
 
-			log.Println("🌐 Retrieving cookies...")
+			ctx, stop := signal.NotifyContext(bc.ctx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
 
-			cookies, err := logic.GetCookies(bc.ctx)
+			calls := make(chan *models.BindingCall, 64)
+			remove, err := logic.ExposeBinding(bc.ctx, args[0], func(call *models.BindingCall) {
+				select {
+				case calls <- call:
+				default:
+					i18n.Printf("Warning: dropped a call of %s (output too slow)", call.Name)
+				}
+			})
 			if err != nil {
-				log.Fatalf("✗ Failed to get cookies: %v", err)
+				fatalf("✗ %v", err)
+			}
+			defer remove()
+
+			if len(args) > 1 {
+				if _, err := logic.Navigate(ctx, args[1], logic.NavigateOptions{}); err != nil {
+					fatalf("✗ Failed to navigate: %v", err)
+				}
 			}
-			prettyPrintResults(cookies)
+			if scriptFile != "" {
+				source, err := logic.PolicyFrom(bc.ctx).ReadEvalScript(scriptFile)
+				if err != nil {
+					fatalf("✗ Failed to read script: %v", err)
+				}
+				if _, err := logic.EvaluateScript(ctx, string(source), scriptFile, logic.EvalOptions{}); err != nil {
+					fatalf("✗ Failed to evaluate script: %v", err)
+				}
+			}
+
+			i18n.Printf("🔗 Listening for calls of %s()...", args[0])
+			received := 0
+			for count <= 0 || received < count {
+				select {
+				case <-ctx.Done():
+					i18n.Printf("✅ Received %d call(s).", received)
+					return
+				case call := <-calls:
+					printJSONLine(call)
+					received++
+				}
+			}
+			i18n.Printf("✅ Received %d call(s).", received)
 		},
 	}
+
+	cmd.Flags().StringVar(&scriptFile, "script", "", "Script to evaluate after the binding is registered (\"-\" for stdin)")
+	cmd.Flags().IntVar(&count, "count", 0, "Stop after this many calls (0 = no limit)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Stop after this duration (0 = until interrupted)")
+	return cmd
+}
+
+func newHighlightCmd() *cobra.Command {
+	var opts logic.HighlightOptions
+
+	cmd := &cobra.Command{
+		Use:   "highlight <selector>",
+		Short: "Draw a DevTools-style overlay on the elements matching a CSS selector",
+		Long: `Draws a DevTools-style box over every element matching <selector> in the
+current page for --duration, so people watching a headful browser can see
+which elements a script is working on. The boxes are drawn with the DevTools
+overlay, so the page's DOM is left untouched. With --label, a tooltip shows
+the tag, id, classes and size of the first element.`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			count, err := logic.Highlight(bc.ctx, args[0], opts)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			if count == 0 {
+				i18n.Printf("✅ No elements found.")
+				return
+			}
+			i18n.Printf("🖍️ Highlighting %d element(s) for %s...", count, opts.Duration)
+
+			ctx, stop := signal.NotifyContext(bc.ctx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			select {
+			case <-ctx.Done():
+			case <-time.After(opts.Duration):
+			}
+			if err := logic.ClearHighlights(bc.ctx); err != nil {
+				i18n.Printf("Warning: could not remove highlights: %v", err)
+			}
+			i18n.Println("✅ Highlight removed.")
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.Duration, "duration", 5*time.Second, "How long to show the overlay")
+	cmd.Flags().BoolVar(&opts.Label, "label", false, "Show the tag, id, classes and size of the first element in a tooltip")
 	return cmd
 }
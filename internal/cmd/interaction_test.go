@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// TestNewEvalCmd_Args はevalコマンドの引数と--fileの組み合わせの検証をテストします。
+func TestNewEvalCmd_Args(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		args      []string
+		expectErr bool
+	}{
+		{"inline expression", "", []string{"document.title"}, false},
+		{"stdin", "", []string{"-"}, false},
+		{"file", "script.js", []string{}, false},
+		{"no input", "", []string{}, true},
+		{"file and expression", "script.js", []string{"document.title"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newEvalCmd()
+			if tt.file != "" {
+				cmd.Flags().Set("file", tt.file)
+			}
+			err := cmd.Args(cmd, tt.args)
+			if tt.expectErr && err == nil {
+				t.Error("Expected an error but got none")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestNewEvalCmd_OutFlags はevalコマンドの--outと--decode-base64の検証をテストします。
+func TestNewEvalCmd_OutFlags(t *testing.T) {
+	cmd := newEvalCmd()
+	cmd.Flags().Set("decode-base64", "true")
+	if err := cmd.Args(cmd, []string{"canvas.toDataURL()"}); err == nil {
+		t.Error("Expected --decode-base64 without --out to fail")
+	}
+	cmd.Flags().Set("out", "chart.png")
+	if err := cmd.Args(cmd, []string{"canvas.toDataURL()"}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	cmd = newEvalCmd()
+	cmd.Flags().Set("out", "result.json")
+	cmd.Flags().Set("all-tabs", "true")
+	if err := cmd.ValidateFlagGroups(); err == nil {
+		t.Error("Expected --out and --all-tabs to be mutually exclusive")
+	}
+}
+
+// TestNewEvalCmd_WatchFlag は--watchフラグの検証をテストします。
+func TestNewEvalCmd_WatchFlag(t *testing.T) {
+	cmd := newEvalCmd()
+	cmd.Flags().Set("watch", "-1s")
+	if err := cmd.Args(cmd, []string{"window.counter"}); err == nil {
+		t.Error("Expected a negative --watch to fail")
+	}
+
+	cmd = newEvalCmd()
+	cmd.Flags().Set("watch", "2s")
+	cmd.Flags().Set("all-tabs", "true")
+	if err := cmd.ValidateFlagGroups(); err == nil {
+		t.Error("Expected --watch and --all-tabs to be mutually exclusive")
+	}
+}
+
+// TestEvalResultBytes は--outで書き込まれる内容をテストします。
+func TestEvalResultBytes(t *testing.T) {
+	tests := []struct {
+		result interface{}
+		want   string
+	}{
+		{[]byte{0x89, 'P', 'N', 'G'}, "\x89PNG"},
+		{"plain text", "plain text"},
+		{map[string]interface{}{"n": 1}, "{\n  \"n\": 1\n}\n"},
+		{nil, "null\n"},
+	}
+	for _, tt := range tests {
+		got, err := evalResultBytes(tt.result)
+		if err != nil {
+			t.Fatalf("evalResultBytes(%v) error = %v", tt.result, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("evalResultBytes(%v) = %q, want %q", tt.result, got, tt.want)
+		}
+	}
+}
+
+// TestNewExistsAndCountCmd はexistsとcountコマンドの引数とフラグをテストします。
+func TestNewExistsAndCountCmd(t *testing.T) {
+	for _, cmd := range []*cobra.Command{newExistsCmd(), newCountCmd()} {
+		if err := cmd.Args(cmd, []string{".item"}); err != nil {
+			t.Errorf("%s: expected one selector to be accepted, got %v", cmd.Name(), err)
+		}
+		if err := cmd.Args(cmd, []string{}); err == nil {
+			t.Errorf("%s: expected a missing selector to fail", cmd.Name())
+		}
+		if visible := cmd.Flags().Lookup("visible"); visible == nil || visible.DefValue != "false" {
+			t.Errorf("%s: expected a visible flag defaulting to false, got %v", cmd.Name(), visible)
+		}
+	}
+}
+
+// TestNewGetCmd_Flags はgetコマンドの--attrと--propの組み合わせをテストします。
+func TestNewGetCmd_Flags(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{"attr", map[string]string{"attr": "href"}, false},
+		{"prop for all", map[string]string{"prop": "checked", "all": "true"}, false},
+		{"neither", nil, true},
+		{"both", map[string]string{"attr": "href", "prop": "value"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newGetCmd()
+			for name, value := range tt.flags {
+				cmd.Flags().Set(name, value)
+			}
+			err := cmd.ValidateFlagGroups()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFlagGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNewTypeCmd_Args はtypeコマンドの引数とフラグの検証をテストします。
+func TestNewTypeCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		totp    string
+		wantErr bool
+	}{
+		{"text", []string{"#q", "hello"}, "", false},
+		{"totp", []string{"#otp"}, "env:EXAMPLE_TOTP", false},
+		{"neither", []string{"#q"}, "", true},
+		{"both", []string{"#otp", "123456"}, "env:EXAMPLE_TOTP", true},
+		{"plain totp secret", []string{"#otp"}, "JBSWY3DPEHPK3PXP", true},
+		{"no selector", nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newTypeCmd()
+			if tt.totp != "" {
+				cmd.Flags().Set("totp-secret", tt.totp)
+			}
+			err := cmd.Args(cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNewSetCmd_Flags はsetコマンドの--valueと--checkedの組み合わせをテストします。
+func TestNewSetCmd_Flags(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{"value", map[string]string{"value": "foo"}, false},
+		{"empty value", map[string]string{"value": ""}, false},
+		{"uncheck", map[string]string{"checked": "false"}, false},
+		{"neither", nil, true},
+		{"both", map[string]string{"value": "foo", "checked": "true"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newSetCmd()
+			for name, value := range tt.flags {
+				cmd.Flags().Set(name, value)
+			}
+			err := cmd.ValidateFlagGroups()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFlagGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestPrintElementValues_Raw は--rawでの値の出力をテストします。
+func TestPrintElementValues_Raw(t *testing.T) {
+	output, err := captureStdout(func() error {
+		printElementValues([]interface{}{"https://example.com/a", nil, true}, true)
+		printElementValues("plain", true)
+		printElementValues("quoted", false)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "https://example.com/a\nnull\ntrue\nplain\n\"quoted\"\n"
+	if output != want {
+		t.Errorf("Unexpected output %q, want %q", output, want)
+	}
+}
+
+// TestNewEvalCmd_AwaitFlag はevalコマンドの--awaitフラグをテストします。
+func TestNewEvalCmd_AwaitFlag(t *testing.T) {
+	cmd := newEvalCmd()
+	await, err := cmd.Flags().GetBool("await")
+	if err != nil {
+		t.Fatalf("Expected await flag to exist: %v", err)
+	}
+	if await {
+		t.Error("Expected await default to be false")
+	}
+}
+
+// TestNewEvalCmd_ArgFlags はevalコマンドの--argと--args-jsonフラグをテストします。
+func TestNewEvalCmd_ArgFlags(t *testing.T) {
+	cmd := newEvalCmd()
+	cmd.Flags().Set("arg", "a=1")
+	cmd.Flags().Set("arg", "b=x,y")
+
+	pairs, err := cmd.Flags().GetStringArray("arg")
+	if err != nil {
+		t.Fatalf("Expected arg flag to exist: %v", err)
+	}
+	if len(pairs) != 2 || pairs[1] != "b=x,y" {
+		t.Errorf("Expected values not to be split on commas, got %v", pairs)
+	}
+	if cmd.Flags().Lookup("args-json") == nil {
+		t.Error("Expected args-json flag to exist")
+	}
+}
+
+// TestNewBindCmd はbindコマンドの引数とフラグのデフォルト値をテストします。
+func TestNewBindCmd(t *testing.T) {
+	cmd := newBindCmd()
+
+	if err := cmd.Args(cmd, []string{}); err == nil {
+		t.Error("Expected an error without a binding name")
+	}
+	if err := cmd.Args(cmd, []string{"__bt_emit", "https://example.com"}); err != nil {
+		t.Errorf("Expected name and url to be accepted, got %v", err)
+	}
+	if err := cmd.Args(cmd, []string{"a", "b", "c"}); err == nil {
+		t.Error("Expected an error with too many arguments")
+	}
+
+	count, _ := cmd.Flags().GetInt("count")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	if count != 0 || timeout != 0 {
+		t.Errorf("Expected count and timeout to default to 0, got %d and %v", count, timeout)
+	}
+}
+
+// TestNewEvalCmd_IsolatedFlag はevalコマンドの--isolatedフラグをテストします。
+func TestNewEvalCmd_IsolatedFlag(t *testing.T) {
+	cmd := newEvalCmd()
+	isolated, err := cmd.Flags().GetBool("isolated")
+	if err != nil {
+		t.Fatalf("Expected isolated flag to exist: %v", err)
+	}
+	if isolated {
+		t.Error("Expected isolated default to be false")
+	}
+}
+
+// TestNewHighlightCmd はhighlightコマンドの引数とフラグのデフォルト値をテストします。
+func TestNewHighlightCmd(t *testing.T) {
+	cmd := newHighlightCmd()
+
+	if err := cmd.Args(cmd, []string{}); err == nil {
+		t.Error("Expected an error without a selector")
+	}
+	duration, _ := cmd.Flags().GetDuration("duration")
+	if duration != 5*time.Second {
+		t.Errorf("Expected duration default 5s, got %v", duration)
+	}
+	label, _ := cmd.Flags().GetBool("label")
+	if label {
+		t.Error("Expected label default to be false")
+	}
+}
+
+// TestNewWaitCmd はwaitコマンドのフラグ指定の検証をテストします。
+func TestNewWaitCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"selector", []string{"--selector", "main"}, false},
+		{"fallback selectors", []string{"--selector", "#main", "--selector", "main"}, false},
+		{"duration", []string{"--duration", "1s"}, false},
+		{"neither", []string{}, true},
+		{"both", []string{"--selector", "main", "--duration", "1s"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newWaitCmd()
+			cmd.PersistentPreRunE = nil
+			cmd.Run = func(*cobra.Command, []string) {}
+			cmd.SetArgs(tt.args)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			if err := cmd.Execute(); (err != nil) != tt.wantErr {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNewPickCmd_Selectors はpickコマンドのセレクタ指定の検証をテストします。
+func TestNewPickCmd_Selectors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"argument", []string{"#price"}, false},
+		{"fallback list", []string{"#price||.price"}, false},
+		{"selector flags", []string{"--selector", "#price", "--selector", ".price"}, false},
+		{"neither", []string{}, true},
+		{"both", []string{"#price", "--selector", ".price"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newPickCmd()
+			cmd.PersistentPreRunE = nil
+			cmd.Run = func(*cobra.Command, []string) {}
+			cmd.SetArgs(tt.args)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			if err := cmd.Execute(); (err != nil) != tt.wantErr {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNewPauseCmd_Flags はpauseコマンドのフラグをテストします。
+func TestNewPauseCmd_Flags(t *testing.T) {
+	cmd := newPauseCmd()
+	for _, name := range []string{"message", "timeout"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected a --%s flag", name)
+		}
+	}
+	if flag := cmd.Flags().Lookup("timeout"); flag != nil && flag.DefValue != "0s" {
+		t.Errorf("Expected --timeout to default to 0s, got %s", flag.DefValue)
+	}
+	if err := cmd.Args(cmd, []string{"extra"}); err == nil {
+		t.Error("Expected positional arguments to be rejected")
+	}
+}
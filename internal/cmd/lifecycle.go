@@ -1,40 +1,135 @@
 package cmd
 
 import (
-	"log"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"browser-tools-go/internal/browser"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/models"
+
 	"github.com/spf13/cobra"
 )
 
 func newStartCmd() *cobra.Command {
-	var port int
-	var headless bool
+	var opts browser.StartOptions
 
 	cmd := &cobra.Command{
 		Use:   "start",
 		Short: "Start a persistent Chrome instance",
+		Long: `Starts Chrome with remote debugging and saves the session for the other
+commands. The profile lives in ~/.browser-tools-go/user-data unless
+--user-data-dir picks another directory, so that several isolated profiles can
+be kept side by side and backed up on their own; --profile-directory picks a
+profile inside it:
+
+  browser-tools-go start --user-data-dir ~/profiles/work --profile-directory "Profile 1"`,
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := browser.Start(port, headless); err != nil {
-				log.Fatalf("✗ Failed to start browser: %v", err)
+			if err := browser.Start(opts); err != nil {
+				fatalf("✗ Failed to start browser: %v", err)
 			}
 		},
 	}
 
-	cmd.Flags().IntVar(&port, "port", 9222, "Port for debugging")
-	cmd.Flags().BoolVar(&headless, "headless", false, "Run headless")
+	cmd.Flags().IntVar(&opts.Port, "port", 9222, "Port for debugging")
+	cmd.Flags().BoolVar(&opts.Headless, "headless", false, "Run headless")
+	cmd.Flags().StringVar(&opts.UserDataDir, "user-data-dir", "", "Chrome user data directory holding the profiles (default ~/.browser-tools-go/user-data)")
+	cmd.Flags().StringVar(&opts.ProfileDirectory, "profile-directory", "", `Profile inside the user data directory, e.g. "Profile 1"`)
 	return cmd
 }
 
 func newCloseCmd() *cobra.Command {
+	var force bool
+
 	cmd := &cobra.Command{
 		Use:   "close",
 		Short: "Close the persistent Chrome instance",
+		Long: `Asks the browser to close, which writes out its profile, and terminates it only
+when that fails. The session is kept until the browser has exited and released
+its debugging port.
+
+With --force, a browser that doesn't exit is killed and the session is removed
+in any case, which also cleans up a stale session left by a browser that
+crashed or didn't survive a reboot.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := browser.Close(); err != nil {
-				log.Fatalf("✗ Failed to close browser: %v", err)
+			if err := browser.Close(force); err != nil {
+				fatalf("✗ Failed to close browser: %v", err)
 			}
 		},
 	}
+	cmd.Flags().BoolVar(&force, "force", false, "Kill a browser that doesn't exit and remove the session in any case")
 	return cmd
 }
+
+func newPingCmd() *cobra.Command {
+	var timeout, interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "ping",
+		Short: "Check that the browser answers and report the latency",
+		Long: `Connects to the browser of the session without touching its tabs, round-trips
+a trivial DevTools call and prints the connect time and latency as JSON. It
+fails when the browser doesn't answer within --timeout, so orchestrators can
+use it as a cheap health check of a shared browser.
+
+With --interval, it pings again at that interval until interrupted, printing
+one JSON line per ping, failed ones included, which also keeps the session in
+use, e.g. for a browser shared by agents:
+  browser-tools-go ping --interval 30s`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if timeout <= 0 {
+				return fmt.Errorf("--timeout must be positive")
+			}
+			if interval < 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+			return cobra.NoArgs(cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if interval == 0 {
+				result, err := browser.Ping(timeout)
+				if err != nil {
+					fatalf("✗ Ping failed: %v", err)
+				}
+				i18n.Printf("🏓 %s answered in %.1f ms", result.Product, result.LatencyMs)
+				prettyPrintResults(result)
+				return
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				result := pingOnce(browser.Ping, timeout)
+				if !result.OK {
+					i18n.Printf("⚠️ Ping failed: %s", result.Error)
+				}
+				printJSONLine(result)
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Second, "How long the browser may take to answer")
+	cmd.Flags().DurationVar(&interval, "interval", 0, "Ping again at this interval until interrupted, printing one JSON line per ping")
+	return cmd
+}
+
+// pingOnce pings the browser with ping, turning a failure into a result
+// that reports it.
+func pingOnce(ping func(time.Duration) (*models.PingResult, error), timeout time.Duration) *models.PingResult {
+	start := time.Now()
+	result, err := ping(timeout)
+	if err != nil {
+		return &models.PingResult{Error: err.Error(), CheckedAt: start.UTC()}
+	}
+	return result
+}
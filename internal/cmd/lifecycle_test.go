@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"browser-tools-go/internal/models"
+)
+
+// TestNewPingCmd_Args はpingコマンドのフラグ検証をテストします。
+func TestNewPingCmd_Args(t *testing.T) {
+	cmd := newPingCmd()
+	if err := cmd.Args(cmd, nil); err != nil {
+		t.Errorf("Expected the defaults to be valid, got %v", err)
+	}
+	if err := cmd.Args(cmd, []string{"extra"}); err == nil {
+		t.Error("Expected positional arguments to be rejected")
+	}
+	_ = cmd.Flags().Set("interval", "-1s")
+	if err := cmd.Args(cmd, nil); err == nil {
+		t.Error("Expected a negative --interval to be rejected")
+	}
+	_ = cmd.Flags().Set("interval", "0s")
+	_ = cmd.Flags().Set("timeout", "0s")
+	if err := cmd.Args(cmd, nil); err == nil {
+		t.Error("Expected a zero --timeout to be rejected")
+	}
+}
+
+// TestPingOnce は失敗したpingが結果として報告されることをテストします。
+func TestPingOnce(t *testing.T) {
+	ok := func(time.Duration) (*models.PingResult, error) {
+		return &models.PingResult{OK: true, LatencyMs: 1.5}, nil
+	}
+	if result := pingOnce(ok, time.Second); !result.OK || result.LatencyMs != 1.5 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	failing := func(time.Duration) (*models.PingResult, error) {
+		return nil, errors.New("browser is not running")
+	}
+	result := pingOnce(failing, time.Second)
+	if result.OK || result.Error != "browser is not running" || result.CheckedAt.IsZero() {
+		t.Errorf("Expected a failed result, got %+v", result)
+	}
+}
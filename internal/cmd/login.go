@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+func newLoginCmd() *cobra.Command {
+	var site string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in to a site with its login recipe",
+		Long: `Logs in to a site following its recipe, ~/.browser-tools-go/logins/<site>.json:
+opens the login page, types the username and password into their fields,
+submits the form and waits until the success condition holds. When the site
+asks for a verification code, the MFA step of the recipe enters it. The
+session cookies stay in the browser, so the next commands run signed in.
+
+  {
+    "url": "https://example.com/login",
+    "usernameSelector": "#email",
+    "passwordSelector": "#password",
+    "submitSelector": "button[type=submit]",
+    "username": "env:EXAMPLE_USER",
+    "password": "keyring:example-password",
+    "failureSelector": ".alert-error",
+    "success": {"url": "/dashboard", "selector": ".avatar"},
+    "mfa": {"selector": "#otp", "submitSelector": "#verify"}
+  }
+
+The password, and the MFA code when given, must be secret references:
+"keyring:ENTRY" reads an entry of the OS keyring (see "secret set") and
+"env:NAME" the environment variable NAME, so that no secret is stored in the
+recipe. With "totpSecret" instead of "code", the MFA code is generated from
+a TOTP secret, the base32 key or otpauth:// URI of an authenticator app setup.
+Without either, the code is asked for on the terminal.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if timeout <= 0 {
+				return fmt.Errorf("--timeout must be positive")
+			}
+			return cobra.NoArgs(cmd, args)
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			recipe, err := config.LoadLoginRecipe(site)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			secrets, err := loginSecrets(site, recipe)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+			if profile, err := config.LoadSite(site); err == nil {
+				if err := applySiteAuth(bc.ctx, profile); err != nil {
+					fatalf("✗ %v", err)
+				}
+			}
+
+			i18n.Printf("🔑 Logging in to %s...", site)
+			result, err := logic.Login(bc.ctx, site, recipe, secrets, timeout)
+			if err != nil {
+				fatalf("✗ Login failed: %v", err)
+			}
+			i18n.Printf("✅ Logged in to %s.", site)
+			prettyPrintResults(result)
+		},
+	}
+
+	cmd.Flags().StringVar(&site, "site", "", "Name of the site, whose recipe is ~/.browser-tools-go/logins/<site>.json")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "How long each step may wait for its element or for the login to succeed")
+	_ = cmd.MarkFlagRequired("site")
+	return cmd
+}
+
+// loginSecrets resolves the credentials of a recipe. The verification code
+// is only resolved, or asked for, when the site asks for it.
+func loginSecrets(site string, recipe *config.LoginRecipe) (logic.LoginSecrets, error) {
+	var secrets logic.LoginSecrets
+	var err error
+	if secrets.Username, err = config.ResolveSecret(recipe.Username); err != nil {
+		return secrets, fmt.Errorf("username: %w", err)
+	}
+	if secrets.Password, err = config.ResolveSecret(recipe.Password); err != nil {
+		return secrets, fmt.Errorf("password: %w", err)
+	}
+	if recipe.MFA == nil {
+		return secrets, nil
+	}
+	secrets.MFACode = func() (string, error) {
+		switch {
+		case recipe.MFA.Code != "":
+			return config.ResolveSecret(recipe.MFA.Code)
+		case recipe.MFA.TOTPSecret != "":
+			return totpCode(recipe.MFA.TOTPSecret)
+		}
+		return promptLine(i18n.Sprintf("🔑 Verification code for %s: ", site))
+	}
+	return secrets, nil
+}
+
+// totpMinValidity is how long a TOTP code must stay valid to be used: a code
+// about to expire is waited out, so that it doesn't reach the site stale.
+const totpMinValidity = 3 * time.Second
+
+// totpCode returns the current TOTP code of the secret ref refers to.
+func totpCode(ref string) (string, error) {
+	secret, err := config.ResolveSecret(ref)
+	if err != nil {
+		return "", err
+	}
+	code, validFor, err := logic.TOTP(secret, time.Now())
+	if err == nil && validFor < totpMinValidity {
+		time.Sleep(validFor)
+		code, _, err = logic.TOTP(secret, time.Now())
+	}
+	return code, err
+}
+
+// promptLine asks for a line on the terminal.
+func promptLine(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		if err != nil {
+			return "", fmt.Errorf("no input: %w", err)
+		}
+		return "", fmt.Errorf("no input")
+	}
+	return line, nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"browser-tools-go/internal/config"
+)
+
+// TestNewLoginCmd_Flags はloginコマンドのフラグをテストします。
+func TestNewLoginCmd_Flags(t *testing.T) {
+	cmd := newLoginCmd()
+	if cmd.Flags().Lookup("site") == nil {
+		t.Fatal("Expected a --site flag")
+	}
+	if err := cmd.ValidateRequiredFlags(); err == nil {
+		t.Error("Expected --site to be required")
+	}
+	if err := cmd.Args(cmd, []string{"extra"}); err == nil {
+		t.Error("Expected positional arguments to be rejected")
+	}
+	_ = cmd.Flags().Set("timeout", "0s")
+	if err := cmd.Args(cmd, nil); err == nil {
+		t.Error("Expected a zero --timeout to be rejected")
+	}
+}
+
+// TestLoginSecrets はレシピの資格情報の解決をテストします。
+func TestLoginSecrets(t *testing.T) {
+	t.Setenv("EXAMPLE_PASSWORD", "s3cret")
+	t.Setenv("EXAMPLE_OTP", "123456")
+	recipe := &config.LoginRecipe{
+		Username: "alice@example.com",
+		Password: "env:EXAMPLE_PASSWORD",
+		MFA:      &config.LoginMFA{Selector: "#otp", Code: "env:EXAMPLE_OTP"},
+	}
+	secrets, err := loginSecrets("example", recipe)
+	if err != nil {
+		t.Fatalf("Failed to resolve secrets: %v", err)
+	}
+	if secrets.Username != "alice@example.com" || secrets.Password != "s3cret" {
+		t.Errorf("Unexpected credentials: %+v", secrets)
+	}
+	if code, err := secrets.MFACode(); err != nil || code != "123456" {
+		t.Errorf("Expected the MFA code from the environment, got %q, %v", code, err)
+	}
+
+	recipe.Password = "env:EXAMPLE_MISSING"
+	if _, err := loginSecrets("example", recipe); err == nil || !strings.Contains(err.Error(), "password") {
+		t.Errorf("Expected an error for an unset password, got %v", err)
+	}
+}
+
+// TestPlanner_Login はloginコマンドの計画がレシピのページを含むことをテストします。
+func TestPlanner_Login(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("EXAMPLE_PASSWORD", "s3cret")
+
+	plan := planLine(t, "login --site example")
+	if len(plan.Problems) != 1 || !strings.Contains(plan.Problems[0], "no login recipe") {
+		t.Errorf("Expected a missing recipe to be a problem, got %v", plan.Problems)
+	}
+
+	dir, _ := config.GetLoginsDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	recipe := `{"url": "https://example.com/login", "usernameSelector": "#email", "passwordSelector": "#password",
+		"username": "alice", "password": "env:EXAMPLE_PASSWORD", "success": {"selector": ".avatar"}}`
+	if err := os.WriteFile(filepath.Join(dir, "example.json"), []byte(recipe), 0600); err != nil {
+		t.Fatal(err)
+	}
+	plan = planLine(t, "login --site example")
+	if len(plan.Problems) != 0 || len(plan.Steps) != 1 || plan.Steps[0].Target != "https://example.com/login" {
+		t.Errorf("Expected a visit of the login page, got %+v %v", plan.Steps, plan.Problems)
+	}
+
+	t.Setenv("EXAMPLE_PASSWORD", "")
+	plan = planLine(t, "login --site example")
+	if len(plan.Problems) != 1 || !strings.Contains(plan.Problems[0], "EXAMPLE_PASSWORD") {
+		t.Errorf("Expected an unset password to be a problem, got %v", plan.Problems)
+	}
+}
+
+// TestTotpCode はシークレット参照からのTOTPコードの生成をテストします。
+func TestTotpCode(t *testing.T) {
+	t.Setenv("EXAMPLE_TOTP", "JBSWY3DPEHPK3PXP")
+	code, err := totpCode("env:EXAMPLE_TOTP")
+	if err != nil {
+		t.Fatalf("Failed to generate a code: %v", err)
+	}
+	if len(code) != 6 || strings.Trim(code, "0123456789") != "" {
+		t.Errorf("Expected a 6-digit code, got %q", code)
+	}
+	if _, err := totpCode("env:EXAMPLE_TOTP_MISSING"); err == nil {
+		t.Error("Expected an error for an unset secret")
+	}
+}
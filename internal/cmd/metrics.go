@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// metricsReportTimeout bounds reporting an event, so a stuck metrics server
+// never holds up a command.
+const metricsReportTimeout = 500 * time.Millisecond
+
+// metricsServer is the address of the running metrics server, looked up once
+// per process; empty when none runs.
+var metricsServer = sync.OnceValue(func() string {
+	info, err := config.LoadMetricsInfo()
+	if err != nil {
+		return ""
+	}
+	return info.Addr
+})
+
+// reportMetric sends an event to the metrics server, if one runs.
+func reportMetric(event models.MetricEvent) {
+	addr := metricsServer()
+	if addr == "" {
+		return
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	client := http.Client{Timeout: metricsReportTimeout}
+	resp, err := client.Post("http://"+addr+"/events", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+// commandMetrics tracks the command run by this process for metrics.
+var commandMetrics struct {
+	name   string
+	start  time.Time
+	finish sync.Once
+}
+
+// beginCommandMetrics notes the command run by this process, before executing it.
+func beginCommandMetrics(name string) {
+	commandMetrics.name = name
+	commandMetrics.start = time.Now()
+	logic.OnNavigation = func(result *models.NavigationResult) {
+		reportMetric(models.MetricEvent{Type: models.MetricNavigation, DurationMs: result.Timing.TotalMs})
+	}
+	logic.OnRetry = func(error) {
+		reportMetric(models.MetricEvent{Type: models.MetricRetry})
+	}
+}
+
+// finishCommandMetrics reports how the command ended; only the first call
+// counts. errorCode classifies failures (see logic.MetricErrorCode).
+func finishCommandMetrics(ok bool, errorCode string) {
+	if commandMetrics.name == "" {
+		return
+	}
+	commandMetrics.finish.Do(func() {
+		reportMetric(models.MetricEvent{
+			Type:       models.MetricCommand,
+			Command:    commandMetrics.name,
+			OK:         ok,
+			ErrorCode:  errorCode,
+			DurationMs: float64(time.Since(commandMetrics.start).Microseconds()) / 1000,
+		})
+	})
+}
+
+// newMetricsHandler serves the metrics of registry and receives events.
+func newMetricsHandler(registry *logic.MetricsRegistry, status func(ctx context.Context) logic.BrowserStatus) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		registry.Write(w, status(r.Context()))
+	})
+	mux.HandleFunc("POST /events", func(w http.ResponseWriter, r *http.Request) {
+		var event models.MetricEvent
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64*1024)).Decode(&event); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		registry.Observe(event)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+// sessionStatus checks the browser session at scrape time.
+func sessionStatus(ctx context.Context) logic.BrowserStatus {
+	info, err := config.LoadWsInfo()
+	if err != nil {
+		return logic.BrowserStatus{}
+	}
+	tabs, err := logic.CountOpenTabs(ctx, info.Url)
+	if err != nil {
+		return logic.BrowserStatus{}
+	}
+	return logic.BrowserStatus{Up: true, OpenTabs: tabs}
+}
+
+func newMetricsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Expose Prometheus metrics about browser-tools-go commands",
+	}
+	cmd.AddCommand(newMetricsServeCmd())
+	return cmd
+}
+
+func newMetricsServeCmd() *cobra.Command {
+	var addr string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve Prometheus metrics until interrupted",
+		Long: `Runs a metrics server exposing /metrics in the Prometheus text format, until
+interrupted. While it runs, every browser-tools-go command reports to it, so the
+operation of a shared browser instance can be monitored:
+
+  browser_tools_commands_total{command,status}   commands executed
+  browser_tools_command_duration_seconds         command run time (histogram)
+  browser_tools_failures_total{code}             failures by error code, e.g. ERR_NAME_NOT_RESOLVED or timeout
+  browser_tools_navigation_duration_seconds      navigation time (histogram)
+  browser_tools_retries_total                    retried navigations
+  browser_tools_open_tabs, browser_tools_browser_up   browser state at scrape time
+
+Counters start from zero when the server starts.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				fatalf("✗ Failed to listen on %s: %v", addr, err)
+			}
+			server := &http.Server{
+				Handler:           newMetricsHandler(logic.NewMetricsRegistry(), sessionStatus),
+				ReadHeaderTimeout: 5 * time.Second,
+			}
+
+			if err := config.WriteMetricsInfo(&config.MetricsInfo{Addr: listener.Addr().String(), Pid: os.Getpid()}); err != nil {
+				fatalf("✗ Failed to save metrics server info: %v", err)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = server.Shutdown(shutdownCtx)
+			}()
+
+			i18n.Printf("📈 Serving metrics on http://%s/metrics", listener.Addr())
+			serveErr := server.Serve(listener)
+			if err := config.RemoveMetricsInfo(); err != nil {
+				i18n.Printf("⚠️ Failed to remove metrics server info: %v", err)
+			}
+			if serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+				fatalf("✗ Metrics server failed: %v", serveErr)
+			}
+			i18n.Println("✅ Metrics server stopped.")
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1:9464", "Address to listen on")
+	return cmd
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"browser-tools-go/internal/logic"
+)
+
+// TestNewMetricsCmd はmetricsコマンドのサブコマンドとフラグをテストします。
+func TestNewMetricsCmd(t *testing.T) {
+	cmd := newMetricsCmd()
+	serve, _, err := cmd.Find([]string{"serve"})
+	if err != nil || serve.Name() != "serve" {
+		t.Fatalf("Expected serve subcommand, got %v", err)
+	}
+	if f := serve.Flags().Lookup("addr"); f == nil || f.DefValue != "127.0.0.1:9464" {
+		t.Error("Expected --addr flag defaulting to 127.0.0.1:9464")
+	}
+}
+
+// TestMetricsHandler はイベントの受信とメトリクスの公開をテストします。
+func TestMetricsHandler(t *testing.T) {
+	status := func(context.Context) logic.BrowserStatus { return logic.BrowserStatus{Up: true, OpenTabs: 2} }
+	server := httptest.NewServer(newMetricsHandler(logic.NewMetricsRegistry(), status))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/events", "application/json", strings.NewReader(`{"type":"command","command":"content","ok":true,"durationMs":120}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected 204 for an event, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Post(server.URL+"/events", "application/json", strings.NewReader(`not json`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid event, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	for _, want := range []string{
+		`browser_tools_commands_total{command="content",status="success"} 1`,
+		"browser_tools_open_tabs 2",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+func newWatchCmd() *cobra.Command {
+	var selector string
+	var interval time.Duration
+	var notifyCmd string
+	var webhook webhookFlags
+	var dedupe dedupeFlags
+	var once bool
+
+	cmd := &cobra.Command{
+		Use:   "watch <url>",
+		Short: "Poll a page region and report changes against the last snapshot",
+		Long: `Periodically extracts a page (or the region matched by --selector), compares it
+with the snapshot stored by the previous check and emits one JSON line per check.
+On change, --notify-cmd receives the change event (including a unified diff) on
+stdin and --webhook receives it as a JSON POST.`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			ctx, stop := signal.NotifyContext(bc.ctx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			seen := dedupe.open()
+			if seen != nil {
+				defer seen.Close()
+			}
+
+			opts := logic.WatchOptions{
+				URL:       args[0],
+				Selector:  selector,
+				Interval:  interval,
+				NotifyCmd: notifyCmd,
+				Webhook:   webhook.webhook(),
+				Once:      once,
+				Dedupe:    seen,
+			}
+			i18n.Printf("👀 Watching %s (selector: %q, interval: %s)...", opts.URL, selector, interval)
+
+			err = logic.Watch(ctx, opts, func(event *models.WatchEvent) {
+				switch {
+				case event.First:
+					i18n.Println("📸 Stored initial snapshot.")
+				case event.Changed:
+					i18n.Printf("🔔 Change detected (+%d/-%d lines).", event.Added, event.Removed)
+				}
+				printJSONLine(event)
+			})
+			if err != nil {
+				fatalf("✗ Watch failed: %v", err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&selector, "selector", "", "CSS selector of the region to watch (default: whole page)")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Minute, "Time between checks")
+	cmd.Flags().StringVar(&notifyCmd, "notify-cmd", "", "Command run on change with the change event as JSON on stdin")
+	webhook.register(cmd)
+	dedupe.register(cmd)
+	cmd.Flags().BoolVar(&once, "once", false, "Check once and exit (for use from cron)")
+	return cmd
+}
+
+func newDiffCmd() *cobra.Command {
+	var live string
+
+	cmd := &cobra.Command{
+		Use:   "diff <snapshot-a.json> <snapshot-b.json>",
+		Short: "Compare two saved content/extract outputs, or a live page against one",
+		Long: `Compares two JSON outputs of content, pick or the scrapers. Fields are compared
+structurally; the "content" field is normalized (HTML-aware for --format html)
+and compared line by line so formatting churn does not show up as a change.
+
+With --live <url>, the page is extracted again using the format and selector
+recorded in the single snapshot argument and compared against it.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if live != "" {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if live == "" {
+				dryRun(cmd, args, false)
+				return nil
+			}
+			return persistentPreRunE(cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			a, err := logic.LoadSnapshot(args[0])
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+
+			var b interface{}
+			if live != "" {
+				bc, err := getBrowserCtx(cmd)
+				if err != nil {
+					fatalf("✗ %v", err)
+				}
+				defer bc.cancel()
+
+				i18n.Printf("🚀 Extracting live content from %s...", live)
+				b, err = logic.LiveSnapshot(bc.ctx, live, a)
+				if err != nil {
+					fatalf("✗ Failed to extract live content: %v", err)
+				}
+			} else {
+				b, err = logic.LoadSnapshot(args[1])
+				if err != nil {
+					fatalf("✗ %v", err)
+				}
+			}
+
+			diff := logic.DiffSnapshots(a, b)
+			if diff.Changed {
+				i18n.Printf("🔍 %d field change(s), +%d/-%d content lines.", len(diff.Changes), diff.Added, diff.Removed)
+			} else {
+				i18n.Println("✅ No differences.")
+			}
+			prettyPrintResults(diff)
+		},
+	}
+
+	cmd.Flags().StringVar(&live, "live", "", "Compare the live page at this URL against the snapshot")
+	return cmd
+}
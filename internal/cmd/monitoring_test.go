@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNewWatchCmd_CommandDefinition はwatchコマンドの定義をテストします。
+func TestNewWatchCmd_CommandDefinition(t *testing.T) {
+	cmd := newWatchCmd()
+
+	if cmd.Use != "watch <url>" {
+		t.Errorf("Expected Use to be 'watch <url>', got %s", cmd.Use)
+	}
+
+	if err := cmd.Args(cmd, []string{}); err == nil {
+		t.Error("Expected error when URL is missing")
+	}
+
+	if cmd.PersistentPreRunE == nil {
+		t.Error("PersistentPreRunE must be set")
+	}
+
+	for _, name := range []string{"selector", "interval", "notify-cmd", "webhook", "once"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected '%s' flag to exist", name)
+		}
+	}
+
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		t.Fatalf("Failed to get interval flag value: %v", err)
+	}
+	if interval != 5*time.Minute {
+		t.Errorf("Expected default interval to be 5m, got %s", interval)
+	}
+}
+
+// TestNewDiffCmd_ArgumentValidation はdiffコマンドの引数検証をテストします。
+func TestNewDiffCmd_ArgumentValidation(t *testing.T) {
+	cmd := newDiffCmd()
+
+	if err := cmd.Args(cmd, []string{"a.json", "b.json"}); err != nil {
+		t.Errorf("Expected two snapshots to be accepted, got %v", err)
+	}
+	if err := cmd.Args(cmd, []string{"a.json"}); err == nil {
+		t.Error("Expected error for a single snapshot without --live")
+	}
+
+	if err := cmd.Flags().Set("live", "https://example.com"); err != nil {
+		t.Fatalf("Failed to set live flag: %v", err)
+	}
+	if err := cmd.Args(cmd, []string{"a.json"}); err != nil {
+		t.Errorf("Expected a single snapshot to be accepted with --live, got %v", err)
+	}
+}
@@ -1,49 +1,195 @@
 package cmd
 
 import (
-	"log"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"browser-tools-go/internal/i18n"
 	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
 
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
 	"github.com/spf13/cobra"
 )
 
+// loadBudget reads a performance budget file, rejecting unknown fields so
+// that a misspelled limit isn't silently ignored.
+func loadBudget(path string) (*models.Budget, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read budget: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var budget models.Budget
+	if err := decoder.Decode(&budget); err != nil {
+		return nil, fmt.Errorf("failed to parse budget %s: %w", path, err)
+	}
+	if err := logic.ValidateBudget(&budget); err != nil {
+		return nil, fmt.Errorf("invalid budget %s: %w", path, err)
+	}
+	return &budget, nil
+}
+
 func newNavigateCmd() *cobra.Command {
+	var opts logic.NavigateOptions
+	var budgetPath string
+
+	cmd := &cobra.Command{
+		Use:   "navigate <url>",
+		Short: "Navigate to a specific URL",
+		Long: `Navigates to <url> and prints the main document's response as JSON: final URL,
+HTTP status, redirect chain, response headers and timing. HTTP error statuses
+are reported, not treated as failures.
+
+With --fail-on-console-error, the console errors and uncaught exceptions
+(including unhandled promise rejections) reported until the load completes are
+listed in "consoleErrors", and the command fails when there are any, so smoke
+tests catch JavaScript breakage.
+
+With --budget, the requests, transferred bytes by resource type, largest
+contentful paint and cumulative layout shift of the load are checked against
+the limits of a JSON file and reported in "budget"; the command exits with
+status 4 when any is exceeded, e.g.
+
+  {"maxRequests": 80, "maxTransferBytes": {"total": 1500000, "script": 400000},
+   "maxLcpMs": 2500, "maxCls": 0.1}
+
+With --stats, the DNS, connect, TLS, time to first byte, DOMContentLoaded and
+load timings the page records with the Navigation Timing API, and the number of
+resources it fetched by type, are reported in "stats".`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			if budgetPath != "" {
+				budget, err := loadBudget(budgetPath)
+				if err != nil {
+					fatalf("✗ %v", err)
+				}
+				opts.Budget = budget
+			}
+			if err := opts.Validate(); err != nil {
+				fatalf("✗ %v", err)
+			}
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Printf("🚀 Navigating to %s...", args[0])
+			result, err := logic.Navigate(bc.ctx, args[0], opts)
+			if err != nil {
+				fatalf("✗ Failed to navigate: %v", err)
+			}
+			if result.Status >= 400 {
+				i18n.Printf("⚠️ %s responded with %d %s", result.FinalURL, result.Status, result.StatusText)
+			} else {
+				i18n.Println("✅ Navigation successful.")
+			}
+			prettyPrintResults(result)
+			if n := len(result.ConsoleErrors); n > 0 {
+				for _, msg := range result.ConsoleErrors {
+					i18n.Printf("  • %s", consoleMessageLine(msg))
+				}
+				fatalf("✗ %d console error(s) during the load of %s", n, args[0])
+			}
+			if result.Budget != nil && len(result.Budget.Violations) > 0 {
+				for _, v := range result.Budget.Violations {
+					i18n.Printf("  • %s: %g > %g", v.Metric, v.Actual, v.Limit)
+				}
+				i18n.Printf("✗ %d budget(s) exceeded by %s", len(result.Budget.Violations), args[0])
+				bc.cancel()
+				exitCommand(ExitBudget, fmt.Sprintf("%d budget(s) exceeded", len(result.Budget.Violations)))
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&opts.BypassServiceWorker, "bypass-sw", false, "Bypass service workers and load the page from the network")
+	cmd.Flags().StringVar(&opts.WaitUntil, "wait-until", logic.WaitUntilLoad, "When navigation is complete: load, domcontentloaded or networkidle")
+	cmd.Flags().StringVar(&opts.Referrer, "referrer", "", "Referrer URL sent with the navigation request")
+	cmd.Flags().StringVar(&opts.Transition, "transition", "", "Transition type of the navigation, e.g. typed or link")
+	cmd.Flags().BoolVar(&opts.CollectConsoleErrors, "fail-on-console-error", false, "Fail when the page reports console errors or uncaught exceptions while loading")
+	cmd.Flags().BoolVar(&opts.Stats, "stats", false, "Report the DNS/connect/TTFB/DOMContentLoaded/load timings and resource counts of the load")
+	cmd.Flags().StringVar(&budgetPath, "budget", "", "JSON file of performance budgets to check the load against; exits with status 4 when one is exceeded")
+	return cmd
+}
+
+// consoleMessageLine renders a console message on one line, with its source
+// location when known.
+func consoleMessageLine(msg models.ConsoleMessage) string {
+	line := "[" + msg.Source + "] " + msg.Text
+	switch {
+	case msg.URL != "" && msg.Line > 0:
+		line += fmt.Sprintf(" (%s:%d)", msg.URL, msg.Line)
+	case msg.URL != "":
+		line += " (" + msg.URL + ")"
+	}
+	return line
+}
+
+func newTraceRedirectsCmd() *cobra.Command {
+	var opts logic.RedirectTraceOptions
+
 	cmd := &cobra.Command{
-		Use:               "navigate <url>",
-		Short:             "Navigate to a specific URL",
+		Use:   "trace-redirects <url>",
+		Short: "Report every redirect hop until the final destination",
+		Long: `Loads <url> and prints every hop of its redirect chain as JSON: URL, status,
+kind (http for 3xx responses, client for script or meta refresh redirects), the
+next location, cookies set, and when the response arrived. Client-side redirects
+are followed until the page stays put for --settle.`,
 		Args:              cobra.ExactArgs(1),
 		PersistentPreRunE: persistentPreRunE,
 		Run: func(cmd *cobra.Command, args []string) {
 			bc, err := getBrowserCtx(cmd)
 			if err != nil {
-				log.Fatalf("✗ %v", err)
+				fatalf("✗ %v", err)
 			}
 			defer bc.cancel()
 
-			log.Printf("🚀 Navigating to %s...", args[0])
-			if err := logic.Navigate(bc.ctx, args[0]); err != nil {
-				log.Fatalf("✗ Failed to navigate: %v", err)
+			i18n.Printf("🔀 Tracing redirects from %s...", args[0])
+			trace, err := logic.TraceRedirects(bc.ctx, args[0], opts)
+			if err != nil {
+				fatalf("✗ Failed to trace redirects: %v", err)
 			}
-			log.Println("✅ Navigation successful.")
+			i18n.Printf("✅ %d hops, final destination: %s", len(trace.Hops), trace.FinalURL)
+			prettyPrintResults(trace)
 		},
 	}
+	cmd.Flags().DurationVar(&opts.Settle, "settle", 3*time.Second, "How long the page must stay without navigating before it counts as the final destination")
 	return cmd
 }
 
 func newScreenshotCmd() *cobra.Command {
 	var url string
-	var fullPage bool
+	var opts logic.ScreenshotOptions
+	var allTabs bool
+	var themes []string
+	var upload uploadFlags
 
 	cmd := &cobra.Command{
-		Use:               "screenshot [path]",
-		Short:             "Capture a screenshot of a web page",
-		Args:              cobra.MaximumNArgs(1),
+		Use:   "screenshot [path]",
+		Short: "Capture a screenshot of a web page",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if _, err := logic.ParseColorSchemes(themes); err != nil {
+				return fmt.Errorf("--themes: %w", err)
+			}
+			if opts.Scale < 0 || opts.Scale > logic.MaxDeviceScale {
+				return fmt.Errorf("--scale must be between 0 and %g", logic.MaxDeviceScale)
+			}
+			return cobra.MaximumNArgs(1)(cmd, args)
+		},
 		PersistentPreRunE: persistentPreRunE,
 		Run: func(cmd *cobra.Command, args []string) {
 			bc, err := getBrowserCtx(cmd)
 			if err != nil {
-				log.Fatalf("✗ %v", err)
+				fatalf("✗ %v", err)
 			}
 			defer bc.cancel()
 
@@ -52,20 +198,101 @@ func newScreenshotCmd() *cobra.Command {
 				filePath = args[0]
 			}
 
+			if allTabs {
+				i18n.Println("📸 Taking screenshots...")
+				results, err := runOnAllTabs(bc.ctx, func(ctx context.Context, tab *target.Info) (interface{}, error) {
+					// Background tabs aren't painted; bring each one to the front first.
+					if err := chromedp.Run(ctx, target.ActivateTarget(tab.TargetID)); err != nil {
+						return nil, err
+					}
+					return logic.Screenshot(ctx, "", tabScreenshotPath(filePath, string(tab.TargetID)), opts)
+				})
+				if err != nil {
+					fatalf("✗ Failed to take screenshots: %v", err)
+				}
+				for _, result := range results {
+					if savedPath, ok := result.Result.(string); ok {
+						upload.upload(bc.ctx, savedPath)
+					}
+				}
+				prettyPrintResults(results)
+				return
+			}
+
+			if len(themes) > 0 {
+				schemes, _ := logic.ParseColorSchemes(themes)
+				for _, scheme := range schemes {
+					i18n.Printf("🎨 Emulating the %s color scheme...", scheme)
+					if err := logic.SetColorScheme(bc.ctx, scheme); err != nil {
+						fatalf("✗ %v", err)
+					}
+					// Loading the page under the scheme also covers pages that only
+					// check it on load.
+					if url != "" {
+						i18n.Printf("🚀 Navigating to %s...", url)
+					}
+					savedPath, err := logic.Screenshot(bc.ctx, url, themeScreenshotPath(filePath, scheme), opts)
+					if err != nil {
+						fatalf("✗ Failed to take screenshot: %v", err)
+					}
+					i18n.Printf("✅ Screenshot saved to: %s", savedPath)
+					upload.upload(bc.ctx, savedPath)
+				}
+				return
+			}
+
 			if url != "" {
-				log.Printf("🚀 Navigating to %s...", url)
+				i18n.Printf("🚀 Navigating to %s...", url)
 			}
-			log.Println("📸 Taking screenshot...")
+			i18n.Println("📸 Taking screenshot...")
 
-			savedPath, err := logic.Screenshot(bc.ctx, url, filePath, fullPage)
+			savedPath, err := logic.Screenshot(bc.ctx, url, filePath, opts)
 			if err != nil {
-				log.Fatalf("✗ Failed to take screenshot: %v", err)
+				fatalf("✗ Failed to take screenshot: %v", err)
 			}
-			log.Printf("✅ Screenshot saved to: %s", savedPath)
+			i18n.Printf("✅ Screenshot saved to: %s", savedPath)
+			upload.upload(bc.ctx, savedPath)
 		},
 	}
 
 	cmd.Flags().StringVar(&url, "url", "", "URL to navigate to first")
-	cmd.Flags().BoolVar(&fullPage, "full-page", false, "Take a full page screenshot")
+	cmd.Flags().BoolVar(&opts.FullPage, "full-page", false, "Take a full page screenshot")
+	cmd.Flags().BoolVar(&opts.Stitch, "stitch", false, "Capture the whole page by scrolling through it and stitching viewport captures (for lazy/virtualized pages)")
+	cmd.Flags().Float64Var(&opts.Scale, "scale", 0, "Device scale factor during the capture, e.g. 2 for high-DPI images (0 keeps the current one)")
+	cmd.Flags().StringArrayVar(&opts.Annotate, "annotate", nil, "Outline and label the elements matching this selector (repeatable)")
+	cmd.Flags().StringVar(&opts.Selector, "selector", "", "Capture only the first visible element matching this CSS selector")
+	cmd.Flags().BoolVar(&opts.NoAutoScroll, "no-auto-scroll", false, "Capture the --selector element where it is instead of scrolling it into view first")
+	cmd.Flags().BoolVar(&allTabs, "all-tabs", false, "Capture every open tab, saving one file per tab")
+	cmd.Flags().StringSliceVar(&themes, "themes", nil, "Capture once per prefers-color-scheme value (light, dark), saving one file per theme")
+	cmd.MarkFlagsMutuallyExclusive("full-page", "stitch")
+	cmd.MarkFlagsMutuallyExclusive("selector", "full-page")
+	cmd.MarkFlagsMutuallyExclusive("selector", "stitch")
+	cmd.MarkFlagsMutuallyExclusive("selector", "annotate")
+	cmd.MarkFlagsMutuallyExclusive("url", "all-tabs")
+	cmd.MarkFlagsMutuallyExclusive("themes", "all-tabs")
+	upload.register(cmd)
 	return cmd
 }
+
+// themeScreenshotPath derives the file of one theme's screenshot from the
+// path given to screenshot --themes by appending the color scheme to its name.
+func themeScreenshotPath(filePath, scheme string) string {
+	if filePath == "" {
+		filePath = "screenshot.png"
+	}
+	ext := filepath.Ext(filePath)
+	return strings.TrimSuffix(filePath, ext) + "-" + scheme + ext
+}
+
+// tabScreenshotPath derives the file of one tab's screenshot from the path
+// given to screenshot --all-tabs by appending a short tab ID to its name.
+func tabScreenshotPath(filePath, tabID string) string {
+	if filePath == "" {
+		filePath = "screenshot.png"
+	}
+	if len(tabID) > 8 {
+		tabID = tabID[:8]
+	}
+	ext := filepath.Ext(filePath)
+	return strings.TrimSuffix(filePath, ext) + "-" + tabID + ext
+}
@@ -3,9 +3,14 @@ package cmd
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
+
+	"github.com/spf13/cobra"
 )
 
 // モック用のブラウザコンテキスト
@@ -41,6 +46,59 @@ func TestNewNavigateCmd_CommandDefinition(t *testing.T) {
 	}
 }
 
+// TestNewNavigateCmd_WaitUntilFlag はnavigateコマンドの--wait-untilフラグをテストします。
+func TestNewNavigateCmd_WaitUntilFlag(t *testing.T) {
+	cmd := newNavigateCmd()
+
+	flag := cmd.Flags().Lookup("wait-until")
+	if flag == nil {
+		t.Fatal("Expected 'wait-until' flag to exist")
+	}
+	if flag.DefValue != logic.WaitUntilLoad {
+		t.Errorf("Expected 'wait-until' default to be %q, got %q", logic.WaitUntilLoad, flag.DefValue)
+	}
+}
+
+// TestNewNavigateCmd_ReferrerFlags はnavigateコマンドの--referrerと--transitionフラグをテストします。
+func TestNewNavigateCmd_ReferrerFlags(t *testing.T) {
+	cmd := newNavigateCmd()
+
+	for _, name := range []string{"referrer", "transition"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected '%s' flag to exist", name)
+		}
+	}
+}
+
+// TestNewNavigateCmd_StatsFlag はnavigateコマンドの--statsフラグをテストします。
+func TestNewNavigateCmd_StatsFlag(t *testing.T) {
+	cmd := newNavigateCmd()
+
+	stats, err := cmd.Flags().GetBool("stats")
+	if err != nil {
+		t.Fatalf("Expected 'stats' flag to exist: %v", err)
+	}
+	if stats {
+		t.Error("Expected --stats to be off by default")
+	}
+}
+
+// TestNewTraceRedirectsCmd はtrace-redirectsコマンドの定義とフラグをテストします。
+func TestNewTraceRedirectsCmd(t *testing.T) {
+	cmd := newTraceRedirectsCmd()
+
+	if cmd.Use != "trace-redirects <url>" {
+		t.Errorf("Expected Use to be 'trace-redirects <url>', got %s", cmd.Use)
+	}
+	if err := cmd.Args(cmd, []string{}); err == nil {
+		t.Error("Expected error without a URL")
+	}
+	flag := cmd.Flags().Lookup("settle")
+	if flag == nil || flag.DefValue != "3s" {
+		t.Error("Expected 'settle' flag with default 3s")
+	}
+}
+
 // TestNewScreenshotCmd_CommandDefinition はscreenshotコマンドの定義をテストします。
 func TestNewScreenshotCmd_CommandDefinition(t *testing.T) {
 	cmd := newScreenshotCmd()
@@ -63,6 +121,23 @@ func TestNewScreenshotCmd_CommandDefinition(t *testing.T) {
 	if fullPageFlag == nil {
 		t.Error("Expected 'full-page' flag to exist")
 	}
+
+	annotateFlag := cmd.Flags().Lookup("annotate")
+	if annotateFlag == nil {
+		t.Error("Expected 'annotate' flag to exist")
+	} else if annotateFlag.Value.Type() != "stringArray" {
+		t.Errorf("Expected 'annotate' to be repeatable without splitting selectors on commas, got %s", annotateFlag.Value.Type())
+	}
+
+	if cmd.Flags().Lookup("stitch") == nil {
+		t.Error("Expected 'stitch' flag to exist")
+	}
+	cmd.SetArgs([]string{"--full-page", "--stitch"})
+	cmd.Run = func(cmd *cobra.Command, args []string) {}
+	cmd.PersistentPreRunE = nil
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected --full-page and --stitch to be mutually exclusive")
+	}
 }
 
 // TestNewNavigateCmd_ArgumentValidation は引数バリデーションをテストします。
@@ -82,13 +157,13 @@ func TestNewNavigateCmd_ArgumentValidation(t *testing.T) {
 			name:          "no arguments",
 			args:          []string{},
 			expectError:   true,
-			expectedError: errors.New("requires exactly 1 arg(s), only received 0"),
+			expectedError: errors.New("accepts 1 arg(s), received 0"),
 		},
 		{
 			name:          "too many arguments",
 			args:          []string{"https://example.com", "extra"},
 			expectError:   true,
-			expectedError: errors.New("requires exactly 1 arg(s), only received 2"),
+			expectedError: errors.New("accepts 1 arg(s), received 2"),
 		},
 	}
 
@@ -296,4 +371,147 @@ func BenchmarkNewScreenshotCmd(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = newScreenshotCmd()
 	}
-}
\ No newline at end of file
+}
+// TestTabScreenshotPath は--all-tabs時のタブごとの保存先をテストします。
+func TestTabScreenshotPath(t *testing.T) {
+	tests := []struct {
+		path, tabID, want string
+	}{
+		{"", "ABCDEF0123456789", "screenshot-ABCDEF01.png"},
+		{"shots/home.png", "1234", "shots/home-1234.png"},
+		{"capture", "ABCDEF0123456789", "capture-ABCDEF01"},
+	}
+	for _, tt := range tests {
+		if got := tabScreenshotPath(tt.path, tt.tabID); got != tt.want {
+			t.Errorf("tabScreenshotPath(%q, %q) = %q, want %q", tt.path, tt.tabID, got, tt.want)
+		}
+	}
+}
+
+// TestThemeScreenshotPath は--themes時のテーマごとの保存先と検証をテストします。
+func TestThemeScreenshotPath(t *testing.T) {
+	tests := []struct {
+		path, scheme, want string
+	}{
+		{"", "dark", "screenshot-dark.png"},
+		{"shots/home.png", "light", "shots/home-light.png"},
+		{"capture", "dark", "capture-dark"},
+	}
+	for _, tt := range tests {
+		if got := themeScreenshotPath(tt.path, tt.scheme); got != tt.want {
+			t.Errorf("themeScreenshotPath(%q, %q) = %q, want %q", tt.path, tt.scheme, got, tt.want)
+		}
+	}
+
+	cmd := newScreenshotCmd()
+	cmd.Flags().Set("themes", "light,sepia")
+	if err := cmd.Args(cmd, nil); err == nil {
+		t.Error("Expected an error for an unknown theme")
+	}
+}
+
+// TestNewScreenshotCmd_Scale は--scaleフラグの検証をテストします。
+func TestNewScreenshotCmd_Scale(t *testing.T) {
+	tests := []struct {
+		scale   string
+		wantErr bool
+	}{
+		{"0", false},
+		{"2", false},
+		{"1.5", false},
+		{"-1", true},
+		{"8", true},
+	}
+	for _, tt := range tests {
+		cmd := newScreenshotCmd()
+		if err := cmd.Flags().Set("scale", tt.scale); err != nil {
+			t.Fatalf("Failed to set scale flag: %v", err)
+		}
+		if err := cmd.Args(cmd, nil); (err != nil) != tt.wantErr {
+			t.Errorf("--scale %s: Args() error = %v, wantErr %v", tt.scale, err, tt.wantErr)
+		}
+	}
+}
+
+// TestAllTabsFlags はeval・pick・screenshotの--all-tabsフラグをテストします。
+func TestAllTabsFlags(t *testing.T) {
+	for _, cmd := range []*cobra.Command{newEvalCmd(), newPickCmd(), newScreenshotCmd()} {
+		if cmd.Flags().Lookup("all-tabs") == nil {
+			t.Errorf("Expected '%s' to have an 'all-tabs' flag", cmd.Name())
+		}
+	}
+}
+
+// TestNoAutoScrollFlags はpick・screenshot・scriptの--no-auto-scrollフラグをテストします。
+func TestNoAutoScrollFlags(t *testing.T) {
+	for _, cmd := range []*cobra.Command{newPickCmd(), newScreenshotCmd(), newScriptCmd()} {
+		if cmd.Flags().Lookup("no-auto-scroll") == nil {
+			t.Errorf("Expected '%s' to have a 'no-auto-scroll' flag", cmd.Name())
+		}
+	}
+}
+
+// TestNewScreenshotCmd_Selector は--selectorと全画面系フラグの排他をテストします。
+func TestNewScreenshotCmd_Selector(t *testing.T) {
+	for _, other := range []string{"full-page", "stitch"} {
+		cmd := newScreenshotCmd()
+		if err := cmd.ParseFlags([]string{"--selector", "main", "--" + other}); err != nil {
+			t.Fatalf("Failed to parse flags: %v", err)
+		}
+		if err := cmd.ValidateFlagGroups(); err == nil {
+			t.Errorf("Expected --selector with --%s to fail", other)
+		}
+	}
+}
+
+// TestConsoleMessageLine はコンソールメッセージの1行表示をテストします。
+func TestConsoleMessageLine(t *testing.T) {
+	tests := []struct {
+		msg  models.ConsoleMessage
+		want string
+	}{
+		{models.ConsoleMessage{Source: "exception", Text: "TypeError: x is undefined", URL: "https://a.test/app.js", Line: 3}, "[exception] TypeError: x is undefined (https://a.test/app.js:3)"},
+		{models.ConsoleMessage{Source: "browser", Text: "Failed to load resource", URL: "https://a.test/x.png"}, "[browser] Failed to load resource (https://a.test/x.png)"},
+		{models.ConsoleMessage{Source: "console", Text: "boom"}, "[console] boom"},
+	}
+	for _, tt := range tests {
+		if got := consoleMessageLine(tt.msg); got != tt.want {
+			t.Errorf("consoleMessageLine(%+v) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+	if newNavigateCmd().Flags().Lookup("fail-on-console-error") == nil {
+		t.Error("Expected navigate to have a 'fail-on-console-error' flag")
+	}
+}
+
+// TestLoadBudget は予算ファイルの読み込みと検証をテストします。
+func TestLoadBudget(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	budget, err := loadBudget(write("ok.json", `{"maxRequests": 80, "maxTransferBytes": {"total": 1500000, "script": 400000}, "maxLcpMs": 2500, "maxCls": 0.1}`))
+	if err != nil {
+		t.Fatalf("loadBudget() = %v", err)
+	}
+	if budget.MaxRequests != 80 || budget.MaxTransferBytes["script"] != 400000 || budget.MaxCLS != 0.1 {
+		t.Errorf("budget = %+v", budget)
+	}
+	for name, content := range map[string]string{
+		"typo.json":    `{"maxRequest": 80}`,
+		"type.json":    `{"maxTransferBytes": {"scripts": 1}}`,
+		"invalid.json": `{`,
+	} {
+		if _, err := loadBudget(write(name, content)); err == nil {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+	if _, err := loadBudget(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected a missing file to be rejected")
+	}
+}
@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+func newNetworkCmd() *cobra.Command {
+	var opts logic.NetworkCaptureOptions
+	var sse, asCurl bool
+
+	cmd := &cobra.Command{
+		Use:   "network [url]",
+		Short: "Log the network requests of a page load as JSON lines",
+		Long: `Records the requests made while loading [url] (or, without a URL, by the current
+page during --wait) and prints one JSON line per finished request. The "source"
+field tells whether a response came from the network, the memory/disk/prefetch
+cache or a service worker. With --sse, every server-sent event received by an
+EventSource is printed as its own line ("kind": "sse") as it arrives.
+With --as-curl, each request is printed as a curl command instead, including
+its cookies and headers. Cookie values, credentials headers and the fields of
+the redaction policy are masked unless --no-redact is given.`,
+		Args:              cobra.MaximumNArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			if len(args) > 0 {
+				opts.URL = args[0]
+				i18n.Printf("📡 Capturing network traffic of %s...", opts.URL)
+			} else {
+				i18n.Printf("📡 Capturing network traffic for %s...", opts.Wait)
+			}
+
+			var onSSE func(*models.SSEEvent)
+			if sse {
+				onSSE = func(event *models.SSEEvent) {
+					printJSONLine(redactor.SSEEvent(event))
+				}
+			}
+
+			total, cached := 0, 0
+			err = logic.CaptureNetwork(bc.ctx, opts, func(entry *models.NetworkEntry) {
+				total++
+				if entry.FromCache {
+					cached++
+				}
+				if asCurl {
+					fmt.Println(logic.FormatCurl(redactor.CurlRequest(logic.CurlRequestFromEntry(entry))))
+					return
+				}
+				printJSONLine(redactor.NetworkEntry(entry))
+			}, onSSE)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("✅ Captured %d request(s), %d served from cache.", total, cached)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.Wait, "wait", 2*time.Second, "How long to keep capturing after the page has loaded")
+	cmd.Flags().BoolVar(&asCurl, "as-curl", false, "Print each request as a curl command instead of JSON")
+	cmd.Flags().BoolVar(&sse, "sse", false, "Also print each server-sent event (EventSource message) with its data")
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Only log URLs containing this string, or matching it as a glob if it contains '*'")
+	return cmd
+}
+
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Disable or enable the browser cache for the session",
+	}
+	cmd.AddCommand(newCacheToggleCmd("disable", true), newCacheToggleCmd("enable", false))
+	return cmd
+}
+
+func newCacheToggleCmd(name string, disabled bool) *cobra.Command {
+	short := "Enable the browser cache again"
+	if disabled {
+		short = "Disable the browser cache for all following commands of the session"
+	}
+
+	cmd := &cobra.Command{
+		Use:   name,
+		Short: short,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			info, err := config.LoadWsInfo()
+			if err != nil {
+				fatalf("✗ Browser is not running (start with 'browser-tools-go start'): %v", err)
+			}
+			info.CacheDisabled = disabled
+			if err := config.WriteWsInfo(info); err != nil {
+				fatalf("✗ Failed to save session info: %v", err)
+			}
+			if disabled {
+				i18n.Println("✅ Browser cache disabled for this session.")
+			} else {
+				i18n.Println("✅ Browser cache enabled.")
+			}
+		},
+	}
+	return cmd
+}
+
+func newFetchCmd() *cobra.Command {
+	var opts logic.FetchOptions
+	var headers []string
+
+	cmd := &cobra.Command{
+		Use:   "fetch <url>",
+		Short: "Run window.fetch inside the current page and print the response",
+		Long: `Performs an HTTP request with window.fetch in the context of the current page,
+so the page's cookies and same-origin privileges apply. Relative URLs are resolved
+against the current page. Prints status, headers and body; JSON bodies are also
+decoded into the "json" field.`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			opts.Headers, err = logic.ParseHeaders(headers)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+
+			i18n.Printf("🌐 Fetching %s %s in page...", strings.ToUpper(opts.Method), args[0])
+			result, err := logic.InPageFetch(bc.ctx, args[0], opts)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			prettyPrintResults(result)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Method, "method", "X", "GET", "HTTP method")
+	cmd.Flags().StringVarP(&opts.Body, "body", "d", "", "Request body")
+	cmd.Flags().StringArrayVarP(&headers, "header", "H", nil, "Request header as 'Name: Value' (repeatable)")
+	return cmd
+}
+
+func newDownloadCmd() *cobra.Command {
+	var headers []string
+
+	cmd := &cobra.Command{
+		Use:   "download <url> [path]",
+		Short: "Download a file with the current page's cookies and session",
+		Long: `Fetches <url> with window.fetch in the context of the current page, so files
+behind a login or SSO can be downloaded, and saves the response body below the
+working directory. Relative URLs are resolved against the current page.
+
+[path] may be a file or a directory (an existing one, or one ending with "/").
+Without a file name, the name comes from the Content-Disposition header or the
+URL. Error responses are not saved.`,
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			requestHeaders, err := logic.ParseHeaders(headers)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			target := ""
+			if len(args) > 1 {
+				target = args[1]
+			}
+
+			i18n.Printf("📥 Downloading %s...", args[0])
+			result, err := logic.Download(bc.ctx, args[0], target, requestHeaders)
+			if err != nil {
+				fatalf("✗ Download failed: %v", err)
+			}
+			i18n.Printf("✅ Saved %s (%d bytes)", result.Path, result.Bytes)
+			prettyPrintResults(result)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&headers, "header", "H", nil, "Request header as 'Name: Value' (repeatable)")
+	return cmd
+}
+
+func newHarCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "har",
+		Short: "Work with HAR (HTTP Archive) files",
+	}
+	cmd.AddCommand(newHarToCurlCmd())
+	return cmd
+}
+
+func newHarToCurlCmd() *cobra.Command {
+	var filter string
+
+	cmd := &cobra.Command{
+		Use:   "to-curl <file.har>",
+		Short: "Convert the requests of a HAR file into curl commands",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				fatalf("✗ Failed to read HAR file: %v", err)
+			}
+
+			requests, err := logic.CurlRequestsFromHAR(data, filter)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			for _, req := range requests {
+				fmt.Println(logic.FormatCurl(redactor.CurlRequest(req)))
+			}
+			i18n.Printf("✅ Converted %d request(s).", len(requests))
+		},
+	}
+
+	cmd.Flags().StringVar(&filter, "filter", "", "Only convert URLs containing this string, or matching it as a glob if it contains '*'")
+	return cmd
+}
+
+func newGraphQLCmd() *cobra.Command {
+	var queryFile, variablesFile, operationName string
+	var headers []string
+
+	cmd := &cobra.Command{
+		Use:   "graphql <endpoint>",
+		Short: "Run a GraphQL operation with the page's authentication",
+		Long: `Posts a GraphQL operation to <endpoint> with window.fetch inside the current page,
+so it inherits the page's cookies. Prints the "data", "errors" and "extensions"
+of the response. Use "-" as --query to read the query from stdin.`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			req := logic.GraphQLRequest{OperationName: operationName}
+			query, err := readInputFile(queryFile)
+			if err != nil {
+				fatalf("✗ Failed to read query: %v", err)
+			}
+			req.Query = string(query)
+			if variablesFile != "" {
+				if req.Variables, err = os.ReadFile(variablesFile); err != nil {
+					fatalf("✗ Failed to read variables: %v", err)
+				}
+			}
+			extraHeaders, err := logic.ParseHeaders(headers)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+
+			i18n.Printf("🔷 Running GraphQL operation against %s...", args[0])
+			response, err := logic.GraphQL(bc.ctx, args[0], req, extraHeaders)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			if len(response.Errors) > 0 {
+				i18n.Printf("⚠️ GraphQL returned %d error(s).", len(response.Errors))
+			}
+			prettyPrintResults(response)
+		},
+	}
+
+	cmd.Flags().StringVar(&queryFile, "query", "", "File containing the GraphQL query (\"-\" for stdin)")
+	cmd.Flags().StringVar(&variablesFile, "variables", "", "JSON file with the operation variables")
+	cmd.Flags().StringVar(&operationName, "operation-name", "", "Operation to run when the query defines several")
+	cmd.Flags().StringArrayVarP(&headers, "header", "H", nil, "Additional request header as 'Name: Value' (repeatable)")
+	cmd.MarkFlagRequired("query")
+	return cmd
+}
+
+// readInputFile reads a file, or stdin when path is "-".
+func readInputFile(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+func newCaptureAPICmd() *cobra.Command {
+	var opts logic.APICaptureOptions
+
+	cmd := &cobra.Command{
+		Use:   "capture-api [url]",
+		Short: "Capture JSON responses of XHR/fetch requests matching a URL pattern",
+		Long: `Waits for XHR/fetch responses whose URL matches --pattern, while loading [url]
+or while you interact with the current page, and prints one JSON line per
+response with its decoded body.`,
+		Args:              cobra.MaximumNArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			if len(args) > 0 {
+				opts.URL = args[0]
+			}
+			i18n.Printf("🎯 Waiting for responses matching %q...", opts.Pattern)
+
+			captured, err := logic.CaptureAPI(bc.ctx, opts, func(response *models.CapturedResponse) {
+				printJSONLine(redactor.CapturedResponse(response))
+			})
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("✅ Captured %d response(s).", captured)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Pattern, "pattern", "", "URL pattern: substring, or glob when it contains '*' (e.g. \"*/api/v1/products*\")")
+	cmd.Flags().IntVar(&opts.Count, "count", 1, "Stop after this many responses (0 = capture until --timeout)")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 30*time.Second, "Maximum time to wait")
+	cmd.MarkFlagRequired("pattern")
+	return cmd
+}
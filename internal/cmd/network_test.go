@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// TestNewNetworkCmd_FlagDefaults はnetworkコマンドのフラグのデフォルト値をテストします。
+func TestNewNetworkCmd_FlagDefaults(t *testing.T) {
+	cmd := newNetworkCmd()
+
+	wait, err := cmd.Flags().GetDuration("wait")
+	if err != nil || wait != 2*time.Second {
+		t.Errorf("Expected wait default 2s, got %v (err: %v)", wait, err)
+	}
+	if err := cmd.Args(cmd, []string{"a", "b"}); err == nil {
+		t.Error("Expected error for more than one URL")
+	}
+}
+
+// TestNewCacheCmd_Subcommands はcacheコマンドのサブコマンド構成をテストします。
+func TestNewCacheCmd_Subcommands(t *testing.T) {
+	cmd := newCacheCmd()
+
+	for _, name := range []string{"disable", "enable"} {
+		sub, _, err := cmd.Find([]string{name})
+		if err != nil || sub.Name() != name {
+			t.Errorf("Expected subcommand %s, got %v (err: %v)", name, sub, err)
+		}
+	}
+}
+
+// TestNewRootCmd_NoCacheFlag はルートコマンドに--no-cacheフラグがあることをテストします。
+func TestNewRootCmd_NoCacheFlag(t *testing.T) {
+	rootCmd := NewRootCmd()
+
+	if rootCmd.PersistentFlags().Lookup("no-cache") == nil {
+		t.Error("Expected persistent no-cache flag on root command")
+	}
+}
+
+// TestNewFetchCmd_Flags はfetchコマンドのフラグ定義をテストします。
+func TestNewFetchCmd_Flags(t *testing.T) {
+	cmd := newFetchCmd()
+
+	tests := []struct {
+		name      string
+		shorthand string
+		defValue  string
+	}{
+		{"method", "X", "GET"},
+		{"body", "d", ""},
+		{"header", "H", "[]"},
+	}
+	for _, tt := range tests {
+		flag := cmd.Flags().Lookup(tt.name)
+		if flag == nil {
+			t.Errorf("Expected flag %s to be defined", tt.name)
+			continue
+		}
+		if flag.Shorthand != tt.shorthand || flag.DefValue != tt.defValue {
+			t.Errorf("Flag %s: got shorthand %q default %q, want %q %q", tt.name, flag.Shorthand, flag.DefValue, tt.shorthand, tt.defValue)
+		}
+	}
+}
+
+// TestNewDownloadCmd_Args はdownloadコマンドの引数の数の検証をテストします。
+func TestNewDownloadCmd_Args(t *testing.T) {
+	cmd := newDownloadCmd()
+	for _, args := range [][]string{{"/export.csv"}, {"/export.csv", "out/"}} {
+		if err := cmd.Args(cmd, args); err != nil {
+			t.Errorf("Args(%v) error = %v", args, err)
+		}
+	}
+	for _, args := range [][]string{{}, {"/a", "b", "c"}} {
+		if err := cmd.Args(cmd, args); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+	if flag := cmd.Flags().Lookup("header"); flag == nil || flag.Shorthand != "H" {
+		t.Errorf("Expected a header flag with shorthand H, got %v", flag)
+	}
+}
+
+// TestNewGraphQLCmd_RequiredQuery はgraphqlコマンドで--queryが必須であることをテストします。
+func TestNewGraphQLCmd_RequiredQuery(t *testing.T) {
+	cmd := newGraphQLCmd()
+
+	flag := cmd.Flags().Lookup("query")
+	if flag == nil {
+		t.Fatal("Expected query flag to be defined")
+	}
+	if _, ok := flag.Annotations[cobra.BashCompOneRequiredFlag]; !ok {
+		t.Error("Expected query flag to be required")
+	}
+}
+
+// TestNewCaptureAPICmd_FlagDefaults はcapture-apiコマンドのフラグのデフォルト値をテストします。
+func TestNewCaptureAPICmd_FlagDefaults(t *testing.T) {
+	cmd := newCaptureAPICmd()
+
+	count, err := cmd.Flags().GetInt("count")
+	if err != nil || count != 1 {
+		t.Errorf("Expected count default 1, got %d (err: %v)", count, err)
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil || timeout != 30*time.Second {
+		t.Errorf("Expected timeout default 30s, got %v (err: %v)", timeout, err)
+	}
+	if _, ok := cmd.Flags().Lookup("pattern").Annotations[cobra.BashCompOneRequiredFlag]; !ok {
+		t.Error("Expected pattern flag to be required")
+	}
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/itchyny/gojq"
+)
+
+// resultFormat is how a command prints its JSON results.
+type resultFormat struct {
+	query    string     // --query, a jq expression
+	code     *gojq.Code // query compiled
+	text     string     // --template, a Go template
+	template *template.Template
+}
+
+// outputFormat is the result format of the running command. The steps of
+// batch and pipe-line start from the default one (see runCommandLine).
+var outputFormat resultFormat
+
+// queryFlag is the --query flag; the query is compiled when the flag is set,
+// so a syntax error is reported before the command runs.
+type queryFlag struct{}
+
+func (queryFlag) String() string { return outputFormat.query }
+func (queryFlag) Type() string   { return "jq" }
+func (queryFlag) Set(v string) error {
+	code, err := compileQuery(v)
+	if err != nil {
+		return err
+	}
+	outputFormat.query, outputFormat.code = v, code
+	return nil
+}
+
+// templateFlag is the --template flag; like --query, the template is parsed
+// when the flag is set.
+type templateFlag struct{}
+
+func (templateFlag) String() string { return outputFormat.text }
+func (templateFlag) Type() string   { return "template" }
+func (templateFlag) Set(v string) error {
+	tmpl, err := parseTemplate(v)
+	if err != nil {
+		return err
+	}
+	outputFormat.text, outputFormat.template = v, tmpl
+	return nil
+}
+
+// templateEscapes are the escape sequences of --template, which shells don't
+// expand in quoted arguments.
+var templateEscapes = strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\t`, "\t", `\r`, "\r")
+
+// parseTemplate parses the Go template text, with \n, \t, \r and \\ standing
+// for a newline, a tab, a carriage return and a backslash.
+func parseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Parse(templateEscapes.Replace(text))
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// compileQuery compiles the jq expression query.
+func compileQuery(query string) (*gojq.Code, error) {
+	parsed, err := gojq.Parse(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", query, err)
+	}
+	code, err := gojq.Compile(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", query, err)
+	}
+	return code, nil
+}
+
+// runQuery returns the values code outputs for data, which is converted to
+// plain JSON values first, as jq would read it.
+func runQuery(code *gojq.Code, data interface{}) ([]interface{}, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var input interface{}
+	if err := json.Unmarshal(encoded, &input); err != nil {
+		return nil, err
+	}
+
+	var values []interface{}
+	iter := code.Run(input)
+	for {
+		value, ok := iter.Next()
+		if !ok {
+			return values, nil
+		}
+		if err, ok := value.(error); ok {
+			if err, ok := err.(*gojq.HaltError); ok && err.Value() == nil {
+				return values, nil
+			}
+			return nil, err
+		}
+		values = append(values, value)
+	}
+}
+
+// printResults prints data as JSON, indented or on one line. With --query,
+// the values the query outputs for data are printed instead, one after the
+// other. With --template, each value is rendered by the template rather
+// than printed as JSON: data as it is, so its fields have their Go names, or
+// the JSON values output by the query.
+func printResults(data interface{}, indent bool) {
+	values := []interface{}{data}
+	if outputFormat.code != nil {
+		var err error
+		if values, err = runQuery(outputFormat.code, data); err != nil {
+			fatalf("✗ --query: %v", err)
+		}
+	}
+	for _, value := range values {
+		if outputFormat.template != nil {
+			if err := outputFormat.template.Execute(os.Stdout, value); err != nil {
+				fatalf("✗ --template: %v", err)
+			}
+			continue
+		}
+		var output []byte
+		var err error
+		if indent {
+			output, err = json.MarshalIndent(value, "", "  ")
+		} else {
+			output, err = json.Marshal(value)
+		}
+		if err != nil {
+			fatalf("Failed to marshal result: %v", err)
+		}
+		fmt.Println(string(output))
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"testing"
+
+	"browser-tools-go/internal/models"
+)
+
+// printed は fn が標準出力に書いた内容を返します。
+func printed(fn func()) string {
+	output, _ := captureStdout(func() error {
+		fn()
+		return nil
+	})
+	return output
+}
+
+// TestQueryFlag は--queryによるJSON出力の絞り込みをテストします。
+func TestQueryFlag(t *testing.T) {
+	defer func() { outputFormat = resultFormat{} }()
+
+	root := NewRootCmd()
+	flag := root.PersistentFlags().Lookup("query")
+	if flag == nil {
+		t.Fatal("Expected persistent query flag on root command")
+	}
+	if err := flag.Value.Set(".[] | {title, link}"); err != nil {
+		t.Fatalf("Failed to set query: %v", err)
+	}
+
+	data := []map[string]interface{}{
+		{"title": "Go", "link": "https://go.dev/", "snippet": "..."},
+		{"title": "jq", "link": "https://jqlang.org/", "snippet": "..."},
+	}
+	got := printed(func() { printJSONLine(data) })
+	want := "{\"link\":\"https://go.dev/\",\"title\":\"Go\"}\n{\"link\":\"https://jqlang.org/\",\"title\":\"jq\"}\n"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// 構造体は JSON の値として問い合わせられる
+	type result struct {
+		Title string `json:"title"`
+	}
+	_ = flag.Value.Set(".title")
+	if got := printed(func() { prettyPrintResults(result{Title: "Go"}) }); got != "\"Go\"\n" {
+		t.Errorf("Expected the title, got %q", got)
+	}
+
+	if err := flag.Value.Set(".[] |"); err == nil {
+		t.Error("Expected error for an invalid query")
+	}
+}
+
+// TestTemplateFlag は--templateによる出力の描画をテストします。
+func TestTemplateFlag(t *testing.T) {
+	defer func() { outputFormat = resultFormat{} }()
+
+	root := NewRootCmd()
+	flag := root.PersistentFlags().Lookup("template")
+	if flag == nil {
+		t.Fatal("Expected persistent template flag on root command")
+	}
+	if err := flag.Value.Set(`{{range .}}{{.Title}}\t{{.Link}}\n{{end}}`); err != nil {
+		t.Fatalf("Failed to set template: %v", err)
+	}
+
+	data := []models.SearchResult{
+		{Title: "Go", Link: "https://go.dev/"},
+		{Title: "jq", Link: "https://jqlang.org/"},
+	}
+	want := "Go\thttps://go.dev/\njq\thttps://jqlang.org/\n"
+	if got := printed(func() { prettyPrintResults(data) }); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// --query と組み合わせると、クエリの出力する JSON の値を描画する
+	_ = root.PersistentFlags().Lookup("query").Value.Set(".[]")
+	_ = flag.Value.Set(`{{.title}}\\n`)
+	if got := printed(func() { printJSONLine(data) }); got != `Go\njq\n` {
+		t.Errorf("Expected the titles, got %q", got)
+	}
+
+	if err := flag.Value.Set("{{range .}}"); err == nil {
+		t.Error("Expected error for an invalid template")
+	}
+}
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// pluginAnnotation marks the commands that run plugins; its value is the
+// plugin's path.
+const pluginAnnotation = "plugin"
+
+// pluginProtocolVersion is sent to plugins in the start message.
+const pluginProtocolVersion = 1
+
+// Plugin protocol message types. Plugins receive "start" and "result"
+// messages on stdin and send "command", "output", "log" and "error" messages
+// on stdout, one JSON object per line.
+const (
+	pluginMsgStart   = "start"
+	pluginMsgResult  = "result"
+	pluginMsgCommand = "command"
+	pluginMsgOutput  = "output"
+	pluginMsgLog     = "log"
+	pluginMsgError   = "error"
+)
+
+// pluginStart is the first message sent to a plugin.
+type pluginStart struct {
+	Type    string   `json:"type"`
+	Version int      `json:"version"`
+	Args    []string `json:"args"`
+	WsURL   string   `json:"wsUrl"` // DevTools endpoint, for plugins that drive the browser themselves; see pluginWsURL
+}
+
+// pluginResult answers a plugin's command message.
+type pluginResult struct {
+	Type   string      `json:"type"`
+	ID     int         `json:"id"`
+	OK     bool        `json:"ok"`
+	Output interface{} `json:"output,omitempty"` // stdout, parsed as JSON when possible
+	Error  string      `json:"error,omitempty"`
+}
+
+// pluginMessage is a message sent by a plugin.
+type pluginMessage struct {
+	Type    string          `json:"type"`
+	ID      int             `json:"id"`      // command: echoed in the result
+	Args    []string        `json:"args"`    // command: the command line to run
+	Data    json.RawMessage `json:"data"`    // output: the value to print
+	Message string          `json:"message"` // log, error
+}
+
+// pluginHost runs a plugin's command messages against the shared browser connection.
+type pluginHost struct {
+	run    func(args []string) (string, error)
+	output func(data json.RawMessage)
+}
+
+// runPlugin runs the plugin executable with args and serves its messages
+// until it exits. It fails when the plugin sends an error message, exits
+// with a non-zero status or breaks the protocol.
+func runPlugin(path string, args []string, wsURL string, host pluginHost) error {
+	proc := exec.Command(path, args...)
+	proc.Stderr = os.Stderr
+	stdin, err := proc.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := proc.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := proc.Start(); err != nil {
+		return fmt.Errorf("failed to start plugin: %w", err)
+	}
+
+	serveErr := servePlugin(stdin, stdout, args, wsURL, host)
+	stdin.Close()
+	if serveErr != nil {
+		_ = proc.Process.Kill()
+	}
+	waitErr := proc.Wait()
+	if serveErr != nil {
+		return serveErr
+	}
+	if waitErr != nil {
+		return fmt.Errorf("plugin failed: %w", waitErr)
+	}
+	return nil
+}
+
+// servePlugin speaks the plugin protocol over the plugin's stdin and stdout.
+func servePlugin(stdin io.Writer, stdout io.Reader, args []string, wsURL string, host pluginHost) error {
+	enc := json.NewEncoder(stdin)
+	if args == nil {
+		args = []string{}
+	}
+	// The plugin may exit without reading stdin; its exit status is what counts.
+	_ = enc.Encode(pluginStart{Type: pluginMsgStart, Version: pluginProtocolVersion, Args: args, WsURL: wsURL})
+
+	var failure error
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var msg pluginMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			return fmt.Errorf("invalid message from plugin: %q", logic.TruncateForError(line))
+		}
+		switch msg.Type {
+		case pluginMsgCommand:
+			result := pluginResult{Type: pluginMsgResult, ID: msg.ID}
+			err := validateInProcess(msg.Args)
+			if err == nil {
+				var output string
+				output, err = host.run(msg.Args)
+				result.Output = parseCommandOutput(output)
+			}
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.OK = true
+			}
+			if err := enc.Encode(result); err != nil {
+				return fmt.Errorf("failed to send result to plugin: %w", err)
+			}
+		case pluginMsgOutput:
+			host.output(msg.Data)
+		case pluginMsgLog:
+			i18n.Printf("🔌 %s", msg.Message)
+		case pluginMsgError:
+			failure = fmt.Errorf("%s", msg.Message)
+		default:
+			return fmt.Errorf("unknown message type %q from plugin", msg.Type)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read from plugin: %w", err)
+	}
+	return failure
+}
+
+// addPluginCommands registers a command for every plugin whose name isn't
+// taken by a built-in command.
+func addPluginCommands(root *cobra.Command) {
+	plugins, err := config.ListPlugins()
+	if err != nil {
+		i18n.Printf("⚠️ Failed to list plugins: %v", err)
+		return
+	}
+	for _, plugin := range plugins {
+		if !isBuiltinCommand(root, plugin.Name) {
+			root.AddCommand(newPluginCmd(plugin))
+		}
+	}
+}
+
+// isBuiltinCommand reports whether root has a built-in command called name.
+func isBuiltinCommand(root *cobra.Command, name string) bool {
+	for _, c := range root.Commands() {
+		if _, ok := c.Annotations[pluginAnnotation]; ok {
+			continue
+		}
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func newPluginCmd(plugin config.Plugin) *cobra.Command {
+	return &cobra.Command{
+		Use:         plugin.Name + " [args]...",
+		Annotations: map[string]string{pluginAnnotation: plugin.Path},
+		Short:       fmt.Sprintf("Run the %s plugin", plugin.Name),
+		Long: fmt.Sprintf(`Runs the plugin %s, passing it all arguments and flags.
+The plugin can run other commands on the current tab and prints its results as
+JSON. See "plugins" for the installed plugins.`, plugin.Path),
+		DisableFlagParsing: true,
+		PersistentPreRunE:  persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			wsURL := pluginWsURL(bc.ctx)
+			host := pluginHost{
+				run: func(args []string) (string, error) { return runCommandLine(bc, args) },
+				output: func(data json.RawMessage) {
+					if len(data) == 0 {
+						data = json.RawMessage("null")
+					}
+					prettyPrintResults(data)
+				},
+			}
+			if err := runPlugin(plugin.Path, args, wsURL, host); err != nil {
+				fatalf("✗ Plugin %s: %v", plugin.Name, err)
+			}
+		},
+	}
+}
+
+// pluginWsURL returns the DevTools endpoint sent to plugins. It is empty when
+// the connection of ctx is read-only or its policy restricts eval: a plugin
+// could run any JavaScript over the endpoint, so it has to send command
+// messages instead, which are checked like the commands of the CLI.
+func pluginWsURL(ctx context.Context) string {
+	if logic.IsReadOnly(ctx) || logic.PolicyFrom(ctx).RestrictsEval() {
+		return ""
+	}
+	info, err := config.LoadWsInfo()
+	if err != nil {
+		return ""
+	}
+	return info.Url
+}
+
+func newPluginsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "plugins",
+		Short: "List the installed plugins",
+		Long: `Lists the plugins discovered in ~/.browser-tools-go/plugins. Every executable
+there becomes a command named after the file without its extension. Plugins
+whose name is taken by a built-in command are marked shadowed and can't be run.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			plugins, err := config.ListPlugins()
+			if err != nil {
+				fatalf("✗ Failed to list plugins: %v", err)
+			}
+			infos := []models.PluginInfo{}
+			for _, plugin := range plugins {
+				infos = append(infos, models.PluginInfo{
+					Name:     plugin.Name,
+					Path:     plugin.Path,
+					Shadowed: isBuiltinCommand(cmd.Root(), plugin.Name),
+				})
+			}
+			prettyPrintResults(infos)
+		},
+	}
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+// TestServePlugin はプラグインとのJSONメッセージのやり取りをテストします。
+func TestServePlugin(t *testing.T) {
+	stdout := strings.Join([]string{
+		`{"type":"log","message":"starting"}`,
+		`{"type":"command","id":1,"args":["eval","document.title"]}`,
+		`{"type":"command","id":2,"args":["batch","x.txt"]}`,
+		`{"type":"command","id":3,"args":["navigate","bad"]}`,
+		`{"type":"output","data":{"title":"Example"}}`,
+	}, "\n")
+
+	var ran [][]string
+	var outputs []string
+	host := pluginHost{
+		run: func(args []string) (string, error) {
+			ran = append(ran, args)
+			if args[0] == "navigate" {
+				return "", errors.New("navigation failed")
+			}
+			return "\"Example\"\n", nil
+		},
+		output: func(data json.RawMessage) { outputs = append(outputs, string(data)) },
+	}
+
+	var stdin bytes.Buffer
+	if err := servePlugin(&stdin, strings.NewReader(stdout), []string{"--user", "x"}, "ws://127.0.0.1:9222", host); err != nil {
+		t.Fatalf("servePlugin() error = %v", err)
+	}
+
+	// batchはプラグインから実行できない
+	wantRan := [][]string{{"eval", "document.title"}, {"navigate", "bad"}}
+	if !reflect.DeepEqual(ran, wantRan) {
+		t.Errorf("Expected commands %v, got %v", wantRan, ran)
+	}
+	if !reflect.DeepEqual(outputs, []string{`{"title":"Example"}`}) {
+		t.Errorf("Unexpected outputs %v", outputs)
+	}
+
+	var sent []map[string]interface{}
+	scanner := bufio.NewScanner(&stdin)
+	for scanner.Scan() {
+		var msg map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			t.Fatalf("Invalid message sent to plugin: %v", err)
+		}
+		sent = append(sent, msg)
+	}
+	if len(sent) != 4 {
+		t.Fatalf("Expected start and 3 results, got %v", sent)
+	}
+	if sent[0]["type"] != "start" || sent[0]["wsUrl"] != "ws://127.0.0.1:9222" || !reflect.DeepEqual(sent[0]["args"], []interface{}{"--user", "x"}) {
+		t.Errorf("Unexpected start message %v", sent[0])
+	}
+	if sent[1]["ok"] != true || sent[1]["output"] != "Example" || sent[1]["id"] != float64(1) {
+		t.Errorf("Unexpected result %v", sent[1])
+	}
+	if sent[2]["ok"] != false || sent[2]["error"] == nil {
+		t.Errorf("Expected batch to be rejected, got %v", sent[2])
+	}
+	if sent[3]["ok"] != false || sent[3]["error"] != "navigation failed" {
+		t.Errorf("Expected failed result, got %v", sent[3])
+	}
+}
+
+// TestServePlugin_Errors はプラグインのエラー報告とプロトコル違反をテストします。
+func TestServePlugin_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		stdout  string
+		wantErr string
+	}{
+		{"error message", `{"type":"error","message":"login required"}`, "login required"},
+		{"not json", "hello", "invalid message from plugin"},
+		{"unknown type", `{"type":"bogus"}`, "unknown message type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdin bytes.Buffer
+			err := servePlugin(&stdin, strings.NewReader(tt.stdout), nil, "", pluginHost{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestRunPlugin はプラグインをサブプロセスとして実行することをテストします。
+func TestRunPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script plugin")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "echo-args")
+	content := "#!/bin/sh\nread start\necho '{\"type\":\"output\",\"data\":'\"$start\"'}'\n[ \"$1\" = fail ] && exit 3\nexit 0\n"
+	if err := os.WriteFile(script, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var outputs []json.RawMessage
+	host := pluginHost{output: func(data json.RawMessage) { outputs = append(outputs, data) }}
+	if err := runPlugin(script, []string{"ok"}, "ws://x", host); err != nil {
+		t.Fatalf("runPlugin() error = %v", err)
+	}
+	if len(outputs) != 1 {
+		t.Fatalf("Expected one output, got %d", len(outputs))
+	}
+	var start pluginStart
+	if err := json.Unmarshal(outputs[0], &start); err != nil {
+		t.Fatal(err)
+	}
+	if start.Type != "start" || start.Version != pluginProtocolVersion || !reflect.DeepEqual(start.Args, []string{"ok"}) {
+		t.Errorf("Unexpected start message %+v", start)
+	}
+
+	if err := runPlugin(script, []string{"fail"}, "ws://x", host); err == nil {
+		t.Error("Expected error for non-zero exit status")
+	}
+}
+
+// TestAddPluginCommands は組み込みコマンドと同名のプラグインが登録されないことをテストします。
+func TestAddPluginCommands(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("execute bits are not used on Windows")
+	}
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	dir, _ := config.GetPluginsDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"x-twitter", "navigate"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root := NewRootCmd()
+	addPluginCommands(root)
+	var plugin *cobra.Command
+	navigates := 0
+	for _, c := range root.Commands() {
+		switch c.Name() {
+		case "x-twitter":
+			plugin = c
+		case "navigate":
+			navigates++
+		}
+	}
+	if plugin == nil {
+		t.Fatal("Expected plugin command x-twitter")
+	}
+	if !plugin.DisableFlagParsing {
+		t.Error("Expected plugin flags to be passed through")
+	}
+	if navigates != 1 {
+		t.Errorf("Expected only the built-in navigate, got %d", navigates)
+	}
+	if !isBuiltinCommand(root, "navigate") || isBuiltinCommand(root, "x-twitter") {
+		t.Error("isBuiltinCommand() doesn't tell built-in commands from plugins")
+	}
+	// プラグインはExecuteでのみ登録される
+	for _, c := range NewRootCmd().Commands() {
+		if c.Name() == "x-twitter" {
+			t.Error("Expected NewRootCmd not to register plugins")
+		}
+	}
+}
+
+// TestPluginWsURL はread-only接続やevalを制限するポリシーの下でプラグインにDevToolsのURLを渡さないことをテストします。
+func TestPluginWsURL(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	const wsURL = "ws://127.0.0.1:9222/devtools/browser/abc"
+	if err := config.SaveWsInfo(wsURL, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if got := pluginWsURL(ctx); got != wsURL {
+		t.Errorf("Expected the session's DevTools URL, got %q", got)
+	}
+	if got := pluginWsURL(logic.WithReadOnly(ctx)); got != "" {
+		t.Errorf("Expected no DevTools URL on a read-only connection, got %q", got)
+	}
+	restricted := logic.WithPolicy(ctx, &config.Policy{EvalScriptDir: tmpDir})
+	if got := pluginWsURL(restricted); got != "" {
+		t.Errorf("Expected no DevTools URL when the policy restricts eval, got %q", got)
+	}
+}
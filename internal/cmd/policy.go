@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"browser-tools-go/internal/config"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// checkPolicyArgs checks the URLs cmd visits or requests, as dryRunSpecs
+// describe them, against the host policy, so that the command fails before
+// it starts rather than on a blocked navigation. Relative URLs are left to
+// the browser.
+func checkPolicyArgs(cmd *cobra.Command, args []string, policy *config.Policy) error {
+	if policy.AllowsAllHosts() {
+		return nil
+	}
+	spec := dryRunSpecs[commandName(cmd)]
+	for i, kind := range spec.args {
+		if i < len(args) && (kind == valueVisit || kind == valueRequest) {
+			if err := policy.Allows(args[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	var err error
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if kind := spec.flags[flag.Name]; err != nil || (kind != valueVisit && kind != valueRequest) {
+			return
+		}
+		values := []string{flag.Value.String()}
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			values = slice.GetSlice()
+		}
+		for _, value := range values {
+			if err = policy.Allows(value); err != nil {
+				return
+			}
+		}
+	})
+	return err
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+
+	"browser-tools-go/internal/config"
+)
+
+// TestCheckPolicyArgs はホストポリシーによる引数とフラグのURLの検査をテストします。
+func TestCheckPolicyArgs(t *testing.T) {
+	policy := &config.Policy{AllowedHosts: []string{"*.example.com"}}
+	root := NewRootCmd()
+
+	navigate, _, _ := root.Find([]string{"navigate"})
+	if err := checkPolicyArgs(navigate, []string{"https://www.example.com/"}, policy); err != nil {
+		t.Errorf("Expected an allowed host to pass, got %v", err)
+	}
+	if err := checkPolicyArgs(navigate, []string{"https://example.org/"}, policy); err == nil {
+		t.Error("Expected a host outside allowed_hosts to be refused")
+	}
+
+	screenshot, _, _ := root.Find([]string{"screenshot"})
+	_ = screenshot.Flags().Set("url", "https://example.org/")
+	if err := checkPolicyArgs(screenshot, nil, policy); err == nil {
+		t.Error("Expected --url to be checked")
+	}
+
+	// 相対URLはブラウザに任せる
+	fetch, _, _ := root.Find([]string{"fetch"})
+	if err := checkPolicyArgs(fetch, []string{"/api/items"}, policy); err != nil {
+		t.Errorf("Expected a relative URL to pass, got %v", err)
+	}
+	if err := checkPolicyArgs(navigate, []string{"https://example.org/"}, &config.Policy{}); err != nil {
+		t.Errorf("Expected an empty policy to allow everything, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+// readOnlyRefused are the commands --read-only refuses outright, keyed by
+// command path, with what they change. Commands whose effect depends on
+// their input, such as eval, fetch and graphql, and the steps of scripts are
+// checked as they run (see logic.WithReadOnly).
+var readOnlyRefused = map[string]string{
+	"clear-data":      "clears the data of the site",
+	"clipboard write": "replaces the clipboard",
+	"cookies clear":   "deletes cookies",
+	"cookies delete":  "deletes cookies",
+	"cookies import":  "sets cookies",
+	"cookies set":     "sets a cookie",
+	"login":           "fills in and submits a login form",
+	"set":             "changes a form control",
+	"state load":      "replaces the cookies and storage of the browser",
+	"sw unregister":   "unregisters service workers",
+	"sw update":       "updates service workers",
+	"type":            "types into the page",
+}
+
+// readOnly reports whether cmd runs in read-only mode: --read-only is given,
+// or cmd is a step of batch, pipe-line or a plugin sharing a read-only
+// connection.
+func readOnly(cmd *cobra.Command) bool {
+	if enabled, _ := cmd.Flags().GetBool("read-only"); enabled {
+		return true
+	}
+	if ctx := cmd.Context(); ctx != nil {
+		if bc, ok := ctx.Value(browserCtxKey).(*browserCtx); ok {
+			return logic.IsReadOnly(bc.ctx)
+		}
+	}
+	return false
+}
+
+// checkReadOnly refuses cmd in read-only mode when it changes state.
+func checkReadOnly(cmd *cobra.Command) error {
+	if !readOnly(cmd) {
+		return nil
+	}
+	if change, ok := readOnlyRefused[commandName(cmd)]; ok {
+		return fmt.Errorf("%w: %s %s", logic.ErrReadOnly, commandName(cmd), change)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"browser-tools-go/internal/logic"
+)
+
+// TestCheckReadOnly は--read-onlyによる状態を変えるコマンドの拒否をテストします。
+func TestCheckReadOnly(t *testing.T) {
+	root := NewRootCmd()
+	typeCmd, _, _ := root.Find([]string{"type"})
+	getCmd, _, _ := root.Find([]string{"get"})
+
+	if err := checkReadOnly(typeCmd); err != nil {
+		t.Errorf("Expected type to run without --read-only, got %v", err)
+	}
+	_ = typeCmd.ParseFlags([]string{"--read-only"})
+	if err := checkReadOnly(typeCmd); !errors.Is(err, logic.ErrReadOnly) {
+		t.Errorf("Expected type to be refused, got %v", err)
+	}
+	_ = getCmd.ParseFlags([]string{"--read-only"})
+	if err := checkReadOnly(getCmd); err != nil {
+		t.Errorf("Expected get to run, got %v", err)
+	}
+
+	// batch と pipe-line のステップは共有された接続の読み取り専用モードを引き継ぐ
+	step := NewRootCmd()
+	cookies, _, _ := step.Find([]string{"cookies", "set"})
+	shared := &browserCtx{ctx: logic.WithReadOnly(context.Background()), cancel: func() {}}
+	cookies.SetContext(context.WithValue(context.Background(), browserCtxKey, shared))
+	if err := checkReadOnly(cookies); !errors.Is(err, logic.ErrReadOnly) {
+		t.Errorf("Expected cookies set to be refused on a read-only connection, got %v", err)
+	}
+}
+
+// TestReadOnlyRefused は拒否されるコマンドが存在することをテストします。
+func TestReadOnlyRefused(t *testing.T) {
+	root := NewRootCmd()
+	for name := range readOnlyRefused {
+		if cmd, _, err := root.Find(strings.Fields(name)); err != nil || commandName(cmd) != name {
+			t.Errorf("%q is not a command", name)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+// redactor masks the secrets of the log and of the network captures the
+// command prints; it is nil, masking nothing, with --no-redact.
+var redactor *logic.Redactor
+
+// setupRedaction sets up the redactor from the redaction of policy, unless
+// --no-redact is given.
+func setupRedaction(cmd *cobra.Command, policy *config.Policy) error {
+	if noRedact, _ := cmd.Flags().GetBool("no-redact"); noRedact {
+		useRedactor(nil)
+		return nil
+	}
+	r, err := logic.NewRedactor(policy.Redaction)
+	if err != nil {
+		return err
+	}
+	useRedactor(r)
+	return nil
+}
+
+// useRedactor makes r the redactor and routes the log through it.
+func useRedactor(r *logic.Redactor) {
+	redactor = r
+	log.SetOutput(r.Writer(os.Stderr))
+}
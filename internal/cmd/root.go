@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 
 	"browser-tools-go/internal/browser"
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
 	"github.com/spf13/cobra"
 )
 
@@ -15,30 +18,138 @@ import (
 const (
 	ExitSuccess = 0
 	ExitError   = 1
+	ExitFalse   = 2 // a query such as exists found nothing
+	ExitCrashed = 3 // the tab crashed or ran out of memory
+	ExitBudget  = 4 // a performance budget was exceeded
 )
 
-// NewRootCmd creates a new root command for the application.
+// NewRootCmd creates a new root command for the application with the
+// built-in commands only; Execute adds the installed plugins.
 func NewRootCmd() *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "browser-tools-go",
 		Short: "A Go implementation of browser-tools",
+		Args:  cobra.NoArgs,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			log.SetOutput(os.Stderr)
+			policy, err := config.LoadPolicy()
+			if err == nil {
+				err = setupRedaction(cmd, policy)
+			}
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			if _, err := applySiteArgs(cmd, args); err != nil {
+				fatalf("✗ %v", err)
+			}
+			dryRun(cmd, args, false)
 		},
 	}
 
-	rootCmd.AddCommand(newStartCmd(), newCloseCmd(), newRunCmd())
-	rootCmd.AddCommand(newNavigateCmd(), newScreenshotCmd(), newPickCmd(), newEvalCmd(), newCookiesCmd(), newSearchCmd(), newContentCmd(), newHnScraperCmd())
+	rootCmd.AddCommand(newStartCmd(), newCloseCmd(), newPingCmd(), newRunCmd(), newBatchCmd(), newPipeLineCmd(), newScriptCmd())
+	rootCmd.AddCommand(newNavigateCmd(), newTraceRedirectsCmd(), newBenchCmd(), newCompareCmd(), newWeighCmd(), newScreenshotCmd(), newPickCmd(), newExistsCmd(), newCountCmd(), newGetCmd(), newSetCmd(), newTypeCmd(), newClipboardCmd(), newWaitCmd(), newPauseCmd(), newEvalCmd(), newBindCmd(), newHighlightCmd(), newCookiesCmd(), newLoginCmd(), newSearchCmd(), newContentCmd(), newSourceCmd(), newHnScraperCmd(), newCrawlCmd())
+	rootCmd.AddCommand(newWatchCmd(), newDiffCmd())
+	rootCmd.AddCommand(newIdbCmd(), newClearDataCmd(), newStateCmd(), newSwCmd())
+	rootCmd.AddCommand(newCacheCmd(), newNetworkCmd(), newFetchCmd(), newDownloadCmd(), newHarCmd(), newGraphQLCmd(), newCaptureAPICmd())
+	rootCmd.AddCommand(newZoomCmd(), newQRCmd(), newPixelCmd())
+	rootCmd.AddCommand(newSaveMHTMLCmd(), newSavePageCmd(), newArchiveCmd())
+	rootCmd.AddCommand(newAuditCmd(), newCertCmd())
+	rootCmd.AddCommand(newHistoryCmd(), newPluginsCmd(), newSecretCmd(), newSitesCmd(), newMetricsCmd(), newVersionCmd())
+
+	rootCmd.PersistentFlags().Var(langFlag{}, "lang", "Language of messages: en or ja (default from LC_ALL, LC_MESSAGES or LANG)")
+	rootCmd.PersistentFlags().Bool("dry-run", false, "Validate the command and print its plan (URLs to visit, files to read and write) without running it")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Disable the browser cache for this command")
+	rootCmd.PersistentFlags().Bool("reuse-tab", false, "Drive the browser's first open tab instead of the dedicated automation tab")
+	rootCmd.PersistentFlags().String("target", "", `Drive the first open tab matching "url~=<regexp>", "title~=<regexp>", "url=", "title=" or "id="`)
+	rootCmd.PersistentFlags().String("on-failure-artifacts", "", "When the command fails, save a screenshot, the HTML, the URL and the latest console messages of the tab below this directory")
+	rootCmd.PersistentFlags().String("site", "", "Apply the profile of this site (see 'sites'): its base URL for relative URLs, its headers and credentials, and its \"@name\" selectors")
+	rootCmd.PersistentFlags().Bool("read-only", false, "Refuse the commands and steps that change the page or the site: clicking, typing, setting form controls, setting cookies, eval code that looks like it modifies the page, and requests other than GET")
+	rootCmd.PersistentFlags().Bool("no-redact", false, "Don't mask cookie values, credentials headers and the fields and patterns of the redaction policy in the log, network captures and curl commands")
+	rootCmd.PersistentFlags().Var(queryFlag{}, "query", `Filter the JSON output through this jq expression, e.g. '.[] | {title, link}'`)
+	rootCmd.PersistentFlags().Var(templateFlag{}, "template", `Render the JSON output with this Go template instead, e.g. '{{range .}}{{.Title}}\t{{.Link}}\n{{end}}'`)
+	rootCmd.PersistentFlags().Bool("auto-recover", false, "When the automation tab crashes, close it so that a new one is opened; batch goes on with the remaining commands")
+
+	registerCompletions(rootCmd)
 
 	return rootCmd
 }
 
 func Execute() {
-	if err := NewRootCmd().Execute(); err != nil {
+	rootCmd := NewRootCmd()
+	addPluginCommands(rootCmd)
+	ctx := beginCommand(rootCmd, os.Args[1:])
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		finishCommand(false, err.Error())
 		os.Exit(ExitError)
 	}
+	finishCommand(true, "")
+}
+
+// beginCommand starts the metrics and the trace of the command args run.
+func beginCommand(root *cobra.Command, args []string) context.Context {
+	ctx := context.Background()
+	found, _, err := root.Find(args)
+	if err != nil || found == root {
+		return ctx
+	}
+	name := commandName(found)
+	if name == "metrics serve" {
+		return ctx
+	}
+	beginCommandMetrics(name)
+	return beginCommandTrace(ctx, name)
+}
+
+// finishCommand reports how the command ended to the metrics server and the
+// trace, before the process exits. message describes a failure.
+func finishCommand(ok bool, message string) {
+	errorCode := ""
+	if !ok {
+		errorCode = logic.MetricErrorCode(message)
+	}
+	finishCommandMetrics(ok, errorCode)
+	finishCommandTrace(ok, message)
+}
+
+// fatalf reports a command failure and exits with ExitError. Commands run by
+// batch and pipe-line share one process; there it aborts only the current
+// command (see runCommandLine).
+var fatalf = failCommand
+
+// failCommand saves the failure artifacts and reports the failure to the
+// metrics server and the trace, when enabled, and exits.
+// A failure caused by a tab crash is reported as such and exits with
+// ExitCrashed.
+func failCommand(format string, v ...interface{}) {
+	message := fmt.Sprintf(format, v...)
+	if crash := tabCrash(); crash != nil {
+		message = crashMessage(message)
+		saveFailureArtifacts(failureArtifacts.command, message)
+		finishCommand(false, message)
+		log.Print(message)
+		discardCrashedTab(crash)
+		os.Exit(ExitCrashed)
+	}
+	saveFailureArtifacts(failureArtifacts.command, message)
+	finishCommand(false, message)
+	log.Fatal(i18n.Sprintf(format, v...))
 }
 
+// exitCommand ends a command that completed but whose outcome is negative,
+// such as exists finding no element, with code. Like fatalf, it only aborts the
+// current command under batch and pipe-line.
+var exitCommand = func(code int, message string) {
+	finishCommand(false, message)
+	os.Exit(code)
+}
+
+// langFlag is the --lang flag; setting it switches the message language
+// right away, before any message is printed.
+type langFlag struct{}
+
+func (langFlag) String() string     { return i18n.Lang() }
+func (langFlag) Type() string       { return "lang" }
+func (langFlag) Set(v string) error { return i18n.SetLang(v) }
+
 type browserCtx struct {
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -49,21 +160,127 @@ type browserCtxKeyType string
 const browserCtxKey browserCtxKeyType = "browserCtx"
 
 func persistentPreRunE(cmd *cobra.Command, args []string) error {
-	if cmd.Context().Value(browserCtxKey) != nil {
+	policy, err := config.LoadPolicy()
+	if err != nil {
+		return err
+	}
+	if err := setupRedaction(cmd, policy); err != nil {
+		return err
+	}
+	site, err := applySiteArgs(cmd, args)
+	if err != nil {
+		return err
+	}
+	if err := checkReadOnly(cmd); err != nil {
+		return err
+	}
+	if dryRun(cmd, args, true) {
+		return nil
+	}
+	if err := checkPolicyArgs(cmd, args, policy); err != nil {
+		return err
+	}
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	if shared, ok := parent.Value(browserCtxKey).(*browserCtx); ok {
+		// A step of batch or pipe-line may be given --read-only of its own.
+		if readOnly(cmd) && !logic.IsReadOnly(shared.ctx) {
+			readOnlyCtx := &browserCtx{ctx: logic.WithReadOnly(shared.ctx), cancel: shared.cancel}
+			cmd.SetContext(context.WithValue(parent, browserCtxKey, readOnlyCtx))
+		}
 		return nil
 	}
 
-	ctx, cancel, err := browser.NewPersistentContext()
+	opts, err := persistentOptions(cmd)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := browser.NewPersistentContext(opts)
+	if errors.Is(err, browser.ErrStaleSession) {
+		return err
+	}
 	if err != nil {
 		return fmt.Errorf("failed to connect to browser: %w. Is it running? (start with 'browser-tools-go start')", err)
 	}
+	ctx, stopWatch := watchCrash(cmd, ctx, opts)
+	if readOnly(cmd) {
+		ctx = logic.WithReadOnly(ctx)
+	}
+	ctx = logic.WithPolicy(ctx, policy)
+	release := cancel
+	cancel = func() {
+		stopWatch()
+		release()
+	}
 
-	browserCtxVal := &browserCtx{ctx: ctx, cancel: cancel}
-	ctxWithBrowser := context.WithValue(cmd.Context(), browserCtxKey, browserCtxVal)
+	if err := applySessionSettings(cmd, ctx); err != nil {
+		cancel()
+		return err
+	}
+	if err := logic.ApplyHostPolicy(ctx); err != nil {
+		cancel()
+		return err
+	}
+	if site != nil {
+		if err := applySiteAuth(ctx, site); err != nil {
+			cancel()
+			return err
+		}
+	}
+	if err := setupFailureArtifacts(cmd, ctx); err != nil {
+		cancel()
+		return err
+	}
+	recordHistory(ctx)
+
+	browserCtxVal := &browserCtx{ctx: withCommandSpan(ctx, parent), cancel: cancel}
+	ctxWithBrowser := context.WithValue(parent, browserCtxKey, browserCtxVal)
 	cmd.SetContext(ctxWithBrowser)
 	return nil
 }
 
+// persistentOptions reads the tab selection flags.
+func persistentOptions(cmd *cobra.Command) (browser.PersistentOptions, error) {
+	reuseTab, _ := cmd.Flags().GetBool("reuse-tab")
+	opts := browser.PersistentOptions{ReuseTab: reuseTab}
+	if expr, _ := cmd.Flags().GetString("target"); expr != "" {
+		if reuseTab {
+			return opts, fmt.Errorf("--target and --reuse-tab can't be combined")
+		}
+		matcher, err := browser.ParseTabMatcher(expr)
+		if err != nil {
+			return opts, err
+		}
+		opts.Target = matcher
+	}
+	return opts, nil
+}
+
+// applySessionSettings applies the settings that only last as long as a
+// connection: it disables the browser cache when --no-cache is given or the
+// session was switched to "cache disable", and restores the "zoom" factor.
+func applySessionSettings(cmd *cobra.Command, ctx context.Context) error {
+	info, err := config.LoadWsInfo()
+	if err != nil {
+		info = &config.WsInfo{}
+	}
+
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	if noCache || info.CacheDisabled {
+		if err := logic.SetCacheDisabled(ctx, true); err != nil {
+			return err
+		}
+	}
+	if info.Zoom != 0 && info.Zoom != 1 {
+		if err := logic.SetZoom(ctx, info.Zoom); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func getBrowserCtx(cmd *cobra.Command) (*browserCtx, error) {
 	val := cmd.Context().Value(browserCtxKey)
 	if val == nil {
@@ -76,10 +293,11 @@ func getBrowserCtx(cmd *cobra.Command) (*browserCtx, error) {
 	return bc, nil
 }
 
+// printJSONLine writes data as a single line of JSON, for streaming (NDJSON) output.
+func printJSONLine(data interface{}) {
+	printResults(data, false)
+}
+
 func prettyPrintResults(data interface{}) {
-	output, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		log.Fatalf("Failed to marshal result: %v", err)
-	}
-	fmt.Println(string(output))
+	printResults(data, true)
 }
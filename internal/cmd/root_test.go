@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -25,25 +26,62 @@ func TestNewRootCmd_CommandStructure(t *testing.T) {
 		t.Errorf("Expected Short description mismatch, got %s", rootCmd.Short)
 	}
 
-	// コマンド数チェック（root + 11サブコマンド）
-	expectedCommands := 11
-	if len(rootCmd.Commands()) != expectedCommands {
-		t.Errorf("Expected %d commands, got %d", expectedCommands, len(rootCmd.Commands()))
-	}
-
 	// 存在するべきコマンド
 	expectedCommandNames := []string{
 		"start",
 		"close",
+		"ping",
 		"run",
+		"batch",
+		"pipe-line",
+		"script",
 		"navigate",
+		"trace-redirects",
+		"bench",
+		"compare",
+		"weigh",
 		"screenshot",
 		"pick",
+		"exists",
+		"count",
+		"get",
+		"set",
+		"type",
+		"clipboard",
+		"wait",
+		"pause",
 		"eval",
 		"cookies",
+		"login",
 		"search",
 		"content",
+		"source",
 		"hn-scraper",
+		"crawl",
+		"watch",
+		"diff",
+		"idb",
+		"clear-data",
+		"state",
+		"sw",
+		"cache",
+		"network",
+		"fetch",
+		"download",
+		"har",
+		"graphql",
+		"capture-api",
+		"bind",
+		"zoom",
+		"highlight",
+		"qr",
+		"pixel",
+		"save-mhtml", "save-page", "archive", "audit", "cert", "history", "plugins", "secret", "sites", "metrics", "version",
+	}
+
+	// コマンド数チェック
+	if len(rootCmd.Commands()) != len(expectedCommandNames) {
+		t.Errorf("Expected %d commands, got %d", len(expectedCommandNames), len(rootCmd.Commands()))
 	}
 
 	for _, name := range expectedCommandNames {
@@ -100,6 +138,84 @@ func TestPersistentPreRunE_ContextAlreadySet(t *testing.T) {
 	}
 }
 
+// TestNewRootCmd_ReuseTabFlag はルートコマンドに--reuse-tabフラグがあることをテストします。
+func TestNewRootCmd_ReuseTabFlag(t *testing.T) {
+	rootCmd := NewRootCmd()
+
+	flag := rootCmd.PersistentFlags().Lookup("reuse-tab")
+	if flag == nil {
+		t.Fatal("Expected persistent reuse-tab flag on root command")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("Expected reuse-tab to default to false, got %s", flag.DefValue)
+	}
+}
+
+// TestNewRootCmd_LangFlag はルートコマンドの--langフラグをテストします。
+func TestNewRootCmd_LangFlag(t *testing.T) {
+	defer i18n.SetLang(i18n.Lang())
+
+	rootCmd := NewRootCmd()
+	flag := rootCmd.PersistentFlags().Lookup("lang")
+	if flag == nil {
+		t.Fatal("Expected persistent lang flag on root command")
+	}
+	if err := flag.Value.Set("ja"); err != nil {
+		t.Fatalf("Failed to set lang to ja: %v", err)
+	}
+	if i18n.Lang() != i18n.Japanese {
+		t.Errorf("Expected language %q, got %q", i18n.Japanese, i18n.Lang())
+	}
+	if err := flag.Value.Set("fr"); err == nil {
+		t.Error("Expected error for unsupported language")
+	}
+}
+
+// TestPersistentOptions はタブ選択フラグの解釈をテストします。
+func TestPersistentOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    bool
+		wantReuse  bool
+		wantTarget string
+	}{
+		{"default", nil, false, false, ""},
+		{"reuse tab", []string{"--reuse-tab"}, false, true, ""},
+		{"target", []string{"--target", "url~=dashboard"}, false, false, "url~=dashboard"},
+		{"invalid target", []string{"--target", "dashboard"}, true, false, ""},
+		{"target with reuse tab", []string{"--target", "title=App", "--reuse-tab"}, true, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, _, err := NewRootCmd().Find([]string{"content"})
+			if err != nil {
+				t.Fatalf("Failed to find content command: %v", err)
+			}
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			opts, err := persistentOptions(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("persistentOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if opts.ReuseTab != tt.wantReuse {
+				t.Errorf("ReuseTab = %v, want %v", opts.ReuseTab, tt.wantReuse)
+			}
+			target := ""
+			if opts.Target != nil {
+				target = opts.Target.String()
+			}
+			if target != tt.wantTarget {
+				t.Errorf("Target = %q, want %q", target, tt.wantTarget)
+			}
+		})
+	}
+}
+
 // TestGetBrowserCtx_ValidContext は有効なブラウザコンテキストの取得をテストします。
 func TestGetBrowserCtx_ValidContext(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
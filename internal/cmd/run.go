@@ -2,42 +2,72 @@ package cmd
 
 import (
 	"context"
-	"log"
+	"os"
 
 	"browser-tools-go/internal/browser"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
 	"github.com/spf13/cobra"
 )
 
 func newRunCmd() *cobra.Command {
 	var headless bool
+	var statePath string
 
 	cmd := &cobra.Command{
 		Use:   "run <subcommand> [args...]",
 		Short: "Run a single command in a temporary browser instance",
 		Long: `Run a subcommand with its own temporary browser that starts and stops automatically.
-Example: browser-tools-go run screenshot --url https://example.com my.png`,
+The flags of run come before the subcommand; --state preloads a state file into the browser.
+Example: browser-tools-go run --state state.json screenshot --url https://example.com my.png`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
 				return cmd.Help()
 			}
+			if dryRun(cmd, args, false) {
+				return nil
+			}
 
-			log.Println("🚀 Starting temporary browser...")
+			i18n.Println("🚀 Starting temporary browser...")
 			ctx, cancel, err := browser.NewTemporaryContext(headless)
 			if err != nil {
-				log.Printf("✗ Failed to create temporary browser: %v", err)
+				i18n.Printf("✗ Failed to create temporary browser: %v", err)
 				return err
 			}
 
-			browserCtxVal := &browserCtx{ctx: ctx, cancel: cancel}
+			if noCache, _ := cmd.Flags().GetBool("no-cache"); noCache {
+				if err := logic.SetCacheDisabled(ctx, true); err != nil {
+					cancel()
+					return err
+				}
+			}
+
+			if statePath != "" {
+				data, err := os.ReadFile(statePath)
+				if err == nil {
+					err = logic.LoadStorageState(ctx, data)
+				}
+				if err != nil {
+					cancel()
+					i18n.Printf("✗ Failed to load storage state: %v", err)
+					return err
+				}
+				i18n.Printf("📂 Loaded storage state from %s", statePath)
+			}
 
 			rootCmd := cmd.Root()
+			browserCtxVal := &browserCtx{ctx: withCommandSpan(ctx, rootCmd.Context()), cancel: cancel}
+
 			ctxWithBrowser := context.WithValue(rootCmd.Context(), browserCtxKey, browserCtxVal)
 			rootCmd.SetContext(ctxWithBrowser)
 
 			return nil
 		},
 		PersistentPostRun: func(cmd *cobra.Command, args []string) {
-			log.Println("✅ Temporary browser closed.")
+			if dryRunEnabled, _ := cmd.Flags().GetBool("dry-run"); dryRunEnabled {
+				return
+			}
+			i18n.Println("✅ Temporary browser closed.")
 			rootCmd := cmd.Root()
 			if browserCtxVal := rootCmd.Context().Value(browserCtxKey); browserCtxVal != nil {
 				if bc, ok := browserCtxVal.(*browserCtx); ok && bc.cancel != nil {
@@ -49,12 +79,25 @@ Example: browser-tools-go run screenshot --url https://example.com my.png`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 {
 				cmd.Help()
+				return
+			}
+			// PersistentPreRunE keeps the temporary browser on the root command.
+			bc, err := getBrowserCtx(cmd.Root())
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			if err := executeCommandLine(bc, args); err != nil {
+				bc.cancel()
+				fatalf("✗ %s failed: %v", args[0], err)
 			}
 		},
 	}
 
 	cmd.Flags().BoolVar(&headless, "headless", true, "Run the temporary browser in headless mode")
+	cmd.Flags().StringVar(&statePath, "state", "", "Preload cookies and storage from a state file written by 'state save'")
 	cmd.FParseErrWhitelist.UnknownFlags = true
+	// The flags after the subcommand are its own.
+	cmd.Flags().SetInterspersed(false)
 
 	return cmd
 }
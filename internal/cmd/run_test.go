@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// TestNewRunCmd_Dispatch はrunコマンドがサブコマンドを一時ブラウザのコンテキストで実行することをテストします。
+func TestNewRunCmd_Dispatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	root := NewRootCmd()
+	run, _, err := root.Find([]string{"run"})
+	if err != nil || run.Name() != "run" {
+		t.Fatalf("Expected 'run' command, got %v (err: %v)", run, err)
+	}
+
+	// サブコマンド以降のフラグはrunではなくサブコマンドに渡される
+	if err := run.ParseFlags([]string{"--state", "state.json", "history", "--all"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	args := run.Flags().Args()
+	if !reflect.DeepEqual(args, []string{"history", "--all"}) {
+		t.Fatalf("Expected the subcommand and its flags as arguments, got %v", args)
+	}
+	if state, _ := run.Flags().GetString("state"); state != "state.json" {
+		t.Errorf("Expected --state to be 'state.json', got %q", state)
+	}
+
+	// PersistentPreRunEはルートコマンドに一時ブラウザのコンテキストを設定する
+	bc := &browserCtx{ctx: context.Background(), cancel: func() {}}
+	root.SetContext(context.WithValue(context.Background(), browserCtxKey, bc))
+	output, err := captureStdout(func() error {
+		run.Run(run, args)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to capture output: %v", err)
+	}
+	if got := parseCommandOutput(output); got == nil {
+		t.Errorf("Expected the JSON output of history, got %q", output)
+	}
+}
@@ -1,10 +1,18 @@
 package cmd
 
 import (
-	"log"
+	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
 	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/utils"
 
 	"github.com/spf13/cobra"
 )
@@ -12,47 +20,79 @@ import (
 func newSearchCmd() *cobra.Command {
 	var n int
 	var content bool
+	var include []string
+	var webhook webhookFlags
+	var dedupe dedupeFlags
 
 	cmd := &cobra.Command{
-		Use:               "search <query>",
-		Short:             "Search Google and return results",
-		Args:              cobra.MinimumNArgs(1),
+		Use:   "search <query>",
+		Short: "Search Google and return results",
+		Long: `Searches Google and prints the results with their title, link, snippet, the
+date Google displays, the favicon of the site and their type: organic or video.
+With --include, the results of the news and images verticals follow, of type
+news and image.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := logic.CheckSearchVerticals(include); err != nil {
+				return err
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
 		PersistentPreRunE: persistentPreRunE,
 		Run: func(cmd *cobra.Command, args []string) {
 			bc, err := getBrowserCtx(cmd)
 			if err != nil {
-				log.Fatalf("✗ %v", err)
+				fatalf("✗ %v", err)
 			}
 			defer bc.cancel()
 
 			query := strings.Join(args, " ")
-			log.Printf("🔍 Searching Google for: %s (results: %d, content: %t)", query, n, content)
+			i18n.Printf("🔍 Searching Google for: %s (results: %d, content: %t)", query, n, content)
 
-			results, err := logic.Search(bc.ctx, query, n, content)
+			results, err := logic.Search(bc.ctx, query, n, content, include)
 			if err != nil {
-				log.Fatalf("✗ Failed to perform search: %v", err)
+				fatalf("✗ Failed to perform search: %v", err)
+			}
+			if seen := dedupe.open(); seen != nil {
+				defer seen.Close()
+				results = filterSeen(seen, "search", results, func(r models.SearchResult) string { return r.Link })
 			}
 			prettyPrintResults(results)
+			webhook.send(bc.ctx, "search", results)
 		},
 	}
 
 	cmd.Flags().IntVar(&n, "n", 5, "Number of results to return")
 	cmd.Flags().BoolVar(&content, "content", false, "Fetch and extract readable content from each result. This may significantly increase execution time.")
+	cmd.Flags().StringSliceVar(&include, "include", nil, "Also return up to --n results of these verticals: "+strings.Join(logic.SearchVerticals(), ", "))
+	webhook.register(cmd)
+	dedupe.register(cmd)
 	return cmd
 }
 
 func newContentCmd() *cobra.Command {
 	var format string
+	var selector string
+	var strip []string
+	var maxChars, maxTokens int
+	var followNext bool
+	var maxPages int
+	var outDir, nameTemplate string
 
 	cmd := &cobra.Command{
-		Use:               "content [url]",
-		Short:             "Extracts readable content from a URL or the current page",
+		Use:   "content [url]",
+		Short: "Extracts readable content from a URL or the current page",
+		Long: `Extracts the readable content of [url], or of the current page, and prints it
+with its title and URL as JSON.
+
+With --out-dir, the content is written instead as a file with a YAML front
+matter (title, url, language, format, fetched_at) below that directory, named by
+--name-template, and the file written is printed.`,
 		Args:              cobra.MaximumNArgs(1),
 		PersistentPreRunE: persistentPreRunE,
 		Run: func(cmd *cobra.Command, args []string) {
 			bc, err := getBrowserCtx(cmd)
 			if err != nil {
-				log.Fatalf("✗ %v", err)
+				fatalf("✗ %v", err)
 			}
 			defer bc.cancel()
 
@@ -60,22 +100,111 @@ func newContentCmd() *cobra.Command {
 			if len(args) > 0 {
 				url = args[0]
 			}
-			log.Printf("📄 Extracting content (format: %s)", format)
+			i18n.Printf("📄 Extracting content (format: %s)", format)
+
+			opts := logic.ContentOptions{
+				Format:     format,
+				Selector:   selector,
+				Strip:      strip,
+				MaxChars:   maxChars,
+				MaxTokens:  maxTokens,
+				FollowNext: followNext,
+				MaxPages:   maxPages,
+			}
+			if selector != "" {
+				selectors, err := utils.LoadSelectorConfig("")
+				if err != nil {
+					fatalf("✗ Failed to load selector config: %v", err)
+				}
+				opts.Selectors = selectors
+			}
+			var writer *logic.DocWriter
+			if outDir != "" {
+				if writer, err = logic.NewDocWriter(outDir, nameTemplate); err != nil {
+					fatalf("✗ %v", err)
+				}
+			}
 
-			result, err := logic.GetContent(bc.ctx, url, format)
+			result, err := logic.GetContent(bc.ctx, url, opts)
 			if err != nil {
-				log.Fatalf("✗ Failed to extract content: %v", err)
+				fatalf("✗ Failed to extract content: %v", err)
+			}
+			if writer != nil {
+				page := logic.ContentDocPage(result)
+				doc, err := writer.Write(page, time.Now())
+				if err != nil {
+					fatalf("✗ Failed to save %s: %v", page.URL, err)
+				}
+				i18n.Printf("💾 Content saved to %s", doc.Path)
+				prettyPrintResults(doc)
+				return
 			}
 			prettyPrintResults(result)
 		},
 	}
 
 	cmd.Flags().StringVar(&format, "format", "markdown", "Output format (markdown, text, or html)")
+	cmd.Flags().StringVar(&selector, "selector", "", "CSS selector of the region to extract (falls back to the content selectors in selectors.json)")
+	cmd.Flags().StringSliceVar(&strip, "strip", logic.DefaultStripSelectors, "Comma-separated CSS selectors removed before conversion (pass \"\" to keep everything)")
+	cmd.Flags().IntVar(&maxChars, "max-chars", 0, "Truncate the content to this many characters (0 = unlimited)")
+	cmd.Flags().IntVar(&maxTokens, "max-tokens", 0, "Truncate the content to roughly this many tokens (0 = unlimited)")
+	cmd.Flags().BoolVar(&followNext, "follow-next", false, "Follow rel=next / \"next page\" links and merge paginated articles into one document")
+	cmd.Flags().IntVar(&maxPages, "max-pages", logic.DefaultMaxPages, "Maximum number of pages to merge with --follow-next")
+	addOutDirFlags(cmd, &outDir, &nameTemplate)
+	return cmd
+}
+
+func newSourceCmd() *cobra.Command {
+	var raw, rendered bool
+
+	cmd := &cobra.Command{
+		Use:   "source [url]",
+		Short: "Prints the raw or rendered HTML source of a URL or the current page",
+		Long: `Prints the HTML of a page to stdout. --raw prints the document exactly as the
+server sent it, before any script ran; --rendered (the default) prints the DOM as
+it is after scripts ran. Diffing the two helps debug hydration and cloaking issues.`,
+		Args:              cobra.MaximumNArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			var url string
+			if len(args) > 0 {
+				url = args[0]
+			}
+
+			var html string
+			if raw {
+				i18n.Println("📄 Reading raw source...")
+				html, err = logic.RawSource(bc.ctx, url)
+			} else {
+				i18n.Println("📄 Serializing rendered source...")
+				html, err = logic.RenderedSource(bc.ctx, url)
+			}
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			os.Stdout.WriteString(html)
+			if !strings.HasSuffix(html, "\n") {
+				os.Stdout.WriteString("\n")
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the original document response body")
+	cmd.Flags().BoolVar(&rendered, "rendered", false, "Print the DOM serialized after scripts ran (default)")
+	cmd.MarkFlagsMutuallyExclusive("raw", "rendered")
 	return cmd
 }
 
 func newHnScraperCmd() *cobra.Command {
 	var limit int
+	var webhook webhookFlags
+	var dedupe dedupeFlags
 
 	cmd := &cobra.Command{
 		Use:               "hn-scraper",
@@ -85,20 +214,185 @@ func newHnScraperCmd() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			bc, err := getBrowserCtx(cmd)
 			if err != nil {
-				log.Fatalf("✗ %v", err)
+				fatalf("✗ %v", err)
 			}
 			defer bc.cancel()
 
-			log.Printf("📰 Scraping Hacker News (limit: %d)...", limit)
+			i18n.Printf("📰 Scraping Hacker News (limit: %d)...", limit)
 
 			submissions, err := logic.HnScraper(bc.ctx, limit)
 			if err != nil {
-				log.Fatalf("✗ Failed to scrape Hacker News: %v", err)
+				fatalf("✗ Failed to scrape Hacker News: %v", err)
+			}
+			if seen := dedupe.open(); seen != nil {
+				defer seen.Close()
+				submissions = filterSeen(seen, "hn-scraper", submissions, func(s models.HnSubmission) string { return s.URL })
 			}
 			prettyPrintResults(submissions)
+			webhook.send(bc.ctx, "hn-scraper", submissions)
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 10, "Number of stories to fetch")
+	webhook.register(cmd)
+	dedupe.register(cmd)
 	return cmd
 }
+
+func newCrawlCmd() *cobra.Command {
+	var depth, maxPages int
+	var format string
+	var sameHost bool
+	var resume string
+	var warcPath string
+	var concurrency int
+	var dedupe dedupeFlags
+	var outDir, nameTemplate string
+	var webhook webhookFlags
+	var upload uploadFlags
+
+	cmd := &cobra.Command{
+		Use:   "crawl <url>",
+		Short: "Crawls a site breadth-first and emits one JSON line per page",
+		Long: `Visits pages breadth-first starting at <url>, following links up to --depth hops,
+and prints one JSON line per page with its extracted content.
+
+Progress is checkpointed after every page under ~/.browser-tools-go/crawl/. When a
+run is interrupted, continue it with --resume <run-id>; the run id is logged when
+the crawl starts.
+
+With --concurrency N, up to N pages are visited at once, each on a tab of its own
+opened for the crawl and closed when it ends.
+
+With --warc, every request and response made while crawling is also recorded into
+a WARC file; resumed runs append to it. With --upload, the WARC file is also
+uploaded once the crawl stops.
+
+With --out-dir, each page is written as a file with a YAML front matter below
+that directory, named by --name-template, and the file written is printed
+instead of the page. Give --out-dir again when resuming.
+
+With --webhook, each page, or the file written for it, is also POSTed as a
+batch of one result as soon as it is crawled.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if concurrency < 1 {
+				return fmt.Errorf("--concurrency must be at least 1")
+			}
+			if warcPath != "" && concurrency > 1 {
+				return fmt.Errorf("--warc records the current tab only and can't be combined with --concurrency")
+			}
+			if upload.destination != "" && warcPath == "" {
+				return fmt.Errorf("--upload uploads the --warc file and requires --warc")
+			}
+			if resume != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			ctx, stop := signal.NotifyContext(bc.ctx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			var checkpoint *config.CrawlCheckpoint
+			if resume != "" {
+				checkpoint, err = config.LoadCrawlCheckpoint(resume)
+				if err != nil {
+					fatalf("✗ %v", err)
+				}
+				if checkpoint.Completed {
+					i18n.Printf("✅ Crawl %s already completed (%d pages).", checkpoint.RunID, len(checkpoint.Visited))
+					return
+				}
+				i18n.Printf("🔁 Resuming crawl %s (%d pages done, %d queued)...", checkpoint.RunID, len(checkpoint.Visited), len(checkpoint.Frontier))
+			} else {
+				checkpoint = logic.NewCrawlCheckpoint(logic.NewCrawlRunID(time.Now()), args[0], logic.CrawlOptions{
+					MaxDepth: depth,
+					MaxPages: maxPages,
+					Format:   format,
+					SameHost: sameHost,
+				})
+				i18n.Printf("🕷️ Crawling %s (run id: %s)...", checkpoint.StartURL, checkpoint.RunID)
+			}
+
+			seen := dedupe.open()
+			if seen != nil {
+				defer seen.Close()
+			}
+
+			var recorder *logic.WARCRecorder
+			var closeWARC func()
+			if warcPath != "" {
+				recorder, closeWARC, err = openWARCRecorder(bc.ctx, warcPath, resume != "")
+				if err != nil {
+					fatalf("✗ %v", err)
+				}
+			}
+
+			var writer *logic.DocWriter
+			if outDir != "" {
+				if writer, err = logic.NewDocWriter(outDir, nameTemplate); err != nil {
+					fatalf("✗ %v", err)
+				}
+			}
+
+			hook := webhook.webhook()
+			err = logic.Crawl(ctx, checkpoint, seen, concurrency, func(page *models.CrawlPage) {
+				var result interface{} = page
+				if writer != nil && page.Error == "" {
+					doc, err := writer.Write(logic.CrawlDocPage(page, checkpoint.Format), page.CrawledAt)
+					if err != nil {
+						i18n.Printf("⚠️ Failed to save %s: %v", page.URL, err)
+						return
+					}
+					result = doc
+				}
+				printJSONLine(result)
+				if hook.URL != "" {
+					if err := logic.SendWebhookBatch(ctx, hook, "crawl", []interface{}{result}); err != nil {
+						i18n.Printf("⚠️ Failed to deliver %s to the webhook: %v", page.URL, err)
+					}
+				}
+			})
+			if recorder != nil {
+				records := recorder.Stop()
+				closeWARC()
+				i18n.Printf("🗄️ Archived %d requests to: %s", records, warcPath)
+				upload.upload(bc.ctx, warcPath)
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					i18n.Printf("⏸️ Crawl interrupted. Resume with: browser-tools-go crawl --resume %s", checkpoint.RunID)
+					exitCommand(ExitError, "crawl interrupted")
+				}
+				fatalf("✗ Crawl failed: %v", err)
+			}
+			i18n.Printf("✅ Crawl %s completed (%d pages).", checkpoint.RunID, len(checkpoint.Visited))
+		},
+	}
+
+	cmd.Flags().IntVar(&depth, "depth", 2, "Maximum number of link hops from the start URL")
+	cmd.Flags().IntVar(&maxPages, "max-pages", 100, "Maximum number of pages to visit (0 = unlimited)")
+	cmd.Flags().StringVar(&format, "format", "markdown", "Content format (markdown, text, or html)")
+	cmd.Flags().BoolVar(&sameHost, "same-host", true, "Only follow links to the host of the start URL")
+	cmd.Flags().StringVar(&resume, "resume", "", "Resume an interrupted crawl by its run id (options are taken from the original run)")
+	cmd.Flags().StringVar(&warcPath, "warc", "", "Also record all requests and responses into this WARC file (.warc or .warc.gz)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 1, "Number of pages visited at once, each on a tab of its own")
+	addOutDirFlags(cmd, &outDir, &nameTemplate)
+	dedupe.register(cmd)
+	webhook.register(cmd)
+	upload.register(cmd)
+	return cmd
+}
+
+// addOutDirFlags adds the flags writing each page as a file of a directory.
+func addOutDirFlags(cmd *cobra.Command, outDir, nameTemplate *string) {
+	cmd.Flags().StringVar(outDir, "out-dir", "", "Write each page as a file with a YAML front matter below this directory, instead of printing its content")
+	cmd.Flags().StringVar(nameTemplate, "name-template", logic.DefaultDocNameTemplate, "Go template of the names of the files below --out-dir, from the page's slug, path, title, url and host")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// TestNewCrawlCmd_ArgumentValidation はcrawlコマンドの引数検証をテストします。
+func TestNewCrawlCmd_ArgumentValidation(t *testing.T) {
+	cmd := newCrawlCmd()
+
+	if err := cmd.Args(cmd, []string{"https://example.com"}); err != nil {
+		t.Errorf("Expected a start URL to be accepted, got %v", err)
+	}
+	if err := cmd.Args(cmd, []string{}); err == nil {
+		t.Error("Expected error without a start URL")
+	}
+
+	if err := cmd.Flags().Set("resume", "20260101-000000-abcdef"); err != nil {
+		t.Fatalf("Failed to set resume flag: %v", err)
+	}
+	if err := cmd.Args(cmd, []string{}); err != nil {
+		t.Errorf("Expected no arguments to be accepted with --resume, got %v", err)
+	}
+	if err := cmd.Args(cmd, []string{"https://example.com"}); err == nil {
+		t.Error("Expected error for a start URL combined with --resume")
+	}
+}
+
+// TestNewCrawlCmd_Concurrency はcrawlコマンドの--concurrency検証をテストします。
+func TestNewCrawlCmd_Concurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{"default", nil, false},
+		{"parallel", map[string]string{"concurrency": "4"}, false},
+		{"zero", map[string]string{"concurrency": "0"}, true},
+		{"warc with one tab", map[string]string{"warc": "out.warc"}, false},
+		{"warc with several tabs", map[string]string{"warc": "out.warc", "concurrency": "2"}, true},
+		{"upload with warc", map[string]string{"warc": "out.warc", "upload": "s3://bucket/crawls"}, false},
+		{"upload without warc", map[string]string{"upload": "s3://bucket/crawls"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newCrawlCmd()
+			for name, value := range tt.flags {
+				if err := cmd.Flags().Set(name, value); err != nil {
+					t.Fatalf("Failed to set %s flag: %v", name, err)
+				}
+			}
+			err := cmd.Args(cmd, []string{"https://example.com"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNewSourceCmd はsourceコマンドのフラグと排他指定をテストします。
+func TestNewSourceCmd(t *testing.T) {
+	cmd := newSourceCmd()
+
+	if cmd.Use != "source [url]" {
+		t.Errorf("Expected Use to be 'source [url]', got %s", cmd.Use)
+	}
+	for _, name := range []string{"raw", "rendered"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected '%s' flag to exist", name)
+		}
+	}
+	if err := cmd.Args(cmd, []string{"a", "b"}); err == nil {
+		t.Error("Expected error for more than one URL")
+	}
+
+	cmd.SetArgs([]string{"--raw", "--rendered"})
+	cmd.PersistentPreRunE = nil
+	cmd.Run = func(*cobra.Command, []string) {}
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error when both --raw and --rendered are set")
+	}
+}
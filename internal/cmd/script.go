@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+func newScriptCmd() *cobra.Command {
+	var noAutoScroll bool
+
+	cmd := &cobra.Command{
+		Use:   "script <file | -> [args...]",
+		Short: "Run a JavaScript automation script",
+		Long: `Runs an automation script written in JavaScript, read from <file> or from stdin
+with "-". Unlike eval, which runs code inside the page, the script runs in an
+interpreter embedded in browser-tools-go and drives the current tab, so it can
+use loops and conditionals across page loads:
+
+  for (const q of args) {
+    browser.navigate('https://example.com/search?q=' + encodeURIComponent(q));
+    if (browser.pick('.no-results')) continue;
+    browser.click('.result a');
+    browser.waitFor('article');
+    console.log(browser.extract({format: 'text'}).title);
+  }
+
+The script sees the remaining arguments as the "args" array, and a "browser"
+object with navigate, pick, pickAll, click, type, waitFor, extract, screenshot,
+eval and sleep. Failed browser calls throw and can be caught. console.log
+writes to stderr; the value of the last statement is printed as JSON.
+
+pick, pickAll and click scroll their elements into view and wait for them to
+stop moving first; --no-auto-scroll turns that off.`,
+		Args:              cobra.MinimumNArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			source, err := readInputFile(args[0])
+			if err != nil {
+				fatalf("✗ Failed to read script: %v", err)
+			}
+			sourceName := filepath.Base(args[0])
+			if args[0] == "-" {
+				sourceName = "stdin"
+			}
+
+			i18n.Printf("📜 Running %s", sourceName)
+			result, err := logic.RunScript(bc.ctx, string(source), sourceName, args[1:], !noAutoScroll)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			if result != nil {
+				prettyPrintResults(result)
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&noAutoScroll, "no-auto-scroll", false, "Don't scroll elements into view before pick, pickAll and click")
+	return cmd
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import "testing"
+
+// TestNewScriptCmd はscriptコマンドの引数検証をテストします。
+func TestNewScriptCmd(t *testing.T) {
+	cmd := newScriptCmd()
+
+	if err := cmd.Args(cmd, []string{}); err == nil {
+		t.Error("Expected error without a script file")
+	}
+	if err := cmd.Args(cmd, []string{"flow.js", "a", "b"}); err != nil {
+		t.Errorf("Expected script arguments to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+func newSecretCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secret",
+		Short: "Manage the secrets stored in the OS keyring",
+		Long: `Stores secrets, such as passwords, TOTP secrets and API keys, in the OS keyring
+(macOS Keychain, Windows Credential Manager or the Secret Service on Linux),
+under the service "browser-tools-go". Login recipes and flags taking a secret
+then refer to them as "keyring:ENTRY", so that they never land in shell
+history or configuration files.`,
+	}
+	cmd.AddCommand(newSecretSetCmd(), newSecretDeleteCmd())
+	return cmd
+}
+
+func newSecretSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <entry>",
+		Short: "Store a secret in the OS keyring",
+		Long: `Stores the secret read from stdin as <entry> in the OS keyring, replacing the
+previous one; "keyring:<entry>" then refers to it. On a terminal, the secret is
+asked for; otherwise all of stdin is read, without its trailing newline:
+
+  printf %s "$PASSWORD" | browser-tools-go secret set example-password`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			secret, err := readSecret(os.Stdin, args[0])
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			if err := config.SetKeyringSecret(args[0], secret); err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("✅ Stored keyring:%s.", args[0])
+		},
+	}
+}
+
+func newSecretDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <entry>",
+		Short: "Delete a secret from the OS keyring",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := config.DeleteKeyringSecret(args[0]); err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("🗑️ Deleted keyring:%s.", args[0])
+		},
+	}
+}
+
+// readSecret reads the secret of entry from in: a line asked for when in is
+// a terminal, or else all of it without the trailing newline.
+func readSecret(in *os.File, entry string) (string, error) {
+	if info, err := in.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		return promptLine(i18n.Sprintf("🔑 Secret for keyring:%s: ", entry))
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the secret: %w", err)
+	}
+	secret := strings.TrimRight(string(data), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("no secret on stdin")
+	}
+	return secret, nil
+}
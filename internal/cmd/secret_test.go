@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+// TestReadSecret は標準入力からのシークレットの読み込みをテストします。
+func TestReadSecret(t *testing.T) {
+	read := func(input string) (string, error) {
+		t.Helper()
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Close()
+		w.WriteString(input)
+		w.Close()
+		return readSecret(r, "example")
+	}
+
+	secret, err := read("s3cret\n")
+	if err != nil || secret != "s3cret" {
+		t.Errorf("Expected the trailing newline to be trimmed, got %q, %v", secret, err)
+	}
+	secret, err = read("multi\nline")
+	if err != nil || secret != "multi\nline" {
+		t.Errorf("Expected all of stdin, got %q, %v", secret, err)
+	}
+	if _, err := read(""); err == nil {
+		t.Error("Expected an error for an empty stdin")
+	}
+}
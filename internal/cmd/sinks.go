@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+)
+
+// webhookFlags holds the --webhook options shared by result-producing commands.
+type webhookFlags struct {
+	url    string
+	secret string
+}
+
+func (w *webhookFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&w.url, "webhook", "", "URL that receives each result batch as a JSON POST")
+	cmd.Flags().StringVar(&w.secret, "webhook-secret", "", `Secret for HMAC-SHA256 signing of webhook bodies, or a reference to it such as "keyring:ENTRY" (default: $BT_WEBHOOK_SECRET)`)
+}
+
+// webhook returns the webhook of the flags, resolving a secret reference.
+func (w *webhookFlags) webhook() logic.Webhook {
+	if w.url == "" {
+		return logic.Webhook{}
+	}
+	secret := w.secret
+	if secret == "" {
+		secret = os.Getenv("BT_WEBHOOK_SECRET")
+	}
+	secret, err := config.ResolveSecret(secret)
+	if err != nil {
+		fatalf("✗ --webhook-secret: %v", err)
+	}
+	return logic.Webhook{URL: w.url, Secret: secret}
+}
+
+// send POSTs results to the webhook when --webhook is set.
+func (w *webhookFlags) send(ctx context.Context, source string, results interface{}) {
+	if w.url == "" {
+		return
+	}
+	i18n.Printf("📤 Sending results to %s...", w.url)
+	if err := logic.SendWebhookBatch(ctx, w.webhook(), source, results); err != nil {
+		fatalf("✗ Failed to deliver webhook: %v", err)
+	}
+}
+
+// uploadFlags holds the --upload option shared by artifact-producing commands.
+type uploadFlags struct {
+	destination string
+}
+
+func (u *uploadFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&u.destination, "upload", "", "Also upload the artifact to s3://bucket/prefix (uses the standard AWS_* environment variables)")
+}
+
+// upload copies the artifact at filePath to the --upload destination, if set.
+func (u *uploadFlags) upload(ctx context.Context, filePath string) {
+	if u.destination == "" {
+		return
+	}
+	i18n.Printf("📤 Uploading %s to %s...", filePath, u.destination)
+	location, err := logic.UploadFile(ctx, u.destination, filePath)
+	if err != nil {
+		fatalf("✗ Failed to upload artifact: %v", err)
+	}
+	i18n.Printf("✅ Uploaded to %s", location)
+}
+
+// uploadDir copies the files below dir to the --upload destination, if set.
+func (u *uploadFlags) uploadDir(ctx context.Context, dir string) {
+	if u.destination == "" {
+		return
+	}
+	i18n.Printf("📤 Uploading %s to %s...", dir, u.destination)
+	location, err := logic.UploadDir(ctx, u.destination, dir)
+	if err != nil {
+		fatalf("✗ Failed to upload artifact: %v", err)
+	}
+	i18n.Printf("✅ Uploaded to %s", location)
+}
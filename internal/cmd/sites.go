@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func newSitesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sites",
+		Short: "Manage the site profiles applied with --site",
+		Long: `Manages site profiles, ~/.browser-tools-go/sites/<name>.json. A command given
+--site <name> applies the profile of the site: relative URLs are resolved
+against its base URL, its headers are sent with every request to its origin,
+its credentials answer HTTP authentication challenges and "@name" stands for
+one of its named selectors. "login --site" also takes the URL and credentials
+its recipe leaves out from the profile.
+
+  browser-tools-go sites add shop --url https://shop.example.com/ \
+    --username alice --credential keyring:shop-password \
+    --header "X-Api-Key: env:SHOP_API_KEY" --selector-config shop-selectors.json
+  browser-tools-go navigate /orders --site shop
+  browser-tools-go get @orderTotal --site shop`,
+	}
+	cmd.AddCommand(newSitesAddCmd(), newSitesListCmd(), newSitesShowCmd(), newSitesRemoveCmd())
+	return cmd
+}
+
+func newSitesAddCmd() *cobra.Command {
+	var site config.Site
+	var headers []string
+	var selectorConfig string
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add or replace a site profile",
+		Long: `Adds the profile of a site, replacing the previous one. The credential and the
+header values are secret references, "keyring:ENTRY" or "env:NAME", resolved
+only when a command runs, or plain values for headers that aren't secret.
+--selector-config reads a JSON object of named selectors:
+
+  {"orderTotal": "#summary .total", "nextPage": "a[rel=next]"}`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			site.Name = args[0]
+			var err error
+			if site.Headers, err = logic.ParseHeaders(headers); err != nil {
+				fatalf("✗ %v", err)
+			}
+			if len(site.Headers) == 0 {
+				site.Headers = nil
+			}
+			if selectorConfig != "" {
+				if site.Selectors, err = readSiteSelectors(selectorConfig); err != nil {
+					fatalf("✗ %v", err)
+				}
+			}
+			if err := config.SaveSite(&site); err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("✅ Saved site %s.", site.Name)
+		},
+	}
+
+	cmd.Flags().StringVar(&site.URL, "url", "", "Base URL of the site, which relative URLs are resolved against")
+	cmd.Flags().StringVar(&site.Username, "username", "", "Username for HTTP authentication and login recipes")
+	cmd.Flags().StringVar(&site.Credential, "credential", "", `Secret reference to the password, "keyring:ENTRY" or "env:NAME"`)
+	cmd.Flags().StringArrayVarP(&headers, "header", "H", nil, "Header sent with every request to the site, as 'Name: Value' (repeatable)")
+	cmd.Flags().StringVar(&selectorConfig, "selector-config", "", `JSON file of named selectors, used as "@name" ("-" for stdin)`)
+	_ = cmd.MarkFlagRequired("url")
+	return cmd
+}
+
+// readSiteSelectors reads a JSON object mapping names to CSS selectors.
+func readSiteSelectors(path string) (map[string]string, error) {
+	data, err := readInputFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var selectors map[string]string
+	if err := json.Unmarshal(data, &selectors); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for name, selector := range selectors {
+		if err := logic.ValidateSelector(selector); err != nil {
+			return nil, fmt.Errorf("selector %q: %w", name, err)
+		}
+	}
+	return selectors, nil
+}
+
+func newSitesListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the site profiles",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			sites, err := config.ListSites()
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			if sites == nil {
+				sites = []*config.Site{}
+			}
+			prettyPrintResults(sites)
+		},
+	}
+}
+
+func newSitesShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <name>",
+		Short: "Print a site profile",
+		Long:  `Prints the profile of a site. Secret references are printed as they are, never resolved.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			site, err := config.LoadSite(args[0])
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			prettyPrintResults(site)
+		},
+	}
+}
+
+func newSitesRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a site profile",
+		Long:  `Removes the profile of a site. The secrets it refers to stay in the OS keyring.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := config.RemoveSite(args[0]); err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("🗑️ Removed site %s.", args[0])
+		},
+	}
+}
+
+// applySiteArgs loads the profile named by the global --site flag and
+// resolves the arguments and flags of cmd for it. It returns nil without the
+// flag; commands with a --site flag of their own, such as login, don't
+// inherit it.
+func applySiteArgs(cmd *cobra.Command, args []string) (*config.Site, error) {
+	flag := cmd.InheritedFlags().Lookup("site")
+	if flag == nil || flag.Value.String() == "" {
+		return nil, nil
+	}
+	site, err := config.LoadSite(flag.Value.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveSiteArgs(cmd, args, site); err != nil {
+		return nil, err
+	}
+	return site, nil
+}
+
+// resolveSiteArgs rewrites the arguments and flags of cmd in place, as
+// dryRunSpecs describe them, for site: relative URLs to visit or request are
+// resolved against its base URL and "@name" selectors replaced by its named
+// selectors. Cobra passes the same args to the pre-run hooks and Run.
+func resolveSiteArgs(cmd *cobra.Command, args []string, site *config.Site) error {
+	spec := dryRunSpecs[commandName(cmd)]
+	for i, kind := range spec.args {
+		if i >= len(args) {
+			break
+		}
+		resolved, err := resolveSiteValue(site, kind, args[i])
+		if err != nil {
+			return err
+		}
+		args[i] = resolved
+	}
+
+	var err error
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		kind, ok := spec.flags[flag.Name]
+		if !ok || err != nil {
+			return
+		}
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			values := slice.GetSlice()
+			for i, value := range values {
+				if values[i], err = resolveSiteValue(site, kind, value); err != nil {
+					return
+				}
+			}
+			err = slice.Replace(values)
+			return
+		}
+		var resolved string
+		if resolved, err = resolveSiteValue(site, kind, flag.Value.String()); err == nil {
+			err = flag.Value.Set(resolved)
+		}
+	})
+	return err
+}
+
+// resolveSiteValue resolves a value of the given kind for site. Each
+// candidate of a selector fallback list is resolved on its own.
+func resolveSiteValue(site *config.Site, kind, value string) (string, error) {
+	switch kind {
+	case valueVisit, valueRequest:
+		if value == "" {
+			return value, nil
+		}
+		return site.ResolveURL(value)
+	case valueSelector:
+		return site.ResolveSelector(value)
+	case valueSelectors:
+		candidates := strings.Split(value, "||")
+		for i, candidate := range candidates {
+			resolved, err := site.ResolveSelector(strings.TrimSpace(candidate))
+			if err != nil {
+				return "", err
+			}
+			candidates[i] = resolved
+		}
+		return strings.Join(candidates, "||"), nil
+	}
+	return value, nil
+}
+
+// applySiteAuth makes the tab of ctx send the headers and credentials of
+// site to its origin, for as long as the connection lasts.
+func applySiteAuth(ctx context.Context, site *config.Site) error {
+	auth := logic.SiteAuth{Origin: site.Origin(), Headers: map[string]string{}, Username: site.Username}
+	for name, value := range site.Headers {
+		resolved, err := config.ResolveSecret(value)
+		if err != nil {
+			return fmt.Errorf("site %s: header %s: %w", site.Name, name, err)
+		}
+		auth.Headers[name] = resolved
+	}
+	if site.Credential != "" {
+		password, err := config.ResolveSecret(site.Credential)
+		if err != nil {
+			return fmt.Errorf("site %s: credential: %w", site.Name, err)
+		}
+		auth.Password = password
+	}
+	return logic.ApplySiteAuth(ctx, auth)
+}
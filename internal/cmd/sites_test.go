@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"browser-tools-go/internal/config"
+)
+
+// TestResolveSiteArgs はサイトによる引数とフラグの解決をテストします。
+func TestResolveSiteArgs(t *testing.T) {
+	site := &config.Site{Name: "shop", URL: "https://shop.example.com/", Selectors: map[string]string{
+		"total": "#summary .total",
+		"next":  "a[rel=next]",
+	}}
+	root := NewRootCmd()
+
+	navigate, _, _ := root.Find([]string{"navigate"})
+	args := []string{"/orders"}
+	if err := resolveSiteArgs(navigate, args, site); err != nil || args[0] != "https://shop.example.com/orders" {
+		t.Errorf("Expected the URL to be resolved, got %q, %v", args[0], err)
+	}
+
+	wait, _, _ := root.Find([]string{"wait"})
+	_ = wait.Flags().Set("selector", "@next || .more")
+	_ = wait.Flags().Set("selector", "@total")
+	if err := resolveSiteArgs(wait, nil, site); err != nil {
+		t.Fatalf("Failed to resolve wait: %v", err)
+	}
+	selectors, _ := wait.Flags().GetStringArray("selector")
+	if len(selectors) != 2 || selectors[0] != "a[rel=next]||.more" || selectors[1] != "#summary .total" {
+		t.Errorf("Expected each candidate to be resolved, got %q", selectors)
+	}
+
+	get, _, _ := root.Find([]string{"get"})
+	if err := resolveSiteArgs(get, []string{"@missing"}, site); err == nil {
+		t.Error("Expected an error for an unknown selector name")
+	}
+}
+
+// TestApplySiteArgs は--siteフラグによるプロファイルの読み込みをテストします。
+func TestApplySiteArgs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := NewRootCmd()
+	cmd, _, _ := root.Find([]string{"navigate"})
+
+	if site, err := applySiteArgs(cmd, []string{"/orders"}); site != nil || err != nil {
+		t.Errorf("Expected nothing without --site, got %v, %v", site, err)
+	}
+	_ = root.PersistentFlags().Set("site", "shop")
+	if _, err := applySiteArgs(cmd, []string{"/orders"}); err == nil {
+		t.Error("Expected an error for a site without a profile")
+	}
+
+	if err := config.SaveSite(&config.Site{Name: "shop", URL: "https://shop.example.com/"}); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"/orders"}
+	site, err := applySiteArgs(cmd, args)
+	if err != nil || site == nil || args[0] != "https://shop.example.com/orders" {
+		t.Errorf("Expected the site to be applied, got %v, %q, %v", site, args, err)
+	}
+
+	// login has a --site flag of its own and doesn't inherit the global one
+	login, _, _ := root.Find([]string{"login"})
+	if site, err := applySiteArgs(login, nil); site != nil || err != nil {
+		t.Errorf("Expected login not to take the global --site, got %v, %v", site, err)
+	}
+}
+
+// TestReadSiteSelectors は名前付きセレクタファイルの読み込みをテストします。
+func TestReadSiteSelectors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(data string) string {
+		t.Helper()
+		path := filepath.Join(dir, "selectors.json")
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	selectors, err := readSiteSelectors(write(`{"total": "#summary .total"}`))
+	if err != nil || selectors["total"] != "#summary .total" {
+		t.Errorf("Unexpected selectors: %v, %v", selectors, err)
+	}
+	if _, err := readSiteSelectors(write(`{"total": "#summary["}`)); err == nil {
+		t.Error("Expected an error for an invalid selector")
+	}
+	if _, err := readSiteSelectors(write(`["#total"]`)); err == nil {
+		t.Error("Expected an error for a JSON array")
+	}
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func newIdbCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "idb",
+		Short: "Inspect and export IndexedDB databases",
+	}
+	cmd.AddCommand(newIdbListCmd(), newIdbDumpCmd())
+	return cmd
+}
+
+func newIdbListCmd() *cobra.Command {
+	var origin string
+
+	cmd := &cobra.Command{
+		Use:               "list",
+		Short:             "List the IndexedDB databases and object stores of an origin",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Println("🗄️ Listing IndexedDB databases...")
+			databases, err := logic.ListIndexedDB(bc.ctx, origin)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			prettyPrintResults(databases)
+		},
+	}
+
+	cmd.Flags().StringVar(&origin, "origin", "", "Security origin, e.g. https://example.com (default: origin of the current page)")
+	return cmd
+}
+
+func newIdbDumpCmd() *cobra.Command {
+	var origin, database, store string
+
+	cmd := &cobra.Command{
+		Use:               "dump",
+		Short:             "Export IndexedDB records as JSON",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Println("🗄️ Dumping IndexedDB records...")
+			dumps, err := logic.DumpIndexedDB(bc.ctx, origin, database, store)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			prettyPrintResults(dumps)
+		},
+	}
+
+	cmd.Flags().StringVar(&origin, "origin", "", "Security origin, e.g. https://example.com (default: origin of the current page)")
+	cmd.Flags().StringVar(&database, "database", "", "Only dump this database")
+	cmd.Flags().StringVar(&store, "store", "", "Only dump this object store")
+	return cmd
+}
+
+func newClearDataCmd() *cobra.Command {
+	var origin string
+	var opts logic.ClearDataOptions
+
+	cmd := &cobra.Command{
+		Use:   "clear-data",
+		Short: "Clear cookies, caches, storage and service workers for an origin",
+		Long: `Clears browsing data for an origin (default: the origin of the current page)
+without touching other sites. Without any selection flag, everything is cleared.
+--cache also empties the browser's HTTP cache, which is shared by all origins.`,
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Println("🧹 Clearing browsing data...")
+			cleared, err := logic.ClearData(bc.ctx, origin, opts)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("✅ Cleared: %s", strings.Join(cleared, ", "))
+		},
+	}
+
+	cmd.Flags().StringVar(&origin, "origin", "", "Security origin, e.g. https://example.com (default: origin of the current page)")
+	cmd.Flags().BoolVar(&opts.Cookies, "cookies", false, "Clear cookies")
+	cmd.Flags().BoolVar(&opts.Cache, "cache", false, "Clear Cache Storage and the HTTP cache")
+	cmd.Flags().BoolVar(&opts.Storage, "storage", false, "Clear local/session storage, IndexedDB, WebSQL and file systems")
+	cmd.Flags().BoolVar(&opts.ServiceWorkers, "service-workers", false, "Unregister service workers")
+	return cmd
+}
+
+func newStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Save and restore cookies plus local/session storage",
+	}
+	cmd.AddCommand(newStateSaveCmd(), newStateLoadCmd())
+	return cmd
+}
+
+func newStateSaveCmd() *cobra.Command {
+	var origins []string
+
+	cmd := &cobra.Command{
+		Use:               "save <file>",
+		Short:             "Save cookies and web storage to a Playwright-compatible state file",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Println("💾 Saving storage state...")
+			state, err := logic.SaveStorageState(bc.ctx, origins)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+
+			data, err := json.MarshalIndent(state, "", "  ")
+			if err != nil {
+				fatalf("✗ Failed to encode state: %v", err)
+			}
+			if err := utils.SecureWriteFile(args[0], data, 0600, "."); err != nil {
+				fatalf("✗ Failed to write state to %s: %v", args[0], err)
+			}
+			i18n.Printf("✅ Saved %d cookie(s) and storage of %d origin(s) to %s", len(state.Cookies), len(state.Origins), args[0])
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&origins, "origin", nil, "Additional origins whose local storage is saved (the current page's origin is always included)")
+	return cmd
+}
+
+func newStateLoadCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "load <file>",
+		Short:             "Restore cookies and web storage from a state file",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				fatalf("✗ Failed to read state file: %v", err)
+			}
+
+			i18n.Printf("📂 Loading storage state from %s...", args[0])
+			if err := logic.LoadStorageState(bc.ctx, data); err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Println("✅ Storage state loaded.")
+		},
+	}
+	return cmd
+}
+
+func newSwCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sw",
+		Short: "List and control service workers",
+	}
+	cmd.AddCommand(newSwListCmd(), newSwUnregisterCmd(), newSwUpdateCmd())
+	return cmd
+}
+
+func newSwListCmd() *cobra.Command {
+	var origin string
+
+	cmd := &cobra.Command{
+		Use:               "list",
+		Short:             "List registered service workers",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Println("⚙️ Listing service workers...")
+			workers, err := logic.ListServiceWorkers(bc.ctx, origin)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			prettyPrintResults(workers)
+		},
+	}
+
+	cmd.Flags().StringVar(&origin, "origin", "", "Only list service workers of this origin (default: all origins)")
+	return cmd
+}
+
+func newSwUnregisterCmd() *cobra.Command {
+	var origin string
+
+	cmd := &cobra.Command{
+		Use:               "unregister",
+		Short:             "Unregister the service workers of an origin",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Println("🗑️ Unregistering service workers...")
+			scopes, err := logic.UnregisterServiceWorkers(bc.ctx, origin)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("✅ Unregistered %d service worker(s).", len(scopes))
+			prettyPrintResults(scopes)
+		},
+	}
+
+	cmd.Flags().StringVar(&origin, "origin", "", "Security origin, e.g. https://example.com (default: origin of the current page)")
+	return cmd
+}
+
+func newSwUpdateCmd() *cobra.Command {
+	var origin string
+
+	cmd := &cobra.Command{
+		Use:               "update",
+		Short:             "Make the service workers of an origin check for a new version",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: persistentPreRunE,
+		Run: func(cmd *cobra.Command, args []string) {
+			bc, err := getBrowserCtx(cmd)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			defer bc.cancel()
+
+			i18n.Println("🔄 Updating service workers...")
+			scopes, err := logic.UpdateServiceWorkers(bc.ctx, origin)
+			if err != nil {
+				fatalf("✗ %v", err)
+			}
+			i18n.Printf("✅ Requested update of %d service worker(s).", len(scopes))
+			prettyPrintResults(scopes)
+		},
+	}
+
+	cmd.Flags().StringVar(&origin, "origin", "", "Security origin, e.g. https://example.com (default: origin of the current page)")
+	return cmd
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import "testing"
+
+// TestNewIdbCmd_Subcommands はidbコマンドのサブコマンド構成をテストします。
+func TestNewIdbCmd_Subcommands(t *testing.T) {
+	cmd := newIdbCmd()
+
+	for _, name := range []string{"list", "dump"} {
+		sub, _, err := cmd.Find([]string{name})
+		if err != nil || sub.Name() != name {
+			t.Errorf("Expected subcommand %s, got %v (err: %v)", name, sub, err)
+		}
+		if sub.Flags().Lookup("origin") == nil {
+			t.Errorf("Expected %s to have an origin flag", name)
+		}
+	}
+}
+
+// TestNewStateCmd_Subcommands はstateコマンドのサブコマンド構成をテストします。
+func TestNewStateCmd_Subcommands(t *testing.T) {
+	cmd := newStateCmd()
+
+	for _, name := range []string{"save", "load"} {
+		sub, _, err := cmd.Find([]string{name})
+		if err != nil || sub.Name() != name {
+			t.Errorf("Expected subcommand %s, got %v (err: %v)", name, sub, err)
+		}
+		if err := sub.Args(sub, []string{}); err == nil {
+			t.Errorf("Expected %s to require a file argument", name)
+		}
+	}
+}
+
+// TestNewSwCmd_Subcommands はswコマンドのサブコマンド構成をテストします。
+func TestNewSwCmd_Subcommands(t *testing.T) {
+	cmd := newSwCmd()
+
+	for _, name := range []string{"list", "unregister", "update"} {
+		sub, _, err := cmd.Find([]string{name})
+		if err != nil || sub.Name() != name {
+			t.Errorf("Expected subcommand %s, got %v (err: %v)", name, sub, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"browser-tools-go/internal/browser"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/logic"
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+)
+
+// tabTimeout bounds the work done in each tab with --all-tabs, so a tab where
+// e.g. a selector never matches doesn't stall the others.
+const tabTimeout = 30 * time.Second
+
+// runOnAllTabs runs fn in every open tab and collects the results keyed by
+// tab. A failure in one tab is recorded in its result and doesn't stop the
+// others.
+func runOnAllTabs(ctx context.Context, fn func(ctx context.Context, tab *target.Info) (interface{}, error)) ([]models.TabResult, error) {
+	tabs, err := browser.ListTabs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i18n.Printf("🗂️ Running in %d tabs...", len(tabs))
+
+	results := make([]models.TabResult, 0, len(tabs))
+	for _, tab := range tabs {
+		result := models.TabResult{TabID: string(tab.TargetID), URL: tab.URL, Title: tab.Title}
+		value, err := runInTab(ctx, tab, fn)
+		if err != nil {
+			i18n.Printf("⚠️ Tab %s (%s): %v", tab.TargetID, tab.URL, err)
+			result.Error = err.Error()
+		} else {
+			result.Result = value
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// runInTab attaches to a tab and runs fn in it with tabTimeout. Like the tabs
+// of a pool, the tab is held to the host policy while fn runs.
+func runInTab(ctx context.Context, tab *target.Info, fn func(ctx context.Context, tab *target.Info) (interface{}, error)) (interface{}, error) {
+	tabCtx, release := browser.AttachTab(ctx, tab.TargetID)
+	defer release()
+	// Attach before applying the timeout: the tab's event loop lives as long
+	// as the context of the first Run.
+	if err := chromedp.Run(tabCtx); err != nil {
+		return nil, err
+	}
+	if err := logic.ApplyHostPolicy(tabCtx); err != nil {
+		return nil, err
+	}
+	timeoutCtx, cancel := context.WithTimeout(tabCtx, tabTimeout)
+	defer cancel()
+	return fn(timeoutCtx, tab)
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"browser-tools-go/internal/i18n"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingShutdownTimeout bounds flushing spans when a command ends.
+const tracingShutdownTimeout = 5 * time.Second
+
+// tracingConfigured reports whether an OTLP endpoint is set in the
+// environment and the SDK isn't disabled.
+func tracingConfigured() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// setupTracing installs a tracer provider exporting spans over OTLP/HTTP,
+// configured by the standard OTEL_* environment variables, and returns a
+// function flushing the pending spans.
+func setupTracing(ctx context.Context) (func(), error) {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", "browser-tools-go")),
+		resource.WithFromEnv(), // OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES take precedence
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			i18n.Printf("⚠️ Failed to export traces: %v", err)
+		}
+	}, nil
+}
+
+// parentTraceContext returns ctx continuing the trace given in the
+// TRACEPARENT and TRACESTATE environment variables, so commands run from a
+// traced process join its trace.
+func parentTraceContext(ctx context.Context) context.Context {
+	carrier := propagation.MapCarrier{
+		"traceparent": os.Getenv("TRACEPARENT"),
+		"tracestate":  os.Getenv("TRACESTATE"),
+	}
+	return propagation.TraceContext{}.Extract(ctx, carrier)
+}
+
+// commandTrace is the span of the command run by this process.
+var commandTrace struct {
+	span     trace.Span
+	shutdown func()
+	finish   sync.Once
+}
+
+// beginCommandTrace starts the command's span when tracing is configured and
+// returns the context carrying it.
+func beginCommandTrace(ctx context.Context, name string) context.Context {
+	if !tracingConfigured() {
+		return ctx
+	}
+	shutdown, err := setupTracing(ctx)
+	if err != nil {
+		i18n.Printf("⚠️ Tracing disabled: %v", err)
+		return ctx
+	}
+	ctx, span := otel.Tracer("browser-tools-go/internal/cmd").Start(parentTraceContext(ctx), name,
+		trace.WithAttributes(attribute.String("browser_tools.command", name)))
+	commandTrace.span = span
+	commandTrace.shutdown = shutdown
+	return ctx
+}
+
+// finishCommandTrace ends the command's span and flushes the spans; only the
+// first call counts.
+func finishCommandTrace(ok bool, message string) {
+	if commandTrace.span == nil {
+		return
+	}
+	commandTrace.finish.Do(func() {
+		if !ok {
+			commandTrace.span.SetStatus(codes.Error, message)
+		}
+		commandTrace.span.End()
+		commandTrace.shutdown()
+	})
+}
+
+// withCommandSpan returns the browser context ctx carrying the command span
+// of parent, so the spans of the logic layer become its children.
+func withCommandSpan(ctx, parent context.Context) context.Context {
+	return trace.ContextWithSpan(ctx, trace.SpanFromContext(parent))
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestTracingConfigured はOTLPエンドポイントの環境変数による有効化をテストします。
+func TestTracingConfigured(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected bool
+	}{
+		{"unset", map[string]string{}, false},
+		{"endpoint", map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318"}, true},
+		{"traces endpoint", map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://collector:4318/v1/traces"}, true},
+		{"disabled", map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318", "OTEL_SDK_DISABLED": "true"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_SDK_DISABLED"} {
+				t.Setenv(key, tt.env[key])
+			}
+			if got := tracingConfigured(); got != tt.expected {
+				t.Errorf("tracingConfigured() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestCommandTrace はコマンドのスパンが親トレースを継続しOTLPで送信されることをテストします。
+func TestCommandTrace(t *testing.T) {
+	var exports atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			exports.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+	t.Setenv("OTEL_SDK_DISABLED", "")
+	t.Setenv("TRACEPARENT", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	defer func() {
+		commandTrace.span = nil
+		commandTrace.shutdown = nil
+	}()
+
+	ctx := beginCommandTrace(context.Background(), "navigate")
+	span := trace.SpanFromContext(ctx)
+	if got := span.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected the trace from TRACEPARENT, got %s", got)
+	}
+
+	// ブラウザコンテキストにスパンを引き継ぐ
+	browserCtx := withCommandSpan(context.Background(), ctx)
+	if trace.SpanFromContext(browserCtx) != span {
+		t.Error("Expected the browser context to carry the command span")
+	}
+
+	finishCommandTrace(false, "✗ failed")
+	finishCommandTrace(true, "") // 二度目は無視される
+	if exports.Load() != 1 {
+		t.Errorf("Expected the spans to be exported once, got %d", exports.Load())
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+
+	"browser-tools-go/internal/browser"
+	"browser-tools-go/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// Version is the tool version, set at build time with
+// -ldflags "-X browser-tools-go/internal/cmd.Version=v1.2.3".
+var Version = ""
+
+// toolVersion returns Version, falling back to the module version recorded
+// by go install.
+func toolVersion() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
+
+// versionInfo collects the version details; the browser part is left out
+// with the reason when no browser answers.
+func versionInfo(browserVersion func() (*models.BrowserInfo, error)) models.VersionInfo {
+	info := models.VersionInfo{
+		Version:   toolVersion(),
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	b, err := browserVersion()
+	if err != nil {
+		info.BrowserError = err.Error()
+	} else {
+		info.Browser = b
+	}
+	return info
+}
+
+// writeVersionText writes info for humans.
+func writeVersionText(w io.Writer, info models.VersionInfo) {
+	fmt.Fprintf(w, "browser-tools-go %s\n", info.Version)
+	fmt.Fprintf(w, "Go:        %s %s\n", info.GoVersion, info.Platform)
+	if info.Browser == nil {
+		fmt.Fprintf(w, "Browser:   unavailable (%s)\n", info.BrowserError)
+		return
+	}
+	fmt.Fprintf(w, "Browser:   %s (revision %s)\n", info.Browser.Product, info.Browser.Revision)
+	fmt.Fprintf(w, "Protocol:  %s\n", info.Browser.ProtocolVersion)
+	fmt.Fprintf(w, "V8:        %s\n", info.Browser.JSVersion)
+	fmt.Fprintf(w, "UserAgent: %s\n", info.Browser.UserAgent)
+}
+
+func newVersionCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the tool, Go and browser versions",
+		Long: `Prints the version of browser-tools-go and the Go toolchain it was built with,
+and, when a session is running, the connected browser's version and DevTools
+protocol version. Include it in bug reports.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid --format %q (expected text or json)", format)
+			}
+			return cobra.NoArgs(cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			info := versionInfo(browser.BrowserVersion)
+			if format == "json" {
+				prettyPrintResults(info)
+				return
+			}
+			writeVersionText(os.Stdout, info)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text or json)")
+	return cmd
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"browser-tools-go/internal/models"
+)
+
+// TestVersionInfo はブラウザの有無に応じたバージョン情報の収集をテストします。
+func TestVersionInfo(t *testing.T) {
+	original := Version
+	Version = "v1.2.3"
+	defer func() { Version = original }()
+
+	chrome := &models.BrowserInfo{Product: "Chrome/131.0.6778.85", Revision: "@abc", ProtocolVersion: "1.3", JSVersion: "13.1.201.9"}
+	info := versionInfo(func() (*models.BrowserInfo, error) { return chrome, nil })
+	if info.Version != "v1.2.3" || info.GoVersion != runtime.Version() {
+		t.Errorf("Unexpected tool versions: %+v", info)
+	}
+	if info.Browser != chrome || info.BrowserError != "" {
+		t.Errorf("Expected the browser version, got %+v", info)
+	}
+
+	info = versionInfo(func() (*models.BrowserInfo, error) { return nil, errors.New("browser is not running") })
+	if info.Browser != nil || info.BrowserError != "browser is not running" {
+		t.Errorf("Expected the browser error, got %+v", info)
+	}
+}
+
+// TestWriteVersionText はテキスト形式の出力をテストします。
+func TestWriteVersionText(t *testing.T) {
+	var buf bytes.Buffer
+	writeVersionText(&buf, models.VersionInfo{
+		Version:   "v1.2.3",
+		GoVersion: "go1.25.4",
+		Platform:  "linux/amd64",
+		Browser:   &models.BrowserInfo{Product: "Chrome/131.0.6778.85", Revision: "@abc", ProtocolVersion: "1.3"},
+	})
+	for _, want := range []string{"browser-tools-go v1.2.3", "go1.25.4 linux/amd64", "Chrome/131.0.6778.85", "Protocol:  1.3"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	writeVersionText(&buf, models.VersionInfo{Version: "dev", BrowserError: "browser is not running"})
+	if !strings.Contains(buf.String(), "unavailable (browser is not running)") {
+		t.Errorf("Expected the browser error, got:\n%s", buf.String())
+	}
+}
+
+// TestNewVersionCmd_Format は--formatの検証をテストします。
+func TestNewVersionCmd_Format(t *testing.T) {
+	for _, format := range []string{"text", "json"} {
+		cmd := newVersionCmd()
+		_ = cmd.Flags().Set("format", format)
+		if err := cmd.Args(cmd, nil); err != nil {
+			t.Errorf("Expected --format %s to be accepted, got %v", format, err)
+		}
+	}
+	cmd := newVersionCmd()
+	_ = cmd.Flags().Set("format", "yaml")
+	if err := cmd.Args(cmd, nil); err == nil {
+		t.Error("Expected error for --format yaml")
+	}
+}
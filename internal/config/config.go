@@ -9,17 +9,37 @@ import (
 type WsInfo struct {
 	Url string `json:"url"`
 	Pid int    `json:"pid"`
+	// CacheDisabled is set by "cache disable" and applied on every connection of the session.
+	CacheDisabled bool `json:"cacheDisabled,omitempty"`
+	// Zoom is set by "zoom" and applied on every connection of the session; zero means no zoom.
+	Zoom float64 `json:"zoom,omitempty"`
+	// AutomationTab is the id of the tab commands drive, opened by the first command of the session.
+	AutomationTab string `json:"automationTab,omitempty"`
 }
 
-func GetConfigPath() (string, error) {
+// GetConfigDir returns the directory holding the tool's session and state files.
+func GetConfigDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ".browser-tools-go", "ws.json"), nil
+	return filepath.Join(home, ".browser-tools-go"), nil
+}
+
+func GetConfigPath() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ws.json"), nil
 }
 
 func SaveWsInfo(url string, pid int) error {
+	return WriteWsInfo(&WsInfo{Url: url, Pid: pid})
+}
+
+// WriteWsInfo stores the session info, replacing the current one.
+func WriteWsInfo(info *WsInfo) error {
 	path, err := GetConfigPath()
 	if err != nil {
 		return err
@@ -29,7 +49,6 @@ func SaveWsInfo(url string, pid int) error {
 		return err
 	}
 
-	info := WsInfo{Url: url, Pid: pid}
 	data, err := json.Marshal(info)
 	if err != nil {
 		return err
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CrawlItem is a URL waiting in a crawl frontier.
+type CrawlItem struct {
+	URL   string `json:"url"`
+	Depth int    `json:"depth"`
+}
+
+// CrawlCheckpoint is the persisted state of a crawl run. It records the options
+// the run was started with so a resumed run behaves like the original one.
+type CrawlCheckpoint struct {
+	RunID     string      `json:"runId"`
+	StartURL  string      `json:"startUrl"`
+	MaxDepth  int         `json:"maxDepth"`
+	MaxPages  int         `json:"maxPages"`
+	Format    string      `json:"format"`
+	SameHost  bool        `json:"sameHost"`
+	Frontier  []CrawlItem `json:"frontier"`
+	Visited   []string    `json:"visited"`
+	Completed bool        `json:"completed"`
+	StartedAt time.Time   `json:"startedAt"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+// GetCrawlCheckpointPath returns the checkpoint file of a crawl run.
+func GetCrawlCheckpointPath(runID string) (string, error) {
+	if runID == "" || strings.ContainsAny(runID, `/\`) || strings.HasPrefix(runID, ".") {
+		return "", fmt.Errorf("invalid crawl run id: %q", runID)
+	}
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "crawl", runID+".json"), nil
+}
+
+// LoadCrawlCheckpoint reads the checkpoint of a previous crawl run.
+func LoadCrawlCheckpoint(runID string) (*CrawlCheckpoint, error) {
+	path, err := GetCrawlCheckpointPath(runID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no crawl run found with id %q", runID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint CrawlCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse crawl checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// SaveCrawlCheckpoint stores the checkpoint. The file is replaced atomically so a
+// run killed mid-write still leaves the previous checkpoint intact.
+func SaveCrawlCheckpoint(checkpoint *CrawlCheckpoint) error {
+	path, err := GetCrawlCheckpointPath(checkpoint.RunID)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// ListCrawlCheckpoints returns the checkpoints of all crawl runs, oldest
+// first. Unreadable checkpoint files are skipped.
+func ListCrawlCheckpoints() ([]*CrawlCheckpoint, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "crawl"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoints []*CrawlCheckpoint
+	for _, entry := range entries {
+		runID, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		checkpoint, err := LoadCrawlCheckpoint(runID)
+		if err != nil {
+			continue
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	// Run ids start with their start time.
+	sort.Slice(checkpoints, func(i, j int) bool { return checkpoints[i].RunID < checkpoints[j].RunID })
+	return checkpoints, nil
+}
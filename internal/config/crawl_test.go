@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+// TestCrawlCheckpoint_SaveAndLoad はクロールのチェックポイントの保存と読み込みをテストします。
+func TestCrawlCheckpoint_SaveAndLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	// 存在しないランIDはエラーになる
+	if _, err := LoadCrawlCheckpoint("missing"); err == nil {
+		t.Fatal("Expected error for unknown run id")
+	}
+
+	saved := &CrawlCheckpoint{
+		RunID:    "20260101-000000",
+		StartURL: "https://example.com",
+		MaxDepth: 2,
+		MaxPages: 10,
+		Format:   "text",
+		SameHost: true,
+		Frontier: []CrawlItem{{URL: "https://example.com/b", Depth: 1}},
+		Visited:  []string{"https://example.com"},
+	}
+	if err := SaveCrawlCheckpoint(saved); err != nil {
+		t.Fatalf("Failed to save checkpoint: %v", err)
+	}
+
+	loaded, err := LoadCrawlCheckpoint(saved.RunID)
+	if err != nil {
+		t.Fatalf("Failed to load checkpoint: %v", err)
+	}
+	if loaded.StartURL != saved.StartURL || len(loaded.Frontier) != 1 || loaded.Frontier[0].Depth != 1 {
+		t.Errorf("Loaded checkpoint does not match saved one: %+v", loaded)
+	}
+	if len(loaded.Visited) != 1 || loaded.Visited[0] != "https://example.com" {
+		t.Errorf("Expected visited URLs to be restored, got %v", loaded.Visited)
+	}
+}
+
+// TestGetCrawlCheckpointPath_InvalidRunID はパスを含むランIDが拒否されることをテストします。
+func TestGetCrawlCheckpointPath_InvalidRunID(t *testing.T) {
+	for _, runID := range []string{"", "../secret", "a/b", `a\b`, ".hidden"} {
+		if _, err := GetCrawlCheckpointPath(runID); err == nil {
+			t.Errorf("Expected error for run id %q", runID)
+		}
+	}
+}
+
+// TestListCrawlCheckpoints はクロールのチェックポイント一覧をテストします。
+func TestListCrawlCheckpoints(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	checkpoints, err := ListCrawlCheckpoints()
+	if err != nil || len(checkpoints) != 0 {
+		t.Fatalf("Expected no checkpoints without a crawl directory, got %v, %v", checkpoints, err)
+	}
+
+	for _, runID := range []string{"20260102-000000-bbbbbb", "20260101-000000-aaaaaa"} {
+		if err := SaveCrawlCheckpoint(&CrawlCheckpoint{RunID: runID, StartURL: "https://example.com"}); err != nil {
+			t.Fatalf("SaveCrawlCheckpoint() error = %v", err)
+		}
+	}
+	path, _ := GetCrawlCheckpointPath("broken")
+	if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoints, err = ListCrawlCheckpoints()
+	if err != nil {
+		t.Fatalf("ListCrawlCheckpoints() error = %v", err)
+	}
+	if len(checkpoints) != 2 || checkpoints[0].RunID != "20260101-000000-aaaaaa" || checkpoints[1].RunID != "20260102-000000-bbbbbb" {
+		t.Errorf("Expected the two valid checkpoints oldest first, got %v", checkpoints)
+	}
+}
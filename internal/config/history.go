@@ -0,0 +1,93 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HistoryEntry is a main-frame navigation observed while a command was connected.
+type HistoryEntry struct {
+	URL       string    `json:"url"`
+	TabID     string    `json:"tabId"`
+	Session   int       `json:"session"` // PID of the browser session the navigation happened in
+	VisitedAt time.Time `json:"visitedAt"`
+}
+
+// GetHistoryPath returns the file that navigation history is appended to.
+func GetHistoryPath() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.jsonl"), nil
+}
+
+// AppendHistory adds an entry to the navigation history.
+func AppendHistory(entry *HistoryEntry) error {
+	path, err := GetHistoryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadHistory reads the navigation history, oldest first. It returns no
+// entries without an error when nothing has been recorded yet. Lines that
+// can't be parsed (e.g. cut short by a crash) are skipped.
+func LoadHistory() ([]HistoryEntry, error) {
+	path, err := GetHistoryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// ClearHistory deletes the navigation history.
+func ClearHistory() error {
+	path, err := GetHistoryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
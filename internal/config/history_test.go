@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// TestHistory_AppendLoadClear はナビゲーション履歴の追記・読み込み・削除をテストします。
+func TestHistory_AppendLoadClear(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	// 未記録の場合は空を返す
+	entries, err := LoadHistory()
+	if err != nil {
+		t.Fatalf("Expected no error for missing history, got %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("Expected no entries, got %d", len(entries))
+	}
+
+	visitedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, url := range []string{"https://a.example/", "https://b.example/"} {
+		if err := AppendHistory(&HistoryEntry{URL: url, TabID: "T1", Session: 42, VisitedAt: visitedAt}); err != nil {
+			t.Fatalf("Failed to append history: %v", err)
+		}
+	}
+
+	// 壊れた行は読み飛ばす
+	path, _ := GetHistoryPath()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{\"url\":\n")
+	f.Close()
+
+	entries, err = LoadHistory()
+	if err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].URL != "https://a.example/" || entries[1].TabID != "T1" || entries[1].Session != 42 || !entries[1].VisitedAt.Equal(visitedAt) {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+
+	if err := ClearHistory(); err != nil {
+		t.Fatalf("Failed to clear history: %v", err)
+	}
+	entries, _ = LoadHistory()
+	if len(entries) != 0 {
+		t.Errorf("Expected empty history after clear, got %d entries", len(entries))
+	}
+	if err := ClearHistory(); err != nil {
+		t.Errorf("Clearing a missing history should not fail, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoginRecipe describes how to sign in to a site, for "login --site". The
+// credentials are secret references such as "keyring:ENTRY", resolved with
+// ResolveSecret when the recipe runs, so that no password is stored in it.
+// The profile of the site, when there is one, fills in the URL and the
+// credentials the recipe leaves out and names its "@name" selectors.
+type LoginRecipe struct {
+	// URL is the page of the login form.
+	URL              string `json:"url"`
+	UsernameSelector string `json:"usernameSelector"`
+	PasswordSelector string `json:"passwordSelector"`
+	// SubmitSelector is the button sending the form; without it, Enter is
+	// pressed in the password field.
+	SubmitSelector string `json:"submitSelector,omitempty"`
+	// Username may be given as it is; Password must be a secret reference.
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// FailureSelector matches the error message the site shows for wrong
+	// credentials, so that the login fails at once instead of timing out.
+	FailureSelector string       `json:"failureSelector,omitempty"`
+	Success         LoginSuccess `json:"success"`
+	MFA             *LoginMFA    `json:"mfa,omitempty"`
+}
+
+// LoginSuccess tells that a login succeeded: every condition given must hold.
+type LoginSuccess struct {
+	// URL is a string the URL of the page contains once signed in.
+	URL string `json:"url,omitempty"`
+	// Selector matches an element only visible once signed in.
+	Selector string `json:"selector,omitempty"`
+}
+
+// LoginMFA is the second step of a login, entering a verification code. It
+// only runs when its field shows up after the credentials were sent.
+type LoginMFA struct {
+	Selector       string `json:"selector"`
+	SubmitSelector string `json:"submitSelector,omitempty"`
+	// Code is a secret reference to the verification code.
+	Code string `json:"code,omitempty"`
+	// TOTPSecret is a secret reference to the TOTP secret the code is
+	// generated from. Without it or Code, the code is asked for on the
+	// terminal.
+	TOTPSecret string `json:"totpSecret,omitempty"`
+}
+
+// Validate checks that the recipe can run.
+func (r *LoginRecipe) Validate() error {
+	u, err := url.Parse(r.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http(s) URL, got %q", r.URL)
+	}
+	switch {
+	case r.UsernameSelector == "":
+		return fmt.Errorf("usernameSelector is required")
+	case r.PasswordSelector == "":
+		return fmt.Errorf("passwordSelector is required")
+	case r.Username == "":
+		return fmt.Errorf("username is required")
+	case r.Password == "":
+		return fmt.Errorf("password is required")
+	case !IsSecretReference(r.Password):
+		return fmt.Errorf(`password must be a secret reference such as "env:NAME" or "keyring:ENTRY", not the password itself`)
+	case r.Success.URL == "" && r.Success.Selector == "":
+		return fmt.Errorf("success needs a url or a selector")
+	}
+	if r.MFA != nil {
+		if r.MFA.Selector == "" {
+			return fmt.Errorf("mfa.selector is required")
+		}
+		if r.MFA.Code != "" && !IsSecretReference(r.MFA.Code) {
+			return fmt.Errorf(`mfa.code must be a secret reference such as "env:NAME" or "keyring:ENTRY"`)
+		}
+		if r.MFA.TOTPSecret != "" && !IsSecretReference(r.MFA.TOTPSecret) {
+			return fmt.Errorf(`mfa.totpSecret must be a secret reference such as "env:NAME" or "keyring:ENTRY"`)
+		}
+		if r.MFA.Code != "" && r.MFA.TOTPSecret != "" {
+			return fmt.Errorf("mfa.code and mfa.totpSecret can't be combined")
+		}
+	}
+	return nil
+}
+
+// GetLoginsDir returns the directory holding the login recipes, one
+// <site>.json file per site.
+func GetLoginsDir() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "logins"), nil
+}
+
+// GetLoginRecipePath returns the recipe file of a site.
+func GetLoginRecipePath(site string) (string, error) {
+	if site == "" || strings.ContainsAny(site, `/\`) || strings.HasPrefix(site, ".") {
+		return "", fmt.Errorf("invalid site name: %q", site)
+	}
+	dir, err := GetLoginsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, site+".json"), nil
+}
+
+// LoadLoginRecipe reads and validates the login recipe of a site. Unknown
+// fields are rejected, so that a misspelled one isn't silently ignored.
+func LoadLoginRecipe(site string) (*LoginRecipe, error) {
+	path, err := GetLoginRecipePath(site)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no login recipe for site %q: create %s", site, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var recipe LoginRecipe
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&recipe); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if profile, err := LoadSite(site); err == nil {
+		if err := recipe.applySite(profile); err != nil {
+			return nil, fmt.Errorf("invalid login recipe %s: %w", path, err)
+		}
+	}
+	if err := recipe.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid login recipe %s: %w", path, err)
+	}
+	return &recipe, nil
+}
+
+// applySite fills in what the recipe leaves out from the profile of its
+// site: the credentials, and the base URL a relative url is resolved against.
+// "@name" selectors are replaced by the site's named selectors.
+func (r *LoginRecipe) applySite(site *Site) error {
+	if r.Username == "" {
+		r.Username = site.Username
+	}
+	if r.Password == "" {
+		r.Password = site.Credential
+	}
+	if r.URL == "" {
+		r.URL = site.URL
+	}
+	resolved, err := site.ResolveURL(r.URL)
+	if err != nil {
+		return err
+	}
+	r.URL = resolved
+
+	selectors := []*string{&r.UsernameSelector, &r.PasswordSelector, &r.SubmitSelector, &r.FailureSelector, &r.Success.Selector}
+	if r.MFA != nil {
+		selectors = append(selectors, &r.MFA.Selector, &r.MFA.SubmitSelector)
+	}
+	for _, selector := range selectors {
+		if *selector, err = site.ResolveSelector(*selector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLoginRecipe_Validate はログインレシピの検証をテストします。
+func TestLoginRecipe_Validate(t *testing.T) {
+	valid := func() *LoginRecipe {
+		return &LoginRecipe{
+			URL:              "https://example.com/login",
+			UsernameSelector: "#email",
+			PasswordSelector: "#password",
+			Username:         "alice@example.com",
+			Password:         "env:EXAMPLE_PASSWORD",
+			Success:          LoginSuccess{Selector: ".avatar"},
+		}
+	}
+	if err := valid().Validate(); err != nil {
+		t.Fatalf("Expected a valid recipe, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *LoginRecipe)
+		want   string
+	}{
+		{"relative url", func(r *LoginRecipe) { r.URL = "/login" }, "url"},
+		{"no username field", func(r *LoginRecipe) { r.UsernameSelector = "" }, "usernameSelector"},
+		{"plain password", func(r *LoginRecipe) { r.Password = "hunter2" }, "secret reference"},
+		{"no success condition", func(r *LoginRecipe) { r.Success = LoginSuccess{} }, "success"},
+		{"mfa without field", func(r *LoginRecipe) { r.MFA = &LoginMFA{} }, "mfa.selector"},
+		{"plain mfa code", func(r *LoginRecipe) { r.MFA = &LoginMFA{Selector: "#otp", Code: "123456"} }, "mfa.code"},
+		{"plain totp secret", func(r *LoginRecipe) { r.MFA = &LoginMFA{Selector: "#otp", TOTPSecret: "JBSWY3DPEHPK3PXP"} }, "mfa.totpSecret"},
+		{"code and totp secret", func(r *LoginRecipe) {
+			r.MFA = &LoginMFA{Selector: "#otp", Code: "env:EXAMPLE_OTP", TOTPSecret: "env:EXAMPLE_TOTP"}
+		}, "can't be combined"},
+	}
+	for _, tt := range tests {
+		r := valid()
+		tt.modify(r)
+		if err := r.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+// TestLoadLoginRecipe はサイトのログインレシピの読み込みをテストします。
+func TestLoadLoginRecipe(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := LoadLoginRecipe("example"); err == nil || !strings.Contains(err.Error(), "no login recipe") {
+		t.Errorf("Expected an error for a missing recipe, got %v", err)
+	}
+	if _, err := LoadLoginRecipe("../example"); err == nil {
+		t.Error("Expected an error for a site name with a path")
+	}
+
+	dir, _ := GetLoginsDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	write := func(data string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "example.json"), []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`{"url": "https://example.com/login", "usernameSelector": "#email", "passwordSelector": "#password",
+		"username": "env:EXAMPLE_USER", "password": "env:EXAMPLE_PASSWORD",
+		"success": {"url": "/dashboard"}, "mfa": {"selector": "#otp"}}`)
+	recipe, err := LoadLoginRecipe("example")
+	if err != nil {
+		t.Fatalf("Failed to load recipe: %v", err)
+	}
+	if recipe.Success.URL != "/dashboard" || recipe.MFA == nil || recipe.MFA.Selector != "#otp" {
+		t.Errorf("Unexpected recipe: %+v", recipe)
+	}
+
+	// 綴りを誤ったフィールドは無視せずエラーにする
+	write(`{"url": "https://example.com/login", "sucess": {}}`)
+	if _, err := LoadLoginRecipe("example"); err == nil || !strings.Contains(err.Error(), "sucess") {
+		t.Errorf("Expected an error for an unknown field, got %v", err)
+	}
+
+	write(`{"url": "https://example.com/login"}`)
+	if _, err := LoadLoginRecipe("example"); err == nil || !strings.Contains(err.Error(), "invalid login recipe") {
+		t.Errorf("Expected an error for an incomplete recipe, got %v", err)
+	}
+}
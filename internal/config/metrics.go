@@ -0,0 +1,68 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// MetricsInfo locates the running metrics server, which commands report to.
+type MetricsInfo struct {
+	Addr string `json:"addr"` // host:port the server listens on
+	Pid  int    `json:"pid"`
+}
+
+// GetMetricsInfoPath returns the file written while a metrics server runs.
+func GetMetricsInfoPath() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "metrics.json"), nil
+}
+
+// WriteMetricsInfo records the running metrics server.
+func WriteMetricsInfo(info *MetricsInfo) error {
+	path, err := GetMetricsInfoPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// LoadMetricsInfo returns the running metrics server; it fails when none was
+// started.
+func LoadMetricsInfo() (*MetricsInfo, error) {
+	path, err := GetMetricsInfoPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var info MetricsInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// RemoveMetricsInfo forgets the metrics server, when it stops.
+func RemoveMetricsInfo() error {
+	path, err := GetMetricsInfoPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
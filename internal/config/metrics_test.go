@@ -0,0 +1,41 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMetricsInfo_WriteLoadRemove はメトリクスサーバー情報の保存・読み込み・削除をテストします。
+func TestMetricsInfo_WriteLoadRemove(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	if _, err := LoadMetricsInfo(); err == nil {
+		t.Fatal("Expected error when no metrics server was started")
+	}
+
+	if err := WriteMetricsInfo(&MetricsInfo{Addr: "127.0.0.1:9464", Pid: 42}); err != nil {
+		t.Fatalf("Failed to write metrics info: %v", err)
+	}
+	info, err := LoadMetricsInfo()
+	if err != nil {
+		t.Fatalf("Failed to load metrics info: %v", err)
+	}
+	if info.Addr != "127.0.0.1:9464" || info.Pid != 42 {
+		t.Errorf("Unexpected metrics info %+v", info)
+	}
+
+	if err := RemoveMetricsInfo(); err != nil {
+		t.Fatalf("Failed to remove metrics info: %v", err)
+	}
+	if _, err := LoadMetricsInfo(); err == nil {
+		t.Error("Expected error after removal")
+	}
+	// 二度目の削除はエラーにならない
+	if err := RemoveMetricsInfo(); err != nil {
+		t.Errorf("Expected no error removing twice, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Plugin is an executable in the plugins directory, run as the command Name.
+type Plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// GetPluginsDir returns the directory plugins are discovered from.
+func GetPluginsDir() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plugins"), nil
+}
+
+// ListPlugins returns the executables in the plugins directory, sorted by
+// name. The command name is the file name without its extension, so
+// "x-twitter.py" provides "x-twitter"; when two files give the same name, the
+// first in sorted order wins. It returns no plugins without an error when the
+// directory doesn't exist.
+func ListPlugins() ([]Plugin, error) {
+	dir, err := GetPluginsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var plugins []Plugin
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		// Follow symlinks, so plugins can be linked from where they're installed.
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() || !isExecutable(name, info.Mode()) {
+			continue
+		}
+		command := strings.TrimSuffix(name, filepath.Ext(name))
+		if command == "" || strings.ContainsAny(command, " \t") || seen[command] {
+			continue
+		}
+		seen[command] = true
+		plugins = append(plugins, Plugin{Name: command, Path: path})
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins, nil
+}
+
+// isExecutable reports whether a plugin file can be run: by its execute bits,
+// or on Windows by its extension.
+func isExecutable(name string, mode os.FileMode) bool {
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".exe", ".bat", ".cmd":
+			return true
+		}
+		return false
+	}
+	return mode&0111 != 0
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestListPlugins はプラグインディレクトリから実行可能ファイルを検出することをテストします。
+func TestListPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("execute bits are not used on Windows")
+	}
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	// ディレクトリがない場合は空を返す
+	plugins, err := ListPlugins()
+	if err != nil {
+		t.Fatalf("Expected no error for missing plugins directory, got %v", err)
+	}
+	if len(plugins) != 0 {
+		t.Fatalf("Expected no plugins, got %d", len(plugins))
+	}
+
+	dir, _ := GetPluginsDir()
+	if err := os.MkdirAll(filepath.Join(dir, "subdir"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]os.FileMode{
+		"x-twitter.py": 0755,
+		"x-twitter.sh": 0755, // 同名は先に見つかった方が優先
+		"alpha":        0700,
+		"README.md":    0644, // 実行権限なし
+		".hidden":      0755,
+	}
+	for name, mode := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plugins, err = ListPlugins()
+	if err != nil {
+		t.Fatalf("Failed to list plugins: %v", err)
+	}
+	expected := []Plugin{
+		{Name: "alpha", Path: filepath.Join(dir, "alpha")},
+		{Name: "x-twitter", Path: filepath.Join(dir, "x-twitter.py")},
+	}
+	if len(plugins) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, plugins)
+	}
+	for i := range expected {
+		if plugins[i] != expected[i] {
+			t.Errorf("Plugin %d: expected %v, got %v", i, expected[i], plugins[i])
+		}
+	}
+}
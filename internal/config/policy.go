@@ -0,0 +1,196 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Policy constrains the hosts the browser may navigate to and the JavaScript
+// eval may run, and configures the redaction of secrets. It is read from
+// policy.json in the config directory:
+//
+//	{"allowed_hosts": ["example.com", "*.example.com"], "blocked_hosts": ["admin.example.com"]}
+//
+// Patterns are host names where "*" stands for any run of characters, so
+// "*.example.com" matches the subdomains of example.com but not example.com
+// itself. Blocked hosts win; with allowed hosts, no other host is allowed.
+type Policy struct {
+	AllowedHosts []string `json:"allowed_hosts,omitempty"`
+	BlockedHosts []string `json:"blocked_hosts,omitempty"`
+	// EvalScriptDir, an absolute path, restricts eval to the scripts of this
+	// directory pinned in EvalScriptHashes: their SHA-256 in hex, keyed by
+	// their path relative to the directory. Inline JavaScript is refused.
+	EvalScriptDir    string            `json:"eval_script_dir,omitempty"`
+	EvalScriptHashes map[string]string `json:"eval_script_hashes,omitempty"`
+	// Redaction configures what is masked in the logs and network captures.
+	Redaction Redaction `json:"redaction"`
+}
+
+// Validate checks the patterns of the policy.
+func (p *Policy) Validate() error {
+	for _, pattern := range append(append([]string{}, p.AllowedHosts...), p.BlockedHosts...) {
+		if pattern == "" || strings.ContainsAny(pattern, "/:") {
+			return fmt.Errorf("invalid host pattern %q: expected a host name such as \"*.example.com\"", pattern)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid host pattern %q: %w", pattern, err)
+		}
+	}
+	if p.EvalScriptDir != "" && !filepath.IsAbs(p.EvalScriptDir) {
+		return fmt.Errorf("eval_script_dir must be an absolute path, got %q", p.EvalScriptDir)
+	}
+	if len(p.EvalScriptHashes) > 0 && p.EvalScriptDir == "" {
+		return fmt.Errorf("eval_script_hashes requires eval_script_dir")
+	}
+	for name, hash := range p.EvalScriptHashes {
+		if !filepath.IsLocal(filepath.FromSlash(name)) {
+			return fmt.Errorf("invalid script name %q in eval_script_hashes: expected a path relative to eval_script_dir", name)
+		}
+		if _, err := hex.DecodeString(hash); err != nil || len(hash) != 2*sha256.Size {
+			return fmt.Errorf("invalid SHA-256 %q for %s in eval_script_hashes", hash, name)
+		}
+	}
+	return p.Redaction.Validate()
+}
+
+// RestrictsEval reports whether eval only runs the approved scripts of
+// EvalScriptDir.
+func (p *Policy) RestrictsEval() bool {
+	return p != nil && p.EvalScriptDir != ""
+}
+
+// ErrEvalRefused is matched by the errors of JavaScript the policy refuses.
+var ErrEvalRefused = errors.New("refused by the eval policy")
+
+// ReadEvalScript reads the script at path for eval, checking, when the
+// policy restricts eval, that it lies in EvalScriptDir, symbolic links
+// resolved, and that its contents match the SHA-256 pinned for it. "-" reads
+// stdin, which a restricting policy refuses.
+func (p *Policy) ReadEvalScript(path string) ([]byte, error) {
+	if !p.RestrictsEval() {
+		if path == "-" {
+			return io.ReadAll(os.Stdin)
+		}
+		return os.ReadFile(path)
+	}
+	if path == "-" {
+		return nil, fmt.Errorf("%w: scripts can't be read from stdin, only from %s", ErrEvalRefused, p.EvalScriptDir)
+	}
+
+	dir, err := filepath.EvalSymlinks(p.EvalScriptDir)
+	if err != nil {
+		return nil, fmt.Errorf("eval_script_dir: %w", err)
+	}
+	resolved, err := filepath.Abs(path)
+	if err == nil {
+		resolved, err = filepath.EvalSymlinks(resolved)
+	}
+	if err != nil {
+		return nil, err
+	}
+	name, err := filepath.Rel(dir, resolved)
+	if err != nil || !filepath.IsLocal(name) {
+		return nil, fmt.Errorf("%w: %s is not in %s", ErrEvalRefused, path, p.EvalScriptDir)
+	}
+	name = filepath.ToSlash(name)
+	pinned, ok := p.EvalScriptHashes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s has no SHA-256 pinned in eval_script_hashes", ErrEvalRefused, name)
+	}
+
+	data, err := os.ReadFile(resolved)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != strings.ToLower(pinned) {
+		return nil, fmt.Errorf("%w: %s doesn't match its pinned SHA-256, it was changed since it was approved", ErrEvalRefused, name)
+	}
+	return data, nil
+}
+
+// AllowsAllHosts reports whether the policy allows every host.
+func (p *Policy) AllowsAllHosts() bool {
+	return p == nil || (len(p.AllowedHosts) == 0 && len(p.BlockedHosts) == 0)
+}
+
+// Allows returns an error when the policy doesn't allow navigating to
+// rawURL. Only http(s) and ws(s) URLs are checked; about:, data: and other
+// URLs without a host are allowed.
+func (p *Policy) Allows(rawURL string) error {
+	if p.AllowsAllHosts() {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "ws", "wss":
+	default:
+		return nil
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if pattern, ok := matchHost(p.BlockedHosts, host); ok {
+		return fmt.Errorf("%s is blocked by the policy (blocked_hosts %q)", host, pattern)
+	}
+	if len(p.AllowedHosts) > 0 {
+		if _, ok := matchHost(p.AllowedHosts, host); !ok {
+			return fmt.Errorf("%s is not in the allowed_hosts of the policy", host)
+		}
+	}
+	return nil
+}
+
+// matchHost returns the first of patterns matching host.
+func matchHost(patterns []string, host string) (string, bool) {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), host); ok {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// GetPolicyPath returns the file holding the host policy.
+func GetPolicyPath() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "policy.json"), nil
+}
+
+// LoadPolicy reads the host policy. Without a policy file, every host is
+// allowed; an invalid one is an error rather than being ignored.
+func LoadPolicy() (*Policy, error) {
+	path, err := GetPolicyPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Policy{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %w", path, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("policy %s: %w", path, err)
+	}
+	return &policy, nil
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestPolicy_Allows はホストポリシーによるURLの許可と拒否をテストします。
+func TestPolicy_Allows(t *testing.T) {
+	policy := &Policy{
+		AllowedHosts: []string{"example.com", "*.example.com"},
+		BlockedHosts: []string{"admin.example.com"},
+	}
+	tests := []struct {
+		url  string
+		want string // 空なら許可
+	}{
+		{"https://example.com/", ""},
+		{"https://docs.example.com:8443/a", ""},
+		{"http://WWW.Example.COM./", ""},
+		{"https://admin.example.com/users", "blocked_hosts"},
+		{"https://example.org/", "allowed_hosts"},
+		{"https://notexample.com/", "allowed_hosts"},
+		{"about:blank", ""},
+		{"data:text/html,hi", ""},
+	}
+	for _, tt := range tests {
+		err := policy.Allows(tt.url)
+		if tt.want == "" && err != nil {
+			t.Errorf("Allows(%q) = %v, want nil", tt.url, err)
+		}
+		if tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)) {
+			t.Errorf("Allows(%q) = %v, want an error mentioning %s", tt.url, err, tt.want)
+		}
+	}
+
+	// 拒否リストだけのポリシーは他のホストをすべて許可する
+	blockOnly := &Policy{BlockedHosts: []string{"*.internal"}}
+	if err := blockOnly.Allows("https://example.org/"); err != nil {
+		t.Errorf("Expected other hosts to be allowed, got %v", err)
+	}
+	if err := blockOnly.Allows("https://wiki.internal/"); err == nil {
+		t.Error("Expected wiki.internal to be blocked")
+	}
+	var none *Policy
+	if err := none.Allows("https://example.org/"); err != nil {
+		t.Errorf("Expected no policy to allow everything, got %v", err)
+	}
+}
+
+// TestLoadPolicy はポリシーファイルの読み込みと検証をテストします。
+func TestLoadPolicy(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	policy, err := LoadPolicy()
+	if err != nil || !policy.AllowsAllHosts() {
+		t.Fatalf("Expected an empty policy without a file, got %+v, %v", policy, err)
+	}
+
+	path, _ := GetPolicyPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	write := func(data string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`{"allowed_hosts": ["*.example.com"], "blocked_hosts": ["admin.example.com"]}`)
+	policy, err = LoadPolicy()
+	if err != nil || len(policy.AllowedHosts) != 1 || len(policy.BlockedHosts) != 1 {
+		t.Errorf("Unexpected policy: %+v, %v", policy, err)
+	}
+
+	for _, data := range []string{
+		`{"allowed_hosts": ["https://example.com/"]}`,
+		`{"blocked_hosts": ["[example.com"]}`,
+		`{"allowed_hosts": "example.com"}`,
+	} {
+		write(data)
+		if _, err := LoadPolicy(); err == nil {
+			t.Errorf("Expected an error for %s", data)
+		}
+	}
+}
+
+// TestPolicy_ReadEvalScript は承認済みディレクトリとハッシュによるスクリプトの検証をテストします。
+func TestPolicy_ReadEvalScript(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "extract.js")
+	if err := os.WriteFile(script, []byte("document.title"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(t.TempDir(), "extract.js")
+	if err := os.WriteFile(outside, []byte("document.title"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256Hex(t, "document.title")
+
+	policy := &Policy{EvalScriptDir: dir, EvalScriptHashes: map[string]string{"extract.js": sum, "other.js": sha256Hex(t, "1")}}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Expected a valid policy, got %v", err)
+	}
+	if data, err := policy.ReadEvalScript(script); err != nil || string(data) != "document.title" {
+		t.Errorf("Expected the approved script to be read, got %q, %v", data, err)
+	}
+
+	link := filepath.Join(dir, "link.js")
+	if err := os.Symlink(outside, link); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{outside, "-", link} {
+		if _, err := policy.ReadEvalScript(path); !errors.Is(err, ErrEvalRefused) {
+			t.Errorf("%s: expected the script to be refused, got %v", path, err)
+		}
+	}
+
+	// 承認後に変更されたスクリプトとハッシュのないスクリプトは拒否される
+	if err := os.WriteFile(script, []byte("document.title = 'x'"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := policy.ReadEvalScript(script); !errors.Is(err, ErrEvalRefused) {
+		t.Errorf("Expected a changed script to be refused, got %v", err)
+	}
+	unpinned := filepath.Join(dir, "new.js")
+	if err := os.WriteFile(unpinned, []byte("1"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := policy.ReadEvalScript(unpinned); !errors.Is(err, ErrEvalRefused) {
+		t.Errorf("Expected an unpinned script to be refused, got %v", err)
+	}
+
+	// 制限のないポリシーはどこからでも読み込む
+	if data, err := (&Policy{}).ReadEvalScript(outside); err != nil || string(data) != "document.title" {
+		t.Errorf("Expected any script without a restriction, got %q, %v", data, err)
+	}
+	for _, invalid := range []*Policy{
+		{EvalScriptDir: "scripts"},
+		{EvalScriptHashes: map[string]string{"a.js": sum}},
+		{EvalScriptDir: dir, EvalScriptHashes: map[string]string{"../a.js": sum}},
+		{EvalScriptDir: dir, EvalScriptHashes: map[string]string{"a.js": "abc"}},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", invalid)
+		}
+	}
+}
+
+func sha256Hex(t *testing.T, s string) string {
+	t.Helper()
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
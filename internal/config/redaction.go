@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultRedactedFields are masked unless Redaction.NoDefaults is set: the
+// cookies and the credentials sent to sites and proxies.
+var DefaultRedactedFields = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Redaction lists what is masked in the logs, network captures and curl
+// commands, so they can be shared without leaking tokens. It is the
+// "redaction" object of the policy:
+//
+//	{"redaction": {"fields": ["X-Api-Key", "access_token"], "patterns": ["sk_live_[0-9A-Za-z]+"]}}
+type Redaction struct {
+	// Fields are the names of headers, URL query parameters and JSON or form
+	// fields whose values are masked. They are matched case-insensitively.
+	Fields []string `json:"fields,omitempty"`
+	// Patterns are regular expressions masked wherever they match.
+	Patterns []string `json:"patterns,omitempty"`
+	// NoDefaults masks only Fields and Patterns, not DefaultRedactedFields.
+	NoDefaults bool `json:"no_defaults,omitempty"`
+}
+
+// Validate checks the fields and compiles the patterns of the redaction.
+func (r *Redaction) Validate() error {
+	for _, field := range r.Fields {
+		if field == "" {
+			return fmt.Errorf("redaction: empty field name")
+		}
+	}
+	for _, pattern := range r.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("redaction: invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// RedactedFields returns the names of the fields masked: the defaults,
+// unless NoDefaults is set, and Fields.
+func (r *Redaction) RedactedFields() []string {
+	var fields []string
+	if !r.NoDefaults {
+		fields = append(fields, DefaultRedactedFields...)
+	}
+	return append(fields, r.Fields...)
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRedaction_Validate はリダクション設定の検証をテストします。
+func TestRedaction_Validate(t *testing.T) {
+	valid := &Redaction{Fields: []string{"X-Api-Key"}, Patterns: []string{`sk_live_\w+`}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected a valid redaction, got %v", err)
+	}
+	invalid := &Policy{Redaction: Redaction{Patterns: []string{"("}}}
+	if err := invalid.Validate(); err == nil || !strings.Contains(err.Error(), "invalid pattern") {
+		t.Errorf("Expected an invalid pattern error, got %v", err)
+	}
+	if err := (&Redaction{Fields: []string{""}}).Validate(); err == nil {
+		t.Error("Expected an error for an empty field name")
+	}
+}
+
+// TestRedaction_RedactedFields は既定でマスクされるフィールドをテストします。
+func TestRedaction_RedactedFields(t *testing.T) {
+	fields := (&Redaction{Fields: []string{"token"}}).RedactedFields()
+	if len(fields) != len(DefaultRedactedFields)+1 || fields[len(fields)-1] != "token" {
+		t.Errorf("Expected the defaults and token, got %v", fields)
+	}
+	fields = (&Redaction{Fields: []string{"token"}, NoDefaults: true}).RedactedFields()
+	if len(fields) != 1 {
+		t.Errorf("Expected only token without the defaults, got %v", fields)
+	}
+}
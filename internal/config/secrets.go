@@ -0,0 +1,103 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Prefixes of secret references: "env:NAME" names an environment variable,
+// "keyring:ENTRY" an entry of the OS keyring.
+const (
+	secretEnvPrefix     = "env:"
+	secretKeyringPrefix = "keyring:"
+)
+
+// KeyringService is the service the tool's entries are stored under in the
+// OS keyring (macOS Keychain, Windows Credential Manager or the Secret
+// Service on Linux).
+const KeyringService = "browser-tools-go"
+
+// IsSecretReference reports whether value refers to a secret kept elsewhere,
+// such as "env:NAME" or "keyring:ENTRY", rather than being the secret itself.
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, secretEnvPrefix) || strings.HasPrefix(value, secretKeyringPrefix)
+}
+
+// ResolveSecret returns the secret a reference points to: "env:NAME" is the
+// value of the environment variable NAME, which must be set and not empty,
+// and "keyring:ENTRY" the secret stored as ENTRY in the OS keyring. Any other
+// value is returned as it is.
+func ResolveSecret(ref string) (string, error) {
+	if entry, ok := strings.CutPrefix(ref, secretKeyringPrefix); ok {
+		return GetKeyringSecret(entry)
+	}
+	name, ok := strings.CutPrefix(ref, secretEnvPrefix)
+	if !ok {
+		return ref, nil
+	}
+	if name == "" {
+		return "", fmt.Errorf("invalid secret reference %q: missing variable name", ref)
+	}
+	value := os.Getenv(name)
+	if value == "" {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
+
+// validateKeyringEntry checks the name of a keyring entry.
+func validateKeyringEntry(entry string) error {
+	if entry == "" || strings.TrimSpace(entry) != entry {
+		return fmt.Errorf("invalid keyring entry %q", entry)
+	}
+	return nil
+}
+
+// GetKeyringSecret returns the secret stored as entry in the OS keyring.
+func GetKeyringSecret(entry string) (string, error) {
+	if err := validateKeyringEntry(entry); err != nil {
+		return "", err
+	}
+	secret, err := keyring.Get(KeyringService, entry)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", fmt.Errorf("no keyring entry %q: store it with 'browser-tools-go secret set %s'", entry, entry)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read keyring entry %q: %w", entry, err)
+	}
+	return secret, nil
+}
+
+// SetKeyringSecret stores secret as entry in the OS keyring, replacing the
+// previous one.
+func SetKeyringSecret(entry, secret string) error {
+	if err := validateKeyringEntry(entry); err != nil {
+		return err
+	}
+	if secret == "" {
+		return fmt.Errorf("empty secret")
+	}
+	if err := keyring.Set(KeyringService, entry, secret); err != nil {
+		return fmt.Errorf("failed to store keyring entry %q: %w", entry, err)
+	}
+	return nil
+}
+
+// DeleteKeyringSecret removes entry from the OS keyring.
+func DeleteKeyringSecret(entry string) error {
+	if err := validateKeyringEntry(entry); err != nil {
+		return err
+	}
+	err := keyring.Delete(KeyringService, entry)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("no keyring entry %q", entry)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete keyring entry %q: %w", entry, err)
+	}
+	return nil
+}
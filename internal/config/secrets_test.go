@@ -0,0 +1,62 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+// TestResolveSecret はシークレット参照の解決をテストします。
+func TestResolveSecret(t *testing.T) {
+	t.Setenv("BROWSER_TOOLS_TEST_SECRET", "s3cret")
+	t.Setenv("BROWSER_TOOLS_TEST_EMPTY", "")
+
+	value, err := ResolveSecret("env:BROWSER_TOOLS_TEST_SECRET")
+	if err != nil || value != "s3cret" {
+		t.Errorf("Expected the environment variable, got %q, %v", value, err)
+	}
+	// 参照でない値はそのまま返す
+	value, err = ResolveSecret("alice@example.com")
+	if err != nil || value != "alice@example.com" {
+		t.Errorf("Expected a plain value to be returned as it is, got %q, %v", value, err)
+	}
+	if _, err := ResolveSecret("env:BROWSER_TOOLS_TEST_EMPTY"); err == nil || !strings.Contains(err.Error(), "BROWSER_TOOLS_TEST_EMPTY") {
+		t.Errorf("Expected an error naming the unset variable, got %v", err)
+	}
+	if _, err := ResolveSecret("env:"); err == nil {
+		t.Error("Expected an error for a reference without a variable name")
+	}
+}
+
+// TestKeyringSecrets はOSキーリングへのシークレットの保存と参照をテストします。
+func TestKeyringSecrets(t *testing.T) {
+	keyring.MockInit()
+
+	if _, err := ResolveSecret("keyring:example-password"); err == nil || !strings.Contains(err.Error(), "secret set example-password") {
+		t.Errorf("Expected an error telling how to store a missing entry, got %v", err)
+	}
+	if err := SetKeyringSecret("example-password", "s3cret"); err != nil {
+		t.Fatalf("Failed to store the secret: %v", err)
+	}
+	value, err := ResolveSecret("keyring:example-password")
+	if err != nil || value != "s3cret" {
+		t.Errorf("Expected the stored secret, got %q, %v", value, err)
+	}
+	if !IsSecretReference("keyring:example-password") {
+		t.Error("Expected keyring: to be a secret reference")
+	}
+
+	if err := DeleteKeyringSecret("example-password"); err != nil {
+		t.Fatalf("Failed to delete the secret: %v", err)
+	}
+	if err := DeleteKeyringSecret("example-password"); err == nil {
+		t.Error("Expected an error when deleting a missing entry")
+	}
+	if err := SetKeyringSecret("", "s3cret"); err == nil {
+		t.Error("Expected an error for an empty entry name")
+	}
+	if err := SetKeyringSecret("example-password", ""); err == nil {
+		t.Error("Expected an error for an empty secret")
+	}
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Site is a site profile, applied to commands by "--site": the base URL
+// relative URLs are resolved against, the credentials and headers sent to
+// the site and named selectors for its pages.
+type Site struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Username and Credential, a secret reference to the password, answer
+	// the HTTP authentication challenges of the site and fill in login
+	// recipes that leave them out.
+	Username   string `json:"username,omitempty"`
+	Credential string `json:"credential,omitempty"`
+	// Headers are sent with every request to the origin of URL only; their
+	// values may be secret references.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Selectors name CSS selectors, given as "@name" in place of a selector.
+	Selectors map[string]string `json:"selectors,omitempty"`
+}
+
+// SiteSelectorPrefix marks a selector naming one of the site's Selectors.
+const SiteSelectorPrefix = "@"
+
+// Validate checks the profile.
+func (s *Site) Validate() error {
+	if err := validateSiteName(s.Name); err != nil {
+		return err
+	}
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http(s) URL, got %q", s.URL)
+	}
+	if s.Credential != "" && !IsSecretReference(s.Credential) {
+		return fmt.Errorf(`credential must be a secret reference such as "keyring:ENTRY", not the password itself`)
+	}
+	for name := range s.Headers {
+		if name == "" || strings.ContainsAny(name, ": \t") {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+	}
+	for name, selector := range s.Selectors {
+		if name == "" || selector == "" {
+			return fmt.Errorf("selectors need a name and a selector, got %q: %q", name, selector)
+		}
+	}
+	return nil
+}
+
+// Origin returns the origin of the site's URL, e.g. "https://example.com".
+func (s *Site) Origin() string {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// ResolveURL resolves ref against the site's URL, unless it is absolute
+// already: "/orders" on the site https://example.com/app/ is
+// https://example.com/orders, "orders" https://example.com/app/orders.
+func (s *Site) ResolveURL(ref string) (string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %q: %w", ref, err)
+	}
+	if u.IsAbs() {
+		return ref, nil
+	}
+	base, err := url.Parse(s.URL)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(u).String(), nil
+}
+
+// ResolveSelector returns the selector "@name" names, or selector itself
+// when it doesn't start with SiteSelectorPrefix.
+func (s *Site) ResolveSelector(selector string) (string, error) {
+	name, ok := strings.CutPrefix(selector, SiteSelectorPrefix)
+	if !ok {
+		return selector, nil
+	}
+	resolved, ok := s.Selectors[name]
+	if !ok {
+		return "", fmt.Errorf("site %q has no selector named %q", s.Name, name)
+	}
+	return resolved, nil
+}
+
+func validateSiteName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid site name: %q", name)
+	}
+	return nil
+}
+
+// GetSitesDir returns the directory holding the site profiles, one
+// <name>.json file per site.
+func GetSitesDir() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sites"), nil
+}
+
+// GetSitePath returns the profile file of a site.
+func GetSitePath(name string) (string, error) {
+	if err := validateSiteName(name); err != nil {
+		return "", err
+	}
+	dir, err := GetSitesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// ErrNoSite is matched by the error of LoadSite for a site without a profile.
+var ErrNoSite = errors.New("no such site")
+
+// LoadSite reads the profile of a site.
+func LoadSite(name string) (*Site, error) {
+	path, err := GetSitePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %q (add it with 'browser-tools-go sites add %s --url <url>')", ErrNoSite, name, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var site Site
+	if err := json.Unmarshal(data, &site); err != nil {
+		return nil, fmt.Errorf("failed to parse site %s: %w", path, err)
+	}
+	site.Name = name
+	return &site, nil
+}
+
+// SaveSite stores the profile of a site, replacing any previous one.
+func SaveSite(site *Site) error {
+	if err := site.Validate(); err != nil {
+		return err
+	}
+	path, err := GetSitePath(site.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(site, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// RemoveSite deletes the profile of a site.
+func RemoveSite(name string) error {
+	path, err := GetSitePath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); os.IsNotExist(err) {
+		return fmt.Errorf("%w: %q", ErrNoSite, name)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListSites returns the site profiles, sorted by name. Unreadable profiles
+// are skipped.
+func ListSites() ([]*Site, error) {
+	dir, err := GetSitesDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sites []*Site
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		site, err := LoadSite(name)
+		if err != nil {
+			continue
+		}
+		sites = append(sites, site)
+	}
+	sort.Slice(sites, func(i, j int) bool { return sites[i].Name < sites[j].Name })
+	return sites, nil
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestSite_Validate はサイトプロファイルの検証をテストします。
+func TestSite_Validate(t *testing.T) {
+	valid := func() *Site {
+		return &Site{Name: "shop", URL: "https://shop.example.com/", Credential: "keyring:shop-password"}
+	}
+	if err := valid().Validate(); err != nil {
+		t.Fatalf("Expected a valid site, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *Site)
+		want   string
+	}{
+		{"path in name", func(s *Site) { s.Name = "../shop" }, "site name"},
+		{"relative url", func(s *Site) { s.URL = "/shop" }, "url"},
+		{"plain credential", func(s *Site) { s.Credential = "hunter2" }, "secret reference"},
+		{"bad header name", func(s *Site) { s.Headers = map[string]string{"X-Key:": "v"} }, "header name"},
+		{"empty selector", func(s *Site) { s.Selectors = map[string]string{"total": ""} }, "selectors"},
+	}
+	for _, tt := range tests {
+		s := valid()
+		tt.modify(s)
+		if err := s.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+// TestSite_Resolve は相対URLと名前付きセレクタの解決をテストします。
+func TestSite_Resolve(t *testing.T) {
+	site := &Site{Name: "shop", URL: "https://shop.example.com/app/", Selectors: map[string]string{"total": "#summary .total"}}
+
+	if got := site.Origin(); got != "https://shop.example.com" {
+		t.Errorf("Origin() = %q", got)
+	}
+	for ref, want := range map[string]string{
+		"/orders":                "https://shop.example.com/orders",
+		"orders?page=2":          "https://shop.example.com/app/orders?page=2",
+		"https://other.test/a":   "https://other.test/a",
+		"http://shop.example.co": "http://shop.example.co",
+	} {
+		if got, err := site.ResolveURL(ref); err != nil || got != want {
+			t.Errorf("ResolveURL(%q) = %q, %v; want %q", ref, got, err, want)
+		}
+	}
+
+	if got, err := site.ResolveSelector("@total"); err != nil || got != "#summary .total" {
+		t.Errorf("ResolveSelector(@total) = %q, %v", got, err)
+	}
+	if got, err := site.ResolveSelector(".price"); err != nil || got != ".price" {
+		t.Errorf("Expected a plain selector to be kept, got %q, %v", got, err)
+	}
+	if _, err := site.ResolveSelector("@missing"); err == nil {
+		t.Error("Expected an error for an unknown selector name")
+	}
+}
+
+// TestSites_SaveLoadRemove はサイトプロファイルの保存・一覧・削除をテストします。
+func TestSites_SaveLoadRemove(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := LoadSite("shop"); !errors.Is(err, ErrNoSite) {
+		t.Errorf("Expected ErrNoSite for a missing site, got %v", err)
+	}
+	if sites, err := ListSites(); err != nil || len(sites) != 0 {
+		t.Errorf("Expected no sites, got %v, %v", sites, err)
+	}
+
+	for _, site := range []*Site{
+		{Name: "shop", URL: "https://shop.example.com/", Headers: map[string]string{"X-Api-Key": "env:SHOP_KEY"}},
+		{Name: "admin", URL: "https://admin.example.com/"},
+	} {
+		if err := SaveSite(site); err != nil {
+			t.Fatalf("Failed to save %s: %v", site.Name, err)
+		}
+	}
+	if err := SaveSite(&Site{Name: "bad", URL: "bad"}); err == nil {
+		t.Error("Expected an invalid site not to be saved")
+	}
+
+	site, err := LoadSite("shop")
+	if err != nil || site.Headers["X-Api-Key"] != "env:SHOP_KEY" {
+		t.Errorf("Unexpected site: %+v, %v", site, err)
+	}
+	path, _ := GetSitePath("shop")
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the profile to be private, got %v, %v", info, err)
+	}
+
+	sites, err := ListSites()
+	if err != nil || len(sites) != 2 || sites[0].Name != "admin" || sites[1].Name != "shop" {
+		t.Errorf("Expected admin and shop, got %v, %v", sites, err)
+	}
+
+	if err := RemoveSite("shop"); err != nil {
+		t.Fatalf("Failed to remove shop: %v", err)
+	}
+	if err := RemoveSite("shop"); !errors.Is(err, ErrNoSite) {
+		t.Errorf("Expected ErrNoSite for a removed site, got %v", err)
+	}
+}
+
+// TestLoadLoginRecipe_Site はサイトプロファイルによるログインレシピの補完をテストします。
+func TestLoadLoginRecipe_Site(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	site := &Site{
+		Name: "shop", URL: "https://shop.example.com/", Username: "alice", Credential: "keyring:shop-password",
+		Selectors: map[string]string{"email": "#email"},
+	}
+	if err := SaveSite(site); err != nil {
+		t.Fatal(err)
+	}
+	dir, _ := GetLoginsDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	recipe := `{"url": "/login", "usernameSelector": "@email", "passwordSelector": "#password", "success": {"selector": ".avatar"}}`
+	if err := os.WriteFile(filepath.Join(dir, "shop.json"), []byte(recipe), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadLoginRecipe("shop")
+	if err != nil {
+		t.Fatalf("Failed to load recipe: %v", err)
+	}
+	if loaded.URL != "https://shop.example.com/login" || loaded.UsernameSelector != "#email" ||
+		loaded.Username != "alice" || loaded.Password != "keyring:shop-password" {
+		t.Errorf("Expected the recipe to be completed from the site, got %+v", loaded)
+	}
+}
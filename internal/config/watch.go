@@ -0,0 +1,70 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// WatchSnapshot is the last observed content of a watched page region.
+type WatchSnapshot struct {
+	URL        string    `json:"url"`
+	Selector   string    `json:"selector,omitempty"`
+	Hash       string    `json:"hash"`
+	Content    string    `json:"content"`
+	CapturedAt time.Time `json:"capturedAt"`
+}
+
+// GetWatchSnapshotPath returns the snapshot file used for a URL and selector pair.
+func GetWatchSnapshotPath(url, selector string) (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(url + "\n" + selector))
+	return filepath.Join(dir, "watch", hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// LoadWatchSnapshot reads the stored snapshot for a URL and selector.
+// It returns nil without an error when no snapshot has been taken yet.
+func LoadWatchSnapshot(url, selector string) (*WatchSnapshot, error) {
+	path, err := GetWatchSnapshotPath(url, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot WatchSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// SaveWatchSnapshot stores the snapshot, replacing any previous one for the same URL and selector.
+func SaveWatchSnapshot(snapshot *WatchSnapshot) error {
+	path, err := GetWatchSnapshotPath(snapshot.URL, snapshot.Selector)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// TestWatchSnapshot_SaveAndLoad はウォッチスナップショットの保存と読み込みをテストします。
+func TestWatchSnapshot_SaveAndLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	// 未保存の場合はnilを返す
+	snapshot, err := LoadWatchSnapshot("https://example.com", "#price")
+	if err != nil {
+		t.Fatalf("Expected no error for missing snapshot, got %v", err)
+	}
+	if snapshot != nil {
+		t.Fatal("Expected nil snapshot before first save")
+	}
+
+	saved := &WatchSnapshot{
+		URL:        "https://example.com",
+		Selector:   "#price",
+		Hash:       "abc",
+		Content:    "$10",
+		CapturedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	if err := SaveWatchSnapshot(saved); err != nil {
+		t.Fatalf("Failed to save snapshot: %v", err)
+	}
+
+	loaded, err := LoadWatchSnapshot("https://example.com", "#price")
+	if err != nil {
+		t.Fatalf("Failed to load snapshot: %v", err)
+	}
+	if loaded == nil || loaded.Content != "$10" || !loaded.CapturedAt.Equal(saved.CapturedAt) {
+		t.Errorf("Loaded snapshot does not match saved one: %+v", loaded)
+	}
+
+	// セレクタが異なれば別のスナップショット
+	other, err := LoadWatchSnapshot("https://example.com", "#stock")
+	if err != nil || other != nil {
+		t.Errorf("Expected no snapshot for a different selector, got %+v (err=%v)", other, err)
+	}
+}
@@ -0,0 +1,278 @@
+package i18n
+
+// catalogJa holds the Japanese messages, keyed by the English format string.
+// Keep the verbs (%s, %d, ...) in the same order as the key.
+var catalogJa = map[string]string{
+	// Browser lifecycle
+	"✅ Browser WebSocket is ready.":                                              "✅ ブラウザのWebSocketが利用可能になりました。",
+	"⏳ Waiting for browser to be ready at %s...":                                 "⏳ ブラウザの準備を待っています (%s)...",
+	"✅ Browser started successfully with PID %d.":                                "✅ ブラウザを起動しました (PID %d)。",
+	"🛑 Closing browser with PID %d...":                                           "🛑 ブラウザを終了しています (PID %d)...",
+	"⚠️ Could not close the browser over DevTools: %v. Terminating the process.": "⚠️ DevTools経由でブラウザを終了できませんでした: %v。プロセスを終了します。",
+	"⚠️ Browser still running after %v. Terminating the process.":                "⚠️ %v経ってもブラウザが動作しています。プロセスを終了します。",
+	"✅ Browser session closed and cleaned up.":                                   "✅ ブラウザセッションを終了し、後片付けしました。",
+	"✗ Failed to start browser: %v":                                              "✗ ブラウザを起動できませんでした: %v",
+	"✗ Failed to close browser: %v":                                              "✗ ブラウザを終了できませんでした: %v",
+	"✗ Browser is not running (start with 'browser-tools-go start'): %v":         "✗ ブラウザが起動していません ('browser-tools-go start' で起動してください): %v",
+	"✗ Failed to save session info: %v":                                          "✗ セッション情報を保存できませんでした: %v",
+	"🚀 Starting temporary browser...":                                            "🚀 一時ブラウザを起動しています...",
+	"✗ Failed to create temporary browser: %v":                                   "✗ 一時ブラウザを作成できませんでした: %v",
+	"✅ Temporary browser closed.":                                                "✅ 一時ブラウザを終了しました。",
+	"Failed to marshal result: %v":                                               "結果をJSONに変換できませんでした: %v",
+	"Warning: %v":                                                                "警告: %v",
+	"Retry attempt %d after error: %v":                                           "リトライ %d 回目 (エラー: %v)",
+	"Retry %d/3 for %s: %v":                                                      "リトライ %d/3 (%s): %v",
+
+	"✗ Dry run found %d problem(s)": "✗ ドライランで %d 件の問題が見つかりました",
+
+	// Navigation and screenshots
+	"🚀 Navigating to %s...":                      "🚀 %s に移動しています...",
+	"⚠️ %s responded with %d %s":                 "⚠️ %s の応答: %d %s",
+	"✅ Navigation successful.":                   "✅ 移動しました。",
+	"✗ Failed to navigate: %v":                   "✗ 移動できませんでした: %v",
+	"🔀 Tracing redirects from %s...":             "🔀 %s からのリダイレクトを追跡しています...",
+	"✗ Failed to trace redirects: %v":            "✗ リダイレクトを追跡できませんでした: %v",
+	"✅ %d hops, final destination: %s":           "✅ %d ホップ、最終的な移動先: %s",
+	"📸 Taking screenshots...":                    "📸 スクリーンショットを撮影しています...",
+	"✗ Failed to take screenshots: %v":           "✗ スクリーンショットを撮影できませんでした: %v",
+	"📸 Taking screenshot...":                     "📸 スクリーンショットを撮影しています...",
+	"🎨 Emulating the %s color scheme...":         "🎨 %s カラースキームをエミュレートしています...",
+	"✗ Failed to take screenshot: %v":            "✗ スクリーンショットを撮影できませんでした: %v",
+	"✅ Screenshot saved to: %s":                  "✅ スクリーンショットを保存しました: %s",
+	"⚠️ Failed to record navigation history: %v": "⚠️ 閲覧履歴を記録できませんでした: %v",
+	"✗ Failed to clear history: %v":              "✗ 閲覧履歴を消去できませんでした: %v",
+	"✅ Navigation history cleared.":              "✅ 閲覧履歴を消去しました。",
+	"✗ Failed to load history: %v":               "✗ 閲覧履歴を読み込めませんでした: %v",
+	"✗ No browser session is running (use --all to list the history of past sessions)": "✗ ブラウザセッションが起動していません (過去のセッションの履歴は --all で表示できます)",
+	"⏱️ Benchmarking %s (%d %s runs)...":                                               "⏱️ %s のベンチマークを実行しています (%d 回, %s)...",
+	"⏱️ Run %d/%d: %.0f ms":                                                            "⏱️ 実行 %d/%d: %.0f ms",
+	"✗ Benchmark failed: %v":                                                           "✗ ベンチマークに失敗しました: %v",
+	"✗ Failed to encode baseline: %v":                                                  "✗ ベースラインをエンコードできませんでした: %v",
+	"✗ Failed to write baseline to %s: %v":                                             "✗ ベースラインを %s に書き込めませんでした: %v",
+	"💾 Baseline saved to %s":                                                           "💾 ベースラインを %s に保存しました",
+	"✗ %d metric(s) regressed by more than %g%% against %s":                            "✗ %d 個の指標が %g%% を超えて悪化しました (ベースライン: %s)",
+	"⚖️ Comparing %s with %s...":                                                       "⚖️ %s と %s を比較しています...",
+	"✗ Comparison failed: %v":                                                          "✗ 比較に失敗しました: %v",
+	"✅ Zoom set to %g%%.":                                                              "✅ ズームを %g%% に設定しました。",
+	"✗ %d console error(s) during the load of %s":                                      "✗ %d 件のコンソールエラーが %s の読み込み中に発生しました",
+
+	// Interaction
+	"🔍 Picking elements with selector: %s (all=%t)...":  "🔍 セレクタ %s で要素を取得しています (all=%t)...",
+	"✗ Failed to pick elements: %v":                     "✗ 要素を取得できませんでした: %v",
+	"✅ No elements found.":                              "✅ 要素は見つかりませんでした。",
+	"⏳ Waiting %s...":                                   "⏳ %s 待機しています...",
+	"⏳ Waiting for %s...":                               "⏳ %s を待っています...",
+	"✅ %s is visible.":                                  "✅ %s が表示されました。",
+	"✗ No selector given":                               "✗ セレクタが指定されていません",
+	"✅ %s set.":                                         "✅ %s を設定しました。",
+	"📋 Copied %d characters to the clipboard.":          "📋 %d 文字をクリップボードにコピーしました。",
+	"✗ Failed to read stdin: %v":                        "✗ 標準入力を読み込めませんでした: %v",
+	"✗ Failed to read args: %v":                         "✗ 引数を読み込めませんでした: %v",
+	"✗ Failed to read script: %v":                       "✗ スクリプトを読み込めませんでした: %v",
+	"📝 Evaluating script: %s":                           "📝 スクリプトを評価しています: %s",
+	"📝 Evaluating JavaScript: %s":                       "📝 JavaScriptを評価しています: %s",
+	"✅ Result saved to %s (%d bytes)":                   "✅ 結果を %s に保存しました (%d バイト)",
+	"✗ Failed to write result to %s: %v":                "✗ 結果を %s に書き込めませんでした: %v",
+	"✗ Failed to evaluate JavaScript: %v":               "✗ JavaScriptを評価できませんでした: %v",
+	"✗ Failed to evaluate script: %v":                   "✗ スクリプトを評価できませんでした: %v",
+	"Warning: dropped a call of %s (output too slow)":   "警告: %s の呼び出しを破棄しました (出力が追いつきません)",
+	"🔗 Listening for calls of %s()...":                  "🔗 %s() の呼び出しを待ち受けています...",
+	"✅ Received %d call(s).":                            "✅ %d 件の呼び出しを受信しました。",
+	"🖍️ Highlighting %d element(s) for %s...":           "🖍️ %d 個の要素を %s の間ハイライトしています...",
+	"Warning: could not remove highlights: %v":          "警告: ハイライトを解除できませんでした: %v",
+	"✅ Highlight removed.":                              "✅ ハイライトを解除しました。",
+	"Warning: could not remove binding %s: %v":          "警告: バインディング %s を削除できませんでした: %v",
+	"Warning: could not remove args from the page: %v":  "警告: ページから引数を削除できませんでした: %v",
+	"Warning: no visible elements matched %s":           "警告: %s に一致する表示中の要素がありません",
+	"Warning: failed to restore the device metrics: %v": "警告: デバイスメトリクスを元に戻せませんでした: %v",
+	"📜 Running %s":                                      "📜 %s を実行しています",
+
+	// Storage and cookies
+	"🗄️ Listing IndexedDB databases...":                      "🗄️ IndexedDBのデータベースを一覧しています...",
+	"🗄️ Dumping IndexedDB records...":                        "🗄️ IndexedDBのレコードを出力しています...",
+	"🧹 Clearing browsing data...":                            "🧹 閲覧データを消去しています...",
+	"✅ Cleared: %s":                                          "✅ 消去しました: %s",
+	"💾 Saving storage state...":                              "💾 ストレージの状態を保存しています...",
+	"✗ Failed to encode state: %v":                           "✗ 状態をエンコードできませんでした: %v",
+	"✗ Failed to write state to %s: %v":                      "✗ 状態を %s に書き込めませんでした: %v",
+	"✅ Saved %d cookie(s) and storage of %d origin(s) to %s": "✅ %d 件のCookieと %d オリジンのストレージを %s に保存しました",
+	"✗ Failed to read state file: %v":                        "✗ 状態ファイルを読み込めませんでした: %v",
+	"📂 Loading storage state from %s...":                     "📂 %s からストレージの状態を読み込んでいます...",
+	"✅ Storage state loaded.":                                "✅ ストレージの状態を読み込みました。",
+	"✗ Failed to load storage state: %v":                     "✗ ストレージの状態を読み込めませんでした: %v",
+	"📂 Loaded storage state from %s":                         "📂 %s からストレージの状態を読み込みました",
+	"Warning: session storage of %s is only restored while a page of that origin is open": "警告: %s のセッションストレージは、そのオリジンのページを開いている間だけ復元されます",
+	"⚙️ Listing service workers...":                                                       "⚙️ Service Workerを一覧しています...",
+	"🗑️ Unregistering service workers...":                                                 "🗑️ Service Workerの登録を解除しています...",
+	"✅ Unregistered %d service worker(s).":                                                "✅ %d 件のService Workerの登録を解除しました。",
+	"🔄 Updating service workers...":                                                       "🔄 Service Workerを更新しています...",
+	"✅ Requested update of %d service worker(s).":                                         "✅ %d 件のService Workerの更新を要求しました。",
+	"🌐 Retrieving cookies...":                                                             "🌐 Cookieを取得しています...",
+	"✗ Failed to get cookies: %v":                                                         "✗ Cookieを取得できませんでした: %v",
+	"🍪 Setting cookie: %s":                                                                "🍪 Cookieを設定しています: %s",
+	"✅ Cookie set.":                                                                       "✅ Cookieを設定しました。",
+	"🗑️ Deleting cookie: %s":                                                              "🗑️ Cookieを削除しています: %s",
+	"✅ Deleted %d cookie(s).":                                                             "✅ %d 件のCookieを削除しました。",
+	"🗑️ Clearing cookies...":                                                              "🗑️ Cookieを消去しています...",
+	"📤 Exporting cookies (format: %s)...":                                                 "📤 Cookieを書き出しています (形式: %s)...",
+	"✗ Failed to write cookies to %s: %v":                                                 "✗ Cookieを %s に書き込めませんでした: %v",
+	"✅ Cookies saved to %s":                                                               "✅ Cookieを %s に保存しました",
+	"✗ Failed to read cookie file: %v":                                                    "✗ Cookieファイルを読み込めませんでした: %v",
+	"✗ Failed to parse cookie file: %v":                                                   "✗ Cookieファイルを解析できませんでした: %v",
+	"📥 Importing %d cookie(s)...":                                                         "📥 %d 件のCookieを取り込んでいます...",
+	"✅ Cookies imported.":                                                                 "✅ Cookieを取り込みました。",
+
+	// Batch, tabs and plugins
+	"✗ Failed to read commands: %v":        "✗ コマンドを読み込めませんでした: %v",
+	"⏹️ Stopping after line %d failed: %v": "⏹️ %d 行目が失敗したため中止します: %v",
+	"✗ Step %d (%s) failed: %v":            "✗ ステップ %d (%s) が失敗しました: %v",
+	"🗂️ Running in %d tabs...":             "🗂️ %d 個のタブで実行しています...",
+	"⚠️ Tab %s (%s): %v":                   "⚠️ タブ %s (%s): %v",
+	"⚠️ Failed to list plugins: %v":        "⚠️ プラグインを一覧できませんでした: %v",
+	"✗ Plugin %s: %v":                      "✗ プラグイン %s: %v",
+	"✗ Failed to list plugins: %v":         "✗ プラグインを一覧できませんでした: %v",
+
+	// Scraping and crawling
+	"🔍 Searching Google for: %s (results: %d, content: %t)":                           "🔍 Googleで検索しています: %s (件数: %d, 本文: %t)",
+	"✗ Failed to perform search: %v":                                                  "✗ 検索できませんでした: %v",
+	"📄 Extracting content (format: %s)":                                               "📄 コンテンツを抽出しています (形式: %s)",
+	"✗ Failed to load selector config: %v":                                            "✗ セレクタ設定を読み込めませんでした: %v",
+	"✗ Failed to extract content: %v":                                                 "✗ コンテンツを抽出できませんでした: %v",
+	"📄 Reading raw source...":                                                         "📄 元のソースを読み込んでいます...",
+	"📄 Serializing rendered source...":                                                "📄 描画後のソースを書き出しています...",
+	"📰 Scraping Hacker News (limit: %d)...":                                           "📰 Hacker Newsを取得しています (件数: %d)...",
+	"✗ Failed to scrape Hacker News: %v":                                              "✗ Hacker Newsを取得できませんでした: %v",
+	"✅ Crawl %s already completed (%d pages).":                                        "✅ クロール %s は完了済みです (%d ページ)。",
+	"🔁 Resuming crawl %s (%d pages done, %d queued)...":                               "🔁 クロール %s を再開しています (完了 %d ページ、待機 %d 件)...",
+	"🕷️ Crawling %s (run id: %s)...":                                                  "🕷️ %s をクロールしています (実行ID: %s)...",
+	"⏸️ Crawl interrupted. Resume with: browser-tools-go crawl --resume %s":           "⏸️ クロールを中断しました。再開するには: browser-tools-go crawl --resume %s",
+	"✗ Crawl failed: %v":                                                              "✗ クロールに失敗しました: %v",
+	"✅ Crawl %s completed (%d pages).":                                                "✅ クロール %s が完了しました (%d ページ)。",
+	"⏭️ Skipping %s (seen in a previous run)":                                         "⏭️ %s をスキップします (以前の実行で取得済み)",
+	"⏭️ Skipped %d item(s) seen in previous runs.":                                    "⏭️ 以前の実行で取得済みの %d 件をスキップしました。",
+	"Warning: could not collect links from %s: %v":                                    "警告: %s のリンクを収集できませんでした: %v",
+	"Warning: could not fetch content for %s: %v":                                     "警告: %s のコンテンツを取得できませんでした: %v",
+	"Warning: could not extract content from %s: %v":                                  "警告: %s からコンテンツを抽出できませんでした: %v",
+	"Warning: could not look for a next page: %v":                                     "警告: 次のページを探せませんでした: %v",
+	"📄 Following next page: %s":                                                       "📄 次のページに進みます: %s",
+	"Selector '%s' matched nothing, falling back to '%s'":                             "セレクタ '%s' に一致する要素がないため '%s' を使います",
+	"Selector '%s' could not be evaluated: %v":                                        "セレクタ '%s' を評価できませんでした: %v",
+	"Repaired UTF-8 content mislabeled as %s":                                         "%s と誤って宣言されたUTF-8のコンテンツを修復しました",
+	"Fallback selector failed: %s":                                                    "フォールバックセレクタが失敗しました: %s",
+	"Successfully extracted %d results with selectors: item=%s, title=%s, snippet=%s": "%d 件の結果を抽出しました (セレクタ: item=%s, title=%s, snippet=%s)",
+	"Selector strategy failed: item=%s, title=%s, snippet=%s: %v":                     "セレクタの組み合わせが失敗しました: item=%s, title=%s, snippet=%s: %v",
+	"Failed to extract from item %d: %v":                                              "項目 %d から抽出できませんでした: %v",
+
+	// Watching and diffing
+	"👀 Watching %s (selector: %q, interval: %s)...":                          "👀 %s を監視しています (セレクタ: %q, 間隔: %s)...",
+	"📸 Stored initial snapshot.":                                             "📸 最初のスナップショットを保存しました。",
+	"🔔 Change detected (+%d/-%d lines).":                                     "🔔 変更を検出しました (+%d/-%d 行)。",
+	"✗ Watch failed: %v":                                                     "✗ 監視に失敗しました: %v",
+	"🚀 Extracting live content from %s...":                                   "🚀 %s から現在のコンテンツを抽出しています...",
+	"✗ Failed to extract live content: %v":                                   "✗ 現在のコンテンツを抽出できませんでした: %v",
+	"🔍 %d field change(s), +%d/-%d content lines.":                           "🔍 フィールドの変更 %d 件、本文 +%d/-%d 行。",
+	"✅ No differences.":                                                      "✅ 差分はありません。",
+	"Warning: watch check failed: %v":                                        "警告: 監視のチェックに失敗しました: %v",
+	"⏭️ Content matches a previously notified state, skipping notification.": "⏭️ 以前に通知した状態と同じ内容のため、通知を省略します。",
+	"Warning: could not encode change event: %v":                             "警告: 変更イベントをエンコードできませんでした: %v",
+
+	// Network
+	"📡 Capturing network traffic of %s...":            "📡 %s の通信を記録しています...",
+	"📡 Capturing network traffic for %s...":           "📡 %s の間、通信を記録しています...",
+	"✅ Captured %d request(s), %d served from cache.": "✅ %d 件のリクエストを記録しました (キャッシュから %d 件)。",
+	"✅ Browser cache disabled for this session.":      "✅ このセッションのブラウザキャッシュを無効にしました。",
+	"✅ Browser cache enabled.":                        "✅ ブラウザキャッシュを有効にしました。",
+	"🌐 Fetching %s %s in page...":                     "🌐 ページ内で %s %s を取得しています...",
+	"📥 Downloading %s...":                             "📥 %s をダウンロードしています...",
+	"✗ Download failed: %v":                           "✗ ダウンロードに失敗しました: %v",
+	"✅ Saved %s (%d bytes)":                           "✅ %s を保存しました (%d バイト)",
+	"✗ Failed to read HAR file: %v":                   "✗ HARファイルを読み込めませんでした: %v",
+	"✅ Converted %d request(s).":                      "✅ %d 件のリクエストを変換しました。",
+	"✗ Failed to read query: %v":                      "✗ クエリを読み込めませんでした: %v",
+	"✗ Failed to read variables: %v":                  "✗ 変数を読み込めませんでした: %v",
+	"🔷 Running GraphQL operation against %s...":       "🔷 %s に対してGraphQLを実行しています...",
+	"⚠️ GraphQL returned %d error(s).":                "⚠️ GraphQLが %d 件のエラーを返しました。",
+	"🎯 Waiting for responses matching %q...":          "🎯 %q に一致するレスポンスを待っています...",
+	"✅ Captured %d response(s).":                      "✅ %d 件のレスポンスを記録しました。",
+	"Warning: dropped response %s (too many pending)": "警告: レスポンス %s を破棄しました (未処理が多すぎます)",
+	"Warning: could not read body of %s: %v":          "警告: %s の本文を読み込めませんでした: %v",
+
+	// Archiving and images
+	"🗄️ Capturing MHTML snapshot...":                                       "🗄️ MHTMLスナップショットを取得しています...",
+	"✅ MHTML snapshot saved to: %s":                                        "✅ MHTMLスナップショットを保存しました: %s",
+	"🗄️ Saving page and resources...":                                      "🗄️ ページとリソースを保存しています...",
+	"⚠️ Could not save %s":                                                 "⚠️ %s を保存できませんでした",
+	"✅ Page saved to: %s (%d resources)":                                   "✅ ページを保存しました: %s (リソース %d 件)",
+	"🗄️ Recording for %s...":                                               "🗄️ %s の間記録しています...",
+	"✅ Archived %d requests to: %s":                                        "✅ %d 件のリクエストをアーカイブしました: %s",
+	"🗄️ Archived %d requests to: %s":                                       "🗄️ %d 件のリクエストをアーカイブしました: %s",
+	"⚠️ Failed to close WARC file: %v":                                     "⚠️ WARCファイルを閉じられませんでした: %v",
+	"⚠️ Failed to write WARC record for %s: %v":                            "⚠️ %s のWARCレコードを書き込めませんでした: %v",
+	"⚠️ %d requests were not archived because the WARC writer fell behind": "⚠️ WARCの書き込みが追いつかず、%d 件のリクエストをアーカイブできませんでした",
+	"📷 Capturing the page for decoding...":                                 "📷 読み取りのためにページを撮影しています...",
+	"✅ No QR codes or barcodes found.":                                     "✅ QRコードやバーコードは見つかりませんでした。",
+	"✅ Saved to %s":                                                        "✅ %s に保存しました",
+
+	// Delivery, metrics and tracing
+	"📤 Sending results to %s...":                                    "📤 結果を %s に送信しています...",
+	"✗ Failed to deliver webhook: %v":                               "✗ Webhookを送信できませんでした: %v",
+	"📤 Uploading %s to %s...":                                       "📤 %s を %s にアップロードしています...",
+	"✗ Failed to upload artifact: %v":                               "✗ 成果物をアップロードできませんでした: %v",
+	"✅ Uploaded to %s":                                              "✅ %s にアップロードしました",
+	"✗ Failed to listen on %s: %v":                                  "✗ %s で待ち受けできませんでした: %v",
+	"✗ Failed to save metrics server info: %v":                      "✗ メトリクスサーバーの情報を保存できませんでした: %v",
+	"📈 Serving metrics on http://%s/metrics":                        "📈 http://%s/metrics でメトリクスを公開しています",
+	"⚠️ Failed to remove metrics server info: %v":                   "⚠️ メトリクスサーバーの情報を削除できませんでした: %v",
+	"✗ Metrics server failed: %v":                                   "✗ メトリクスサーバーが異常終了しました: %v",
+	"✅ Metrics server stopped.":                                     "✅ メトリクスサーバーを停止しました。",
+	"⚠️ Failed to export traces: %v":                                "⚠️ トレースを送信できませんでした: %v",
+	"⚠️ Tracing disabled: %v":                                       "⚠️ トレースを無効にしました: %v",
+	"⚠️ Failed to save failure artifacts: %v":                       "⚠️ 失敗時の成果物を保存できませんでした: %v",
+	"🧾 Failure artifacts saved to %s":                               "🧾 失敗時の成果物を %s に保存しました",
+	"💡 Run again with --auto-recover to replace the crashed tab":    "💡 クラッシュしたタブを置き換えるには --auto-recover を付けて再実行してください",
+	"⚠️ Failed to recover from the crash: %v":                       "⚠️ クラッシュから復旧できませんでした: %v",
+	"🔁 Opened a new automation tab in place of the crashed one":     "🔁 クラッシュしたタブの代わりに新しい自動操作用タブを開きました",
+	"🛡️ Auditing the security headers of %s...":                     "🛡️ %s のセキュリティヘッダーを監査しています...",
+	"✗ Audit failed: %v":                                            "✗ 監査に失敗しました: %v",
+	"📋 Score %d/%d (grade %s)":                                      "📋 スコア %d/%d（評価 %s）",
+	"🛡️ Auditing the mixed content of %s...":                        "🛡️ %s の混在コンテンツを監査しています...",
+	"⚠️ %d insecure request(s): %d loaded, %d blocked, %d upgraded": "⚠️ 安全でないリクエストが %d 件あります: 読み込み %d 件、ブロック %d 件、アップグレード %d 件",
+	"✅ No mixed content.":                                           "✅ 混在コンテンツはありません。",
+	"🔒 Retrieving the certificate of %s...":                         "🔒 %s の証明書を取得しています...",
+	"✗ Failed to retrieve the certificate: %v":                      "✗ 証明書を取得できませんでした: %v",
+	"⚠️ The certificate expires in %d day(s), on %s":                "⚠️ 証明書はあと %d 日、%s に期限切れになります",
+	"🛡️ Auditing the assets of %s...":                               "🛡️ %s のアセットを監査しています...",
+	"⚠️ %d of %d asset(s) are broken":                               "⚠️ %d 件のアセットが壊れています（全 %d 件中）",
+	"✅ No broken assets.":                                           "✅ 壊れたアセットはありません。",
+	"✗ %d budget(s) exceeded by %s":                                 "✗ %d 件の予算を %s が超過しました",
+	"⚖️ Weighing %s...":                                             "⚖️ %s の重さを量っています...",
+	"✗ Failed to weigh the page: %v":                                "✗ ページの重さを量れませんでした: %v",
+	"📦 %d requests, %d bytes transferred, %d bytes decoded":         "📦 リクエスト %d 件、転送 %d バイト、展開後 %d バイト",
+	"🛡️ Auditing the third parties of %s...":                        "🛡️ %s のサードパーティを監査しています...",
+	"📋 %d third part(ies) besides %s":                               "📋 サードパーティ %d 件（%s 以外）",
+	"🔑 Logging in to %s...":                                         "🔑 %s にログインしています...",
+	"✅ Logged in to %s.":                                            "✅ %s にログインしました。",
+	"✗ Login failed: %v":                                            "✗ ログインに失敗しました: %v",
+	"🔑 Verification code for %s: ":                                  "🔑 %s の確認コード: ",
+	"✗ Failed to generate the TOTP code: %v":                        "✗ TOTP コードを生成できませんでした: %v",
+	"✅ Typed into %s.":                                              "✅ %s に入力しました。",
+	"Press Enter to continue":                                       "Enter キーを押すと続行します",
+	"▶️ Resumed after %s":                                           "▶️ %s 経過後に再開しました",
+	"✅ Stored keyring:%s.":                                          "✅ keyring:%s を保存しました。",
+	"🗑️ Deleted keyring:%s.":                                        "🗑️ keyring:%s を削除しました。",
+	"🔑 Secret for keyring:%s: ":                                     "🔑 keyring:%s のシークレット: ",
+	"✅ Saved site %s.":                                              "✅ サイト %s を保存しました。",
+	"🗑️ Removed site %s.":                                           "🗑️ サイト %s を削除しました。",
+	"👀 Evaluating every %s, press Ctrl+C to stop...":                "👀 %s ごとに評価しています。Ctrl+C で停止します...",
+	"Warning: evaluation failed: %v":                                "警告: 評価に失敗しました: %v",
+	"⚠️ %v. Removing the session anyway.":                           "⚠️ %v。セッションを削除します。",
+	"⚠️ Killing browser process %d.":                                "⚠️ ブラウザのプロセス %d を強制終了します。",
+	"🏓 %s answered in %.1f ms":                                      "🏓 %s が %.1f ms で応答しました",
+	"⚠️ Ping failed: %s":                                            "⚠️ ping に失敗しました: %s",
+	"✗ Ping failed: %v":                                             "✗ ping に失敗しました: %v",
+	"💾 Content saved to %s":                                         "💾 コンテンツを %s に保存しました",
+	"✗ Failed to save %s: %v":                                       "✗ %s を保存できませんでした: %v",
+	"⚠️ Failed to save %s: %v":                                      "⚠️ %s を保存できませんでした: %v",
+	"⚠️ Nothing listens at %s; removing the stale session without signalling process %d.": "⚠️ %s で待ち受けていないため、プロセス %d にシグナルを送らずに古いセッションを削除します。",
+	"⚠️ Failed to deliver %s to the webhook: %v":                                          "⚠️ %s をWebhookに送信できませんでした: %v",
+}
@@ -0,0 +1,97 @@
+// Package i18n translates the user-facing messages of the CLI.
+//
+// Messages are keyed by their English format string, so code keeps logging
+// English text and untranslated messages fall back to it:
+//
+//	i18n.Printf("✅ Saved to %s", path)
+package i18n
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// Supported languages.
+const (
+	English  = "en"
+	Japanese = "ja"
+)
+
+// catalogs map English format strings to their translations, per language.
+var catalogs = map[string]map[string]string{
+	Japanese: catalogJa,
+}
+
+// lang is the language messages are printed in.
+var lang = DetectLang()
+
+// DetectLang returns the language of the environment's locale, taken from
+// LC_ALL, LC_MESSAGES or LANG like gettext does; English by default.
+func DetectLang() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			if l, err := normalize(value); err == nil {
+				return l
+			}
+			return English
+		}
+	}
+	return English
+}
+
+// normalize maps a language or locale name such as "ja_JP.UTF-8" to a
+// supported language.
+func normalize(name string) (string, error) {
+	base := strings.ToLower(name)
+	if i := strings.IndexAny(base, "_-.@"); i >= 0 {
+		base = base[:i]
+	}
+	switch base {
+	case English, "c", "posix":
+		return English, nil
+	case Japanese:
+		return Japanese, nil
+	}
+	return "", fmt.Errorf("unsupported language %q (expected en or ja)", name)
+}
+
+// Lang returns the current language.
+func Lang() string {
+	return lang
+}
+
+// SetLang switches the language; name is "en", "ja" or a locale name.
+func SetLang(name string) error {
+	l, err := normalize(name)
+	if err != nil {
+		return err
+	}
+	lang = l
+	return nil
+}
+
+// T returns the translation of the English format string in the current
+// language, or format itself when there is none.
+func T(format string) string {
+	if translated, ok := catalogs[lang][format]; ok {
+		return translated
+	}
+	return format
+}
+
+// Sprintf formats the translation of format.
+func Sprintf(format string, v ...interface{}) string {
+	return fmt.Sprintf(T(format), v...)
+}
+
+// Printf logs the translation of format.
+func Printf(format string, v ...interface{}) {
+	log.Print(Sprintf(format, v...))
+}
+
+// Println logs the translation of message.
+func Println(message string) {
+	log.Print(T(message))
+}
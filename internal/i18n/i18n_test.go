@@ -0,0 +1,98 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// TestDetectLang は環境変数からの言語判定をテストします。
+func TestDetectLang(t *testing.T) {
+	tests := []struct {
+		name       string
+		lcAll      string
+		lcMessages string
+		lang       string
+		want       string
+	}{
+		{"unset", "", "", "", English},
+		{"japanese LANG", "", "", "ja_JP.UTF-8", Japanese},
+		{"english LANG", "", "", "en_US.UTF-8", English},
+		{"C locale", "", "", "C", English},
+		{"LC_ALL wins", "en_US.UTF-8", "", "ja_JP.UTF-8", English},
+		{"LC_MESSAGES before LANG", "", "ja_JP.UTF-8", "en_US.UTF-8", Japanese},
+		{"unsupported", "", "", "fr_FR.UTF-8", English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_MESSAGES", tt.lcMessages)
+			t.Setenv("LANG", tt.lang)
+			if got := DetectLang(); got != tt.want {
+				t.Errorf("DetectLang() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSetLang は言語の切り替えと不正な言語の拒否をテストします。
+func TestSetLang(t *testing.T) {
+	defer SetLang(Lang())
+
+	for _, name := range []string{"ja", "JA", "ja_JP.UTF-8"} {
+		if err := SetLang(name); err != nil {
+			t.Fatalf("SetLang(%q) failed: %v", name, err)
+		}
+		if Lang() != Japanese {
+			t.Errorf("SetLang(%q): expected %q, got %q", name, Japanese, Lang())
+		}
+	}
+	if err := SetLang("fr"); err == nil {
+		t.Error("Expected error for unsupported language")
+	}
+	if Lang() != Japanese {
+		t.Errorf("Expected language to stay %q after a failed SetLang, got %q", Japanese, Lang())
+	}
+}
+
+// TestT は翻訳と英語へのフォールバックをテストします。
+func TestT(t *testing.T) {
+	defer SetLang(Lang())
+
+	const message = "✅ Navigation successful."
+	if err := SetLang(English); err != nil {
+		t.Fatal(err)
+	}
+	if got := T(message); got != message {
+		t.Errorf("Expected English message, got %q", got)
+	}
+
+	if err := SetLang(Japanese); err != nil {
+		t.Fatal(err)
+	}
+	if got := T(message); got != catalogJa[message] {
+		t.Errorf("Expected Japanese message, got %q", got)
+	}
+	if got := T("no such message %s"); got != "no such message %s" {
+		t.Errorf("Expected untranslated message to fall back to English, got %q", got)
+	}
+	if got := Sprintf("✅ Saved to %s", "out.png"); got != "✅ out.png に保存しました" {
+		t.Errorf("Unexpected Sprintf result: %q", got)
+	}
+}
+
+// verbPattern matches the formatting verbs of a format string.
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+// TestCatalogVerbs は翻訳が元の文字列と同じ書式指定子を同じ順で持つことをテストします。
+func TestCatalogVerbs(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key, translated := range catalog {
+			want := verbPattern.FindAllString(key, -1)
+			got := verbPattern.FindAllString(translated, -1)
+			if !slices.Equal(got, want) {
+				t.Errorf("%s: %q has verbs %v, want %v", lang, translated, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"github.com/chromedp/chromedp"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Annotation is a labelled element box drawn onto a screenshot, in CSS pixels
+// relative to the captured area.
+type Annotation struct {
+	Label  string  `json:"label"`
+	Group  int     `json:"group"` // index of the selector, selects the color
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// annotationColors are cycled through per selector.
+var annotationColors = []color.RGBA{
+	{R: 230, G: 25, B: 75, A: 255},
+	{R: 0, G: 130, B: 200, A: 255},
+	{R: 60, G: 180, B: 75, A: 255},
+	{R: 245, G: 130, B: 48, A: 255},
+	{R: 145, G: 30, B: 180, A: 255},
+	{R: 0, G: 128, B: 128, A: 255},
+}
+
+// annotationBorder is the box outline width in image pixels.
+const annotationBorder = 3
+
+// collectAnnotationsFunction returns the boxes of the elements matching each
+// selector, in document coordinates for full-page captures and viewport
+// coordinates otherwise, together with the device pixel ratio.
+const collectAnnotationsFunction = `function(selectors, fullPage) {
+	const offsetX = fullPage ? scrollX : 0, offsetY = fullPage ? scrollY : 0;
+	const boxes = [];
+	selectors.forEach((selector, group) => {
+		const elements = Array.from(document.querySelectorAll(selector));
+		elements.forEach((el, i) => {
+			const r = el.getBoundingClientRect();
+			if (r.width === 0 && r.height === 0) {
+				return;
+			}
+			boxes.push({
+				label: elements.length > 1 ? selector + ' #' + (i + 1) : selector,
+				group, x: r.left + offsetX, y: r.top + offsetY, width: r.width, height: r.height,
+			});
+		});
+	});
+	return {ratio: devicePixelRatio, boxes};
+}`
+
+// collectAnnotations measures the elements to annotate on the current page.
+// It returns the boxes and the device pixel ratio mapping CSS to image pixels.
+func collectAnnotations(ctx context.Context, selectors []string, fullPage bool) ([]Annotation, float64, error) {
+	var result struct {
+		Ratio float64      `json:"ratio"`
+		Boxes []Annotation `json:"boxes"`
+	}
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		id, err := isolatedContext(ctx)
+		if err != nil {
+			return err
+		}
+		return callOnGlobal(ctx, id, collectAnnotationsFunction, &result, selectors, fullPage)
+	}))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to locate elements to annotate: %w", err)
+	}
+	if result.Ratio <= 0 {
+		result.Ratio = 1
+	}
+	return result.Boxes, result.Ratio, nil
+}
+
+// AnnotateImage draws the annotations onto a PNG image as colored boxes with a
+// label above (or, at the top edge, inside) each box. scale converts the CSS
+// pixels of the annotations into image pixels.
+func AnnotateImage(data []byte, annotations []Annotation, scale float64) ([]byte, error) {
+	src, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+	img := image.NewRGBA(src.Bounds())
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	for _, a := range annotations {
+		c := annotationColors[a.Group%len(annotationColors)]
+		box := image.Rect(
+			int(math.Round(a.X*scale)), int(math.Round(a.Y*scale)),
+			int(math.Round((a.X+a.Width)*scale)), int(math.Round((a.Y+a.Height)*scale)),
+		).Add(img.Bounds().Min)
+		drawOutline(img, box, annotationBorder, c)
+		drawLabel(img, box, a.Label, c)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode annotated screenshot: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// drawOutline draws a rectangle outline of the given width inside box.
+func drawOutline(img draw.Image, box image.Rectangle, width int, c color.Color) {
+	fill := image.NewUniform(c)
+	edges := []image.Rectangle{
+		image.Rect(box.Min.X, box.Min.Y, box.Max.X, box.Min.Y+width),
+		image.Rect(box.Min.X, box.Max.Y-width, box.Max.X, box.Max.Y),
+		image.Rect(box.Min.X, box.Min.Y, box.Min.X+width, box.Max.Y),
+		image.Rect(box.Max.X-width, box.Min.Y, box.Max.X, box.Max.Y),
+	}
+	for _, edge := range edges {
+		draw.Draw(img, edge.Intersect(img.Bounds()), fill, image.Point{}, draw.Src)
+	}
+}
+
+// drawLabel draws text in white on a colored tag at the top-left corner of box.
+func drawLabel(img draw.Image, box image.Rectangle, text string, c color.Color) {
+	face := basicfont.Face7x13
+	const padding = 3
+	width := font.MeasureString(face, text).Ceil() + 2*padding
+	height := face.Metrics().Height.Ceil() + 2*padding
+
+	tag := image.Rect(box.Min.X, box.Min.Y-height, box.Min.X+width, box.Min.Y)
+	if tag.Min.Y < img.Bounds().Min.Y {
+		tag = tag.Add(image.Pt(0, height)) // no room above: put it inside the box
+	}
+	draw.Draw(img, tag.Intersect(img.Bounds()), image.NewUniform(c), image.Point{}, draw.Src)
+
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.White,
+		Face: face,
+		Dot:  fixed.P(tag.Min.X+padding, tag.Min.Y+padding+face.Metrics().Ascent.Ceil()),
+	}
+	drawer.DrawString(text)
+}
@@ -0,0 +1,57 @@
+package logic
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestAnnotateImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	for i := range src.Pix {
+		src.Pix[i] = 255
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	annotations := []Annotation{
+		{Label: "#main", Group: 0, X: 20, Y: 20, Width: 30, Height: 15},
+		{Label: "header", Group: 1, X: 0, Y: 0, Width: 40, Height: 10},
+	}
+	out, err := AnnotateImage(buf.Bytes(), annotations, 2)
+	if err != nil {
+		t.Fatalf("AnnotateImage() error = %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("result is not a PNG: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		x, y int
+		want color.RGBA
+	}{
+		{"scaled box outline", 40, 60, annotationColors[0]},
+		{"inside the box", 70, 55, color.RGBA{255, 255, 255, 255}},
+		{"outside all boxes", 190, 90, color.RGBA{255, 255, 255, 255}},
+		{"label tag above the box", 40, 38, annotationColors[0]},
+		{"label tag inside a box at the top edge", 1, 17, annotationColors[1]},
+	}
+	for _, tt := range tests {
+		got := color.RGBAModel.Convert(img.At(tt.x, tt.y)).(color.RGBA)
+		if got != tt.want {
+			t.Errorf("%s: pixel (%d, %d) = %v, want %v", tt.name, tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestAnnotateImage_InvalidPNG(t *testing.T) {
+	if _, err := AnnotateImage([]byte("not a png"), nil, 1); err == nil {
+		t.Error("expected an error for invalid image data")
+	}
+}
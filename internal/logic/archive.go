@@ -0,0 +1,53 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"browser-tools-go/internal/utils"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// MHTMLPath returns the validated output path for an MHTML snapshot, adding
+// the .mhtml extension when the path has none.
+func MHTMLPath(filePath string) (string, error) {
+	if filePath == "" {
+		filePath = "page.mhtml"
+	}
+	if filepath.Ext(filePath) == "" {
+		filePath += ".mhtml"
+	}
+	return utils.ValidateFilePath(filePath, false, ".")
+}
+
+// SaveMHTML saves the rendered page as a single-file MHTML archive, including
+// its stylesheets, images and frames. filePath defaults to "page.mhtml" and is
+// validated like screenshot paths.
+func SaveMHTML(ctx context.Context, targetURL, filePath string) (string, error) {
+	validatedPath, err := MHTMLPath(filePath)
+	if err != nil {
+		return "", fmt.Errorf("invalid MHTML file path: %w", err)
+	}
+
+	tasks := make(chromedp.Tasks, 0)
+	if targetURL != "" {
+		tasks = append(tasks, chromedp.Navigate(targetURL))
+	}
+	var snapshot string
+	tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		snapshot, err = page.CaptureSnapshot().WithFormat(page.CaptureSnapshotFormatMhtml).Do(ctx)
+		return err
+	}))
+	if err := chromedp.Run(ctx, tasks); err != nil {
+		return "", fmt.Errorf("failed to capture MHTML snapshot: %w", err)
+	}
+
+	if err := utils.SecureWriteFile(validatedPath, []byte(snapshot), 0644, "."); err != nil {
+		return "", fmt.Errorf("failed to save MHTML snapshot to %s: %w", validatedPath, err)
+	}
+	return validatedPath, nil
+}
@@ -0,0 +1,26 @@
+package logic
+
+import "testing"
+
+func TestMHTMLPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"", "page.mhtml", false},
+		{"evidence", "evidence.mhtml", false},
+		{"captures/evidence.mht", "captures/evidence.mht", false},
+		{"../evidence.mhtml", "", true},
+	}
+	for _, tt := range tests {
+		got, err := MHTMLPath(tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("MHTMLPath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("MHTMLPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
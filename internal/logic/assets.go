@@ -0,0 +1,144 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// brokenImagesScript lists the images of the page that finished loading
+// without decodable pixels. SVGs are skipped: one without intrinsic size
+// reports a natural width of 0 while rendering fine.
+const brokenImagesScript = `Array.from(document.images)
+	.filter(img => img.complete && img.naturalWidth === 0 && img.currentSrc &&
+		!/^data:image\/svg|\.svg([?#]|$)/i.test(img.currentSrc))
+	.map(img => img.currentSrc)`
+
+// brokenImageError is the error of an image that loaded but can't be shown.
+const brokenImageError = "image has no pixels (naturalWidth 0)"
+
+// AuditAssets loads url and reports its subresources that responded with an
+// HTTP error status or failed to load, and the images it couldn't decode,
+// grouped by resource type.
+func AuditAssets(ctx context.Context, url string, waitUntil string) (*models.AssetAudit, error) {
+	ctx, span := startSpan(ctx, "audit.assets", attribute.String("url.full", url))
+	audit, err := runAssetAudit(ctx, url, waitUntil)
+	if audit != nil {
+		span.SetAttributes(attribute.Int("browser_tools.broken_assets", audit.Broken))
+	}
+	endSpan(span, err)
+	return audit, err
+}
+
+// runAssetAudit implements AuditAssets.
+func runAssetAudit(ctx context.Context, url string, waitUntil string) (*models.AssetAudit, error) {
+	if err := chromedp.Run(ctx); err != nil {
+		return nil, err
+	}
+	// The main frame has the ID of its target.
+	collector := newAssetCollector(cdp.FrameID(chromedp.FromContext(ctx).Target.TargetID))
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chromedp.ListenTarget(listenCtx, collector.handle)
+
+	result, err := runNavigation(ctx, url, NavigateOptions{WaitUntil: waitUntil})
+	if err != nil {
+		return nil, err
+	}
+	var images []string
+	if err := chromedp.Run(ctx, EvaluateIsolated(brokenImagesScript, &images)); err != nil {
+		return nil, fmt.Errorf("failed to check images: %w", err)
+	}
+	audit := collector.audit(images)
+	audit.URL = url
+	audit.FinalURL = result.FinalURL
+	return audit, nil
+}
+
+// assetCollector collects the failed subresource requests of a page load.
+type assetCollector struct {
+	mu        sync.Mutex
+	mainFrame cdp.FrameID
+	types     map[network.RequestID]network.ResourceType
+	urls      map[network.RequestID]string
+	broken    map[network.RequestID]*models.BrokenAsset
+	order     []network.RequestID
+}
+
+func newAssetCollector(mainFrame cdp.FrameID) *assetCollector {
+	return &assetCollector{
+		mainFrame: mainFrame,
+		types:     map[network.RequestID]network.ResourceType{},
+		urls:      map[network.RequestID]string{},
+		broken:    map[network.RequestID]*models.BrokenAsset{},
+	}
+}
+
+// fail records the failure of a request; a later one, such as the load
+// failing after an error status, completes it.
+func (c *assetCollector) fail(id network.RequestID) *models.BrokenAsset {
+	asset, ok := c.broken[id]
+	if !ok {
+		asset = &models.BrokenAsset{URL: c.urls[id]}
+		c.broken[id] = asset
+		c.order = append(c.order, id)
+	}
+	return asset
+}
+
+// handle processes a single CDP event.
+func (c *assetCollector) handle(ev interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch ev := ev.(type) {
+	case *network.EventRequestWillBeSent:
+		if ev.Type == network.ResourceTypeDocument && ev.FrameID == c.mainFrame {
+			return // the page itself
+		}
+		c.types[ev.RequestID] = ev.Type
+		c.urls[ev.RequestID] = ev.Request.URL
+	case *network.EventResponseReceived:
+		if _, ok := c.types[ev.RequestID]; ok && ev.Response.Status >= 400 {
+			c.fail(ev.RequestID).Status = ev.Response.Status
+		}
+	case *network.EventLoadingFailed:
+		// Requests cancelled by the page, e.g. as it navigates, aren't broken.
+		if _, ok := c.types[ev.RequestID]; ok && !ev.Canceled {
+			c.fail(ev.RequestID).Error = ev.ErrorText
+		}
+	}
+}
+
+// audit returns the failed requests, in the order they failed, completed with
+// the broken images of the page that no failed request explains.
+func (c *assetCollector) audit(brokenImages []string) *models.AssetAudit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	audit := &models.AssetAudit{Requests: len(c.types), ByType: map[string][]models.BrokenAsset{}}
+	reported := map[string]bool{}
+	add := func(resourceType string, asset models.BrokenAsset) {
+		audit.ByType[resourceType] = append(audit.ByType[resourceType], asset)
+		audit.Broken++
+	}
+	for _, id := range c.order {
+		asset := *c.broken[id]
+		reported[asset.URL] = true
+		add(c.types[id].String(), asset)
+	}
+	for _, u := range brokenImages {
+		if !reported[u] {
+			reported[u] = true
+			add(network.ResourceTypeImage.String(), models.BrokenAsset{URL: u, Error: brokenImageError})
+		}
+	}
+	return audit
+}
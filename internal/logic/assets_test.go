@@ -0,0 +1,49 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestAssetCollector(t *testing.T) {
+	c := newAssetCollector("main")
+	request := func(id network.RequestID, url string, resourceType network.ResourceType) {
+		c.handle(&network.EventRequestWillBeSent{RequestID: id, Type: resourceType, FrameID: "main", Request: &network.Request{URL: url}})
+	}
+	response := func(id network.RequestID, status int64) {
+		c.handle(&network.EventResponseReceived{RequestID: id, Response: &network.Response{Status: status}})
+	}
+
+	request("page", "https://a.test/", network.ResourceTypeDocument)
+	response("page", 404) // the page itself isn't an asset
+	request("1", "https://a.test/app.css", network.ResourceTypeStylesheet)
+	response("1", 200)
+	request("2", "https://a.test/missing.png", network.ResourceTypeImage)
+	response("2", 404)
+	c.handle(&network.EventLoadingFailed{RequestID: "2", ErrorText: "net::ERR_ABORTED"})
+	request("3", "https://cdn.test/lib.js", network.ResourceTypeScript)
+	c.handle(&network.EventLoadingFailed{RequestID: "3", ErrorText: "net::ERR_NAME_NOT_RESOLVED"})
+	request("4", "https://a.test/poll", network.ResourceTypeXHR)
+	c.handle(&network.EventLoadingFailed{RequestID: "4", ErrorText: "net::ERR_ABORTED", Canceled: true})
+	request("5", "https://a.test/corrupt.jpg", network.ResourceTypeImage)
+	response("5", 200)
+
+	audit := c.audit([]string{"https://a.test/missing.png", "https://a.test/corrupt.jpg"})
+	if audit.Requests != 5 || audit.Broken != 3 {
+		t.Errorf("Requests = %d, Broken = %d", audit.Requests, audit.Broken)
+	}
+	images := audit.ByType["Image"]
+	if len(images) != 2 || images[0].Status != 404 || images[0].Error != "net::ERR_ABORTED" {
+		t.Fatalf("images = %+v", images)
+	}
+	if images[1].URL != "https://a.test/corrupt.jpg" || images[1].Error != brokenImageError {
+		t.Errorf("undecodable image = %+v", images[1])
+	}
+	if scripts := audit.ByType["Script"]; len(scripts) != 1 || scripts[0].Error != "net::ERR_NAME_NOT_RESOLVED" {
+		t.Errorf("scripts = %+v", scripts)
+	}
+	if _, ok := audit.ByType["XHR"]; ok {
+		t.Error("Expected cancelled requests to be ignored")
+	}
+}
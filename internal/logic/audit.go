@@ -0,0 +1,256 @@
+package logic
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"browser-tools-go/internal/models"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// hstsMinMaxAge is the HSTS max-age, 180 days in seconds, below which the
+// header protects too short a time for full points.
+const hstsMinMaxAge = 180 * 24 * 60 * 60
+
+// headerCheck scores one security header. value is empty when the header is
+// missing, which is noted as such unless score notes why; headers gives
+// access to the others, e.g. the CSP for framing.
+type headerCheck struct {
+	header string
+	max    int
+	score  func(value string, headers securityHeaders) (int, []string)
+}
+
+// securityHeaders looks up response headers case-insensitively, as HTTP/1
+// and HTTP/2 responses spell them differently.
+type securityHeaders struct {
+	values map[string]string
+	https  bool
+}
+
+func (h securityHeaders) get(name string) string {
+	return h.values[strings.ToLower(name)]
+}
+
+// securityHeaderChecks is the scoring rubric of AuditHeaders, 100 points in all.
+var securityHeaderChecks = []headerCheck{
+	{"Content-Security-Policy", 25, scoreCSP},
+	{"Strict-Transport-Security", 20, scoreHSTS},
+	{"X-Frame-Options", 15, scoreFrameOptions},
+	{"X-Content-Type-Options", 10, func(value string, _ securityHeaders) (int, []string) {
+		switch {
+		case value == "":
+			return 0, nil
+		case strings.EqualFold(strings.TrimSpace(value), "nosniff"):
+			return 10, nil
+		}
+		return 0, []string{`expected "nosniff"`}
+	}},
+	{"Referrer-Policy", 10, scoreReferrerPolicy},
+	{"Permissions-Policy", 5, func(value string, _ securityHeaders) (int, []string) {
+		if value == "" {
+			return 0, nil
+		}
+		return 5, nil
+	}},
+	{"Cross-Origin-Opener-Policy", 10, func(value string, _ securityHeaders) (int, []string) {
+		switch policyToken(value) {
+		case "":
+			return 0, nil
+		case "same-origin":
+			return 10, nil
+		case "same-origin-allow-popups":
+			return 5, []string{"popups opened by the page keep a reference to it"}
+		}
+		return 0, []string{`expected "same-origin"`}
+	}},
+	{"Cross-Origin-Embedder-Policy", 5, func(value string, _ securityHeaders) (int, []string) {
+		switch policyToken(value) {
+		case "":
+			return 0, nil
+		case "require-corp", "credentialless":
+			return 5, nil
+		}
+		return 0, []string{`expected "require-corp" or "credentialless"`}
+	}},
+}
+
+// AuditHeaders loads url and scores the security headers of its main document
+// response with the rubric of ScoreSecurityHeaders.
+func AuditHeaders(ctx context.Context, url string) (*models.HeaderAudit, error) {
+	ctx, span := startSpan(ctx, "audit.headers", attribute.String("url.full", url))
+	result, err := runNavigation(ctx, url, NavigateOptions{WaitUntil: WaitUntilDOMContentLoaded})
+	endSpan(span, err)
+	if err != nil {
+		return nil, err
+	}
+	audit := ScoreSecurityHeaders(result.FinalURL, result.Headers)
+	audit.URL = url
+	audit.Status = result.Status
+	return audit, nil
+}
+
+// ScoreSecurityHeaders scores the security headers of a response from
+// finalURL: CSP, HSTS, X-Frame-Options, X-Content-Type-Options,
+// Referrer-Policy, Permissions-Policy, COOP and COEP. Each check notes why
+// it withheld points.
+func ScoreSecurityHeaders(finalURL string, headers map[string]string) *models.HeaderAudit {
+	h := securityHeaders{values: make(map[string]string, len(headers))}
+	for name, value := range headers {
+		// CDP joins the values of a repeated header with newlines.
+		h.values[strings.ToLower(name)] = strings.ReplaceAll(value, "\n", ", ")
+	}
+	if u, err := url.Parse(finalURL); err == nil {
+		h.https = u.Scheme == "https"
+	}
+
+	audit := &models.HeaderAudit{FinalURL: finalURL, Checks: []models.HeaderCheck{}}
+	for _, c := range securityHeaderChecks {
+		check := models.HeaderCheck{Header: c.header, MaxScore: c.max}
+		check.Value = h.get(c.header)
+		check.Present = check.Value != ""
+		check.Score, check.Notes = c.score(check.Value, h)
+		if !check.Present && len(check.Notes) == 0 {
+			check.Notes = []string{"missing"}
+		}
+		audit.Score += check.Score
+		audit.MaxScore += c.max
+		audit.Checks = append(audit.Checks, check)
+	}
+	audit.Grade = headerGrade(audit.Score, audit.MaxScore)
+	return audit
+}
+
+// headerGrade turns a score into a letter grade.
+func headerGrade(score, maxScore int) string {
+	pct := score * 100 / maxScore
+	switch {
+	case pct >= 90:
+		return "A"
+	case pct >= 75:
+		return "B"
+	case pct >= 60:
+		return "C"
+	case pct >= 40:
+		return "D"
+	}
+	return "F"
+}
+
+// policyToken returns the value of a single-token policy header, ignoring
+// parameters such as the report-to of COOP.
+func policyToken(value string) string {
+	token, _, _ := strings.Cut(value, ";")
+	return strings.ToLower(strings.Trim(strings.TrimSpace(token), `"`))
+}
+
+// cspDirectives parses a Content-Security-Policy into its directives and
+// their sources. The directives of several policies are merged.
+func cspDirectives(policy string) map[string][]string {
+	directives := map[string][]string{}
+	for _, part := range strings.FieldsFunc(policy, func(r rune) bool { return r == ';' || r == ',' }) {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		name := strings.ToLower(fields[0])
+		directives[name] = append(directives[name], fields[1:]...)
+	}
+	return directives
+}
+
+func scoreCSP(value string, h securityHeaders) (int, []string) {
+	if value == "" {
+		if h.get("Content-Security-Policy-Report-Only") != "" {
+			return 5, []string{"only Content-Security-Policy-Report-Only is set, which enforces nothing"}
+		}
+		return 0, nil
+	}
+	directives := cspDirectives(value)
+	scripts, ok := directives["script-src"]
+	if !ok {
+		scripts, ok = directives["default-src"]
+	}
+	if !ok {
+		return 10, []string{"neither script-src nor default-src restricts scripts"}
+	}
+	score, notes := 25, []string(nil)
+	for _, source := range scripts {
+		switch strings.ToLower(source) {
+		case "'unsafe-inline'":
+			score -= 10
+			notes = append(notes, "scripts allow 'unsafe-inline'")
+		case "'unsafe-eval'":
+			score -= 5
+			notes = append(notes, "scripts allow 'unsafe-eval'")
+		case "*", "http:", "https:":
+			score -= 5
+			notes = append(notes, "scripts may load from any host ("+source+")")
+		}
+	}
+	return max(score, 5), notes
+}
+
+func scoreHSTS(value string, h securityHeaders) (int, []string) {
+	if !h.https {
+		return 0, []string{"the page isn't served over HTTPS"}
+	}
+	if value == "" {
+		return 0, nil
+	}
+	maxAge := -1
+	includeSubDomains := false
+	for _, part := range strings.Split(value, ";") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch strings.ToLower(name) {
+		case "max-age":
+			if n, err := strconv.Atoi(strings.Trim(arg, `"`)); err == nil {
+				maxAge = n
+			}
+		case "includesubdomains":
+			includeSubDomains = true
+		}
+	}
+	switch {
+	case maxAge <= 0:
+		return 0, []string{"max-age is missing or 0, which disables HSTS"}
+	case maxAge < hstsMinMaxAge:
+		return 10, []string{"max-age is below 180 days"}
+	case !includeSubDomains:
+		return 15, []string{"includeSubDomains is missing"}
+	}
+	return 20, nil
+}
+
+func scoreFrameOptions(value string, h securityHeaders) (int, []string) {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "DENY", "SAMEORIGIN":
+		return 15, nil
+	}
+	if _, ok := cspDirectives(h.get("Content-Security-Policy"))["frame-ancestors"]; ok {
+		return 15, []string{"framing is restricted by the CSP frame-ancestors directive instead"}
+	}
+	if value != "" {
+		return 0, []string{`expected "DENY" or "SAMEORIGIN"`}
+	}
+	return 0, nil
+}
+
+func scoreReferrerPolicy(value string, _ securityHeaders) (int, []string) {
+	if value == "" {
+		return 5, []string{"missing; browsers default to strict-origin-when-cross-origin"}
+	}
+	// Browsers use the last policy they support.
+	policies := strings.Split(value, ",")
+	switch policy := strings.ToLower(strings.TrimSpace(policies[len(policies)-1])); policy {
+	case "no-referrer", "same-origin", "strict-origin", "strict-origin-when-cross-origin":
+		return 10, nil
+	case "unsafe-url":
+		return 0, []string{"full URLs are sent to every origin, even over HTTP"}
+	default:
+		return 5, []string{policy + " sends the origin or full URL to other origins"}
+	}
+}
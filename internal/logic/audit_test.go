@@ -0,0 +1,69 @@
+package logic
+
+import "testing"
+
+func TestScoreSecurityHeaders(t *testing.T) {
+	strict := map[string]string{
+		"content-security-policy":      "default-src 'self'; frame-ancestors 'none'",
+		"strict-transport-security":    "max-age=31536000; includeSubDomains",
+		"x-frame-options":              "DENY",
+		"x-content-type-options":       "nosniff",
+		"referrer-policy":              "no-referrer",
+		"permissions-policy":           "camera=()",
+		"cross-origin-opener-policy":   "same-origin",
+		"cross-origin-embedder-policy": "require-corp",
+	}
+	audit := ScoreSecurityHeaders("https://a.test/", strict)
+	if audit.Score != 100 || audit.MaxScore != 100 || audit.Grade != "A" {
+		t.Errorf("strict headers scored %d/%d (%s)", audit.Score, audit.MaxScore, audit.Grade)
+	}
+	for _, check := range audit.Checks {
+		if !check.Present || len(check.Notes) > 0 {
+			t.Errorf("check %+v", check)
+		}
+	}
+
+	audit = ScoreSecurityHeaders("https://a.test/", nil)
+	if audit.Score != 5 || audit.Grade != "F" {
+		t.Errorf("no headers scored %d (%s), want 5 for the default referrer policy", audit.Score, audit.Grade)
+	}
+	if notes := audit.Checks[0].Notes; len(notes) != 1 || notes[0] != "missing" {
+		t.Errorf("missing CSP notes = %v", notes)
+	}
+
+	scores := func(finalURL string, headers map[string]string) map[string]int {
+		got := map[string]int{}
+		for _, check := range ScoreSecurityHeaders(finalURL, headers).Checks {
+			got[check.Header] = check.Score
+		}
+		return got
+	}
+	got := scores("http://a.test/", map[string]string{
+		// HTTP/1 spelling, weak values.
+		"Content-Security-Policy":    "script-src 'self' 'unsafe-inline' 'unsafe-eval'; style-src 'unsafe-inline'",
+		"Strict-Transport-Security":  "max-age=31536000",
+		"X-Frame-Options":            "ALLOW-FROM https://b.test/",
+		"Referrer-Policy":            "no-referrer, unsafe-url",
+		"Cross-Origin-Opener-Policy": `same-origin-allow-popups; report-to="coop"`,
+	})
+	want := map[string]int{
+		"Content-Security-Policy":    10,
+		"Strict-Transport-Security":  0, // not served over HTTPS
+		"X-Frame-Options":            0,
+		"Referrer-Policy":            0,
+		"Cross-Origin-Opener-Policy": 5,
+	}
+	for header, score := range want {
+		if got[header] != score {
+			t.Errorf("%s scored %d, want %d", header, got[header], score)
+		}
+	}
+
+	got = scores("https://a.test/", map[string]string{
+		"Content-Security-Policy":   "img-src *; frame-ancestors 'self'",
+		"Strict-Transport-Security": "max-age=86400",
+	})
+	if got["Content-Security-Policy"] != 10 || got["X-Frame-Options"] != 15 || got["Strict-Transport-Security"] != 10 {
+		t.Errorf("scores = %v", got)
+	}
+}
@@ -0,0 +1,95 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/chromedp"
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/aztec"
+	"github.com/makiuchi-d/gozxing/datamatrix"
+	multiqrcode "github.com/makiuchi-d/gozxing/multi/qrcode"
+	"github.com/makiuchi-d/gozxing/oned"
+)
+
+// barcodeHints favours accuracy over speed and also finds light-on-dark codes.
+var barcodeHints = map[gozxing.DecodeHintType]interface{}{
+	gozxing.DecodeHintType_TRY_HARDER:    true,
+	gozxing.DecodeHintType_ALSO_INVERTED: true,
+}
+
+// CaptureForDecoding captures the first element matching selector, or the
+// viewport when selector is empty, as a PNG image.
+func CaptureForDecoding(ctx context.Context, selector string) ([]byte, error) {
+	var buf []byte
+	action := chromedp.CaptureScreenshot(&buf)
+	if selector != "" {
+		action = chromedp.Screenshot(selector, &buf, chromedp.NodeVisible, chromedp.ByQuery)
+	}
+	if err := chromedp.Run(ctx, action); err != nil {
+		return nil, fmt.Errorf("failed to capture %s: %w", describeCaptureTarget(selector), err)
+	}
+	return buf, nil
+}
+
+// describeCaptureTarget names the captured area in error messages.
+func describeCaptureTarget(selector string) string {
+	if selector == "" {
+		return "the viewport"
+	}
+	return fmt.Sprintf("'%s'", selector)
+}
+
+// DecodeCodes decodes all QR codes and the first barcode of every other
+// supported format (Data Matrix, Aztec, EAN/UPC, Code 128/39/93, Codabar, ITF)
+// found in a PNG, JPEG or GIF image.
+func DecodeCodes(data []byte) ([]models.DecodedCode, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare image: %w", err)
+	}
+
+	codes := []models.DecodedCode{}
+	seen := map[models.DecodedCode]bool{}
+	add := func(result *gozxing.Result) {
+		code := models.DecodedCode{Format: result.GetBarcodeFormat().String(), Text: result.GetText()}
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+
+	// Readers fail with a "not found" error when there is no code of their format.
+	if results, err := multiqrcode.NewQRCodeMultiReader().DecodeMultiple(bitmap, barcodeHints); err == nil {
+		for _, result := range results {
+			add(result)
+		}
+	}
+	readers := []gozxing.Reader{
+		datamatrix.NewDataMatrixReader(),
+		aztec.NewAztecReader(),
+		oned.NewMultiFormatUPCEANReader(barcodeHints),
+		oned.NewCode128Reader(),
+		oned.NewCode39Reader(),
+		oned.NewCode93Reader(),
+		oned.NewCodaBarReader(),
+		oned.NewITFReader(),
+	}
+	for _, reader := range readers {
+		if result, err := reader.Decode(bitmap, barcodeHints); err == nil {
+			add(result)
+		}
+	}
+	return codes, nil
+}
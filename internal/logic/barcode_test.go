@@ -0,0 +1,81 @@
+package logic
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/png"
+	"testing"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/oned"
+	"github.com/makiuchi-d/gozxing/qrcode"
+)
+
+// encodeTestImage renders the codes side by side into a PNG with a white background.
+func encodeTestImage(t *testing.T, codes ...image.Image) []byte {
+	t.Helper()
+	width, height := 20, 0
+	for _, code := range codes {
+		width += code.Bounds().Dx() + 20
+		if code.Bounds().Dy() > height {
+			height = code.Bounds().Dy()
+		}
+	}
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height+40))
+	draw.Draw(canvas, canvas.Bounds(), image.White, image.Point{}, draw.Src)
+	x := 20
+	for _, code := range codes {
+		draw.Draw(canvas, code.Bounds().Add(image.Pt(x, 20)), code, code.Bounds().Min, draw.Src)
+		x += code.Bounds().Dx() + 20
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeCodes(t *testing.T) {
+	qr, err := qrcode.NewQRCodeWriter().Encode("otpauth://totp/example?secret=JBSWY3DPEHPK3PXP", gozxing.BarcodeFormat_QR_CODE, 200, 200, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	barcode, err := oned.NewCode128Writer().Encode("PAIR-1234", gozxing.BarcodeFormat_CODE_128, 240, 80, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	codes, err := DecodeCodes(encodeTestImage(t, qr, barcode))
+	if err != nil {
+		t.Fatalf("DecodeCodes() error = %v", err)
+	}
+
+	found := map[string]string{}
+	for _, code := range codes {
+		found[code.Format] = code.Text
+	}
+	if found["QR_CODE"] != "otpauth://totp/example?secret=JBSWY3DPEHPK3PXP" {
+		t.Errorf("QR code not decoded, got %+v", codes)
+	}
+	if found["CODE_128"] != "PAIR-1234" {
+		t.Errorf("Code 128 barcode not decoded, got %+v", codes)
+	}
+}
+
+func TestDecodeCodes_NoCode(t *testing.T) {
+	blank := image.NewGray(image.Rect(0, 0, 50, 50))
+	codes, err := DecodeCodes(encodeTestImage(t, blank))
+	if err != nil {
+		t.Fatalf("DecodeCodes() error = %v", err)
+	}
+	if len(codes) != 0 {
+		t.Errorf("expected no codes, got %+v", codes)
+	}
+}
+
+func TestDecodeCodes_InvalidImage(t *testing.T) {
+	if _, err := DecodeCodes([]byte("not an image")); err == nil {
+		t.Error("expected an error for invalid image data")
+	}
+}
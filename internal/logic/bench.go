@@ -0,0 +1,211 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// Benchmark cache modes.
+const (
+	BenchCold = "cold" // browser cache disabled, every run loads from the network
+	BenchWarm = "warm" // cache primed by an uncounted first load
+)
+
+// BenchOptions configures Bench.
+type BenchOptions struct {
+	Runs      int
+	Mode      string // BenchCold or BenchWarm
+	WaitUntil string // as in NavigateOptions
+}
+
+// benchMetrics are the metrics summarized by Bench, with their sample values.
+var benchMetrics = []struct {
+	name  string
+	value func(s models.BenchSample) float64
+}{
+	{"responseMs", func(s models.BenchSample) float64 { return s.ResponseMs }},
+	{"domContentLoadedMs", func(s models.BenchSample) float64 { return s.DOMContentLoadedMs }},
+	{"loadMs", func(s models.BenchSample) float64 { return s.LoadMs }},
+	{"firstPaintMs", func(s models.BenchSample) float64 { return s.FirstPaintMs }},
+	{"firstContentfulPaintMs", func(s models.BenchSample) float64 { return s.FirstContentfulPaintMs }},
+	{"largestContentfulPaintMs", func(s models.BenchSample) float64 { return s.LargestContentfulPaintMs }},
+	{"totalMs", func(s models.BenchSample) float64 { return s.TotalMs }},
+}
+
+// paintTimingScript resolves to the paint timings of the page; the largest
+// contentful paint is only reported through a buffered observer.
+const paintTimingScript = `new Promise(resolve => {
+	const timings = {fp: 0, fcp: 0, lcp: 0};
+	for (const entry of performance.getEntriesByType('paint')) {
+		if (entry.name === 'first-paint') timings.fp = entry.startTime;
+		if (entry.name === 'first-contentful-paint') timings.fcp = entry.startTime;
+	}
+	try {
+		new PerformanceObserver(list => {
+			const entries = list.getEntries();
+			if (entries.length) timings.lcp = entries[entries.length - 1].startTime;
+		}).observe({type: 'largest-contentful-paint', buffered: true});
+	} catch (e) {}
+	setTimeout(() => resolve(timings), 50);
+})`
+
+// Bench loads url opts.Runs times and summarizes the navigation and paint
+// timings. Every run starts from about:blank. onSample is called after each
+// run, when not nil.
+func Bench(ctx context.Context, url string, opts BenchOptions, onSample func(models.BenchSample)) (*models.BenchReport, error) {
+	if opts.Runs < 1 {
+		return nil, fmt.Errorf("runs must be at least 1")
+	}
+	if err := SetCacheDisabled(ctx, opts.Mode == BenchCold); err != nil {
+		return nil, err
+	}
+	navOpts := NavigateOptions{WaitUntil: opts.WaitUntil}
+	if opts.Mode == BenchWarm {
+		if _, err := Navigate(ctx, url, navOpts); err != nil {
+			return nil, fmt.Errorf("warm-up load failed: %w", err)
+		}
+	}
+
+	report := &models.BenchReport{URL: url, Mode: opts.Mode, Runs: opts.Runs, Samples: []models.BenchSample{}}
+	for i := 1; i <= opts.Runs; i++ {
+		if err := chromedp.Run(ctx, chromedp.Navigate("about:blank")); err != nil {
+			return nil, fmt.Errorf("failed to reset the tab: %w", err)
+		}
+		_, sample, err := measureLoad(ctx, url, navOpts)
+		if err != nil {
+			return nil, fmt.Errorf("run %d: %w", i, err)
+		}
+		sample.Run = i
+		report.Samples = append(report.Samples, sample)
+		if onSample != nil {
+			onSample(sample)
+		}
+	}
+	report.Metrics = SummarizeBench(report.Samples)
+	return report, nil
+}
+
+// measureLoad loads url in the tab and returns the navigation result with its
+// navigation and paint timings.
+func measureLoad(ctx context.Context, url string, opts NavigateOptions) (*models.NavigationResult, models.BenchSample, error) {
+	result, err := Navigate(ctx, url, opts)
+	if err != nil {
+		return nil, models.BenchSample{}, err
+	}
+	var paint struct {
+		FP  float64 `json:"fp"`
+		FCP float64 `json:"fcp"`
+		LCP float64 `json:"lcp"`
+	}
+	err = chromedp.Run(ctx, EvaluateIsolated(paintTimingScript, &paint, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+		return p.WithAwaitPromise(true)
+	}))
+	if err != nil {
+		return nil, models.BenchSample{}, fmt.Errorf("failed to read paint timings: %w", err)
+	}
+	return result, models.BenchSample{
+		Status:                   result.Status,
+		ResponseMs:               result.Timing.ResponseMs,
+		DOMContentLoadedMs:       result.Timing.DOMContentLoadedMs,
+		LoadMs:                   result.Timing.LoadMs,
+		FirstPaintMs:             paint.FP,
+		FirstContentfulPaintMs:   paint.FCP,
+		LargestContentfulPaintMs: paint.LCP,
+		TotalMs:                  result.Timing.TotalMs,
+	}, nil
+}
+
+// SummarizeBench computes the statistics of every metric over samples.
+// Metrics that are zero in every sample, such as paint timings of pages that
+// report none, are left out.
+func SummarizeBench(samples []models.BenchSample) map[string]models.BenchStats {
+	metrics := map[string]models.BenchStats{}
+	for _, metric := range benchMetrics {
+		var values []float64
+		for _, s := range samples {
+			if v := metric.value(s); v > 0 {
+				values = append(values, v)
+			}
+		}
+		if len(values) > 0 {
+			metrics[metric.name] = benchStats(values)
+		}
+	}
+	return metrics
+}
+
+// benchStats summarizes values, which must not be empty.
+func benchStats(values []float64) models.BenchStats {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(len(sorted))
+	var squares float64
+	for _, v := range sorted {
+		squares += (v - mean) * (v - mean)
+	}
+	stdDev := 0.0
+	if len(sorted) > 1 {
+		stdDev = math.Sqrt(squares / float64(len(sorted)-1))
+	}
+	return models.BenchStats{
+		Samples: len(sorted),
+		Min:     sorted[0],
+		Max:     sorted[len(sorted)-1],
+		Mean:    mean,
+		StdDev:  stdDev,
+		P50:     percentile(sorted, 50),
+		P95:     percentile(sorted, 95),
+	}
+}
+
+// percentile returns the p-th percentile of sorted values, interpolating
+// linearly between the closest ranks.
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// CompareBench compares the medians of report with those of baseline and
+// records the comparison in report. A metric regresses when its median grew
+// by more than thresholdPct percent. It returns the number of regressions.
+func CompareBench(report, baseline *models.BenchReport, thresholdPct float64) int {
+	report.ThresholdPct = thresholdPct
+	report.Comparison = nil
+	report.Regressions = 0
+	for _, metric := range benchMetrics {
+		current, ok := report.Metrics[metric.name]
+		if !ok {
+			continue
+		}
+		base, ok := baseline.Metrics[metric.name]
+		if !ok || base.P50 <= 0 {
+			continue
+		}
+		change := (current.P50 - base.P50) / base.P50 * 100
+		comparison := models.BenchComparison{
+			Metric:     metric.name,
+			Baseline:   base.P50,
+			Current:    current.P50,
+			ChangePct:  math.Round(change*10) / 10,
+			Regression: change > thresholdPct,
+		}
+		if comparison.Regression {
+			report.Regressions++
+		}
+		report.Comparison = append(report.Comparison, comparison)
+	}
+	return report.Regressions
+}
@@ -0,0 +1,79 @@
+package logic
+
+import (
+	"math"
+	"testing"
+
+	"browser-tools-go/internal/models"
+)
+
+func TestBenchStats(t *testing.T) {
+	stats := benchStats([]float64{50, 10, 40, 20, 30})
+	if stats.Samples != 5 || stats.Min != 10 || stats.Max != 50 || stats.Mean != 30 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if stats.P50 != 30 || stats.P95 != 48 {
+		t.Errorf("Expected p50 30 and p95 48, got %g and %g", stats.P50, stats.P95)
+	}
+	if math.Abs(stats.StdDev-15.811) > 0.001 {
+		t.Errorf("Expected sample standard deviation 15.811, got %g", stats.StdDev)
+	}
+
+	single := benchStats([]float64{7})
+	if single.P50 != 7 || single.P95 != 7 || single.StdDev != 0 {
+		t.Errorf("Unexpected stats of a single sample: %+v", single)
+	}
+}
+
+func TestSummarizeBench(t *testing.T) {
+	metrics := SummarizeBench([]models.BenchSample{
+		{Run: 1, ResponseMs: 100, LoadMs: 300, FirstContentfulPaintMs: 200, TotalMs: 300},
+		{Run: 2, ResponseMs: 120, LoadMs: 340, TotalMs: 340},
+	})
+	if metrics["loadMs"].P50 != 320 {
+		t.Errorf("Expected loadMs p50 320, got %+v", metrics["loadMs"])
+	}
+	if metrics["firstContentfulPaintMs"].Samples != 1 {
+		t.Errorf("Expected runs without a paint timing to be skipped, got %+v", metrics["firstContentfulPaintMs"])
+	}
+	if _, ok := metrics["largestContentfulPaintMs"]; ok {
+		t.Error("Expected metrics missing from every run to be left out")
+	}
+}
+
+func TestCompareBench(t *testing.T) {
+	baseline := &models.BenchReport{Metrics: map[string]models.BenchStats{
+		"loadMs":     {P50: 100},
+		"responseMs": {P50: 50},
+		"totalMs":    {P50: 200},
+	}}
+	report := &models.BenchReport{Metrics: map[string]models.BenchStats{
+		"loadMs":       {P50: 125},
+		"responseMs":   {P50: 52},
+		"firstPaintMs": {P50: 80},
+		"totalMs":      {P50: 150},
+	}}
+
+	if n := CompareBench(report, baseline, 10); n != 1 {
+		t.Errorf("Expected 1 regression, got %d", n)
+	}
+	if len(report.Comparison) != 3 {
+		t.Fatalf("Expected 3 compared metrics, got %+v", report.Comparison)
+	}
+	for _, c := range report.Comparison {
+		switch c.Metric {
+		case "loadMs":
+			if !c.Regression || c.ChangePct != 25 {
+				t.Errorf("Expected loadMs to regress by 25%%, got %+v", c)
+			}
+		case "responseMs":
+			if c.Regression || c.ChangePct != 4 {
+				t.Errorf("Expected responseMs within the threshold, got %+v", c)
+			}
+		case "totalMs":
+			if c.Regression || c.ChangePct != -25 {
+				t.Errorf("Expected totalMs to improve by 25%%, got %+v", c)
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// bindingNamePattern restricts binding names to plain JavaScript identifiers.
+var bindingNamePattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// bindingWrapperScript replaces the raw binding, which only accepts a string,
+// with a function that sends any other value as JSON. %s is the JSON-encoded
+// binding name, validated by bindingNamePattern.
+const bindingWrapperScript = `(() => {
+	const name = %s;
+	const raw = globalThis[name];
+	if (typeof raw !== 'function' || raw.__browserToolsWrapped) {
+		return;
+	}
+	const wrapped = (data) => raw(typeof data === 'string' ? data : JSON.stringify(data === undefined ? null : data));
+	wrapped.__browserToolsWrapped = true;
+	globalThis[name] = wrapped;
+})()`
+
+// ExposeBinding registers name as a function callable from page JavaScript,
+// in the current document and in every document loaded afterwards. Each call
+// is passed to onCall, which runs on the event loop and must not block or run
+// browser actions. The returned function removes the binding again.
+func ExposeBinding(ctx context.Context, name string, onCall func(*models.BindingCall)) (func(), error) {
+	if !bindingNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid binding name %q: must be a JavaScript identifier", name)
+	}
+	quoted, err := json.Marshal(name)
+	if err != nil {
+		return nil, err
+	}
+	wrapper := fmt.Sprintf(bindingWrapperScript, quoted)
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	chromedp.ListenTarget(listenCtx, func(ev interface{}) {
+		if ev, ok := ev.(*runtime.EventBindingCalled); ok && ev.Name == name {
+			onCall(newBindingCall(ev.Name, ev.Payload, time.Now()))
+		}
+	})
+
+	var scriptID page.ScriptIdentifier
+	err = chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		if err := runtime.AddBinding(name).Do(ctx); err != nil {
+			return err
+		}
+		var err error
+		if scriptID, err = page.AddScriptToEvaluateOnNewDocument(wrapper).Do(ctx); err != nil {
+			return err
+		}
+		_, exp, err := runtime.Evaluate(wrapper).Do(ctx)
+		if exp != nil {
+			return exp
+		}
+		return err
+	}))
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to expose binding %s: %w", name, err)
+	}
+
+	remove := func() {
+		cancel()
+		err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+			if err := page.RemoveScriptToEvaluateOnNewDocument(scriptID).Do(ctx); err != nil {
+				return err
+			}
+			return runtime.RemoveBinding(name).Do(ctx)
+		}))
+		if err != nil {
+			i18n.Printf("Warning: could not remove binding %s: %v", name, err)
+		}
+	}
+	return remove, nil
+}
+
+// newBindingCall builds a binding call, decoding the payload when it is JSON.
+func newBindingCall(name, payload string, calledAt time.Time) *models.BindingCall {
+	call := &models.BindingCall{
+		Kind:     "binding",
+		Name:     name,
+		Payload:  payload,
+		CalledAt: calledAt,
+	}
+	var decoded interface{}
+	if json.Unmarshal([]byte(payload), &decoded) == nil {
+		call.JSON = decoded
+	}
+	return call
+}
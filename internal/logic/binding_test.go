@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewBindingCall(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	call := newBindingCall("__bt_emit", `{"price": 10}`, now)
+	if call.Kind != "binding" || call.Name != "__bt_emit" || !call.CalledAt.Equal(now) {
+		t.Errorf("unexpected call: %+v", call)
+	}
+	if decoded, ok := call.JSON.(map[string]interface{}); !ok || decoded["price"] != float64(10) {
+		t.Errorf("expected JSON payload to be decoded, got %#v", call.JSON)
+	}
+
+	call = newBindingCall("__bt_emit", "plain text", now)
+	if call.JSON != nil || call.Payload != "plain text" {
+		t.Errorf("expected plain payload to be kept as is, got %+v", call)
+	}
+}
+
+func TestBindingNamePattern(t *testing.T) {
+	for name, want := range map[string]bool{
+		"__bt_emit": true,
+		"$emit":     true,
+		"emit2":     true,
+		"2emit":     false,
+		"a.b":       false,
+		"a-b":       false,
+		"":          false,
+		"x');y('":   false,
+	} {
+		if got := bindingNamePattern.MatchString(name); got != want {
+			t.Errorf("bindingNamePattern.MatchString(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// BudgetTotal is the key of the transfer budget of all resources together.
+const BudgetTotal = "total"
+
+// budgetResourceTypes are the keys accepted in Budget.MaxTransferBytes
+// besides BudgetTotal: the lowercase resource types.
+var budgetResourceTypes = map[string]bool{}
+
+func init() {
+	for _, t := range []network.ResourceType{
+		network.ResourceTypeDocument, network.ResourceTypeStylesheet, network.ResourceTypeImage,
+		network.ResourceTypeMedia, network.ResourceTypeFont, network.ResourceTypeScript,
+		network.ResourceTypeTextTrack, network.ResourceTypeXHR, network.ResourceTypeFetch,
+		network.ResourceTypePrefetch, network.ResourceTypeEventSource, network.ResourceTypeWebSocket,
+		network.ResourceTypeManifest, network.ResourceTypeSignedExchange, network.ResourceTypePing,
+		network.ResourceTypeCSPViolationReport, network.ResourceTypePreflight, network.ResourceTypeOther,
+	} {
+		budgetResourceTypes[strings.ToLower(t.String())] = true
+	}
+}
+
+// webVitalsScript resolves to the largest contentful paint and the
+// cumulative layout shift of the page, both only reported through buffered
+// observers. Shifts right after user input don't count, as in Chrome's CLS.
+const webVitalsScript = `new Promise(resolve => {
+	const vitals = {lcp: 0, cls: 0};
+	try {
+		new PerformanceObserver(list => {
+			const entries = list.getEntries();
+			if (entries.length) vitals.lcp = entries[entries.length - 1].startTime;
+		}).observe({type: 'largest-contentful-paint', buffered: true});
+		new PerformanceObserver(list => {
+			for (const entry of list.getEntries()) {
+				if (!entry.hadRecentInput) vitals.cls += entry.value;
+			}
+		}).observe({type: 'layout-shift', buffered: true});
+	} catch (e) {}
+	setTimeout(() => resolve(vitals), 50);
+})`
+
+// ValidateBudget checks the limits of a budget.
+func ValidateBudget(budget *models.Budget) error {
+	if budget.MaxRequests < 0 || budget.MaxLCPMs < 0 || budget.MaxCLS < 0 {
+		return fmt.Errorf("budget limits must not be negative")
+	}
+	for key, limit := range budget.MaxTransferBytes {
+		if key != BudgetTotal && !budgetResourceTypes[key] {
+			return fmt.Errorf("unknown resource type %q in maxTransferBytes (e.g. total, script, image or font)", key)
+		}
+		if limit < 0 {
+			return fmt.Errorf("budget limits must not be negative")
+		}
+	}
+	return nil
+}
+
+// measureBudget reads the web vitals of the loaded page and checks them and
+// resources against budget.
+func measureBudget(ctx context.Context, budget *models.Budget, resources []models.Resource) (*models.BudgetReport, error) {
+	var vitals struct {
+		LCP float64 `json:"lcp"`
+		CLS float64 `json:"cls"`
+	}
+	err := chromedp.Run(ctx, EvaluateIsolated(webVitalsScript, &vitals, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+		return p.WithAwaitPromise(true)
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read web vitals: %w", err)
+	}
+	report := &models.BudgetReport{LCPMs: vitals.LCP, CLS: vitals.CLS}
+	CheckBudget(budget, resources, report)
+	return report, nil
+}
+
+// CheckBudget fills in the requests and transfer sizes of report from
+// resources and lists the limits of budget they, or the web vitals already in
+// report, exceed.
+func CheckBudget(budget *models.Budget, resources []models.Resource, report *models.BudgetReport) {
+	report.Requests = len(resources)
+	report.TransferBytes = map[string]int64{BudgetTotal: 0}
+	for _, res := range resources {
+		report.TransferBytes[strings.ToLower(res.Type)] += res.TransferBytes
+		report.TransferBytes[BudgetTotal] += res.TransferBytes
+	}
+
+	report.Violations = []models.BudgetViolation{}
+	exceeds := func(metric string, limit, actual float64) {
+		if limit > 0 && actual > limit {
+			report.Violations = append(report.Violations, models.BudgetViolation{Metric: metric, Limit: limit, Actual: actual})
+		}
+	}
+	exceeds("requests", float64(budget.MaxRequests), float64(report.Requests))
+	keys := make([]string, 0, len(budget.MaxTransferBytes))
+	for key := range budget.MaxTransferBytes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		exceeds("transferBytes."+key, float64(budget.MaxTransferBytes[key]), float64(report.TransferBytes[key]))
+	}
+	exceeds("lcpMs", budget.MaxLCPMs, report.LCPMs)
+	exceeds("cls", budget.MaxCLS, report.CLS)
+}
@@ -0,0 +1,65 @@
+package logic
+
+import (
+	"testing"
+
+	"browser-tools-go/internal/models"
+)
+
+func TestValidateBudget(t *testing.T) {
+	valid := &models.Budget{MaxRequests: 80, MaxTransferBytes: map[string]int64{"total": 1500000, "script": 400000, "xhr": 1}, MaxLCPMs: 2500, MaxCLS: 0.1}
+	if err := ValidateBudget(valid); err != nil {
+		t.Errorf("ValidateBudget() = %v", err)
+	}
+	for _, budget := range []*models.Budget{
+		{MaxRequests: -1},
+		{MaxCLS: -0.1},
+		{MaxTransferBytes: map[string]int64{"Script": 1}},
+		{MaxTransferBytes: map[string]int64{"js": 1}},
+		{MaxTransferBytes: map[string]int64{"image": -1}},
+	} {
+		if err := ValidateBudget(budget); err == nil {
+			t.Errorf("Expected %+v to be rejected", budget)
+		}
+	}
+}
+
+func TestCheckBudget(t *testing.T) {
+	resources := []models.Resource{
+		{URL: "https://a.test/", Type: "Document", TransferBytes: 20000},
+		{URL: "https://a.test/app.js", Type: "Script", TransferBytes: 300000},
+		{URL: "https://a.test/vendor.js", Type: "Script", TransferBytes: 250000},
+		{URL: "https://a.test/hero.jpg", Type: "Image", TransferBytes: 400000},
+	}
+	budget := &models.Budget{
+		MaxRequests:      3,
+		MaxTransferBytes: map[string]int64{"total": 2000000, "script": 500000, "image": 500000},
+		MaxLCPMs:         2500,
+		MaxCLS:           0.1,
+	}
+	report := &models.BudgetReport{LCPMs: 3100, CLS: 0.05}
+	CheckBudget(budget, resources, report)
+
+	if report.Requests != 4 || report.TransferBytes["total"] != 970000 || report.TransferBytes["script"] != 550000 {
+		t.Errorf("report = %+v", report)
+	}
+	want := []models.BudgetViolation{
+		{Metric: "requests", Limit: 3, Actual: 4},
+		{Metric: "transferBytes.script", Limit: 500000, Actual: 550000},
+		{Metric: "lcpMs", Limit: 2500, Actual: 3100},
+	}
+	if len(report.Violations) != len(want) {
+		t.Fatalf("violations = %+v, want %+v", report.Violations, want)
+	}
+	for i, v := range want {
+		if report.Violations[i] != v {
+			t.Errorf("violation %d = %+v, want %+v", i, report.Violations[i], v)
+		}
+	}
+
+	report = &models.BudgetReport{}
+	CheckBudget(&models.Budget{}, resources, report)
+	if len(report.Violations) != 0 {
+		t.Errorf("Expected an empty budget to check nothing, got %+v", report.Violations)
+	}
+}
@@ -0,0 +1,106 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// APICaptureOptions configures CaptureAPI.
+type APICaptureOptions struct {
+	// URL is navigated to after capturing starts; empty waits for responses
+	// triggered by interaction with the current page.
+	URL string
+	// Pattern selects response URLs (substring, or glob when it contains '*').
+	Pattern string
+	// Count stops the capture after this many responses; zero waits for Timeout.
+	Count int
+	// Timeout bounds the whole capture.
+	Timeout time.Duration
+}
+
+// apiCaptureQueueSize bounds the responses waiting for their bodies to be fetched.
+const apiCaptureQueueSize = 256
+
+// CaptureAPI waits for XHR/fetch responses matching Pattern and calls onResponse
+// with each decoded body. It returns the number of captured responses; reaching
+// the timeout before Count responses arrived is reported as an error.
+func CaptureAPI(ctx context.Context, opts APICaptureOptions, onResponse func(*models.CapturedResponse)) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	finished := make(chan *models.NetworkEntry, apiCaptureQueueSize)
+	capture := newNetworkCapture(opts.Pattern, func(entry *models.NetworkEntry) {
+		if entry.Error != "" || !isAPIResourceType(entry.ResourceType) {
+			return
+		}
+		select {
+		case finished <- entry:
+		default:
+			i18n.Printf("Warning: dropped response %s (too many pending)", entry.URL)
+		}
+	})
+	chromedp.ListenTarget(ctx, capture.handle)
+
+	tasks := chromedp.Tasks{network.Enable()}
+	if opts.URL != "" {
+		tasks = append(tasks, chromedp.Navigate(opts.URL))
+	}
+	if err := chromedp.Run(ctx, tasks); err != nil {
+		return 0, fmt.Errorf("failed to start capturing: %w", err)
+	}
+
+	captured := 0
+	for opts.Count <= 0 || captured < opts.Count {
+		select {
+		case <-ctx.Done():
+			if opts.Count > 0 {
+				return captured, fmt.Errorf("timed out after capturing %d of %d responses", captured, opts.Count)
+			}
+			return captured, nil
+		case entry := <-finished:
+			var body []byte
+			err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+				var err error
+				body, err = network.GetResponseBody(network.RequestID(entry.RequestID)).Do(ctx)
+				return err
+			}))
+			if err != nil {
+				i18n.Printf("Warning: could not read body of %s: %v", entry.URL, err)
+				continue
+			}
+			onResponse(newCapturedResponse(entry, body))
+			captured++
+		}
+	}
+	return captured, nil
+}
+
+// newCapturedResponse builds a captured response, decoding the body when it is JSON.
+func newCapturedResponse(entry *models.NetworkEntry, body []byte) *models.CapturedResponse {
+	response := &models.CapturedResponse{
+		URL:      entry.URL,
+		Method:   entry.Method,
+		Status:   entry.Status,
+		MimeType: entry.MimeType,
+	}
+	var decoded interface{}
+	if json.Unmarshal(body, &decoded) == nil {
+		response.JSON = decoded
+	} else {
+		response.Body = string(body)
+	}
+	return response
+}
+
+// isAPIResourceType reports whether a resource type is an XHR or fetch request.
+func isAPIResourceType(resourceType string) bool {
+	return resourceType == string(network.ResourceTypeXHR) || resourceType == string(network.ResourceTypeFetch)
+}
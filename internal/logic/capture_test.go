@@ -0,0 +1,32 @@
+package logic
+
+import (
+	"testing"
+
+	"browser-tools-go/internal/models"
+)
+
+func TestNewCapturedResponse(t *testing.T) {
+	entry := &models.NetworkEntry{URL: "https://example.com/api/v1/products", Method: "GET", Status: 200, MimeType: "application/json"}
+
+	response := newCapturedResponse(entry, []byte(`{"items": [1, 2]}`))
+	if response.Body != "" || response.JSON == nil {
+		t.Errorf("expected JSON body to be decoded, got %+v", response)
+	}
+	if response.URL != entry.URL || response.Status != 200 {
+		t.Errorf("expected entry fields to be copied, got %+v", response)
+	}
+
+	response = newCapturedResponse(entry, []byte("<html></html>"))
+	if response.JSON != nil || response.Body != "<html></html>" {
+		t.Errorf("expected non-JSON body to be kept as text, got %+v", response)
+	}
+}
+
+func TestIsAPIResourceType(t *testing.T) {
+	for resourceType, want := range map[string]bool{"XHR": true, "Fetch": true, "Document": false, "Script": false, "": false} {
+		if got := isAPIResourceType(resourceType); got != want {
+			t.Errorf("isAPIResourceType(%q) = %v, want %v", resourceType, got, want)
+		}
+	}
+}
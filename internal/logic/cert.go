@@ -0,0 +1,89 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"sync"
+	"time"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Certificate loads the HTTPS page url and reports the TLS certificate and
+// connection of its main document: subject, issuer, SANs, validity and the
+// days left until it expires, protocol and cipher.
+func Certificate(ctx context.Context, url string) (*models.CertificateInfo, error) {
+	ctx, span := startSpan(ctx, "cert", attribute.String("url.full", url))
+	info, err := loadCertificate(ctx, url)
+	if info != nil {
+		span.SetAttributes(attribute.Int("browser_tools.days_until_expiry", info.DaysUntilExpiry))
+	}
+	endSpan(span, err)
+	return info, err
+}
+
+// loadCertificate implements Certificate.
+func loadCertificate(ctx context.Context, pageURL string) (*models.CertificateInfo, error) {
+	var mu sync.Mutex
+	details := map[string]*network.SecurityDetails{} // by document URL
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chromedp.ListenTarget(listenCtx, func(ev interface{}) {
+		if ev, ok := ev.(*network.EventResponseReceived); ok && ev.Type == network.ResourceTypeDocument && ev.Response.SecurityDetails != nil {
+			mu.Lock()
+			details[ev.Response.URL] = ev.Response.SecurityDetails
+			mu.Unlock()
+		}
+	})
+
+	result, err := runNavigation(ctx, pageURL, NavigateOptions{WaitUntil: WaitUntilDOMContentLoaded})
+	if err != nil {
+		return nil, err
+	}
+	if u, err := url.Parse(result.FinalURL); err != nil || u.Scheme != "https" {
+		return nil, fmt.Errorf("%s isn't served over HTTPS", result.FinalURL)
+	}
+	mu.Lock()
+	sd := details[result.FinalURL]
+	mu.Unlock()
+	if sd == nil {
+		return nil, fmt.Errorf("no TLS details were reported for %s", result.FinalURL)
+	}
+	info := certificateInfo(sd, time.Now())
+	info.URL = pageURL
+	info.FinalURL = result.FinalURL
+	return info, nil
+}
+
+// certificateInfo converts the security details of a response, counting the
+// days until the certificate expires from now.
+func certificateInfo(sd *network.SecurityDetails, now time.Time) *models.CertificateInfo {
+	info := &models.CertificateInfo{
+		Subject:                 sd.SubjectName,
+		Issuer:                  sd.Issuer,
+		SANs:                    sd.SanList,
+		Protocol:                sd.Protocol,
+		Cipher:                  sd.Cipher,
+		KeyExchange:             sd.KeyExchange,
+		KeyExchangeGroup:        sd.KeyExchangeGroup,
+		CertificateTransparency: sd.CertificateTransparencyCompliance.String(),
+	}
+	if info.SANs == nil {
+		info.SANs = []string{}
+	}
+	if sd.ValidFrom != nil {
+		info.ValidFrom = sd.ValidFrom.Time().UTC()
+	}
+	if sd.ValidTo != nil {
+		info.ValidTo = sd.ValidTo.Time().UTC()
+		info.DaysUntilExpiry = int(math.Floor(info.ValidTo.Sub(now).Hours() / 24))
+		info.Expired = !now.Before(info.ValidTo)
+	}
+	return info
+}
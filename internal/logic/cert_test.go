@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestCertificateInfo(t *testing.T) {
+	validFrom := cdp.TimeSinceEpoch(time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC))
+	validTo := cdp.TimeSinceEpoch(time.Date(2026, 10, 30, 12, 0, 0, 0, time.UTC))
+	sd := &network.SecurityDetails{
+		Protocol: "TLS 1.3", Cipher: "AES_128_GCM", KeyExchangeGroup: "X25519",
+		SubjectName: "example.com", Issuer: "R11", SanList: []string{"example.com", "www.example.com"},
+		ValidFrom: &validFrom, ValidTo: &validTo,
+		CertificateTransparencyCompliance: network.CertificateTransparencyComplianceCompliant,
+	}
+
+	info := certificateInfo(sd, time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+	if info.DaysUntilExpiry != 14 || info.Expired {
+		t.Errorf("DaysUntilExpiry = %d, Expired = %v", info.DaysUntilExpiry, info.Expired)
+	}
+	if info.Subject != "example.com" || info.Issuer != "R11" || len(info.SANs) != 2 || info.Protocol != "TLS 1.3" {
+		t.Errorf("info = %+v", info)
+	}
+	if info.CertificateTransparency != "compliant" || !info.ValidFrom.Equal(time.Time(validFrom)) {
+		t.Errorf("info = %+v", info)
+	}
+
+	info = certificateInfo(sd, time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC))
+	if info.DaysUntilExpiry != -1 || !info.Expired {
+		t.Errorf("expired: DaysUntilExpiry = %d, Expired = %v", info.DaysUntilExpiry, info.Expired)
+	}
+
+	if info := certificateInfo(&network.SecurityDetails{}, time.Now()); info.SANs == nil {
+		t.Error("Expected an empty SAN list rather than null")
+	}
+}
@@ -0,0 +1,31 @@
+package logic
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+)
+
+// repairMislabeledText undoes mojibake produced when a UTF-8 page is served with
+// a single-byte charset (e.g. windows-1252) and decoded accordingly by the browser.
+// It returns the original text and false when no repair applies.
+func repairMislabeledText(text, label string) (string, bool) {
+	enc, name := charset.Lookup(label)
+	if enc == nil || name != "windows-1252" {
+		return text, false
+	}
+	raw, err := enc.NewEncoder().String(text)
+	if err != nil || raw == text || !utf8.ValidString(raw) {
+		return text, false
+	}
+	return raw, true
+}
+
+// isUTF8Charset reports whether label names UTF-8 (or is empty, which browsers treat as UTF-8).
+func isUTF8Charset(label string) bool {
+	if label == "" {
+		return true
+	}
+	_, name := charset.Lookup(label)
+	return name == "utf-8"
+}
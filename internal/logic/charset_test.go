@@ -0,0 +1,26 @@
+package logic
+
+import "testing"
+
+func TestRepairMislabeledText(t *testing.T) {
+	got, repaired := repairMislabeledText("cafÃ© â€“ naÃ¯ve", "iso-8859-1")
+	if !repaired || got != "café – naïve" {
+		t.Errorf("expected mojibake to be repaired, got (%q, %t)", got, repaired)
+	}
+
+	if got, repaired := repairMislabeledText("café", "windows-1252"); repaired {
+		t.Errorf("expected correctly decoded text to be left alone, got %q", got)
+	}
+
+	if _, repaired := repairMislabeledText("cafÃ©", "utf-8"); repaired {
+		t.Error("expected no repair for UTF-8 pages")
+	}
+}
+
+func TestIsUTF8Charset(t *testing.T) {
+	for label, expected := range map[string]bool{"": true, "UTF-8": true, "utf8": true, "Shift_JIS": false, "windows-1252": false} {
+		if got := isUTF8Charset(label); got != expected {
+			t.Errorf("isUTF8Charset(%q) = %t, want %t", label, got, expected)
+		}
+	}
+}
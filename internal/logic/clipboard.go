@@ -0,0 +1,81 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	cdpbrowser "github.com/chromedp/cdproto/browser"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// clipboardPermissions are granted to the page before the clipboard is used,
+// so no permission prompt blocks it.
+var clipboardPermissions = []cdpbrowser.PermissionType{
+	cdpbrowser.PermissionTypeClipboardReadWrite,
+	cdpbrowser.PermissionTypeClipboardSanitizedWrite,
+}
+
+// ReadClipboard returns the text on the system clipboard, read through the
+// current page with navigator.clipboard, e.g. after a copy button was clicked.
+func ReadClipboard(ctx context.Context) (string, error) {
+	var text string
+	if err := runWithClipboard(ctx, `navigator.clipboard.readText()`, &text); err != nil {
+		return "", fmt.Errorf("failed to read the clipboard: %w", err)
+	}
+	return text, nil
+}
+
+// WriteClipboard puts text on the system clipboard through the current page.
+func WriteClipboard(ctx context.Context, text string) error {
+	textJSON, err := json.Marshal(text)
+	if err != nil {
+		return err
+	}
+	var done bool
+	if err := runWithClipboard(ctx, fmt.Sprintf(`navigator.clipboard.writeText(%s).then(() => true)`, textJSON), &done); err != nil {
+		return fmt.Errorf("failed to write the clipboard: %w", err)
+	}
+	return nil
+}
+
+// runWithClipboard evaluates a clipboard expression in the page after granting
+// the page's origin the clipboard permissions (which replaces the other
+// permissions granted to it) and emulating focus, since the clipboard API only
+// works in a focused document.
+func runWithClipboard(ctx context.Context, expression string, res interface{}) error {
+	return chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var location string
+		if err := chromedp.Location(&location).Do(ctx); err != nil {
+			return err
+		}
+		origin, err := clipboardOrigin(location)
+		if err != nil {
+			return err
+		}
+		c := chromedp.FromContext(ctx)
+		if err := cdpbrowser.GrantPermissions(clipboardPermissions).WithOrigin(origin).Do(cdp.WithExecutor(ctx, c.Browser)); err != nil {
+			return fmt.Errorf("failed to grant clipboard permissions: %w", err)
+		}
+		if err := emulation.SetFocusEmulationEnabled(true).Do(ctx); err != nil {
+			return fmt.Errorf("failed to emulate focus: %w", err)
+		}
+		return EvaluateIsolated(expression, res, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+			return p.WithAwaitPromise(true).WithUserGesture(true)
+		}).Do(ctx)
+	}))
+}
+
+// clipboardOrigin returns the origin of the page at location; the clipboard
+// API is only available to secure http(s) origins.
+func clipboardOrigin(location string) (string, error) {
+	u, err := url.Parse(location)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("the clipboard needs a page with an http(s) origin, the current page is %s", location)
+	}
+	return u.Scheme + "://" + u.Host, nil
+}
@@ -0,0 +1,27 @@
+package logic
+
+import "testing"
+
+func TestClipboardOrigin(t *testing.T) {
+	tests := []struct {
+		location string
+		want     string
+		wantErr  bool
+	}{
+		{"https://example.com/app?x=1#y", "https://example.com", false},
+		{"http://localhost:8080/", "http://localhost:8080", false},
+		{"about:blank", "", true},
+		{"file:///tmp/page.html", "", true},
+		{"data:text/html,hi", "", true},
+	}
+	for _, tt := range tests {
+		got, err := clipboardOrigin(tt.location)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("clipboardOrigin(%q) error = %v, wantErr %v", tt.location, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("clipboardOrigin(%q) = %q, want %q", tt.location, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/chromedp"
+)
+
+// CompareOptions configures ComparePages.
+type CompareOptions struct {
+	// Runs is the number of loads of each page; metrics are their medians.
+	Runs int
+	// WaitUntil is the condition that completes a load, as in NavigateOptions.
+	WaitUntil string
+	// Format and Selector select the content that is diffed, as for GetContent.
+	Format   string
+	Selector string
+	// ScreenshotDir is where a.png and b.png are saved; empty for none.
+	ScreenshotDir string
+	FullPage      bool
+}
+
+// ComparePages loads two pages in the tab, one after the other and with the
+// browser cache disabled, and reports their performance metrics, content
+// differences, console errors and screenshots side by side.
+func ComparePages(ctx context.Context, urlA, urlB string, opts CompareOptions) (*models.CompareReport, error) {
+	if opts.Runs < 1 {
+		opts.Runs = 1
+	}
+	if err := SetCacheDisabled(ctx, true); err != nil {
+		return nil, err
+	}
+
+	sideA, contentA, err := inspectPage(ctx, urlA, "a", opts)
+	if err != nil {
+		return nil, fmt.Errorf("page a: %w", err)
+	}
+	sideB, contentB, err := inspectPage(ctx, urlB, "b", opts)
+	if err != nil {
+		return nil, fmt.Errorf("page b: %w", err)
+	}
+
+	report := &models.CompareReport{
+		A:                *sideA,
+		B:                *sideB,
+		Performance:      compareMetrics(sideA.Metrics, sideB.Metrics),
+		Content:          DiffSnapshots(contentA, contentB),
+		NewConsoleErrors: newConsoleErrors(sideA.ConsoleErrors, sideB.ConsoleErrors),
+	}
+	if sideA.Screenshot != "" && sideB.Screenshot != "" {
+		diff, err := screenshotDifference(sideA.Screenshot, sideB.Screenshot)
+		if err != nil {
+			return nil, err
+		}
+		report.ScreenshotDiffPct = &diff
+	}
+	return report, nil
+}
+
+// inspectPage loads url opts.Runs times and collects what ComparePages
+// reports about it, along with the content to diff. Console errors, content
+// and screenshot are taken from the last load.
+func inspectPage(ctx context.Context, url, name string, opts CompareOptions) (*models.CompareSide, map[string]interface{}, error) {
+	side := &models.CompareSide{URL: url}
+	var samples []models.BenchSample
+	var watcher *ConsoleWatcher
+	for i := 1; i <= opts.Runs; i++ {
+		if err := chromedp.Run(ctx, chromedp.Navigate("about:blank")); err != nil {
+			return nil, nil, fmt.Errorf("failed to reset the tab: %w", err)
+		}
+		if i == opts.Runs {
+			watcher = WatchConsole(ctx)
+		}
+		result, sample, err := measureLoad(ctx, url, NavigateOptions{WaitUntil: opts.WaitUntil})
+		if err != nil {
+			if watcher != nil {
+				watcher.Stop()
+			}
+			return nil, nil, err
+		}
+		samples = append(samples, sample)
+		side.FinalURL, side.Status = result.FinalURL, result.Status
+	}
+	side.Metrics = map[string]float64{}
+	for metric, stats := range SummarizeBench(samples) {
+		side.Metrics[metric] = stats.P50
+	}
+
+	content, err := GetContent(ctx, "", ContentOptions{Format: opts.Format, Selector: opts.Selector, Strip: DefaultStripSelectors})
+	if err != nil {
+		watcher.Stop()
+		return nil, nil, err
+	}
+	side.Title, _ = content["title"].(string)
+	if opts.ScreenshotDir != "" {
+		side.Screenshot, err = Screenshot(ctx, "", filepath.Join(opts.ScreenshotDir, name+".png"), ScreenshotOptions{FullPage: opts.FullPage})
+		if err != nil {
+			watcher.Stop()
+			return nil, nil, err
+		}
+	}
+	side.ConsoleErrors = watcher.Stop()
+
+	// Only what is shown is diffed, not the URL or the extraction details.
+	diffed := map[string]interface{}{}
+	for _, key := range []string{"title", "format", "content"} {
+		diffed[key] = content[key]
+	}
+	return side, diffed, nil
+}
+
+// compareMetrics compares the metrics both pages report, in the order of
+// benchMetrics.
+func compareMetrics(a, b map[string]float64) []models.MetricDelta {
+	deltas := []models.MetricDelta{}
+	for _, metric := range benchMetrics {
+		valueA, okA := a[metric.name]
+		valueB, okB := b[metric.name]
+		if !okA || !okB {
+			continue
+		}
+		delta := models.MetricDelta{Metric: metric.name, A: valueA, B: valueB, DeltaMs: valueB - valueA}
+		if valueA > 0 {
+			delta.DeltaPct = math.Round((valueB-valueA)/valueA*1000) / 10
+		}
+		deltas = append(deltas, delta)
+	}
+	return deltas
+}
+
+// newConsoleErrors returns the errors of b whose text a doesn't report.
+func newConsoleErrors(a, b []models.ConsoleMessage) []models.ConsoleMessage {
+	known := map[string]bool{}
+	for _, msg := range a {
+		known[msg.Source+"\x00"+msg.Text] = true
+	}
+	errors := []models.ConsoleMessage{}
+	for _, msg := range b {
+		if !known[msg.Source+"\x00"+msg.Text] {
+			errors = append(errors, msg)
+		}
+	}
+	return errors
+}
+
+// screenshotDifference returns the percentage of pixels that differ between
+// two PNG files.
+func screenshotDifference(pathA, pathB string) (float64, error) {
+	var images [2]image.Image
+	for i, path := range []string{pathA, pathB} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0, err
+		}
+		if images[i], err = png.Decode(bytes.NewReader(data)); err != nil {
+			return 0, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+	}
+	return imageDifference(images[0], images[1]), nil
+}
+
+// imageDifference returns the percentage of pixels that differ between a and
+// b. When their sizes differ, the pixels only one of them covers count as
+// different.
+func imageDifference(a, b image.Image) float64 {
+	boundsA, boundsB := a.Bounds(), b.Bounds()
+	width := max(boundsA.Dx(), boundsB.Dx())
+	height := max(boundsA.Dy(), boundsB.Dy())
+	if width == 0 || height == 0 {
+		return 0
+	}
+	different := 0
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			pa := image.Pt(boundsA.Min.X+x, boundsA.Min.Y+y)
+			pb := image.Pt(boundsB.Min.X+x, boundsB.Min.Y+y)
+			if !pa.In(boundsA) || !pb.In(boundsB) {
+				different++
+				continue
+			}
+			r1, g1, b1, a1 := a.At(pa.X, pa.Y).RGBA()
+			r2, g2, b2, a2 := b.At(pb.X, pb.Y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				different++
+			}
+		}
+	}
+	return math.Round(float64(different)/float64(width*height)*10000) / 100
+}
@@ -0,0 +1,65 @@
+package logic
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"browser-tools-go/internal/models"
+)
+
+func TestCompareMetrics(t *testing.T) {
+	deltas := compareMetrics(
+		map[string]float64{"loadMs": 200, "responseMs": 50, "firstPaintMs": 90},
+		map[string]float64{"loadMs": 250, "responseMs": 40, "totalMs": 300},
+	)
+	if len(deltas) != 2 {
+		t.Fatalf("Expected the 2 metrics both pages report, got %+v", deltas)
+	}
+	if deltas[0].Metric != "responseMs" || deltas[0].DeltaMs != -10 || deltas[0].DeltaPct != -20 {
+		t.Errorf("Unexpected responseMs delta: %+v", deltas[0])
+	}
+	if deltas[1].Metric != "loadMs" || deltas[1].DeltaMs != 50 || deltas[1].DeltaPct != 25 {
+		t.Errorf("Unexpected loadMs delta: %+v", deltas[1])
+	}
+}
+
+func TestNewConsoleErrors(t *testing.T) {
+	a := []models.ConsoleMessage{{Source: ConsoleSourceConsole, Text: "known"}}
+	b := []models.ConsoleMessage{
+		{Source: ConsoleSourceConsole, Text: "known", Line: 3},
+		{Source: ConsoleSourceException, Text: "known"},
+		{Source: ConsoleSourceBrowser, Text: "Failed to load resource"},
+	}
+	got := newConsoleErrors(a, b)
+	if len(got) != 2 || got[0].Source != ConsoleSourceException || got[1].Source != ConsoleSourceBrowser {
+		t.Errorf("Unexpected new errors: %+v", got)
+	}
+}
+
+func TestImageDifference(t *testing.T) {
+	fill := func(w, h int, c color.Color) *image.RGBA {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				img.Set(x, y, c)
+			}
+		}
+		return img
+	}
+	white := color.RGBA{255, 255, 255, 255}
+
+	if d := imageDifference(fill(10, 10, white), fill(10, 10, white)); d != 0 {
+		t.Errorf("Expected identical images to differ by 0%%, got %g", d)
+	}
+	changed := fill(10, 10, white)
+	for x := 0; x < 10; x++ {
+		changed.Set(x, 0, color.RGBA{255, 0, 0, 255})
+	}
+	if d := imageDifference(fill(10, 10, white), changed); d != 10 {
+		t.Errorf("Expected one changed row to differ by 10%%, got %g", d)
+	}
+	if d := imageDifference(fill(10, 10, white), fill(10, 20, white)); d != 50 {
+		t.Errorf("Expected the extra half to differ by 50%%, got %g", d)
+	}
+}
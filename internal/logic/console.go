@@ -0,0 +1,140 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"browser-tools-go/internal/models"
+
+	cdplog "github.com/chromedp/cdproto/log"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// Sources of console errors reported in ConsoleMessage.Source.
+const (
+	ConsoleSourceConsole   = "console"   // console.error or a failed console.assert
+	ConsoleSourceException = "exception" // uncaught exception or unhandled rejection
+	ConsoleSourceBrowser   = "browser"   // browser log entry, e.g. a failed resource load
+)
+
+// ConsoleLevelError is the ConsoleMessage.Level of errors, including failed
+// console.assert calls and uncaught exceptions.
+const ConsoleLevelError = "error"
+
+// consoleTailSize is how many of the latest console messages a
+// ConsoleWatcher keeps for Tail.
+const consoleTailSize = 50
+
+// ConsoleWatcher collects the errors reported by the page of a tab: console
+// errors, uncaught exceptions and error entries of the browser log. It also
+// keeps the latest messages of every level.
+type ConsoleWatcher struct {
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	errors []models.ConsoleMessage
+	tail   []models.ConsoleMessage
+}
+
+// WatchConsole starts collecting the console errors of the tab in ctx until
+// Stop is called.
+func WatchConsole(ctx context.Context) *ConsoleWatcher {
+	listenCtx, cancel := context.WithCancel(ctx)
+	w := &ConsoleWatcher{cancel: cancel}
+	chromedp.ListenTarget(listenCtx, w.handle)
+	return w
+}
+
+// Errors returns the errors collected so far.
+func (w *ConsoleWatcher) Errors() []models.ConsoleMessage {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]models.ConsoleMessage{}, w.errors...)
+}
+
+// Tail returns the latest messages of every level, oldest first.
+func (w *ConsoleWatcher) Tail() []models.ConsoleMessage {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]models.ConsoleMessage{}, w.tail...)
+}
+
+// Stop stops collecting and returns the errors collected.
+func (w *ConsoleWatcher) Stop() []models.ConsoleMessage {
+	w.cancel()
+	return w.Errors()
+}
+
+func (w *ConsoleWatcher) handle(ev interface{}) {
+	var msg models.ConsoleMessage
+	switch ev := ev.(type) {
+	case *runtime.EventConsoleAPICalled:
+		parts := make([]string, len(ev.Args))
+		for i, arg := range ev.Args {
+			parts[i] = remoteObjectText(arg)
+		}
+		msg = models.ConsoleMessage{Source: ConsoleSourceConsole, Level: string(ev.Type), Text: strings.Join(parts, " ")}
+		if ev.Type == runtime.APITypeError || ev.Type == runtime.APITypeAssert {
+			msg.Level = ConsoleLevelError
+		}
+		if ev.StackTrace != nil && len(ev.StackTrace.CallFrames) > 0 {
+			frame := ev.StackTrace.CallFrames[0]
+			msg.URL, msg.Line = frame.URL, frame.LineNumber+1
+		}
+	case *runtime.EventExceptionThrown:
+		details := ev.ExceptionDetails
+		if details == nil {
+			return
+		}
+		text := details.Text
+		if details.Exception != nil && details.Exception.Description != "" {
+			text = details.Exception.Description
+		}
+		msg = models.ConsoleMessage{Source: ConsoleSourceException, Level: ConsoleLevelError, Text: text, URL: details.URL, Line: details.LineNumber + 1}
+	case *cdplog.EventEntryAdded:
+		entry := ev.Entry
+		if entry == nil {
+			return
+		}
+		msg = models.ConsoleMessage{Source: ConsoleSourceBrowser, Level: string(entry.Level), Text: entry.Text, URL: entry.URL}
+		if entry.LineNumber > 0 {
+			msg.Line = entry.LineNumber + 1
+		}
+	default:
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if msg.Level == ConsoleLevelError {
+		w.errors = append(w.errors, msg)
+	}
+	w.tail = append(w.tail, msg)
+	if len(w.tail) > consoleTailSize {
+		w.tail = w.tail[len(w.tail)-consoleTailSize:]
+	}
+}
+
+// remoteObjectText renders a console argument the way DevTools prints it:
+// strings unquoted, primitives as JSON, objects by their description.
+func remoteObjectText(obj *runtime.RemoteObject) string {
+	if obj == nil {
+		return ""
+	}
+	if len(obj.Value) > 0 {
+		var s string
+		if json.Unmarshal(obj.Value, &s) == nil {
+			return s
+		}
+		return string(obj.Value)
+	}
+	if obj.UnserializableValue != "" {
+		return string(obj.UnserializableValue)
+	}
+	if obj.Description != "" {
+		return obj.Description
+	}
+	return string(obj.Type)
+}
@@ -0,0 +1,67 @@
+package logic
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	cdplog "github.com/chromedp/cdproto/log"
+	"github.com/chromedp/cdproto/runtime"
+)
+
+func TestConsoleWatcher(t *testing.T) {
+	w := &ConsoleWatcher{cancel: func() {}}
+	w.handle(&runtime.EventConsoleAPICalled{Type: runtime.APITypeLog, Args: []*runtime.RemoteObject{{Value: []byte(`"ignored"`)}}})
+	w.handle(&runtime.EventConsoleAPICalled{
+		Type: runtime.APITypeError,
+		Args: []*runtime.RemoteObject{
+			{Type: runtime.TypeString, Value: []byte(`"failed:"`)},
+			{Type: runtime.TypeNumber, Value: []byte(`42`)},
+			{Type: runtime.TypeObject, Description: "Error: boom"},
+		},
+		StackTrace: &runtime.StackTrace{CallFrames: []*runtime.CallFrame{{URL: "https://a.test/app.js", LineNumber: 9}}},
+	})
+	w.handle(&runtime.EventExceptionThrown{ExceptionDetails: &runtime.ExceptionDetails{
+		Text: "Uncaught", URL: "https://a.test/app.js", LineNumber: 0,
+		Exception: &runtime.RemoteObject{Description: "TypeError: x is undefined"},
+	}})
+	w.handle(&cdplog.EventEntryAdded{Entry: &cdplog.Entry{Level: cdplog.LevelWarning, Text: "ignored"}})
+	w.handle(&cdplog.EventEntryAdded{Entry: &cdplog.Entry{Level: cdplog.LevelError, Text: "Failed to load resource", URL: "https://a.test/x.png"}})
+
+	got := w.Stop()
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 errors, got %+v", got)
+	}
+	if got[0].Source != ConsoleSourceConsole || got[0].Text != "failed: 42 Error: boom" || got[0].Line != 10 {
+		t.Errorf("Unexpected console error: %+v", got[0])
+	}
+	if got[1].Source != ConsoleSourceException || got[1].Text != "TypeError: x is undefined" || got[1].Line != 1 {
+		t.Errorf("Unexpected exception: %+v", got[1])
+	}
+	if got[2].Source != ConsoleSourceBrowser || got[2].URL != "https://a.test/x.png" || got[2].Line != 0 {
+		t.Errorf("Unexpected browser log error: %+v", got[2])
+	}
+
+	tail := w.Tail()
+	var levels []string
+	for _, msg := range tail {
+		levels = append(levels, msg.Level)
+	}
+	if want := []string{"log", "error", "error", "warning", "error"}; !slices.Equal(levels, want) {
+		t.Errorf("Expected tail levels %v, got %v", want, levels)
+	}
+}
+
+func TestConsoleWatcher_TailSize(t *testing.T) {
+	w := &ConsoleWatcher{cancel: func() {}}
+	for i := 0; i < consoleTailSize+5; i++ {
+		w.handle(&runtime.EventConsoleAPICalled{Type: runtime.APITypeLog, Args: []*runtime.RemoteObject{{Value: []byte(strconv.Itoa(i))}}})
+	}
+	tail := w.Tail()
+	if len(tail) != consoleTailSize || tail[0].Text != "5" || tail[len(tail)-1].Text != strconv.Itoa(consoleTailSize+4) {
+		t.Errorf("Expected the latest %d messages, got %d from %q", consoleTailSize, len(tail), tail[0].Text)
+	}
+	if len(w.Errors()) != 0 {
+		t.Errorf("Expected no errors, got %v", w.Errors())
+	}
+}
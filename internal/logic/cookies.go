@@ -0,0 +1,397 @@
+package logic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// CookieParams describes a cookie written by SetCookie.
+type CookieParams struct {
+	Name  string
+	Value string
+	// Domain defaults to the host of the current page when empty.
+	Domain   string
+	Path     string
+	Secure   bool
+	HTTPOnly bool
+	// SameSite is Strict, Lax or None; empty leaves it unset.
+	SameSite string
+	// Expires is zero for a session cookie.
+	Expires time.Time
+}
+
+// SetCookie creates or replaces a cookie in the browser.
+func SetCookie(ctx context.Context, params CookieParams) error {
+	if params.Name == "" {
+		return fmt.Errorf("cookie name is required")
+	}
+
+	action := network.SetCookie(params.Name, params.Value).
+		WithSecure(params.Secure).
+		WithHTTPOnly(params.HTTPOnly)
+	if params.Path != "" {
+		action = action.WithPath(params.Path)
+	}
+	if !params.Expires.IsZero() {
+		expires := cdp.TimeSinceEpoch(params.Expires)
+		action = action.WithExpires(&expires)
+	}
+	if params.SameSite != "" {
+		sameSite, err := parseSameSite(params.SameSite)
+		if err != nil {
+			return err
+		}
+		action = action.WithSameSite(sameSite)
+	}
+
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		if params.Domain != "" {
+			action = action.WithDomain(params.Domain)
+		} else {
+			// Chrome needs either a domain or a URL to scope the cookie.
+			var location string
+			if err := chromedp.Location(&location).Do(ctx); err != nil {
+				return err
+			}
+			if !strings.HasPrefix(location, "http") {
+				return fmt.Errorf("no --domain given and the current page (%s) has no host", location)
+			}
+			action = action.WithURL(location)
+		}
+		return action.Do(ctx)
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to set cookie '%s': %w", params.Name, err)
+	}
+	return nil
+}
+
+// DeleteCookies deletes the cookies matching name and domain and returns how many
+// were deleted. An empty name matches every cookie; an empty domain matches every
+// domain, and a domain also matches its subdomains.
+func DeleteCookies(ctx context.Context, name, domain string) (int, error) {
+	deleted := 0
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		if name == "" && domain == "" {
+			cookies, err := network.GetCookies().Do(ctx)
+			if err != nil {
+				return err
+			}
+			deleted = len(cookies)
+			return network.ClearBrowserCookies().Do(ctx)
+		}
+
+		cookies, err := network.GetCookies().Do(ctx)
+		if err != nil {
+			return err
+		}
+		for _, c := range cookies {
+			if (name != "" && c.Name != name) || !cookieDomainMatches(c.Domain, domain) {
+				continue
+			}
+			if err := network.DeleteCookies(c.Name).WithDomain(c.Domain).WithPath(c.Path).Do(ctx); err != nil {
+				return err
+			}
+			deleted++
+		}
+		return nil
+	}))
+	if err != nil {
+		return deleted, fmt.Errorf("failed to delete cookies: %w", err)
+	}
+	return deleted, nil
+}
+
+// CookieFilter selects the cookies returned by ListCookies. Empty fields
+// match every cookie.
+type CookieFilter struct {
+	// URLs limits the cookies to those the browser would send with a request
+	// to one of the URLs.
+	URLs []string
+	// Domain matches the cookie domain and its subdomains, as in DeleteCookies.
+	Domain string
+	Name   string
+}
+
+// ListCookies returns the cookies of the browser that match filter.
+func ListCookies(ctx context.Context, filter CookieFilter) ([]*network.Cookie, error) {
+	action := network.GetCookies()
+	if len(filter.URLs) > 0 {
+		action = action.WithUrls(filter.URLs)
+	}
+	var cookies []*network.Cookie
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		cookies, err = action.Do(ctx)
+		return err
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cookies: %w", err)
+	}
+	return filterCookies(cookies, filter), nil
+}
+
+// filterCookies returns the cookies matching the name and domain of filter.
+func filterCookies(cookies []*network.Cookie, filter CookieFilter) []*network.Cookie {
+	matched := []*network.Cookie{}
+	for _, c := range cookies {
+		if (filter.Name != "" && c.Name != filter.Name) || !cookieDomainMatches(c.Domain, filter.Domain) {
+			continue
+		}
+		matched = append(matched, c)
+	}
+	return matched
+}
+
+// CookieHeader formats cookies as the value of a Cookie request header.
+// Cookies with longer paths come first, as browsers send them.
+func CookieHeader(cookies []*network.Cookie) string {
+	sorted := append([]*network.Cookie(nil), cookies...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Path) > len(sorted[j].Path)
+	})
+	pairs := make([]string, len(sorted))
+	for i, c := range sorted {
+		pairs[i] = c.Name + "=" + c.Value
+	}
+	return strings.Join(pairs, "; ")
+}
+
+// ParseCookieExpiry parses an expiry given as RFC 3339 time, Unix seconds or a
+// duration from now (e.g. "720h"). An empty string means a session cookie.
+func ParseCookieExpiry(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid expiry '%s': use RFC 3339, Unix seconds or a duration like 720h", value)
+}
+
+// cookieDomainMatches reports whether a cookie domain belongs to domain or one of its subdomains.
+func cookieDomainMatches(cookieDomain, domain string) bool {
+	if domain == "" {
+		return true
+	}
+	cookieDomain = strings.TrimPrefix(strings.ToLower(cookieDomain), ".")
+	domain = strings.TrimPrefix(strings.ToLower(domain), ".")
+	return cookieDomain == domain || strings.HasSuffix(cookieDomain, "."+domain)
+}
+
+// parseSameSite converts a case-insensitive SameSite value to its CDP form.
+// The "no_restriction" spelling used by browser extensions is accepted for None.
+func parseSameSite(value string) (network.CookieSameSite, error) {
+	switch strings.ToLower(value) {
+	case "strict":
+		return network.CookieSameSiteStrict, nil
+	case "lax":
+		return network.CookieSameSiteLax, nil
+	case "none", "no_restriction":
+		return network.CookieSameSiteNone, nil
+	default:
+		return "", fmt.Errorf("invalid SameSite value '%s': use Strict, Lax or None", value)
+	}
+}
+
+// netscapeHeader is the first line of a Netscape cookie file, as written by curl.
+const netscapeHeader = "# Netscape HTTP Cookie File"
+
+// httpOnlyPrefix marks HttpOnly cookies in Netscape cookie files (curl convention).
+const httpOnlyPrefix = "#HttpOnly_"
+
+// ExportCookies returns all browser cookies in the given format ("json" or "netscape").
+func ExportCookies(ctx context.Context, format string) ([]byte, error) {
+	cookies, err := GetCookies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(cookies, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode cookies: %w", err)
+		}
+		return append(data, '\n'), nil
+	case "netscape":
+		return FormatNetscapeCookies(cookies), nil
+	default:
+		return nil, fmt.Errorf("unsupported cookie format: %s", format)
+	}
+}
+
+// ImportCookies writes the given cookies into the browser.
+func ImportCookies(ctx context.Context, cookies []*network.CookieParam) error {
+	if len(cookies) == 0 {
+		return nil
+	}
+	if err := chromedp.Run(ctx, network.SetCookies(cookies)); err != nil {
+		return fmt.Errorf("failed to import cookies: %w", err)
+	}
+	return nil
+}
+
+// FormatNetscapeCookies renders cookies as a Netscape cookie file readable by curl and wget.
+func FormatNetscapeCookies(cookies []*network.Cookie) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(netscapeHeader + "\n\n")
+	for _, c := range cookies {
+		domain := c.Domain
+		if c.HTTPOnly {
+			domain = httpOnlyPrefix + domain
+		}
+		expires := int64(0)
+		if !c.Session && c.Expires > 0 {
+			expires = int64(c.Expires)
+		}
+		fmt.Fprintf(&buf, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			domain,
+			netscapeBool(strings.HasPrefix(c.Domain, ".")),
+			c.Path,
+			netscapeBool(c.Secure),
+			expires,
+			c.Name,
+			c.Value)
+	}
+	return buf.Bytes()
+}
+
+// ParseCookieFile parses a cookie file in Netscape format or as a JSON array
+// (the output of "cookies export --format json" or of common browser extensions).
+func ParseCookieFile(data []byte) ([]*network.CookieParam, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return parseJSONCookies(trimmed)
+	}
+	return parseNetscapeCookies(data)
+}
+
+// parseNetscapeCookies parses the tab-separated Netscape cookie file format.
+func parseNetscapeCookies(data []byte) ([]*network.CookieParam, error) {
+	var cookies []*network.CookieParam
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		httpOnly := false
+		if strings.HasPrefix(line, httpOnlyPrefix) {
+			httpOnly = true
+			line = strings.TrimPrefix(line, httpOnlyPrefix)
+		} else if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("line %d: expected 7 tab-separated fields, got %d", lineNo, len(fields))
+		}
+		expires, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid expiry '%s'", lineNo, fields[4])
+		}
+
+		cookie := &network.CookieParam{
+			Name:     fields[5],
+			Value:    fields[6],
+			Domain:   fields[0],
+			Path:     fields[2],
+			Secure:   strings.EqualFold(fields[3], "TRUE"),
+			HTTPOnly: httpOnly,
+		}
+		if expires > 0 {
+			t := cdp.TimeSinceEpoch(time.Unix(expires, 0))
+			cookie.Expires = &t
+		}
+		cookies = append(cookies, cookie)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return cookies, nil
+}
+
+// jsonCookie accepts both CDP cookies ("expires") and browser extension exports ("expirationDate").
+type jsonCookie struct {
+	Name           string   `json:"name"`
+	Value          string   `json:"value"`
+	Domain         string   `json:"domain"`
+	Path           string   `json:"path"`
+	Secure         bool     `json:"secure"`
+	HTTPOnly       bool     `json:"httpOnly"`
+	SameSite       string   `json:"sameSite"`
+	Session        bool     `json:"session"`
+	Expires        *float64 `json:"expires"`
+	ExpirationDate *float64 `json:"expirationDate"`
+}
+
+// parseJSONCookies parses a JSON array of cookies.
+func parseJSONCookies(data []byte) ([]*network.CookieParam, error) {
+	var raw []jsonCookie
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse cookie JSON: %w", err)
+	}
+
+	cookies := make([]*network.CookieParam, 0, len(raw))
+	for i, c := range raw {
+		if c.Name == "" {
+			return nil, fmt.Errorf("cookie %d: missing name", i)
+		}
+		cookie := &network.CookieParam{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			Secure:   c.Secure,
+			HTTPOnly: c.HTTPOnly,
+		}
+		if cookie.Path == "" {
+			cookie.Path = "/"
+		}
+		if c.SameSite != "" && !strings.EqualFold(c.SameSite, "unspecified") {
+			sameSite, err := parseSameSite(c.SameSite)
+			if err != nil {
+				return nil, fmt.Errorf("cookie %d: %w", i, err)
+			}
+			cookie.SameSite = sameSite
+		}
+
+		expires := c.Expires
+		if expires == nil {
+			expires = c.ExpirationDate
+		}
+		if !c.Session && expires != nil && *expires > 0 {
+			sec, frac := math.Modf(*expires)
+			t := cdp.TimeSinceEpoch(time.Unix(int64(sec), int64(frac*1e9)))
+			cookie.Expires = &t
+		}
+		cookies = append(cookies, cookie)
+	}
+	return cookies, nil
+}
+
+// netscapeBool renders a boolean the way Netscape cookie files do.
+func netscapeBool(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
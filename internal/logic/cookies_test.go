@@ -0,0 +1,156 @@
+package logic
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestParseCookieExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"session", "", time.Time{}, false},
+		{"rfc3339", "2026-02-01T00:00:00Z", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), false},
+		{"unix seconds", "1767225600", time.Unix(1767225600, 0), false},
+		{"duration", "24h", now.Add(24 * time.Hour), false},
+		{"invalid", "tomorrow", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCookieExpiry(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCookieExpiry(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseCookieExpiry(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCookieDomainMatches(t *testing.T) {
+	tests := []struct {
+		cookieDomain, domain string
+		want                 bool
+	}{
+		{"example.com", "", true},
+		{".example.com", "example.com", true},
+		{"www.example.com", "example.com", true},
+		{"example.com", ".EXAMPLE.com", true},
+		{"notexample.com", "example.com", false},
+		{"example.org", "example.com", false},
+	}
+	for _, tt := range tests {
+		if got := cookieDomainMatches(tt.cookieDomain, tt.domain); got != tt.want {
+			t.Errorf("cookieDomainMatches(%q, %q) = %v, want %v", tt.cookieDomain, tt.domain, got, tt.want)
+		}
+	}
+}
+
+func TestFilterCookies(t *testing.T) {
+	cookies := []*network.Cookie{
+		{Name: "session", Domain: ".example.com"},
+		{Name: "session", Domain: "api.example.com"},
+		{Name: "theme", Domain: "example.com"},
+		{Name: "session", Domain: "other.test"},
+	}
+	tests := []struct {
+		filter CookieFilter
+		want   int
+	}{
+		{CookieFilter{}, 4},
+		{CookieFilter{Name: "session"}, 3},
+		{CookieFilter{Domain: "example.com"}, 3},
+		{CookieFilter{Domain: "api.example.com", Name: "session"}, 1},
+		{CookieFilter{Name: "missing"}, 0},
+	}
+	for _, tt := range tests {
+		if got := filterCookies(cookies, tt.filter); len(got) != tt.want {
+			t.Errorf("filterCookies(%+v) returned %d cookies, want %d", tt.filter, len(got), tt.want)
+		}
+	}
+}
+
+func TestCookieHeader(t *testing.T) {
+	cookies := []*network.Cookie{
+		{Name: "a", Value: "1", Path: "/"},
+		{Name: "b", Value: "2", Path: "/account"},
+		{Name: "c", Value: "x=y", Path: "/"},
+	}
+	if got, want := CookieHeader(cookies), "b=2; a=1; c=x=y"; got != want {
+		t.Errorf("CookieHeader() = %q, want %q", got, want)
+	}
+	if got := CookieHeader(nil); got != "" {
+		t.Errorf("CookieHeader(nil) = %q, want empty", got)
+	}
+}
+
+func TestNetscapeCookies_RoundTrip(t *testing.T) {
+	cookies := []*network.Cookie{
+		{Name: "sid", Value: "abc", Domain: ".example.com", Path: "/", Secure: true, HTTPOnly: true, Expires: 1767225600},
+		{Name: "theme", Value: "dark", Domain: "www.example.com", Path: "/app", Session: true, Expires: -1},
+	}
+
+	data := FormatNetscapeCookies(cookies)
+	if !strings.HasPrefix(string(data), netscapeHeader) {
+		t.Errorf("expected Netscape header, got %q", data)
+	}
+	if !strings.Contains(string(data), "#HttpOnly_.example.com\tTRUE\t/\tTRUE\t1767225600\tsid\tabc\n") {
+		t.Errorf("unexpected HttpOnly cookie line in %q", data)
+	}
+
+	parsed, err := ParseCookieFile(data)
+	if err != nil {
+		t.Fatalf("ParseCookieFile returned error: %v", err)
+	}
+	if len(parsed) != 2 {
+		t.Fatalf("expected 2 cookies, got %d", len(parsed))
+	}
+	if !parsed[0].HTTPOnly || !parsed[0].Secure || parsed[0].Domain != ".example.com" || parsed[0].Expires == nil {
+		t.Errorf("unexpected first cookie: %+v", parsed[0])
+	}
+	if parsed[1].Expires != nil || parsed[1].Path != "/app" || parsed[1].HTTPOnly {
+		t.Errorf("unexpected session cookie: %+v", parsed[1])
+	}
+}
+
+func TestParseCookieFile_JSON(t *testing.T) {
+	data := []byte(`[
+		{"name": "a", "value": "1", "domain": "example.com", "expires": -1, "session": true},
+		{"name": "b", "value": "2", "domain": ".example.com", "expirationDate": 1767225600.5, "sameSite": "no_restriction", "httpOnly": true}
+	]`)
+
+	cookies, err := ParseCookieFile(data)
+	if err != nil {
+		t.Fatalf("ParseCookieFile returned error: %v", err)
+	}
+	if len(cookies) != 2 {
+		t.Fatalf("expected 2 cookies, got %d", len(cookies))
+	}
+	if cookies[0].Expires != nil || cookies[0].Path != "/" {
+		t.Errorf("unexpected session cookie: %+v", cookies[0])
+	}
+	if cookies[1].Expires == nil || cookies[1].Expires.Time().Unix() != 1767225600 {
+		t.Errorf("expected expirationDate to be used, got %+v", cookies[1].Expires)
+	}
+	if cookies[1].SameSite != network.CookieSameSiteNone || !cookies[1].HTTPOnly {
+		t.Errorf("unexpected extension cookie: %+v", cookies[1])
+	}
+
+	if _, err := ParseCookieFile([]byte(`[{"value": "x"}]`)); err == nil {
+		t.Error("expected error for cookie without name")
+	}
+}
+
+func TestParseCookieFile_InvalidNetscapeLine(t *testing.T) {
+	if _, err := ParseCookieFile([]byte("example.com\tFALSE\t/\n")); err == nil {
+		t.Error("expected error for malformed line")
+	}
+}
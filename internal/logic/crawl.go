@@ -0,0 +1,263 @@
+package logic
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"browser-tools-go/internal/browser"
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/store"
+
+	"github.com/chromedp/chromedp"
+)
+
+// CrawlOptions configures a new crawl run.
+type CrawlOptions struct {
+	// MaxDepth is the number of link hops followed from the start URL.
+	MaxDepth int
+	// MaxPages caps the number of pages visited over the whole run, including resumed parts.
+	MaxPages int
+	Format   string
+	// SameHost restricts the crawl to the host of the start URL.
+	SameHost bool
+}
+
+// collectLinksScript returns the absolute http(s) URLs linked from the current page, without fragments.
+const collectLinksScript = `
+	(() => {
+		const links = new Set();
+		for (const a of document.querySelectorAll('a[href]')) {
+			try {
+				const u = new URL(a.href, document.baseURI);
+				if (u.protocol !== 'http:' && u.protocol !== 'https:') continue;
+				u.hash = '';
+				links.add(u.href);
+			} catch (e) {}
+		}
+		return Array.from(links);
+	})();
+`
+
+// NewCrawlRunID returns a sortable, unique identifier for a crawl run.
+func NewCrawlRunID(now time.Time) string {
+	suffix := make([]byte, 3)
+	if _, err := rand.Read(suffix); err != nil {
+		return now.UTC().Format("20060102-150405")
+	}
+	return now.UTC().Format("20060102-150405") + "-" + hex.EncodeToString(suffix)
+}
+
+// NewCrawlCheckpoint returns the initial state of a crawl run starting at startURL.
+func NewCrawlCheckpoint(runID, startURL string, opts CrawlOptions) *config.CrawlCheckpoint {
+	now := time.Now().UTC()
+	return &config.CrawlCheckpoint{
+		RunID:     runID,
+		StartURL:  startURL,
+		MaxDepth:  opts.MaxDepth,
+		MaxPages:  opts.MaxPages,
+		Format:    opts.Format,
+		SameHost:  opts.SameHost,
+		Frontier:  []config.CrawlItem{{URL: startURL}},
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Crawl visits pages breadth-first from the checkpoint's frontier, calling onPage
+// for every visited page. The checkpoint is saved after each page, so an
+// interrupted run can continue from where it stopped by passing the loaded
+// checkpoint back in. Pages already in dedupe are skipped and newly visited
+// pages are recorded there. With a concurrency above 1, up to that many pages
+// are visited at once on tabs of their own; otherwise pages are visited on the
+// tab of ctx.
+func Crawl(ctx context.Context, checkpoint *config.CrawlCheckpoint, dedupe *store.DedupeStore, concurrency int, onPage func(*models.CrawlPage)) error {
+	visited := make(map[string]bool, len(checkpoint.Visited))
+	for _, u := range checkpoint.Visited {
+		visited[u] = true
+	}
+
+	var pool *browser.TabPool
+	if concurrency > 1 {
+		pool = NewTabPool(ctx, concurrency)
+		defer pool.Close()
+	} else {
+		concurrency = 1
+	}
+
+	for len(checkpoint.Frontier) > 0 && (checkpoint.MaxPages <= 0 || len(checkpoint.Visited) < checkpoint.MaxPages) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		limit := concurrency
+		if checkpoint.MaxPages > 0 {
+			limit = min(limit, checkpoint.MaxPages-len(checkpoint.Visited))
+		}
+		items, err := nextCrawlItems(checkpoint, visited, dedupe, limit)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		pages, links := crawlPages(ctx, pool, items, checkpoint.Format)
+		if ctx.Err() != nil {
+			// The pages were cut short by the interruption; keep them for the resumed run.
+			checkpoint.Frontier = append(items, checkpoint.Frontier...)
+			return ctx.Err()
+		}
+
+		for i, item := range items {
+			page := pages[i]
+			visited[item.URL] = true
+			checkpoint.Visited = append(checkpoint.Visited, item.URL)
+			if item.Depth < checkpoint.MaxDepth {
+				checkpoint.Frontier = enqueueLinks(checkpoint, visited, allowedLinks(ctx, links[i]), item.Depth+1)
+			}
+			if dedupe != nil && page.Error == "" {
+				if err := dedupe.Mark("crawl", item.URL); err != nil {
+					return err
+				}
+			}
+
+			onPage(page)
+			checkpoint.UpdatedAt = time.Now().UTC()
+			if err := config.SaveCrawlCheckpoint(checkpoint); err != nil {
+				return fmt.Errorf("failed to save crawl checkpoint: %w", err)
+			}
+		}
+	}
+
+	checkpoint.Completed = true
+	checkpoint.UpdatedAt = time.Now().UTC()
+	if err := config.SaveCrawlCheckpoint(checkpoint); err != nil {
+		return fmt.Errorf("failed to save crawl checkpoint: %w", err)
+	}
+	return nil
+}
+
+// nextCrawlItems takes up to limit items to visit off the front of the
+// checkpoint's frontier, dropping the ones already visited or seen in dedupe.
+func nextCrawlItems(checkpoint *config.CrawlCheckpoint, visited map[string]bool, dedupe *store.DedupeStore, limit int) ([]config.CrawlItem, error) {
+	var items []config.CrawlItem
+	taken := make(map[string]bool, limit)
+	for len(checkpoint.Frontier) > 0 && len(items) < limit {
+		item := checkpoint.Frontier[0]
+		checkpoint.Frontier = checkpoint.Frontier[1:]
+		if visited[item.URL] || taken[item.URL] {
+			continue
+		}
+		if dedupe != nil {
+			seen, err := dedupe.Seen("crawl", item.URL)
+			if err != nil {
+				return nil, err
+			}
+			if seen {
+				i18n.Printf("⏭️ Skipping %s (seen in a previous run)", item.URL)
+				continue
+			}
+		}
+		taken[item.URL] = true
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// crawlPages visits items, on tabs checked out of pool when there is one and
+// one after the other on the tab of ctx otherwise. Results are in the order
+// of items.
+func crawlPages(ctx context.Context, pool *browser.TabPool, items []config.CrawlItem, format string) ([]*models.CrawlPage, [][]string) {
+	pages := make([]*models.CrawlPage, len(items))
+	links := make([][]string, len(items))
+	if pool == nil {
+		for i, item := range items {
+			pages[i], links[i] = crawlPage(ctx, item, format)
+		}
+		return pages, links
+	}
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := pool.Do(ctx, func(tabCtx context.Context) error {
+				pages[i], links[i] = crawlPage(tabCtx, item, format)
+				return nil
+			})
+			if err != nil {
+				pages[i] = &models.CrawlPage{URL: item.URL, Depth: item.Depth, CrawledAt: time.Now().UTC(), Error: err.Error()}
+			}
+		}()
+	}
+	wg.Wait()
+	return pages, links
+}
+
+// crawlPage visits a single frontier item and returns the page together with its outgoing links.
+// Failures are reported in the page's Error field so one broken page does not stop the crawl.
+func crawlPage(ctx context.Context, item config.CrawlItem, format string) (*models.CrawlPage, []string) {
+	page := &models.CrawlPage{URL: item.URL, Depth: item.Depth, CrawledAt: time.Now().UTC()}
+
+	if err := navigateAndWait(ctx, item.URL); err != nil {
+		page.Error = err.Error()
+		return page, nil
+	}
+	content, err := extractPageContent(ctx, ContentOptions{Format: format, Strip: DefaultStripSelectors})
+	if err != nil {
+		page.Error = err.Error()
+		return page, nil
+	}
+	page.Title = content.title
+	page.Content = content.content
+
+	var links []string
+	if err := chromedp.Run(ctx, EvaluateIsolated(collectLinksScript, &links)); err != nil {
+		i18n.Printf("Warning: could not collect links from %s: %v", item.URL, err)
+	}
+	page.Links = len(links)
+	return page, links
+}
+
+// enqueueLinks appends the in-scope links that are neither visited nor already queued
+// to the checkpoint's frontier and returns the new frontier.
+func enqueueLinks(checkpoint *config.CrawlCheckpoint, visited map[string]bool, links []string, depth int) []config.CrawlItem {
+	queued := make(map[string]bool, len(checkpoint.Frontier))
+	for _, item := range checkpoint.Frontier {
+		queued[item.URL] = true
+	}
+
+	frontier := checkpoint.Frontier
+	for _, link := range links {
+		if visited[link] || queued[link] {
+			continue
+		}
+		if checkpoint.SameHost && !sameHost(checkpoint.StartURL, link) {
+			continue
+		}
+		queued[link] = true
+		frontier = append(frontier, config.CrawlItem{URL: link, Depth: depth})
+	}
+	return frontier
+}
+
+// sameHost reports whether two URLs point to the same host.
+func sameHost(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return ua.Hostname() == ub.Hostname()
+}
@@ -0,0 +1,54 @@
+package logic
+
+import (
+	"testing"
+
+	"browser-tools-go/internal/config"
+)
+
+func TestEnqueueLinks(t *testing.T) {
+	checkpoint := &config.CrawlCheckpoint{
+		StartURL: "https://example.com/",
+		SameHost: true,
+		Frontier: []config.CrawlItem{{URL: "https://example.com/queued", Depth: 1}},
+	}
+	visited := map[string]bool{"https://example.com/": true}
+	links := []string{
+		"https://example.com/",
+		"https://example.com/queued",
+		"https://example.com/new",
+		"https://example.com/new",
+		"https://other.example.org/page",
+	}
+
+	frontier := enqueueLinks(checkpoint, visited, links, 2)
+	if len(frontier) != 2 {
+		t.Fatalf("expected 2 frontier items, got %+v", frontier)
+	}
+	if frontier[1].URL != "https://example.com/new" || frontier[1].Depth != 2 {
+		t.Errorf("unexpected new frontier item: %+v", frontier[1])
+	}
+
+	checkpoint.SameHost = false
+	frontier = enqueueLinks(checkpoint, visited, links, 2)
+	if len(frontier) != 3 || frontier[2].URL != "https://other.example.org/page" {
+		t.Errorf("expected off-host link to be queued without SameHost, got %+v", frontier)
+	}
+}
+
+func TestSameHost(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"https://example.com/a", "https://example.com/b?x=1", true},
+		{"https://example.com/", "http://example.com:8080/", true},
+		{"https://example.com/", "https://www.example.com/", false},
+		{"https://example.com/", "://bad", false},
+	}
+	for _, tt := range tests {
+		if got := sameHost(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameHost(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"browser-tools-go/internal/models"
+)
+
+// CurlRequest is an HTTP request to be rendered as a curl command.
+type CurlRequest struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    string
+}
+
+// curlSkippedHeaders are recomputed by curl or belong to HTTP/2 framing.
+var curlSkippedHeaders = map[string]bool{
+	"content-length":    true,
+	"host":              true,
+	"connection":        true,
+	"transfer-encoding": true,
+}
+
+// FormatCurl renders a request as a single-line curl command. Cookies are passed
+// with -b and compressed responses are accepted with --compressed, as browsers do.
+func FormatCurl(req CurlRequest) string {
+	parts := []string{"curl", shellQuote(req.URL)}
+	method := strings.ToUpper(req.Method)
+	if method != "" && method != "GET" {
+		parts = append(parts, "-X", method)
+	}
+
+	names := make([]string, 0, len(req.Headers))
+	for name := range req.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	compressed := false
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		value := req.Headers[name]
+		switch {
+		case strings.HasPrefix(name, ":") || curlSkippedHeaders[lower]:
+			continue
+		case lower == "cookie":
+			parts = append(parts, "-b", shellQuote(value))
+		case lower == "accept-encoding":
+			compressed = true
+		default:
+			parts = append(parts, "-H", shellQuote(name+": "+value))
+		}
+	}
+
+	if req.Body != "" {
+		parts = append(parts, "--data-raw", shellQuote(req.Body))
+	}
+	if compressed {
+		parts = append(parts, "--compressed")
+	}
+	return strings.Join(parts, " ")
+}
+
+// CurlRequestFromEntry converts a captured network entry into a curl request.
+func CurlRequestFromEntry(entry *models.NetworkEntry) CurlRequest {
+	return CurlRequest{
+		Method:  entry.Method,
+		URL:     entry.URL,
+		Headers: entry.RequestHeaders,
+		Body:    entry.PostData,
+	}
+}
+
+// harFile is the subset of the HAR 1.2 format needed to rebuild requests.
+type harFile struct {
+	Log struct {
+		Entries []struct {
+			Request struct {
+				Method  string `json:"method"`
+				URL     string `json:"url"`
+				Headers []struct {
+					Name  string `json:"name"`
+					Value string `json:"value"`
+				} `json:"headers"`
+				Cookies []struct {
+					Name  string `json:"name"`
+					Value string `json:"value"`
+				} `json:"cookies"`
+				PostData *struct {
+					Text string `json:"text"`
+				} `json:"postData"`
+			} `json:"request"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+// CurlRequestsFromHAR extracts the requests of a HAR file whose URL passes filter
+// (see matchesURLFilter). Cookies listed separately from the headers are merged
+// into the Cookie header.
+func CurlRequestsFromHAR(data []byte, filter string) ([]CurlRequest, error) {
+	var har harFile
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, fmt.Errorf("failed to parse HAR file: %w", err)
+	}
+
+	var requests []CurlRequest
+	for _, entry := range har.Log.Entries {
+		r := entry.Request
+		if !matchesURLFilter(r.URL, filter) {
+			continue
+		}
+
+		req := CurlRequest{Method: r.Method, URL: r.URL, Headers: map[string]string{}}
+		for _, h := range r.Headers {
+			if existing, ok := req.Headers[h.Name]; ok && strings.EqualFold(h.Name, "cookie") {
+				req.Headers[h.Name] = existing + "; " + h.Value
+				continue
+			}
+			req.Headers[h.Name] = h.Value
+		}
+		if len(r.Cookies) > 0 && !hasHeader(req.Headers, "cookie") {
+			pairs := make([]string, len(r.Cookies))
+			for i, c := range r.Cookies {
+				pairs[i] = c.Name + "=" + c.Value
+			}
+			req.Headers["Cookie"] = strings.Join(pairs, "; ")
+		}
+		if r.PostData != nil {
+			req.Body = r.PostData.Text
+		}
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
+
+// hasHeader reports whether headers contain name, ignoring case.
+func hasHeader(headers map[string]string, name string) bool {
+	for h := range headers {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// shellQuote quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
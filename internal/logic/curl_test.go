@@ -0,0 +1,93 @@
+package logic
+
+import (
+	"testing"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestFormatCurl(t *testing.T) {
+	tests := []struct {
+		name string
+		req  CurlRequest
+		want string
+	}{
+		{
+			name: "simple get",
+			req:  CurlRequest{Method: "GET", URL: "https://example.com/"},
+			want: `curl 'https://example.com/'`,
+		},
+		{
+			name: "post with headers, cookies and body",
+			req: CurlRequest{
+				Method: "POST",
+				URL:    "https://example.com/api",
+				Headers: map[string]string{
+					"Content-Type":    "application/json",
+					"Cookie":          "sid=abc; theme=dark",
+					"Accept-Encoding": "gzip, br",
+					"Content-Length":  "13",
+					":authority":      "example.com",
+				},
+				Body: `{"q": "it's"}`,
+			},
+			want: `curl 'https://example.com/api' -X POST -H 'Content-Type: application/json' -b 'sid=abc; theme=dark' --data-raw '{"q": "it'\''s"}' --compressed`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatCurl(tt.req); got != tt.want {
+				t.Errorf("FormatCurl() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurlRequestsFromHAR(t *testing.T) {
+	data := []byte(`{"log": {"entries": [
+		{"request": {"method": "GET", "url": "https://example.com/static/app.js", "headers": []}},
+		{"request": {
+			"method": "POST",
+			"url": "https://example.com/api/items",
+			"headers": [{"name": "Content-Type", "value": "application/json"}],
+			"cookies": [{"name": "sid", "value": "abc"}, {"name": "lang", "value": "en"}],
+			"postData": {"mimeType": "application/json", "text": "{}"}
+		}}
+	]}}`)
+
+	requests, err := CurlRequestsFromHAR(data, "/api/")
+	if err != nil {
+		t.Fatalf("CurlRequestsFromHAR returned error: %v", err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 filtered request, got %d", len(requests))
+	}
+	want := `curl 'https://example.com/api/items' -X POST -H 'Content-Type: application/json' -b 'sid=abc; lang=en' --data-raw '{}'`
+	if got := FormatCurl(requests[0]); got != want {
+		t.Errorf("FormatCurl() =\n%s\nwant\n%s", got, want)
+	}
+
+	if _, err := CurlRequestsFromHAR([]byte("not json"), ""); err == nil {
+		t.Error("expected error for invalid HAR")
+	}
+}
+
+func TestNetworkCapture_ExtraInfoHeaders(t *testing.T) {
+	var entries []*models.NetworkEntry
+	capture := newNetworkCapture("", func(e *models.NetworkEntry) { entries = append(entries, e) })
+
+	// Extra info may arrive before the request itself.
+	capture.handle(&network.EventRequestWillBeSentExtraInfo{RequestID: "1", Headers: network.Headers{"Cookie": "sid=abc"}})
+	capture.handle(&network.EventRequestWillBeSent{RequestID: "1", Request: &network.Request{URL: "https://example.com/", Method: "GET", Headers: network.Headers{"Accept": "*/*"}}})
+	capture.handle(&network.EventLoadingFinished{RequestID: "1"})
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	curl := FormatCurl(CurlRequestFromEntry(entries[0]))
+	if want := `curl 'https://example.com/' -H 'Accept: */*' -b 'sid=abc'`; curl != want {
+		t.Errorf("got %s, want %s", curl, want)
+	}
+}
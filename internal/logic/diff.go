@@ -0,0 +1,225 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/utils"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// LoadSnapshot reads a JSON document previously written by content, pick or a scraper.
+func LoadSnapshot(path string) (interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", path, err)
+	}
+	var snapshot interface{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// LiveSnapshot extracts the current page content using the URL, format and
+// selector recorded in a stored content snapshot, so both sides are comparable.
+func LiveSnapshot(ctx context.Context, targetURL string, stored interface{}) (interface{}, error) {
+	opts := ContentOptions{Format: "markdown", Strip: DefaultStripSelectors}
+	if doc, ok := stored.(map[string]interface{}); ok {
+		if format, ok := doc["format"].(string); ok && format != "" {
+			opts.Format = format
+		}
+		if selector, ok := doc["selector"].(string); ok && selector != "body" {
+			opts.Selector = selector
+		}
+		if targetURL == "" {
+			targetURL, _ = doc["url"].(string)
+		}
+	}
+	if targetURL == "" {
+		return nil, fmt.Errorf("no URL given and the snapshot has no 'url' field")
+	}
+
+	result, err := GetContent(ctx, targetURL, opts)
+	if err != nil {
+		return nil, err
+	}
+	// Round-trip through JSON so the live result has the same types as a loaded file.
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode live snapshot: %w", err)
+	}
+	var live interface{}
+	if err := json.Unmarshal(data, &live); err != nil {
+		return nil, fmt.Errorf("failed to decode live snapshot: %w", err)
+	}
+	return live, nil
+}
+
+// DiffSnapshots compares two JSON documents. Fields are compared structurally;
+// a top-level "content" string is normalized according to the document's
+// "format" and compared line by line instead.
+func DiffSnapshots(a, b interface{}) *models.SnapshotDiff {
+	result := &models.SnapshotDiff{Changes: []models.FieldChange{}}
+
+	docA, okA := a.(map[string]interface{})
+	docB, okB := b.(map[string]interface{})
+	contentA, strA := docA["content"].(string)
+	contentB, strB := docB["content"].(string)
+	if okA && okB && strA && strB {
+		format, _ := docB["format"].(string)
+		normA := normalizeForDiff(contentA, format)
+		normB := normalizeForDiff(contentB, format)
+		result.TextDiff = utils.UnifiedDiff("a/content", "b/content", normA, normB, diffContextLines)
+		result.Added, result.Removed = utils.DiffStats(utils.DiffLines(utils.SplitLines(normA), utils.SplitLines(normB)))
+
+		a, b = withoutKey(docA, "content"), withoutKey(docB, "content")
+	}
+
+	diffValues("", a, b, &result.Changes)
+	result.Changed = len(result.Changes) > 0 || result.TextDiff != ""
+	return result
+}
+
+// withoutKey returns a shallow copy of doc without key.
+func withoutKey(doc map[string]interface{}, key string) map[string]interface{} {
+	copied := make(map[string]interface{}, len(doc))
+	for k, v := range doc {
+		if k != key {
+			copied[k] = v
+		}
+	}
+	return copied
+}
+
+// diffValues appends the differences between a and b, rooted at path, to changes.
+func diffValues(path string, a, b interface{}, changes *[]models.FieldChange) {
+	mapA, okA := a.(map[string]interface{})
+	mapB, okB := b.(map[string]interface{})
+	if okA && okB {
+		keys := make([]string, 0, len(mapA)+len(mapB))
+		for k := range mapA {
+			keys = append(keys, k)
+		}
+		for k := range mapB {
+			if _, ok := mapA[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			childPath := path + "." + k
+			va, inA := mapA[k]
+			vb, inB := mapB[k]
+			switch {
+			case !inA:
+				*changes = append(*changes, models.FieldChange{Path: childPath, Kind: "added", New: vb})
+			case !inB:
+				*changes = append(*changes, models.FieldChange{Path: childPath, Kind: "removed", Old: va})
+			default:
+				diffValues(childPath, va, vb, changes)
+			}
+		}
+		return
+	}
+
+	sliceA, okA := a.([]interface{})
+	sliceB, okB := b.([]interface{})
+	if okA && okB {
+		for i := 0; i < len(sliceA) || i < len(sliceB); i++ {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(sliceA):
+				*changes = append(*changes, models.FieldChange{Path: childPath, Kind: "added", New: sliceB[i]})
+			case i >= len(sliceB):
+				*changes = append(*changes, models.FieldChange{Path: childPath, Kind: "removed", Old: sliceA[i]})
+			default:
+				diffValues(childPath, sliceA[i], sliceB[i], changes)
+			}
+		}
+		return
+	}
+
+	if strA, ok := a.(string); ok {
+		if strB, ok := b.(string); ok && collapseWhitespace(strA) == collapseWhitespace(strB) {
+			return
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		if path == "" {
+			path = "."
+		}
+		*changes = append(*changes, models.FieldChange{Path: path, Kind: "changed", Old: a, New: b})
+	}
+}
+
+// normalizeForDiff removes formatting churn from content before it is diffed.
+// HTML is reduced to one canonical line per element or text node; other formats
+// have their whitespace collapsed and blank lines dropped.
+func normalizeForDiff(content, format string) string {
+	if format == "html" {
+		if normalized, err := normalizeHTML(content); err == nil {
+			return normalized
+		}
+	}
+
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if line = collapseWhitespace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// normalizeHTML renders an HTML fragment as one line per element (with sorted
+// attributes) or non-empty text node, dropping comments and insignificant whitespace.
+func normalizeHTML(content string) (string, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(content), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return "", fmt.Errorf("failed to parse html: %w", err)
+	}
+
+	var lines []string
+	var walk func(n *html.Node, depth int)
+	walk = func(n *html.Node, depth int) {
+		indent := strings.Repeat("  ", depth)
+		switch n.Type {
+		case html.TextNode:
+			if text := collapseWhitespace(n.Data); text != "" {
+				lines = append(lines, indent+text)
+			}
+		case html.ElementNode:
+			attrs := make([]string, 0, len(n.Attr))
+			for _, attr := range n.Attr {
+				attrs = append(attrs, fmt.Sprintf("%s=%q", attr.Key, collapseWhitespace(attr.Val)))
+			}
+			sort.Strings(attrs)
+			tag := "<" + n.Data
+			if len(attrs) > 0 {
+				tag += " " + strings.Join(attrs, " ")
+			}
+			lines = append(lines, indent+tag+">")
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				walk(c, depth+1)
+			}
+		}
+	}
+	for _, n := range nodes {
+		walk(n, 0)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// collapseWhitespace trims s and replaces internal whitespace runs with a single space.
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
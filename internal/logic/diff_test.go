@@ -0,0 +1,72 @@
+package logic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffSnapshots_Structural(t *testing.T) {
+	a := []interface{}{
+		map[string]interface{}{"title": "One", "points": 10.0},
+		map[string]interface{}{"title": "Two", "points": 5.0},
+	}
+	b := []interface{}{
+		map[string]interface{}{"title": "One", "points": 12.0},
+		map[string]interface{}{"title": "Two", "points": 5.0, "author": "pg"},
+		map[string]interface{}{"title": "Three"},
+	}
+
+	diff := DiffSnapshots(a, b)
+	if !diff.Changed {
+		t.Fatal("expected snapshots to differ")
+	}
+
+	got := map[string]string{}
+	for _, change := range diff.Changes {
+		got[change.Path] = change.Kind
+	}
+	expected := map[string]string{"[0].points": "changed", "[1].author": "added", "[2]": "added"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d changes, got %v", len(expected), diff.Changes)
+	}
+	for path, kind := range expected {
+		if got[path] != kind {
+			t.Errorf("expected %s to be %s, got %q", path, kind, got[path])
+		}
+	}
+}
+
+func TestDiffSnapshots_ContentWhitespaceChurn(t *testing.T) {
+	a := map[string]interface{}{"format": "markdown", "title": "Page", "content": "# Title\n\nSome   text\n"}
+	b := map[string]interface{}{"format": "markdown", "title": "Page ", "content": "# Title\nSome text"}
+
+	if diff := DiffSnapshots(a, b); diff.Changed {
+		t.Errorf("expected whitespace-only changes to be ignored, got %+v", diff)
+	}
+}
+
+func TestDiffSnapshots_ContentChange(t *testing.T) {
+	a := map[string]interface{}{"format": "text", "content": "price: 10\nstock: yes"}
+	b := map[string]interface{}{"format": "text", "content": "price: 12\nstock: yes"}
+
+	diff := DiffSnapshots(a, b)
+	if !diff.Changed || diff.Added != 1 || diff.Removed != 1 {
+		t.Fatalf("expected one line changed, got %+v", diff)
+	}
+	if !strings.Contains(diff.TextDiff, "-price: 10") || !strings.Contains(diff.TextDiff, "+price: 12") {
+		t.Errorf("unexpected text diff:\n%s", diff.TextDiff)
+	}
+	if len(diff.Changes) != 0 {
+		t.Errorf("expected no structural changes, got %v", diff.Changes)
+	}
+}
+
+func TestNormalizeForDiff_HTML(t *testing.T) {
+	a := `<div class="b a" id="x"><p>Hello   <b>world</b></p><!-- comment --></div>`
+	b := "<div id=\"x\" class=\"b a\">\n  <p>Hello\n<b>world</b></p>\n</div>"
+
+	if normalizeForDiff(a, "html") != normalizeForDiff(b, "html") {
+		t.Errorf("expected formatting-only HTML changes to normalize equally:\n%s\n---\n%s",
+			normalizeForDiff(a, "html"), normalizeForDiff(b, "html"))
+	}
+}
@@ -0,0 +1,171 @@
+package logic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/utils"
+)
+
+// DefaultDocNameTemplate names the files of DocWriter after the URL of the
+// page.
+const DefaultDocNameTemplate = "{{.slug}}.md"
+
+// DocPage is a page written by DocWriter.
+type DocPage struct {
+	URL      string
+	Title    string
+	Content  string
+	Format   string
+	Language string
+}
+
+// ContentDocPage returns the page of a GetContent result.
+func ContentDocPage(result map[string]interface{}) DocPage {
+	text := func(key string) string {
+		value, _ := result[key].(string)
+		return value
+	}
+	return DocPage{URL: text("url"), Title: text("title"), Content: text("content"), Format: text("format"), Language: text("language")}
+}
+
+// CrawlDocPage returns the page of a crawled page whose content is in format.
+func CrawlDocPage(page *models.CrawlPage, format string) DocPage {
+	return DocPage{URL: page.URL, Title: page.Title, Content: page.Content, Format: format}
+}
+
+// DocWriter writes pages as files of a directory, each with a YAML front
+// matter holding its title, URL and when it was fetched, so that extracted
+// content can be exported as docs.
+type DocWriter struct {
+	dir  string
+	name *template.Template
+	used map[string]bool
+}
+
+// NewDocWriter returns a DocWriter writing below dir, which must lie below the
+// working directory, and naming the files with the Go template nameTemplate.
+// The template gets the slug, title, url, host and path of the page; see
+// docNameData.
+func NewDocWriter(dir, nameTemplate string) (*DocWriter, error) {
+	if _, err := utils.ValidateFilePathStrict(dir); err != nil {
+		return nil, fmt.Errorf("invalid output directory %q: %w", dir, err)
+	}
+	name, err := template.New("name").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name template: %w", err)
+	}
+	return &DocWriter{dir: filepath.Clean(dir), name: name, used: map[string]bool{}}, nil
+}
+
+// Write writes page, fetched at fetchedAt, and describes the file written. A
+// page whose name is already taken by another page of the same writer gets a
+// numbered name.
+func (w *DocWriter) Write(page DocPage, fetchedAt time.Time) (*models.ExportedDoc, error) {
+	var name bytes.Buffer
+	if err := w.name.Execute(&name, docNameData(page)); err != nil {
+		return nil, fmt.Errorf("name template: %w", err)
+	}
+	file := filepath.FromSlash(strings.TrimSpace(name.String()))
+	if file == "" || !filepath.IsLocal(file) {
+		return nil, fmt.Errorf("invalid file name %q: expected a path relative to the output directory", file)
+	}
+	ext := filepath.Ext(file)
+	for i := 2; w.used[file]; i++ {
+		file = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(file, ext), i, ext)
+	}
+	w.used[file] = true
+
+	target := filepath.Join(w.dir, file)
+	if err := utils.SecureWriteFile(target, formatDoc(page, fetchedAt), 0644, "."); err != nil {
+		return nil, err
+	}
+	return &models.ExportedDoc{Path: target, URL: page.URL, Title: page.Title}, nil
+}
+
+// docNameData is what the name template of a page gets:
+//   - slug: the path of the URL as a single file name, e.g. "docs-getting-started"
+//   - path: the same with its directories kept, e.g. "docs/getting-started"
+//   - title, url and host: those of the page
+//
+// Extensions are dropped, and the page of a directory, such as "/docs/", has
+// the slug "docs" and the path "docs/index". The home page is "index".
+func docNameData(page DocPage) map[string]string {
+	data := map[string]string{"title": page.Title, "url": page.URL}
+	u, err := url.Parse(page.URL)
+	if err != nil {
+		u = &url.URL{}
+	}
+	data["host"] = u.Hostname()
+
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if slug := slugify(strings.TrimSuffix(segment, path.Ext(segment))); slug != "" {
+			segments = append(segments, slug)
+		}
+	}
+	if len(segments) == 0 {
+		data["slug"], data["path"] = "index", "index"
+		return data
+	}
+	data["slug"] = strings.Join(segments, "-")
+	data["path"] = strings.Join(segments, "/")
+	if strings.HasSuffix(u.Path, "/") {
+		data["path"] += "/index"
+	}
+	return data
+}
+
+// slugify lower-cases s and turns its runs of other characters than letters
+// and digits into single dashes.
+func slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// formatDoc returns the contents of the file of page: a YAML front matter
+// followed by the content.
+func formatDoc(page DocPage, fetchedAt time.Time) []byte {
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	field := func(name, value string) {
+		if value == "" {
+			return
+		}
+		// JSON strings are YAML double-quoted scalars.
+		fmt.Fprintf(&b, "%s: ", name)
+		encoder := json.NewEncoder(&b)
+		encoder.SetEscapeHTML(false)
+		encoder.Encode(value)
+	}
+	field("title", page.Title)
+	field("url", page.URL)
+	field("language", page.Language)
+	field("format", page.Format)
+	field("fetched_at", fetchedAt.UTC().Format(time.RFC3339))
+	b.WriteString("---\n\n")
+	b.WriteString(strings.TrimSpace(page.Content))
+	b.WriteString("\n")
+	return b.Bytes()
+}
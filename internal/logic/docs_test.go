@@ -0,0 +1,91 @@
+package logic
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDocNameData(t *testing.T) {
+	tests := []struct {
+		url, slug, path string
+	}{
+		{"https://example.com/", "index", "index"},
+		{"https://example.com", "index", "index"},
+		{"https://example.com/docs/Getting_Started.html", "docs-getting-started", "docs/getting-started"},
+		{"https://example.com/docs/", "docs", "docs/index"},
+		{"https://example.com/blog/2024/hello%20world?page=2", "blog-2024-hello-world", "blog/2024/hello-world"},
+		{"https://example.jp/ニュース/", "ニュース", "ニュース/index"},
+	}
+	for _, tt := range tests {
+		data := docNameData(DocPage{URL: tt.url})
+		if data["slug"] != tt.slug || data["path"] != tt.path {
+			t.Errorf("docNameData(%q) = slug %q, path %q, want %q, %q", tt.url, data["slug"], data["path"], tt.slug, tt.path)
+		}
+	}
+}
+
+func TestDocWriter(t *testing.T) {
+	dir := t.TempDir()
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if _, err := NewDocWriter("../outside", DefaultDocNameTemplate); err == nil {
+		t.Error("expected an output directory outside the working directory to be refused")
+	}
+	if _, err := NewDocWriter("docs", "{{.slug"); err == nil {
+		t.Error("expected an invalid name template to be refused")
+	}
+
+	w, err := NewDocWriter("docs", "{{.host}}/{{.path}}.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetchedAt := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	page := DocPage{URL: "https://example.com/docs/", Title: `Docs: "A & B"`, Content: "# Docs\n\nHello.\n", Format: "markdown", Language: "en"}
+	doc, err := w.Write(page, fetchedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join("docs", "example.com", "docs", "index.md"); doc.Path != want {
+		t.Errorf("path = %s, want %s", doc.Path, want)
+	}
+	data, err := os.ReadFile(doc.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `---
+title: "Docs: \"A & B\""
+url: "https://example.com/docs/"
+language: "en"
+format: "markdown"
+fetched_at: "2026-10-16T09:30:00Z"
+---
+
+# Docs
+
+Hello.
+`
+	if string(data) != want {
+		t.Errorf("file = %q, want %q", data, want)
+	}
+
+	// A second page with the same name gets a numbered one.
+	doc, err = w.Write(DocPage{URL: "https://example.com/docs/index.html"}, fetchedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(doc.Path, "index-2.md") {
+		t.Errorf("path = %s, want a numbered name", doc.Path)
+	}
+
+	w, _ = NewDocWriter("docs", "../{{.slug}}.md")
+	if _, err := w.Write(page, fetchedAt); err == nil {
+		t.Error("expected a name outside the output directory to be refused")
+	}
+}
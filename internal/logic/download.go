@@ -0,0 +1,140 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/utils"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// defaultDownloadName is the file name of downloads whose response and URL
+// don't suggest one.
+const defaultDownloadName = "download"
+
+// inPageDownloadScript fetches url with the page's cookies and returns the
+// response with its body as base64, since binary bodies can't be returned by
+// value.
+const inPageDownloadScript = `
+	(async (url, init) => {
+		const res = await fetch(url, init);
+		const headers = {};
+		res.headers.forEach((value, name) => { headers[name] = value; });
+		const bytes = new Uint8Array(await res.arrayBuffer());
+		let binary = '';
+		for (let i = 0; i < bytes.length; i += 0x8000) {
+			binary += String.fromCharCode.apply(null, bytes.subarray(i, i + 0x8000));
+		}
+		return {url: res.url, status: res.status, statusText: res.statusText, headers, body: btoa(binary)};
+	})(%s, %s)
+`
+
+// Download fetches url with window.fetch inside the current page, so the
+// request carries the page's cookies and session, and saves the response body
+// below the working directory. target is the file or directory to save to;
+// when it is a directory or empty, the file is named after the
+// Content-Disposition header or the URL. Error statuses are not saved.
+func Download(ctx context.Context, url, target string, headers map[string]string) (*models.DownloadResult, error) {
+	init := map[string]interface{}{"credentials": "include"}
+	if len(headers) > 0 {
+		init["headers"] = headers
+	}
+	urlJSON, err := json.Marshal(url)
+	if err != nil {
+		return nil, err
+	}
+	initJSON, err := json.Marshal(init)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		URL        string            `json:"url"`
+		Status     int               `json:"status"`
+		StatusText string            `json:"statusText"`
+		Headers    map[string]string `json:"headers"`
+		Body       string            `json:"body"`
+	}
+	script := fmt.Sprintf(inPageDownloadScript, urlJSON, initJSON)
+	err = chromedp.Run(ctx, EvaluateIsolated(script, &response, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+		return p.WithAwaitPromise(true)
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch '%s' in page: %w", url, err)
+	}
+	if response.Status < 200 || response.Status > 299 {
+		return nil, fmt.Errorf("server responded %d %s for %s", response.Status, response.StatusText, response.URL)
+	}
+	data, err := base64.StdEncoding.DecodeString(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the response body: %w", err)
+	}
+
+	filePath := DownloadPath(target, response.Headers["content-disposition"], response.URL)
+	validated, err := utils.ValidateFilePath(filePath, false, ".")
+	if err != nil {
+		return nil, fmt.Errorf("invalid download path %q: %w", filePath, err)
+	}
+	if err := utils.SecureWriteFile(validated, data, 0644, "."); err != nil {
+		return nil, fmt.Errorf("failed to save download to %s: %w", validated, err)
+	}
+	sum := sha256.Sum256(data)
+	return &models.DownloadResult{
+		URL:         url,
+		FinalURL:    response.URL,
+		Status:      response.Status,
+		ContentType: response.Headers["content-type"],
+		Path:        validated,
+		Bytes:       len(data),
+		SHA256:      hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// DownloadPath returns the file a download is saved to. target is used as it
+// is unless it is empty, ends with a slash or is an existing directory; then
+// the file is named after the Content-Disposition header or the last segment
+// of finalURL.
+func DownloadPath(target, contentDisposition, finalURL string) string {
+	if target != "" && !strings.HasSuffix(target, "/") {
+		if info, err := os.Stat(target); err != nil || !info.IsDir() {
+			return target
+		}
+	}
+	name := downloadFileName(contentDisposition, finalURL)
+	if target == "" {
+		return name
+	}
+	return filepath.Join(target, name)
+}
+
+// downloadFileName suggests a file name for a download, as browsers do. Only
+// the base name is kept, so a server can't choose the directory.
+func downloadFileName(contentDisposition, finalURL string) string {
+	var name string
+	if _, params, err := mime.ParseMediaType(contentDisposition); err == nil {
+		name = params["filename"]
+	}
+	if name == "" {
+		if u, err := url.Parse(finalURL); err == nil {
+			name = path.Base(u.Path)
+		}
+	}
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" || name == ".." || name == "" {
+		return defaultDownloadName
+	}
+	return name
+}
@@ -0,0 +1,33 @@
+package logic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDownloadPath(t *testing.T) {
+	t.Chdir(t.TempDir())
+	os.Mkdir("existing", 0755)
+
+	tests := []struct {
+		name, target, disposition, finalURL, want string
+	}{
+		{"url name", "", "", "https://example.com/files/report.csv?x=1", "report.csv"},
+		{"escaped url name", "", "", "https://example.com/files/my%20report.pdf", "my report.pdf"},
+		{"disposition", "", `attachment; filename="export 2026.csv"`, "https://example.com/export", "export 2026.csv"},
+		{"encoded disposition", "", `attachment; filename*=UTF-8''%E3%83%87%E3%83%BC%E3%82%BF.csv`, "https://example.com/export", "データ.csv"},
+		{"traversal in disposition", "", `attachment; filename="../../etc/passwd"`, "https://example.com/x", "passwd"},
+		{"no name", "", "", "https://example.com/", defaultDownloadName},
+		{"file target", "out/data.bin", `attachment; filename="ignored.csv"`, "https://example.com/x", "out/data.bin"},
+		{"directory target", "downloads/", "", "https://example.com/a.zip", filepath.Join("downloads", "a.zip")},
+		{"existing directory", "existing", "", "https://example.com/a.zip", filepath.Join("existing", "a.zip")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DownloadPath(tt.target, tt.disposition, tt.finalURL); got != tt.want {
+				t.Errorf("DownloadPath(%q, %q, %q) = %q, want %q", tt.target, tt.disposition, tt.finalURL, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// Zoom factors accepted by ParseZoomFactor.
+const (
+	MinZoom = 0.1
+	MaxZoom = 5.0
+)
+
+// MaxDeviceScale is the largest device scale factor accepted by
+// SetDeviceScale.
+const MaxDeviceScale = 4.0
+
+// ParseZoomFactor parses a zoom factor given as a number ("0.5") or a
+// percentage ("50%"). "reset" is the same as 1.
+func ParseZoomFactor(value string) (float64, error) {
+	number := strings.TrimSpace(value)
+	if strings.EqualFold(number, "reset") {
+		return 1, nil
+	}
+	divisor := 1.0
+	if strings.HasSuffix(number, "%") {
+		number, divisor = strings.TrimSuffix(number, "%"), 100
+	}
+	factor, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid zoom factor %q: expected e.g. 0.5 or 50%%", value)
+	}
+	factor /= divisor
+	if factor < MinZoom || factor > MaxZoom {
+		return 0, fmt.Errorf("zoom factor %g out of range (%g to %g)", factor, MinZoom, MaxZoom)
+	}
+	return factor, nil
+}
+
+// SetZoom zooms the page by factor, like the browser's zoom: the viewport is
+// emulated factor times smaller in CSS pixels and rendered factor times
+// smaller, so at 0.5 twice as much of the page fits on the screen and
+// full-page screenshots have half the pixels. A factor of 1 removes the zoom.
+// Like all emulation, the zoom only lasts as long as the connection.
+func SetZoom(ctx context.Context, factor float64) error {
+	return chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		if factor == 1 {
+			return emulation.ClearDeviceMetricsOverride().Do(ctx)
+		}
+		window, err := readWindowMetrics(ctx)
+		if err != nil {
+			return err
+		}
+		width, height := int64(window.Width/factor), int64(window.Height/factor)
+		if err := emulation.SetDeviceMetricsOverride(width, height, window.Ratio*factor, false).Do(ctx); err != nil {
+			return fmt.Errorf("failed to set zoom: %w", err)
+		}
+		return nil
+	}))
+}
+
+// windowMetrics are the viewport size in CSS pixels and the device pixel
+// ratio of the page, including any emulation.
+type windowMetrics struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Ratio  float64 `json:"ratio"`
+}
+
+func readWindowMetrics(ctx context.Context) (windowMetrics, error) {
+	var window windowMetrics
+	if err := EvaluateIsolated(`({width: innerWidth, height: innerHeight, ratio: devicePixelRatio})`, &window).Do(ctx); err != nil {
+		return window, fmt.Errorf("failed to read the window size: %w", err)
+	}
+	return window, nil
+}
+
+// SetDeviceScale emulates a device pixel ratio of scale at the current
+// viewport size, so screenshots have scale times as many pixels per CSS
+// pixel. The returned function restores the metrics the page had before,
+// including an earlier emulation such as a zoom.
+func SetDeviceScale(ctx context.Context, scale float64) (restore func() error, err error) {
+	if scale <= 0 || scale > MaxDeviceScale {
+		return nil, fmt.Errorf("device scale factor %g out of range (up to %g)", scale, MaxDeviceScale)
+	}
+	var original windowMetrics
+	err = chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		if original, err = readWindowMetrics(ctx); err != nil {
+			return err
+		}
+		if err := emulation.SetDeviceMetricsOverride(int64(original.Width), int64(original.Height), scale, false).Do(ctx); err != nil {
+			return fmt.Errorf("failed to set the device scale factor: %w", err)
+		}
+		return nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return func() error {
+		return chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+			if err := emulation.ClearDeviceMetricsOverride().Do(ctx); err != nil {
+				return err
+			}
+			// Without an override the page has its own metrics again; if they
+			// differ, the page was emulating other metrics before.
+			current, err := readWindowMetrics(ctx)
+			if err != nil || current == original {
+				return err
+			}
+			return emulation.SetDeviceMetricsOverride(int64(original.Width), int64(original.Height), original.Ratio, false).Do(ctx)
+		}))
+	}, nil
+}
+
+// Color schemes accepted by SetColorScheme, in the order they are captured.
+var ColorSchemes = []string{"light", "dark"}
+
+// ParseColorSchemes validates a list of color schemes, such as the values of
+// screenshot --themes, and returns them lower-cased without duplicates.
+func ParseColorSchemes(values []string) ([]string, error) {
+	var schemes []string
+	for _, value := range values {
+		scheme := strings.ToLower(strings.TrimSpace(value))
+		if !slices.Contains(ColorSchemes, scheme) {
+			return nil, fmt.Errorf("invalid color scheme %q (expected %s)", value, strings.Join(ColorSchemes, ", "))
+		}
+		if !slices.Contains(schemes, scheme) {
+			schemes = append(schemes, scheme)
+		}
+	}
+	return schemes, nil
+}
+
+// colorSchemeFrameScript resolves once the page rendered two frames, by which
+// time styles and matchMedia listeners reacted to a new color scheme.
+const colorSchemeFrameScript = `new Promise(resolve => requestAnimationFrame(() => requestAnimationFrame(() => resolve(true))))`
+
+// SetColorScheme emulates the prefers-color-scheme media feature of the page
+// and waits until the page is repainted. An empty scheme removes the
+// emulation. Like all emulation, it only lasts as long as the connection.
+func SetColorScheme(ctx context.Context, scheme string) error {
+	return chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		features := []*emulation.MediaFeature{{Name: "prefers-color-scheme", Value: scheme}}
+		if err := emulation.SetEmulatedMedia().WithFeatures(features).Do(ctx); err != nil {
+			return fmt.Errorf("failed to emulate the %s color scheme: %w", scheme, err)
+		}
+		var painted bool
+		return EvaluateIsolated(colorSchemeFrameScript, &painted, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+			return p.WithAwaitPromise(true)
+		}).Do(ctx)
+	}))
+}
@@ -0,0 +1,47 @@
+package logic
+
+import "testing"
+
+func TestParseZoomFactor(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{"0.5", 0.5, false},
+		{"50%", 0.5, false},
+		{"150%", 1.5, false},
+		{" 2 ", 2, false},
+		{"reset", 1, false},
+		{"RESET", 1, false},
+		{"0.05", 0, true},
+		{"600%", 0, true},
+		{"-1", 0, true},
+		{"half", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseZoomFactor(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseZoomFactor(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseZoomFactor(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseColorSchemes(t *testing.T) {
+	got, err := ParseColorSchemes([]string{" Dark", "light", "dark"})
+	if err != nil {
+		t.Fatalf("ParseColorSchemes() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != "dark" || got[1] != "light" {
+		t.Errorf("ParseColorSchemes() = %v, want [dark light]", got)
+	}
+	if _, err := ParseColorSchemes([]string{"light", "sepia"}); err == nil {
+		t.Error("Expected an error for an unknown color scheme")
+	}
+}
@@ -0,0 +1,81 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/utils"
+
+	"github.com/chromedp/chromedp"
+)
+
+// failureArtifactsTimeout bounds capturing the artifacts of a failed command,
+// so that a hung page can't keep the process from exiting.
+const failureArtifactsTimeout = 10 * time.Second
+
+// Files saved by SaveFailureArtifacts.
+const (
+	FailureReportFile     = "failure.json"
+	FailureScreenshotFile = "screenshot.png"
+	FailureHTMLFile       = "page.html"
+)
+
+// SaveFailureArtifacts saves what helps debug a failed command in dir: a
+// screenshot of the tab, its HTML and the report, completed with the URL and
+// title of the page. Artifacts that can't be captured, e.g. because the page
+// hangs, are listed as problems in the report rather than failing the rest. It
+// returns the path of the report.
+func SaveFailureArtifacts(ctx context.Context, dir string, report *models.FailureReport) (string, error) {
+	// Attach to the tab with the caller's context first, as in runOnElement.
+	if err := chromedp.Run(ctx); err != nil {
+		report.Problems = append(report.Problems, fmt.Sprintf("tab: %v", err))
+	} else {
+		captureCtx, cancel := context.WithTimeout(ctx, failureArtifactsTimeout)
+		defer cancel()
+		captureFailure(captureCtx, dir, report)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, FailureReportFile)
+	if err := utils.SecureWriteFile(path, data, 0644, "."); err != nil {
+		return "", fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// captureFailure fills in the page details of report and saves its screenshot
+// and HTML in dir.
+func captureFailure(ctx context.Context, dir string, report *models.FailureReport) {
+	problem := func(what string, err error) {
+		report.Problems = append(report.Problems, fmt.Sprintf("%s: %v", what, err))
+	}
+
+	if err := chromedp.Run(ctx, chromedp.Location(&report.URL), chromedp.Title(&report.Title)); err != nil {
+		problem("url", err)
+	}
+
+	var screenshot []byte
+	if err := chromedp.Run(ctx, chromedp.CaptureScreenshot(&screenshot)); err != nil {
+		problem("screenshot", err)
+	} else if err := utils.SecureWriteFile(filepath.Join(dir, FailureScreenshotFile), screenshot, 0644, "."); err != nil {
+		problem("screenshot", err)
+	} else {
+		report.Screenshot = FailureScreenshotFile
+	}
+
+	var html string
+	if err := chromedp.Run(ctx, EvaluateIsolated("document.documentElement.outerHTML", &html)); err != nil {
+		problem("html", err)
+	} else if err := utils.SecureWriteFile(filepath.Join(dir, FailureHTMLFile), []byte(html), 0644, "."); err != nil {
+		problem("html", err)
+	} else {
+		report.HTML = FailureHTMLFile
+	}
+}
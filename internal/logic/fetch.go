@@ -0,0 +1,111 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// FetchOptions describes a request made with InPageFetch.
+type FetchOptions struct {
+	Method  string
+	Body    string
+	Headers map[string]string
+}
+
+// inPageFetchScript calls window.fetch with the page's cookies and returns the response.
+const inPageFetchScript = `
+	(async (url, init) => {
+		const res = await fetch(url, init);
+		const headers = {};
+		res.headers.forEach((value, name) => { headers[name] = value; });
+		return {
+			url: res.url,
+			status: res.status,
+			statusText: res.statusText,
+			ok: res.ok,
+			redirected: res.redirected,
+			headers,
+			body: await res.text(),
+		};
+	})(%s, %s)
+`
+
+// InPageFetch performs an HTTP request with window.fetch inside the current page,
+// so it carries the page's cookies and same-origin privileges. In read-only
+// mode only GET, HEAD and OPTIONS requests are made.
+func InPageFetch(ctx context.Context, url string, opts FetchOptions) (*models.FetchResult, error) {
+	if err := checkReadOnlyMethod(ctx, opts.Method); err != nil {
+		return nil, err
+	}
+	return inPageFetch(ctx, url, opts)
+}
+
+func inPageFetch(ctx context.Context, url string, opts FetchOptions) (*models.FetchResult, error) {
+	init := map[string]interface{}{
+		"method":      strings.ToUpper(opts.Method),
+		"credentials": "include",
+	}
+	if init["method"] == "" {
+		init["method"] = "GET"
+	}
+	if len(opts.Headers) > 0 {
+		init["headers"] = opts.Headers
+	}
+	if opts.Body != "" {
+		init["body"] = opts.Body
+	}
+
+	urlJSON, err := json.Marshal(url)
+	if err != nil {
+		return nil, err
+	}
+	initJSON, err := json.Marshal(init)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.FetchResult
+	script := fmt.Sprintf(inPageFetchScript, urlJSON, initJSON)
+	err = chromedp.Run(ctx, EvaluateIsolated(script, &result, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+		return p.WithAwaitPromise(true)
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch '%s' in page: %w", url, err)
+	}
+
+	if isJSONContentType(result.Headers["content-type"]) {
+		var decoded interface{}
+		if json.Unmarshal([]byte(result.Body), &decoded) == nil {
+			result.JSON = decoded
+		}
+	}
+	return &result, nil
+}
+
+// ParseHeaders parses "Name: Value" header flags.
+func ParseHeaders(values []string) (map[string]string, error) {
+	headers := make(map[string]string, len(values))
+	for _, value := range values {
+		name, val, ok := strings.Cut(value, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid header '%s': expected 'Name: Value'", value)
+		}
+		headers[name] = strings.TrimSpace(val)
+	}
+	return headers, nil
+}
+
+// isJSONContentType reports whether a Content-Type header denotes JSON.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(strings.ToLower(contentType), ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
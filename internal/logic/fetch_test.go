@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders([]string{"Content-Type: application/json", "X-Token:abc:def", "Empty:"})
+	if err != nil {
+		t.Fatalf("ParseHeaders returned error: %v", err)
+	}
+	want := map[string]string{"Content-Type": "application/json", "X-Token": "abc:def", "Empty": ""}
+	if !reflect.DeepEqual(headers, want) {
+		t.Errorf("ParseHeaders() = %v, want %v", headers, want)
+	}
+
+	for _, invalid := range []string{"NoColon", ": value"} {
+		if _, err := ParseHeaders([]string{invalid}); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestIsJSONContentType(t *testing.T) {
+	tests := map[string]bool{
+		"application/json":                  true,
+		"application/json; charset=utf-8":   true,
+		"application/problem+json":          true,
+		"Application/JSON":                  true,
+		"text/html; charset=utf-8":          false,
+		"":                                  false,
+		"application/x-www-form-urlencoded": false,
+	}
+	for contentType, want := range tests {
+		if got := isJSONContentType(contentType); got != want {
+			t.Errorf("isJSONContentType(%q) = %v, want %v", contentType, got, want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"browser-tools-go/internal/models"
+)
+
+// GraphQLRequest is a GraphQL operation sent with GraphQL.
+type GraphQLRequest struct {
+	Query         string          `json:"query"`
+	Variables     json.RawMessage `json:"variables,omitempty"`
+	OperationName string          `json:"operationName,omitempty"`
+}
+
+// GraphQL posts an operation to endpoint with window.fetch inside the current page,
+// so it is authenticated the same way as the page's own requests. In
+// read-only mode, queries defining a mutation are refused.
+func GraphQL(ctx context.Context, endpoint string, req GraphQLRequest, headers map[string]string) (*models.GraphQLResponse, error) {
+	if req.Query == "" {
+		return nil, fmt.Errorf("graphql query is empty")
+	}
+	if err := checkReadOnlyGraphQL(ctx, req.Query); err != nil {
+		return nil, err
+	}
+	if len(req.Variables) > 0 && !json.Valid(req.Variables) {
+		return nil, fmt.Errorf("graphql variables are not valid JSON")
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	allHeaders := map[string]string{
+		"Content-Type": "application/json",
+		"Accept":       "application/graphql-response+json, application/json",
+	}
+	for name, value := range headers {
+		allHeaders[name] = value
+	}
+
+	result, err := inPageFetch(ctx, endpoint, FetchOptions{Method: "POST", Body: string(body), Headers: allHeaders})
+	if err != nil {
+		return nil, err
+	}
+	return parseGraphQLResponse(result)
+}
+
+// parseGraphQLResponse decodes a GraphQL response body. Error statuses are only
+// reported as failures when the body is not a GraphQL response, since servers
+// commonly pair 4xx/5xx statuses with a regular "errors" list.
+func parseGraphQLResponse(result *models.FetchResult) (*models.GraphQLResponse, error) {
+	var response models.GraphQLResponse
+	if err := json.Unmarshal([]byte(result.Body), &response); err != nil {
+		if !result.OK {
+			return nil, fmt.Errorf("graphql request failed with status %d: %s", result.Status, TruncateForError(result.Body))
+		}
+		return nil, fmt.Errorf("graphql response is not JSON: %s", TruncateForError(result.Body))
+	}
+	if response.Data == nil && len(response.Errors) == 0 {
+		return nil, fmt.Errorf("graphql response has neither data nor errors (status %d)", result.Status)
+	}
+	return &response, nil
+}
+
+// TruncateForError shortens text, such as a response body, for inclusion in an
+// error message, without splitting a UTF-8 character.
+func TruncateForError(body string) string {
+	const limit = 200
+	if runes := []rune(body); len(runes) > limit {
+		return string(runes[:limit]) + "..."
+	}
+	return body
+}
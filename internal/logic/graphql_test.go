@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"browser-tools-go/internal/models"
+)
+
+func TestParseGraphQLResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     models.FetchResult
+		wantErr    bool
+		wantErrors int
+	}{
+		{"data", models.FetchResult{OK: true, Status: 200, Body: `{"data": {"viewer": {"login": "me"}}}`}, false, 0},
+		{"errors with error status", models.FetchResult{OK: false, Status: 400, Body: `{"errors": [{"message": "bad field"}]}`}, false, 1},
+		{"html error page", models.FetchResult{OK: false, Status: 502, Body: "<html>Bad Gateway</html>"}, true, 0},
+		{"not graphql", models.FetchResult{OK: true, Status: 200, Body: `{"hello": "world"}`}, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := parseGraphQLResponse(&tt.result)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseGraphQLResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(response.Errors) != tt.wantErrors {
+				t.Errorf("expected %d errors, got %+v", tt.wantErrors, response.Errors)
+			}
+		})
+	}
+}
+
+func TestTruncateForError(t *testing.T) {
+	if got := TruncateForError("short"); got != "short" {
+		t.Errorf("TruncateForError(short) = %q", got)
+	}
+	got := TruncateForError(strings.Repeat("あ", 300))
+	if !utf8.ValidString(got) || got != strings.Repeat("あ", 200)+"..." {
+		t.Errorf("TruncateForError split a character or kept too much: %q", got)
+	}
+}
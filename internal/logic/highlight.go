@@ -0,0 +1,66 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/overlay"
+	"github.com/chromedp/chromedp"
+)
+
+// HighlightOptions configures Highlight.
+type HighlightOptions struct {
+	// Duration is how long the boxes stay on the page.
+	Duration time.Duration
+	// Label shows the tag, id, classes and size of the first element in a
+	// tooltip.
+	Label bool
+}
+
+// highlightConfig is the DevTools look of the boxes: content in blue, padding
+// in green and margin in orange.
+func highlightConfig(label bool) *overlay.HighlightConfig {
+	return &overlay.HighlightConfig{
+		ShowInfo:     label,
+		ContentColor: &cdp.RGBA{R: 111, G: 168, B: 220, A: 0.66},
+		PaddingColor: &cdp.RGBA{R: 147, G: 196, B: 125, A: 0.55},
+		MarginColor:  &cdp.RGBA{R: 246, G: 178, B: 107, A: 0.66},
+	}
+}
+
+// Highlight draws boxes over the elements matching selector with the
+// DevTools overlay and returns how many elements matched. The overlay is
+// drawn by the browser rather than injected into the page, so it neither
+// changes the DOM nor shows up in the page's own scripts. It stays until
+// ClearHighlights is called or the DevTools session ends.
+func Highlight(ctx context.Context, selector string, opts HighlightOptions) (int, error) {
+	var nodes []*cdp.Node
+	err := chromedp.Run(ctx,
+		chromedp.Nodes(selector, &nodes, chromedp.ByQueryAll, chromedp.AtLeast(0)),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			if len(nodes) == 0 {
+				return nil
+			}
+			if err := overlay.Enable().Do(ctx); err != nil {
+				return err
+			}
+			// With a selector, the overlay boxes every element matching it,
+			// and the tooltip shows the node given.
+			return overlay.HighlightNode(highlightConfig(opts.Label)).
+				WithNodeID(nodes[0].NodeID).
+				WithSelector(selector).
+				Do(ctx)
+		}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to highlight '%s': %w", selector, err)
+	}
+	return len(nodes), nil
+}
+
+// ClearHighlights removes the boxes drawn by Highlight.
+func ClearHighlights(ctx context.Context) error {
+	return chromedp.Run(ctx, overlay.HideHighlight())
+}
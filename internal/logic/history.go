@@ -0,0 +1,26 @@
+package logic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// TrackNavigations calls onNavigate with the URL and tab ID of every
+// main-frame navigation of the tab in ctx, until ctx is done. Blank pages
+// are not reported. onNavigate runs on the event loop and must not block.
+func TrackNavigations(ctx context.Context, onNavigate func(url, tabID string)) {
+	chromedp.ListenTarget(ctx, func(ev interface{}) {
+		navigated, ok := ev.(*page.EventFrameNavigated)
+		if !ok || navigated.Frame.ParentID != "" || strings.HasPrefix(navigated.Frame.URL, "about:") {
+			return
+		}
+		tabID := ""
+		if c := chromedp.FromContext(ctx); c != nil && c.Target != nil {
+			tabID = string(c.Target.TargetID)
+		}
+		onNavigate(navigated.Frame.URL+navigated.Frame.URLFragment, tabID)
+	})
+}
@@ -0,0 +1,214 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/indexeddb"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// idbPageSize is the number of records requested per IndexedDB.requestData call.
+const idbPageSize = 200
+
+// idbSerializeFunction converts a structured-clone value into JSON-friendly data.
+// Types JSON.stringify would lose (Map, Set, binary data, Blob) are represented explicitly.
+const idbSerializeFunction = `function() {
+	const convert = (v, seen) => {
+		if (v === null || typeof v !== 'object') {
+			return typeof v === 'bigint' ? v.toString() : v;
+		}
+		if (seen.has(v)) return '[Circular]';
+		seen.add(v);
+		if (v instanceof Date) return v.toISOString();
+		if (v instanceof Map) return { $map: Array.from(v, ([k, val]) => [convert(k, seen), convert(val, seen)]) };
+		if (v instanceof Set) return { $set: Array.from(v, x => convert(x, seen)) };
+		if (v instanceof ArrayBuffer) v = new Uint8Array(v);
+		if (ArrayBuffer.isView(v)) return { $bytes: btoa(String.fromCharCode(...new Uint8Array(v.buffer, v.byteOffset, v.byteLength))) };
+		if (typeof Blob !== 'undefined' && v instanceof Blob) return { $blob: { type: v.type, size: v.size } };
+		if (Array.isArray(v)) return v.map(x => convert(x, seen));
+		const out = {};
+		for (const k of Object.keys(v)) out[k] = convert(v[k], seen);
+		return out;
+	};
+	return convert(this, new Set());
+}`
+
+// ListIndexedDB returns the IndexedDB databases of an origin with their object stores.
+// An empty origin uses the origin of the current page.
+func ListIndexedDB(ctx context.Context, origin string) ([]models.IDBDatabase, error) {
+	var databases []models.IDBDatabase
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		if origin, err = resolveOrigin(ctx, origin); err != nil {
+			return err
+		}
+		if err := indexeddb.Enable().Do(ctx); err != nil {
+			return err
+		}
+
+		names, err := indexeddb.RequestDatabaseNames().WithSecurityOrigin(origin).Do(ctx)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			db, err := indexeddb.RequestDatabase(name).WithSecurityOrigin(origin).Do(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to read database '%s': %w", name, err)
+			}
+
+			info := models.IDBDatabase{Name: db.Name, Version: db.Version, ObjectStores: []models.IDBObjectStore{}}
+			for _, store := range db.ObjectStores {
+				entries, _, err := indexeddb.GetMetadata(name, store.Name).WithSecurityOrigin(origin).Do(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to read metadata of '%s/%s': %w", name, store.Name, err)
+				}
+				indexes := make([]string, 0, len(store.Indexes))
+				for _, index := range store.Indexes {
+					indexes = append(indexes, index.Name)
+				}
+				info.ObjectStores = append(info.ObjectStores, models.IDBObjectStore{
+					Name:          store.Name,
+					KeyPath:       formatKeyPath(store.KeyPath),
+					AutoIncrement: store.AutoIncrement,
+					Indexes:       indexes,
+					Entries:       int64(entries),
+				})
+			}
+			databases = append(databases, info)
+		}
+		return nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IndexedDB databases: %w", err)
+	}
+	return databases, nil
+}
+
+// DumpIndexedDB exports the records of an origin's object stores. Empty database
+// or store names select every database or store.
+func DumpIndexedDB(ctx context.Context, origin, database, store string) ([]models.IDBStoreDump, error) {
+	databases, err := ListIndexedDB(ctx, origin)
+	if err != nil {
+		return nil, err
+	}
+
+	var dumps []models.IDBStoreDump
+	err = chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		if origin, err = resolveOrigin(ctx, origin); err != nil {
+			return err
+		}
+		for _, db := range databases {
+			if database != "" && db.Name != database {
+				continue
+			}
+			for _, s := range db.ObjectStores {
+				if store != "" && s.Name != store {
+					continue
+				}
+				records, err := readObjectStore(ctx, origin, db.Name, s.Name)
+				if err != nil {
+					return fmt.Errorf("failed to read '%s/%s': %w", db.Name, s.Name, err)
+				}
+				dumps = append(dumps, models.IDBStoreDump{Database: db.Name, Store: s.Name, Records: records})
+			}
+		}
+		return nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump IndexedDB: %w", err)
+	}
+	if len(dumps) == 0 && (database != "" || store != "") {
+		return nil, fmt.Errorf("no object store found matching database '%s' and store '%s'", database, store)
+	}
+	return dumps, nil
+}
+
+// readObjectStore pages through all records of an object store.
+func readObjectStore(ctx context.Context, origin, database, store string) ([]models.IDBRecord, error) {
+	records := []models.IDBRecord{}
+	for skip := int64(0); ; skip += idbPageSize {
+		entries, hasMore, err := indexeddb.RequestData(database, store, "", skip, idbPageSize).WithSecurityOrigin(origin).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			key, err := remoteObjectValue(ctx, entry.PrimaryKey)
+			if err != nil {
+				return nil, err
+			}
+			value, err := remoteObjectValue(ctx, entry.Value)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, models.IDBRecord{Key: key, Value: value})
+		}
+		if !hasMore || len(entries) == 0 {
+			return records, nil
+		}
+	}
+}
+
+// remoteObjectValue returns the JSON value of a remote object and releases it.
+func remoteObjectValue(ctx context.Context, obj *runtime.RemoteObject) (interface{}, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	raw := []byte(obj.Value)
+	if obj.ObjectID != "" {
+		defer runtime.ReleaseObject(obj.ObjectID).Do(ctx)
+		res, exp, err := runtime.CallFunctionOn(idbSerializeFunction).
+			WithObjectID(obj.ObjectID).
+			WithReturnByValue(true).
+			Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if exp != nil {
+			return nil, exp
+		}
+		raw = res.Value
+	}
+	if len(raw) == 0 {
+		if obj.UnserializableValue != "" {
+			return obj.UnserializableValue.String(), nil
+		}
+		return nil, nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// resolveOrigin returns origin, or the origin of the current page when it is empty.
+func resolveOrigin(ctx context.Context, origin string) (string, error) {
+	if origin != "" {
+		return strings.TrimSuffix(origin, "/"), nil
+	}
+	if err := EvaluateIsolated("location.origin", &origin).Do(ctx); err != nil {
+		return "", err
+	}
+	if origin == "" || origin == "null" {
+		return "", fmt.Errorf("the current page has no origin; pass --origin")
+	}
+	return origin, nil
+}
+
+// formatKeyPath renders an object store key path as a string.
+func formatKeyPath(keyPath *indexeddb.KeyPath) string {
+	if keyPath == nil {
+		return ""
+	}
+	if keyPath.Type == indexeddb.KeyPathTypeArray {
+		return strings.Join(keyPath.Array, ",")
+	}
+	return keyPath.String
+}
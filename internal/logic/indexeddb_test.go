@@ -0,0 +1,27 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/chromedp/cdproto/indexeddb"
+)
+
+func TestFormatKeyPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyPath *indexeddb.KeyPath
+		want    string
+	}{
+		{"nil", nil, ""},
+		{"null", &indexeddb.KeyPath{Type: indexeddb.KeyPathTypeNull}, ""},
+		{"string", &indexeddb.KeyPath{Type: indexeddb.KeyPathTypeString, String: "id"}, "id"},
+		{"array", &indexeddb.KeyPath{Type: indexeddb.KeyPathTypeArray, Array: []string{"user", "date"}}, "user,date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatKeyPath(tt.keyPath); got != tt.want {
+				t.Errorf("formatKeyPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
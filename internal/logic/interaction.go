@@ -2,19 +2,36 @@ package logic
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
+	"time"
 
+	"browser-tools-go/internal/i18n"
 	"browser-tools-go/internal/models"
 	"github.com/chromedp/cdproto/cdp"
-	"github.com/chromedp/cdproto/dom"
 	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/cdproto/runtime"
 	"github.com/chromedp/chromedp"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // PickElements extracts information from elements matching a CSS selector.
-func PickElements(ctx context.Context, selector string, all bool) ([]models.ElementInfo, error) {
+// With autoScroll, each element is scrolled into view and allowed to settle
+// before its rect is read, so that the rect is where the element is shown.
+func PickElements(ctx context.Context, selector string, all, autoScroll bool) ([]models.ElementInfo, error) {
+	ctx, span := startSpan(ctx, "pick", attribute.String("browser_tools.selector", selector))
+	infos, err := pickElements(ctx, selector, all, autoScroll)
+	span.SetAttributes(attribute.Int("browser_tools.elements", len(infos)))
+	endSpan(span, err)
+	return infos, err
+}
+
+// pickElements implements PickElements.
+func pickElements(ctx context.Context, selector string, all, autoScroll bool) ([]models.ElementInfo, error) {
 	var nodes []*cdp.Node
 	if err := chromedp.Run(ctx, chromedp.Nodes(selector, &nodes, chromedp.NodeVisible, chromedp.ByQuery)); err != nil {
 		return nil, fmt.Errorf("could not get nodes for selector '%s': %w", selector, err)
@@ -37,6 +54,11 @@ func PickElements(ctx context.Context, selector string, all bool) ([]models.Elem
 			chromedp.TextContent(node.NodeID, &text),
 			chromedp.Attributes(node.NodeID, &attrs),
 			chromedp.ActionFunc(func(ctx context.Context) error {
+				if autoScroll {
+					if err := scrollIntoViewStable(ctx, node.NodeID); err != nil {
+						return err
+					}
+				}
 				result, err := GetBoundingBox(ctx, node.NodeID)
 				if err != nil {
 					fmt.Printf("Warning: could not get bounding box for node %d: %v\n", node.NodeID, err)
@@ -64,14 +86,129 @@ func PickElements(ctx context.Context, selector string, all bool) ([]models.Elem
 	return infos, nil
 }
 
+// countElementsScript counts the elements matching a selector, optionally only
+// those that are rendered and not hidden.
+const countElementsScript = `((selector, visibleOnly) => {
+	const elements = Array.from(document.querySelectorAll(selector));
+	if (!visibleOnly) return elements.length;
+	return elements.filter(el => el.getClientRects().length > 0 && getComputedStyle(el).visibility !== 'hidden').length;
+})(%s, %t)`
+
+// CountElements returns the number of elements matching selector in the
+// current page; with visibleOnly, only those that are displayed count.
+func CountElements(ctx context.Context, selector string, visibleOnly bool) (int, error) {
+	selectorJSON, err := json.Marshal(selector)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	if err := chromedp.Run(ctx, EvaluateIsolated(fmt.Sprintf(countElementsScript, selectorJSON, visibleOnly), &count)); err != nil {
+		return 0, fmt.Errorf("failed to count elements matching '%s': %w", selector, err)
+	}
+	return count, nil
+}
+
+// elementValuesScript reads an attribute or a property of the first or of
+// every element matching a selector. Properties holding nodes or functions
+// are returned as strings, since they can't be returned by value.
+const elementValuesScript = `((selector, attr, prop, all) => {
+	const elements = all ? Array.from(document.querySelectorAll(selector)) : [document.querySelector(selector)].filter(Boolean);
+	return elements.map(el => {
+		if (attr !== '') return el.getAttribute(attr);
+		const value = el[prop];
+		if (value === undefined) return null;
+		return typeof value === 'function' || value instanceof Node ? String(value) : value;
+	});
+})(%s, %s, %s, %t)`
+
+// GetElementValues returns the attribute attr, or else the DOM property prop,
+// of the first element matching selector, or of every one with all. Missing
+// attributes and properties are nil; no match returns an empty slice.
+func GetElementValues(ctx context.Context, selector, attr, prop string, all bool) ([]interface{}, error) {
+	var params [3][]byte
+	for i, value := range []string{selector, attr, prop} {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		params[i] = encoded
+	}
+	values := []interface{}{}
+	script := fmt.Sprintf(elementValuesScript, params[0], params[1], params[2], all)
+	if err := chromedp.Run(ctx, EvaluateIsolated(script, &values)); err != nil {
+		return nil, fmt.Errorf("failed to read elements matching '%s': %w", selector, err)
+	}
+	return values, nil
+}
+
+// setElementScript sets the value or checked state of a form control through
+// the native property setters, which frameworks that wrap them (e.g. React)
+// don't intercept, and dispatches input and change events as typing or
+// clicking would. It returns the resulting value or checked state.
+const setElementScript = `((selector, value, checked) => {
+	const el = document.querySelector(selector);
+	if (!el) throw new Error('no element matches ' + selector);
+	const setNative = (proto, name, v) => Object.getOwnPropertyDescriptor(proto, name).set.call(el, v);
+	let result;
+	if (checked !== null) {
+		if (!(el instanceof HTMLInputElement) || (el.type !== 'checkbox' && el.type !== 'radio')) {
+			throw new Error(selector + ' is not a checkbox or radio button');
+		}
+		setNative(HTMLInputElement.prototype, 'checked', checked);
+		result = el.checked;
+	} else if (el instanceof HTMLSelectElement) {
+		if (!Array.from(el.options).some(o => o.value === value)) {
+			throw new Error(selector + ' has no option with value ' + JSON.stringify(value));
+		}
+		setNative(HTMLSelectElement.prototype, 'value', value);
+		result = el.value;
+	} else if (el instanceof HTMLInputElement || el instanceof HTMLTextAreaElement) {
+		setNative(el instanceof HTMLTextAreaElement ? HTMLTextAreaElement.prototype : HTMLInputElement.prototype, 'value', value);
+		result = el.value;
+	} else if (el.isContentEditable) {
+		el.textContent = value;
+		result = el.textContent;
+	} else {
+		throw new Error(selector + ' is not a form control');
+	}
+	el.dispatchEvent(new Event('input', {bubbles: true}));
+	el.dispatchEvent(new Event('change', {bubbles: true}));
+	return result;
+})(%s, %s, %s)`
+
+// SetElementValue sets the value of the form control matching selector, or its
+// checked state when checked is not nil, as if the user had changed it, and
+// returns the resulting value or checked state. Selects only accept the value
+// of one of their options. It is refused in read-only mode.
+func SetElementValue(ctx context.Context, selector, value string, checked *bool) (interface{}, error) {
+	if err := checkReadOnly(ctx, "setting "+selector+" changes the page"); err != nil {
+		return nil, err
+	}
+	var params [3][]byte
+	for i, v := range []interface{}{selector, value, checked} {
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		params[i] = encoded
+	}
+	var result interface{}
+	script := fmt.Sprintf(setElementScript, params[0], params[1], params[2])
+	if err := chromedp.Run(ctx, EvaluateIsolated(script, &result)); err != nil {
+		return nil, fmt.Errorf("failed to set %s: %w", selector, err)
+	}
+	return result, nil
+}
+
 // GetBoundingBox gets the bounding box for a given node ID.
 func GetBoundingBox(ctx context.Context, nodeID cdp.NodeID) (map[string]interface{}, error) {
-	remoteObject, err := dom.ResolveNode().WithNodeID(nodeID).Do(ctx)
+	contextID, err := isolatedContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("could not resolve node: %w", err)
+		return nil, err
 	}
-	if remoteObject == nil {
-		return nil, fmt.Errorf("resolved node object is nil")
+	remoteObject, err := resolveNodeIsolated(ctx, nodeID, contextID)
+	if err != nil {
+		return nil, err
 	}
 
 	var res map[string]interface{}
@@ -89,16 +226,280 @@ func GetBoundingBox(ctx context.Context, nodeID cdp.NodeID) (map[string]interfac
 	return res, nil
 }
 
-// EvaluateJS executes a JavaScript expression and returns the result.
-func EvaluateJS(ctx context.Context, jsExpression string) (interface{}, error) {
+// EvalOptions configures EvaluateJS and EvaluateScript.
+type EvalOptions struct {
+	// Await enables top-level await in the evaluated code.
+	Await bool
+	// Args is exposed to the evaluated code as a deeply frozen global "args".
+	Args map[string]interface{}
+	// Isolated evaluates the code in an isolated world, where it shares the
+	// DOM with the page but not its JavaScript globals.
+	Isolated bool
+	// Binary returns the result as []byte. The code must produce an
+	// ArrayBuffer, typed array, DataView or Blob, or a string holding base64
+	// or a data: URL, such as the result of canvas.toDataURL().
+	Binary bool
+}
+
+// exposeArgsFunction defines the global "args" binding from its call argument,
+// saving any previous binding so that restoreArgsFunction can put it back.
+const exposeArgsFunction = `function(value) {
+	const freeze = (v) => {
+		if (v && typeof v === 'object' && !Object.isFrozen(v)) {
+			Object.values(v).forEach(freeze);
+			Object.freeze(v);
+		}
+		return v;
+	};
+	this[Symbol.for('browser-tools-go.args')] = Object.getOwnPropertyDescriptor(this, 'args');
+	Object.defineProperty(this, 'args', {value: freeze(value), configurable: true, enumerable: false, writable: false});
+}`
+
+// restoreArgsFunction removes the binding defined by exposeArgsFunction.
+const restoreArgsFunction = `function() {
+	const key = Symbol.for('browser-tools-go.args');
+	const previous = this[key];
+	delete this[key];
+	delete this.args;
+	if (previous) {
+		Object.defineProperty(this, 'args', previous);
+	}
+}`
+
+// callOnGlobal calls functionDeclaration with globalThis of the execution
+// context contextID (zero for the page's main world) as this. Arguments are sent
+// as JSON call arguments, so user data is never interpolated into the source.
+func callOnGlobal(ctx context.Context, contextID runtime.ExecutionContextID, functionDeclaration string, res interface{}, args ...interface{}) error {
+	params := runtime.Evaluate("globalThis")
+	if contextID != 0 {
+		params = params.WithContextID(contextID)
+	}
+	global, exp, err := params.Do(ctx)
+	if err != nil {
+		return err
+	}
+	if exp != nil {
+		return exp
+	}
+	return chromedp.CallFunctionOn(functionDeclaration, res, func(p *runtime.CallFunctionOnParams) *runtime.CallFunctionOnParams {
+		return p.WithObjectID(global.ObjectID)
+	}, args...).Do(ctx)
+}
+
+// evaluate returns an action evaluating expression according to opts.
+func (opts EvalOptions) evaluate(expression string, res interface{}) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		var contextID runtime.ExecutionContextID
+		if opts.Isolated {
+			var err error
+			if contextID, err = isolatedContext(ctx); err != nil {
+				return err
+			}
+		}
+
+		if len(opts.Args) > 0 {
+			if err := callOnGlobal(ctx, contextID, exposeArgsFunction, nil, opts.Args); err != nil {
+				return fmt.Errorf("failed to expose args: %w", err)
+			}
+			defer func() {
+				if err := callOnGlobal(ctx, contextID, restoreArgsFunction, nil); err != nil {
+					i18n.Printf("Warning: could not remove args from the page: %v", err)
+				}
+			}()
+		}
+
+		params := func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+			p = opts.evaluateParams(p)
+			if contextID != 0 {
+				p = p.WithContextID(contextID)
+			}
+			return p
+		}
+		if !opts.Binary {
+			return chromedp.Evaluate(expression, res, params).Do(ctx)
+		}
+
+		var obj *runtime.RemoteObject
+		if err := chromedp.Evaluate(expression, &obj, params).Do(ctx); err != nil {
+			return err
+		}
+		data, err := remoteObjectBytes(ctx, obj)
+		if err != nil {
+			return err
+		}
+		*res.(*interface{}) = data
+		return nil
+	})
+}
+
+// binaryToBase64Function encodes the ArrayBuffer, view or Blob it is called
+// on as base64, since binary values can't be returned by value.
+const binaryToBase64Function = `async function() {
+	let value = this;
+	if (value instanceof Blob) value = await value.arrayBuffer();
+	if (ArrayBuffer.isView(value)) value = value.buffer.slice(value.byteOffset, value.byteOffset + value.byteLength);
+	if (!(value instanceof ArrayBuffer)) {
+		throw new TypeError('expected an ArrayBuffer, typed array, DataView, Blob or string, got ' + Object.prototype.toString.call(value));
+	}
+	const bytes = new Uint8Array(value);
+	let binary = '';
+	for (let i = 0; i < bytes.length; i += 0x8000) {
+		binary += String.fromCharCode.apply(null, bytes.subarray(i, i + 0x8000));
+	}
+	return btoa(binary);
+}`
+
+// remoteObjectBytes returns the bytes of an evaluation result, as described
+// for EvalOptions.Binary.
+func remoteObjectBytes(ctx context.Context, obj *runtime.RemoteObject) ([]byte, error) {
+	if obj.ObjectID == "" {
+		var text string
+		if obj.Type != runtime.TypeString || json.Unmarshal(obj.Value, &text) != nil {
+			return nil, fmt.Errorf("expected an ArrayBuffer, typed array, DataView, Blob or string, got %s", obj.Type)
+		}
+		return DecodeBase64Result(text)
+	}
+	defer runtime.ReleaseObject(obj.ObjectID).Do(ctx)
+	var encoded string
+	err := chromedp.CallFunctionOn(binaryToBase64Function, &encoded, func(p *runtime.CallFunctionOnParams) *runtime.CallFunctionOnParams {
+		return p.WithObjectID(obj.ObjectID).WithAwaitPromise(true)
+	}).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+// DecodeBase64Result decodes a data: URL or a base64 string, in the standard
+// or URL-safe alphabet, with or without padding.
+func DecodeBase64Result(text string) ([]byte, error) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "data:") {
+		meta, data, ok := strings.Cut(strings.TrimPrefix(text, "data:"), ",")
+		if !ok {
+			return nil, fmt.Errorf("invalid data: URL: missing ','")
+		}
+		if !strings.HasSuffix(meta, ";base64") {
+			decoded, err := url.PathUnescape(data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid data: URL: %w", err)
+			}
+			return []byte(decoded), nil
+		}
+		text = data
+	}
+	text = strings.Join(strings.Fields(text), "")
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if data, err := encoding.DecodeString(text); err == nil {
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("result is not valid base64 or a data: URL")
+}
+
+// ParseEvalArgs builds the args object from the contents of an --args-json file
+// (a JSON object, may be empty) and key=value pairs, which take precedence.
+func ParseEvalArgs(pairs []string, argsJSON []byte) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	if len(argsJSON) > 0 {
+		if err := json.Unmarshal(argsJSON, &args); err != nil {
+			return nil, fmt.Errorf("args JSON must be an object: %w", err)
+		}
+		if args == nil { // the file contained null
+			args = map[string]interface{}{}
+		}
+	}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid arg %q: expected key=value", pair)
+		}
+		args[key] = value
+	}
+	return args, nil
+}
+
+// evaluateParams returns the Runtime.evaluate options for opts. Promise results
+// are always awaited so their resolved value is returned instead of "{}".
+func (opts EvalOptions) evaluateParams(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+	p = p.WithAwaitPromise(true)
+	if opts.Await {
+		p = p.WithReplMode(true)
+	}
+	return p
+}
+
+// EvaluateJS executes a JavaScript expression and returns the result. It is
+// refused when the policy of ctx restricts eval to approved scripts, and in
+// read-only mode for code that looks like it changes the page (see
+// MutatingJS).
+func EvaluateJS(ctx context.Context, jsExpression string, opts EvalOptions) (interface{}, error) {
+	if err := CheckEvalPolicy(ctx); err != nil {
+		return nil, err
+	}
+	if err := checkReadOnlyJS(ctx, jsExpression); err != nil {
+		return nil, err
+	}
+	ctx, span := startSpan(ctx, "eval")
 	var result interface{}
-	err := chromedp.Run(ctx, chromedp.Evaluate(jsExpression, &result))
+	err := chromedp.Run(ctx, opts.evaluate(jsExpression, &result))
+	endSpan(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to evaluate javascript: %w", err)
 	}
 	return result, nil
 }
 
+// EvaluateScript executes a multi-line script read from a file or stdin.
+// sourceName is attached as the script's sourceURL so that exceptions are
+// reported with a line and column in the original source. Like EvaluateJS,
+// it refuses code that looks like it changes the page in read-only mode.
+func EvaluateScript(ctx context.Context, source, sourceName string, opts EvalOptions) (interface{}, error) {
+	if err := checkReadOnlyJS(ctx, source); err != nil {
+		return nil, fmt.Errorf("%s: %w", sourceName, err)
+	}
+	ctx, span := startSpan(ctx, "eval", attribute.String("browser_tools.script", sourceName))
+	var result interface{}
+	script := source + "\n//# sourceURL=" + sourceName
+	err := chromedp.Run(ctx, opts.evaluate(script, &result))
+	endSpan(span, err)
+	var details *runtime.ExceptionDetails
+	if errors.As(err, &details) {
+		return nil, errors.New(formatScriptError(source, sourceName, details))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate %s: %w", sourceName, err)
+	}
+	return result, nil
+}
+
+// formatScriptError renders an exception as "name:line:col: message" followed
+// by the offending source line and a caret under the column.
+func formatScriptError(source, sourceName string, details *runtime.ExceptionDetails) string {
+	message := details.Text
+	if details.Exception != nil && details.Exception.Description != "" {
+		message = strings.SplitN(details.Exception.Description, "\n", 2)[0]
+	}
+	line, column := details.LineNumber, details.ColumnNumber
+	if details.StackTrace != nil {
+		// Report the innermost frame that belongs to the script itself.
+		for _, frame := range details.StackTrace.CallFrames {
+			if frame.URL == sourceName {
+				line, column = frame.LineNumber, frame.ColumnNumber
+				break
+			}
+		}
+	}
+
+	formatted := fmt.Sprintf("%s:%d:%d: %s", sourceName, line+1, column+1, message)
+	lines := strings.Split(source, "\n")
+	if line >= 0 && int(line) < len(lines) {
+		text := strings.TrimRight(lines[line], "\r")
+		formatted += fmt.Sprintf("\n%5d | %s\n      | %s^", line+1, text, strings.Repeat(" ", int(column)))
+	}
+	return formatted
+}
+
 // GetCookies retrieves all cookies for the current context.
 func GetCookies(ctx context.Context) ([]*network.Cookie, error) {
 	cookies, err := network.GetCookies().Do(ctx)
@@ -107,3 +508,166 @@ func GetCookies(ctx context.Context) ([]*network.Cookie, error) {
 	}
 	return cookies, nil
 }
+
+// elementTimeout bounds how long Click and TypeText wait for their element.
+const elementTimeout = 30 * time.Second
+
+// WaitForSelector waits until an element matching selector is visible, for at
+// most timeout.
+func WaitForSelector(ctx context.Context, selector string, timeout time.Duration) error {
+	if err := runOnElement(ctx, "wait", selector, timeout, chromedp.WaitVisible(selector, chromedp.ByQuery)); err != nil {
+		return fmt.Errorf("failed waiting for %q: %w", selector, err)
+	}
+	return nil
+}
+
+// SelectorFallbackSeparator separates the candidates of a selector fallback
+// list, e.g. "#main||main||body".
+const SelectorFallbackSeparator = "||"
+
+// SelectorCandidates splits values on SelectorFallbackSeparator into the
+// candidates of a fallback list, in order and without empty ones.
+func SelectorCandidates(values ...string) []string {
+	var candidates []string
+	for _, value := range values {
+		for _, candidate := range strings.Split(value, SelectorFallbackSeparator) {
+			if candidate = strings.TrimSpace(candidate); candidate != "" {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates
+}
+
+// firstVisibleCandidateFunction returns the index of the first candidate
+// matching a visible element, as chromedp.NodeVisible judges it, or -1.
+const firstVisibleCandidateFunction = `function(candidates) {
+	const visible = el => Boolean(el.offsetWidth || el.offsetHeight || el.getClientRects().length);
+	return candidates.findIndex(selector => Array.from(document.querySelectorAll(selector)).some(visible));
+}`
+
+// selectorPollInterval is how often WaitForAnySelector checks the candidates.
+const selectorPollInterval = 100 * time.Millisecond
+
+// WaitForAnySelector waits until one of candidates matches a visible element,
+// for at most timeout, and returns it. Earlier candidates win when several
+// match, and falling back to a later one is logged. A single candidate is
+// waited for like WaitForSelector does.
+func WaitForAnySelector(ctx context.Context, candidates []string, timeout time.Duration) (string, error) {
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no selectors provided")
+	}
+	if len(candidates) == 1 {
+		return candidates[0], WaitForSelector(ctx, candidates[0], timeout)
+	}
+
+	ctx, span := startSpan(ctx, "wait", attribute.StringSlice("browser_tools.selectors", candidates))
+	index, err := waitForAnySelector(ctx, candidates, timeout)
+	endSpan(span, err)
+	if err != nil {
+		return "", err
+	}
+	if index > 0 {
+		i18n.Printf("Selector '%s' matched nothing, falling back to '%s'", candidates[0], candidates[index])
+	}
+	return candidates[index], nil
+}
+
+// waitForAnySelector implements WaitForAnySelector, returning the index of the
+// matching candidate. Failed checks, e.g. while a page is loading, are retried;
+// a failure of the last check is reported when no candidate matched in time,
+// since it may come from an invalid selector.
+func waitForAnySelector(ctx context.Context, candidates []string, timeout time.Duration) (int, error) {
+	// Attach to the tab with the caller's context first, as in runOnElement.
+	if err := chromedp.Run(ctx); err != nil {
+		return -1, err
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(selectorPollInterval)
+	defer ticker.Stop()
+	var lastErr error
+	for {
+		index := -1
+		err := chromedp.Run(waitCtx, chromedp.ActionFunc(func(ctx context.Context) error {
+			id, err := isolatedContext(ctx)
+			if err != nil {
+				return err
+			}
+			return callOnGlobal(ctx, id, firstVisibleCandidateFunction, &index, candidates)
+		}))
+		if err == nil && index >= 0 {
+			return index, nil
+		}
+		if waitCtx.Err() == nil {
+			lastErr = err
+		}
+		select {
+		case <-waitCtx.Done():
+			if !errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+				return -1, waitCtx.Err()
+			}
+			if lastErr != nil {
+				return -1, fmt.Errorf("no visible match for %s after %s: %w", strings.Join(candidates, ", "), timeout, lastErr)
+			}
+			return -1, fmt.Errorf("no visible match for %s after %s", strings.Join(candidates, ", "), timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Click clicks the first element matching selector once it is visible. With
+// autoScroll, the element is first scrolled into view and allowed to settle,
+// so that the click lands on it rather than on whatever covers its old place.
+// It is refused in read-only mode.
+func Click(ctx context.Context, selector string, autoScroll bool) error {
+	if err := checkReadOnly(ctx, "clicking "+selector+" may change the page"); err != nil {
+		return err
+	}
+	action := chromedp.QueryAfter(selector, func(ctx context.Context, _ runtime.ExecutionContextID, nodes ...*cdp.Node) error {
+		if autoScroll {
+			if err := scrollIntoViewStable(ctx, nodes[0].NodeID); err != nil {
+				return err
+			}
+		}
+		return chromedp.MouseClickNode(nodes[0]).Do(ctx)
+	}, chromedp.ByQuery, chromedp.NodeVisible)
+	if err := runOnElement(ctx, "click", selector, elementTimeout, action); err != nil {
+		return fmt.Errorf("failed to click %q: %w", selector, err)
+	}
+	return nil
+}
+
+// TypeText focuses the first element matching selector once it is visible
+// and types text into it. It is refused in read-only mode.
+func TypeText(ctx context.Context, selector, text string) error {
+	if err := checkReadOnly(ctx, "typing into "+selector+" changes the page"); err != nil {
+		return err
+	}
+	if err := runOnElement(ctx, "type", selector, elementTimeout, chromedp.SendKeys(selector, text, chromedp.ByQuery, chromedp.NodeVisible)); err != nil {
+		return fmt.Errorf("failed to type into %q: %w", selector, err)
+	}
+	return nil
+}
+
+// runOnElement runs an action that waits for selector, giving up after
+// timeout, in a span called name.
+func runOnElement(ctx context.Context, name, selector string, timeout time.Duration, action chromedp.Action) (err error) {
+	ctx, span := startSpan(ctx, name, attribute.String("browser_tools.selector", selector))
+	defer func() { endSpan(span, err) }()
+
+	// Attach to the tab with the caller's context first: the tab's event loop
+	// lives as long as the context of the first Run.
+	if err := chromedp.Run(ctx); err != nil {
+		return err
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := chromedp.Run(waitCtx, action); err != nil {
+		if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("no visible match after %s", timeout)
+		}
+		return err
+	}
+	return nil
+}
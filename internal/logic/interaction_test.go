@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os/exec"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/chromedp/cdproto/runtime"
 	"github.com/chromedp/chromedp"
 )
 
@@ -23,6 +26,7 @@ func setupTestServer() *httptest.Server {
 				<span id="span1" style="position: absolute; top: 100px; left: 120px; width: 130px; height: 140px;">Second</span>
 				<div id="div2" class="multiple" style="position: absolute; top: 200px; left: 220px; width: 230px; height: 240px;">Third</div>
 				<div id="div3" class="multiple" style="position: absolute; top: 300px; left: 320px; width: 330px; height: 340px;">Fourth</div>
+				<div id="far" style="position: absolute; top: 3000px; left: 20px; width: 30px; height: 40px;">Far</div>
 			</body>
 			</html>
 		`)
@@ -57,7 +61,7 @@ func TestPickElements(t *testing.T) {
 	}
 
 	t.Run("pick single element", func(t *testing.T) {
-		elements, err := PickElements(ctx, "#div1", false)
+		elements, err := PickElements(ctx, "#div1", false, true)
 		if err != nil {
 			t.Fatalf("PickElements failed: %v", err)
 		}
@@ -79,7 +83,7 @@ func TestPickElements(t *testing.T) {
 	})
 
 	t.Run("pick multiple elements", func(t *testing.T) {
-		elements, err := PickElements(ctx, ".multiple", true)
+		elements, err := PickElements(ctx, ".multiple", true, true)
 		if err != nil {
 			t.Fatalf("PickElements with --all failed: %v", err)
 		}
@@ -113,8 +117,53 @@ func TestPickElements(t *testing.T) {
 		}
 	})
 
+	t.Run("pick scrolls element into view", func(t *testing.T) {
+		elements, err := PickElements(ctx, "#far", false, true)
+		if err != nil {
+			t.Fatalf("PickElements failed: %v", err)
+		}
+		if len(elements) != 1 {
+			t.Fatalf("Expected 1 element, got %d", len(elements))
+		}
+		var innerHeight float64
+		if err := chromedp.Run(ctx, chromedp.Evaluate("window.innerHeight", &innerHeight)); err != nil {
+			t.Fatalf("Failed to read the viewport height: %v", err)
+		}
+		top, _ := elements[0].Rect["top"].(float64)
+		bottom, _ := elements[0].Rect["bottom"].(float64)
+		if top < 0 || bottom > innerHeight {
+			t.Errorf("Expected the element within the viewport (0-%v), got top %v and bottom %v", innerHeight, top, bottom)
+		}
+	})
+
+	t.Run("pick without auto-scroll", func(t *testing.T) {
+		if err := chromedp.Run(ctx, chromedp.Evaluate("window.scrollTo(0, 0)", nil)); err != nil {
+			t.Fatalf("Failed to scroll to the top: %v", err)
+		}
+		elements, err := PickElements(ctx, "#far", false, false)
+		if err != nil {
+			t.Fatalf("PickElements failed: %v", err)
+		}
+		if len(elements) != 1 || elements[0].Rect["top"] != 3000.0 {
+			t.Errorf("Expected the element at top 3000, got %v", elements)
+		}
+	})
+
+	t.Run("wait for the first matching candidate", func(t *testing.T) {
+		selector, err := WaitForAnySelector(ctx, []string{"#nonexistent", "#div1", "#span1"}, time.Second)
+		if err != nil {
+			t.Fatalf("WaitForAnySelector failed: %v", err)
+		}
+		if selector != "#div1" {
+			t.Errorf("Expected '#div1', got '%s'", selector)
+		}
+		if _, err := WaitForAnySelector(ctx, []string{"#nonexistent", "#missing"}, 300*time.Millisecond); err == nil {
+			t.Error("Expected an error when no candidate matches")
+		}
+	})
+
 	t.Run("pick non-existent element", func(t *testing.T) {
-		elements, err := PickElements(ctx, "#nonexistent", true)
+		elements, err := PickElements(ctx, "#nonexistent", true, true)
 		if err != nil {
 			t.Fatalf("PickElements failed for non-existent element: %v", err)
 		}
@@ -123,3 +172,141 @@ func TestPickElements(t *testing.T) {
 		}
 	})
 }
+
+func TestSelectorCandidates(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   []string
+	}{
+		{[]string{"#main"}, []string{"#main"}},
+		{[]string{"#main||main || body"}, []string{"#main", "main", "body"}},
+		{[]string{"#main", ".content||article"}, []string{"#main", ".content", "article"}},
+		{[]string{"||", " "}, nil},
+	}
+	for _, tt := range tests {
+		if got := SelectorCandidates(tt.values...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SelectorCandidates(%q) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestFormatScriptError(t *testing.T) {
+	source := "const a = 1;\nfoo();\nreturn a;"
+	details := &runtime.ExceptionDetails{
+		Text:         "Uncaught",
+		LineNumber:   1,
+		ColumnNumber: 0,
+		Exception:    &runtime.RemoteObject{Description: "ReferenceError: foo is not defined\n    at script.js:2:1"},
+		StackTrace: &runtime.StackTrace{CallFrames: []*runtime.CallFrame{
+			{URL: "https://example.com/app.js", LineNumber: 10, ColumnNumber: 4},
+			{URL: "script.js", LineNumber: 1, ColumnNumber: 0},
+		}},
+	}
+
+	got := formatScriptError(source, "script.js", details)
+	want := "script.js:2:1: ReferenceError: foo is not defined\n    2 | foo();\n      | ^"
+	if got != want {
+		t.Errorf("formatScriptError() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Without an exception object or a matching frame, the details' own position is used.
+	details = &runtime.ExceptionDetails{Text: "Uncaught SyntaxError", LineNumber: 5, ColumnNumber: 2}
+	got = formatScriptError(source, "script.js", details)
+	if got != "script.js:6:3: Uncaught SyntaxError" {
+		t.Errorf("formatScriptError() = %q", got)
+	}
+}
+
+func TestEvalOptions_EvaluateParams(t *testing.T) {
+	p := EvalOptions{}.evaluateParams(runtime.Evaluate("1"))
+	if !p.AwaitPromise || p.ReplMode {
+		t.Errorf("expected promises to be awaited without REPL mode, got %+v", p)
+	}
+	p = EvalOptions{Await: true}.evaluateParams(runtime.Evaluate("await 1"))
+	if !p.AwaitPromise || !p.ReplMode {
+		t.Errorf("expected --await to enable REPL mode, got %+v", p)
+	}
+}
+
+func TestParseEvalArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairs   []string
+		json    string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{"empty", nil, "", map[string]interface{}{}, false},
+		{"pairs", []string{"q=it's", "empty=", "eq=a=b"}, "", map[string]interface{}{"q": "it's", "empty": "", "eq": "a=b"}, false},
+		{"json", nil, `{"n": 1, "list": ["x"]}`, map[string]interface{}{"n": float64(1), "list": []interface{}{"x"}}, false},
+		{"pairs override json", []string{"n=2"}, `{"n": 1}`, map[string]interface{}{"n": "2"}, false},
+		{"null json", []string{"a=b"}, "null", map[string]interface{}{"a": "b"}, false},
+		{"json array", nil, `[1]`, nil, true},
+		{"missing equals", []string{"flag"}, "", nil, true},
+		{"empty key", []string{"=v"}, "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEvalArgs(tt.pairs, []byte(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEvalArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEvalArgs() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeBase64Result(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{"standard", "aGk/Pz4+", "hi??>>", false},
+		{"url-safe", "aGk_Pz4-", "hi??>>", false},
+		{"unpadded", "aGk", "hi", false},
+		{"wrapped", "aGVs\nbG8=\n", "hello", false},
+		{"data url", "data:image/png;base64,aGVsbG8=", "hello", false},
+		{"plain data url", "data:text/plain,a%20b", "a b", false},
+		{"data url without comma", "data:text/plain", "", true},
+		{"not base64", "hello world!", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeBase64Result(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeBase64Result() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("DecodeBase64Result() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateIsolated(t *testing.T) {
+	if _, err := exec.LookPath("google-chrome"); err != nil {
+		t.Skip("google-chrome not found, skipping test")
+	}
+
+	ctx, cancel := chromedp.NewContext(context.Background())
+	defer cancel()
+
+	// A page global must not be visible from the isolated world, while the DOM is shared.
+	var pageValue, isolatedValue string
+	err := chromedp.Run(ctx,
+		chromedp.Navigate("data:text/html,<title>shared</title><script>var marker = 'page'</script>"),
+		chromedp.Evaluate("typeof marker", &pageValue),
+		EvaluateIsolated("typeof marker + ':' + document.title", &isolatedValue),
+	)
+	if err != nil {
+		t.Fatalf("evaluation failed: %v", err)
+	}
+	if pageValue != "string" || isolatedValue != "undefined:shared" {
+		t.Errorf("got page %q, isolated %q", pageValue, isolatedValue)
+	}
+}
@@ -0,0 +1,102 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/chromedp"
+)
+
+// requestRule is a rule of the request interception of a tab.
+type requestRule struct {
+	pattern *fetch.RequestPattern
+	// paused handles a paused request; it returns false to pass it on to the
+	// next rule.
+	paused func(ctx context.Context, ev *fetch.EventRequestPaused) bool
+	// authRequired answers an authentication challenge the same way; nil
+	// when the rule doesn't answer any.
+	authRequired func(ctx context.Context, ev *fetch.EventAuthRequired) bool
+}
+
+// requestInterceptor holds the rules of the request interception of a tab.
+type requestInterceptor struct {
+	mu    sync.Mutex
+	rules []requestRule
+}
+
+// interceptors are the request interceptors of the tabs, by target.
+var interceptors = struct {
+	sync.Mutex
+	byTarget map[*chromedp.Target]*requestInterceptor
+}{byTarget: map[*chromedp.Target]*requestInterceptor{}}
+
+// interceptRequests adds rule to the request interception of the tab of ctx,
+// for as long as ctx is connected. Each Fetch.enable replaces the patterns
+// of the previous one, so the rules of a tab share one interception: a
+// paused request or challenge goes to each rule in the order they were added
+// until one handles it, and continues unchanged when none does.
+func interceptRequests(ctx context.Context, rule requestRule) error {
+	c := chromedp.FromContext(ctx)
+	if c == nil || c.Target == nil {
+		return fmt.Errorf("no tab to intercept the requests of")
+	}
+
+	interceptors.Lock()
+	interceptor, ok := interceptors.byTarget[c.Target]
+	if !ok {
+		interceptor = &requestInterceptor{}
+		interceptors.byTarget[c.Target] = interceptor
+		context.AfterFunc(ctx, func() {
+			interceptors.Lock()
+			delete(interceptors.byTarget, c.Target)
+			interceptors.Unlock()
+		})
+		chromedp.ListenTarget(ctx, func(ev interface{}) { interceptor.handle(ctx, ev) })
+	}
+	interceptors.Unlock()
+
+	interceptor.mu.Lock()
+	interceptor.rules = append(interceptor.rules, rule)
+	patterns := make([]*fetch.RequestPattern, len(interceptor.rules))
+	handleAuth := false
+	for i, r := range interceptor.rules {
+		patterns[i] = r.pattern
+		handleAuth = handleAuth || r.authRequired != nil
+	}
+	interceptor.mu.Unlock()
+
+	return chromedp.Run(ctx, fetch.Enable().WithPatterns(patterns).WithHandleAuthRequests(handleAuth))
+}
+
+// handle dispatches the paused requests and challenges to the rules.
+func (i *requestInterceptor) handle(ctx context.Context, ev interface{}) {
+	switch ev := ev.(type) {
+	case *fetch.EventRequestPaused:
+		go func() {
+			for _, rule := range i.snapshot() {
+				if rule.paused != nil && rule.paused(ctx, ev) {
+					return
+				}
+			}
+			_ = chromedp.Run(ctx, fetch.ContinueRequest(ev.RequestID))
+		}()
+	case *fetch.EventAuthRequired:
+		go func() {
+			for _, rule := range i.snapshot() {
+				if rule.authRequired != nil && rule.authRequired(ctx, ev) {
+					return
+				}
+			}
+			response := &fetch.AuthChallengeResponse{Response: fetch.AuthChallengeResponseResponseDefault}
+			_ = chromedp.Run(ctx, fetch.ContinueWithAuth(ev.RequestID, response))
+		}()
+	}
+}
+
+func (i *requestInterceptor) snapshot() []requestRule {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]requestRule(nil), i.rules...)
+}
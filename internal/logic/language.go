@@ -0,0 +1,114 @@
+package logic
+
+import (
+	"strings"
+	"unicode"
+)
+
+// languageStopwords are frequent function words used to tell Latin-script languages apart.
+var languageStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "for", "with", "this"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "mit", "ein", "auf", "für"},
+	"fr": {"le", "la", "les", "et", "des", "est", "une", "dans", "pour", "que"},
+	"es": {"el", "la", "los", "y", "que", "del", "las", "por", "una", "para"},
+	"pt": {"o", "a", "os", "que", "do", "da", "não", "uma", "para", "com"},
+	"it": {"il", "di", "che", "è", "della", "per", "una", "non", "sono", "gli"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "dat", "op", "voor"},
+}
+
+// normalizeLanguageTag lowercases a BCP 47 tag and reduces it to its primary subtag.
+func normalizeLanguageTag(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+// detectLanguage returns the document language, preferring the declared lang
+// attribute and falling back to a script and stopword heuristic on the text.
+// It returns an empty string when the language cannot be determined.
+func detectLanguage(declared, text string) string {
+	if lang := normalizeLanguageTag(declared); lang != "" {
+		return lang
+	}
+
+	counts := map[string]int{}
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			counts["kana"]++
+		case unicode.Is(unicode.Han, r):
+			counts["han"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["he"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["el"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		case unicode.Is(unicode.Devanagari, r):
+			counts["hi"]++
+		case unicode.Is(unicode.Latin, r):
+			counts["latin"]++
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+
+	// Any meaningful amount of kana means Japanese; Han-only text is treated as Chinese.
+	if counts["kana"] > 0 && counts["kana"]*20 >= counts["han"] {
+		return "ja"
+	}
+
+	best, bestCount := "", 0
+	for script, n := range counts {
+		if n > bestCount {
+			best, bestCount = script, n
+		}
+	}
+	switch best {
+	case "kana":
+		return "ja"
+	case "han":
+		return "zh"
+	case "latin":
+		return detectLatinLanguage(text)
+	default:
+		return best
+	}
+}
+
+// detectLatinLanguage picks the Latin-script language whose stopwords occur most often.
+func detectLatinLanguage(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	freq := make(map[string]int, len(words))
+	for _, w := range words {
+		freq[w]++
+	}
+
+	best, bestScore := "", 0
+	for _, lang := range []string{"en", "de", "fr", "es", "pt", "it", "nl"} {
+		score := 0
+		for _, w := range languageStopwords[lang] {
+			score += freq[w]
+		}
+		if score > bestScore {
+			best, bestScore = lang, score
+		}
+	}
+	return best
+}
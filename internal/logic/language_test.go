@@ -0,0 +1,31 @@
+package logic
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		declared string
+		text     string
+		expected string
+	}{
+		{"declared tag wins", "en-US", "これは日本語です", "en"},
+		{"declared underscore tag", "pt_BR", "", "pt"},
+		{"japanese", "", "これは日本語の文章です。", "ja"},
+		{"chinese", "", "这是一个中文句子", "zh"},
+		{"korean", "", "이것은 한국어 문장입니다", "ko"},
+		{"russian", "", "Это предложение на русском языке", "ru"},
+		{"english", "", "This is the story of a man and his dog in the park", "en"},
+		{"german", "", "Das ist nicht der Hund, und die Katze ist mit ein Ball", "de"},
+		{"french", "", "Le chat est dans la maison et les enfants sont pour une fois calmes", "fr"},
+		{"no letters", "", "12345 !!!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectLanguage(tt.declared, tt.text); got != tt.expected {
+				t.Errorf("detectLanguage(%q, %q) = %q, want %q", tt.declared, tt.text, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/chromedp"
+	"github.com/chromedp/chromedp/kb"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// LoginSecrets are the resolved credentials of a login recipe. MFACode is
+// only called when the site asks for a verification code, so that a code
+// typed in or generated then is still valid when it is sent.
+type LoginSecrets struct {
+	Username string
+	Password string
+	MFACode  func() (string, error)
+}
+
+// States of a login, as reported by loginStateFunction.
+const (
+	loginStatePending = ""
+	loginStateSuccess = "success"
+	loginStateFailure = "failure"
+	loginStateMFA     = "mfa"
+)
+
+// loginStateFunction tells how far a login got: signed in when every success
+// condition holds, rejected when the failure element shows, with its text, or
+// asked for a verification code when the MFA field shows. Empty selectors
+// match nothing.
+const loginStateFunction = `function(successURL, successSelector, failureSelector, mfaSelector) {
+	const visible = el => Boolean(el.offsetWidth || el.offsetHeight || el.getClientRects().length);
+	const shown = selector => selector === "" ? null : Array.from(document.querySelectorAll(selector)).find(visible) || null;
+	if ((successURL === "" || location.href.includes(successURL)) && (successSelector === "" || shown(successSelector))) {
+		return {state: "success"};
+	}
+	const failure = shown(failureSelector);
+	if (failure) {
+		return {state: "failure", message: (failure.innerText || failure.textContent || "").trim()};
+	}
+	if (shown(mfaSelector)) {
+		return {state: "mfa"};
+	}
+	return {state: ""};
+}`
+
+type loginState struct {
+	State   string `json:"state"`
+	Message string `json:"message"`
+}
+
+// Login signs in to site with recipe: it opens the login page, types the
+// credentials, submits them and waits until the success condition of the
+// recipe holds, entering a verification code on the way when the site asks
+// for one. Each step waits for at most timeout. The session cookies stay in
+// the browser for the next commands.
+func Login(ctx context.Context, site string, recipe *config.LoginRecipe, secrets LoginSecrets, timeout time.Duration) (*models.LoginResult, error) {
+	ctx, span := startSpan(ctx, "login", attribute.String("browser_tools.site", site), attribute.String("url.full", recipe.URL))
+	result, err := runLogin(ctx, site, recipe, secrets, timeout)
+	endSpan(span, err)
+	return result, err
+}
+
+func runLogin(ctx context.Context, site string, recipe *config.LoginRecipe, secrets LoginSecrets, timeout time.Duration) (*models.LoginResult, error) {
+	start := time.Now()
+	if _, err := runNavigation(ctx, recipe.URL, NavigateOptions{WaitUntil: WaitUntilLoad}); err != nil {
+		return nil, err
+	}
+	if err := fillField(ctx, recipe.UsernameSelector, secrets.Username, timeout); err != nil {
+		return nil, err
+	}
+	if err := fillField(ctx, recipe.PasswordSelector, secrets.Password, timeout); err != nil {
+		return nil, err
+	}
+	if err := submitForm(ctx, recipe.SubmitSelector, recipe.PasswordSelector, timeout); err != nil {
+		return nil, err
+	}
+
+	mfaSelector := ""
+	if recipe.MFA != nil {
+		mfaSelector = recipe.MFA.Selector
+	}
+	state, err := waitForLogin(ctx, recipe, mfaSelector, timeout)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.LoginResult{Site: site}
+	if state == loginStateMFA {
+		result.MFA = true
+		if secrets.MFACode == nil {
+			return nil, fmt.Errorf("the site asked for a verification code")
+		}
+		code, err := secrets.MFACode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the verification code: %w", err)
+		}
+		if err := fillField(ctx, recipe.MFA.Selector, code, timeout); err != nil {
+			return nil, err
+		}
+		if err := submitForm(ctx, recipe.MFA.SubmitSelector, recipe.MFA.Selector, timeout); err != nil {
+			return nil, err
+		}
+		if _, err := waitForLogin(ctx, recipe, "", timeout); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := chromedp.Run(ctx, chromedp.Location(&result.FinalURL)); err != nil {
+		return nil, err
+	}
+	result.DurationMs = float64(time.Since(start).Microseconds()) / 1000
+	return result, nil
+}
+
+// fillField replaces the value of the field matching selector by typing
+// value into it, so that the page sees the key events of a user.
+func fillField(ctx context.Context, selector, value string, timeout time.Duration) error {
+	action := chromedp.Tasks{
+		chromedp.SetValue(selector, "", chromedp.ByQuery, chromedp.NodeVisible),
+		chromedp.SendKeys(selector, value, chromedp.ByQuery, chromedp.NodeVisible),
+	}
+	if err := runOnElement(ctx, "type", selector, timeout, action); err != nil {
+		return fmt.Errorf("failed to type into %q: %w", selector, err)
+	}
+	return nil
+}
+
+// submitForm clicks the element matching submitSelector, or without one,
+// presses Enter in the field matching fieldSelector.
+func submitForm(ctx context.Context, submitSelector, fieldSelector string, timeout time.Duration) error {
+	if submitSelector != "" {
+		return Click(ctx, submitSelector, false)
+	}
+	if err := runOnElement(ctx, "type", fieldSelector, timeout, chromedp.SendKeys(fieldSelector, kb.Enter, chromedp.ByQuery, chromedp.NodeVisible)); err != nil {
+		return fmt.Errorf("failed to submit %q: %w", fieldSelector, err)
+	}
+	return nil
+}
+
+// waitForLogin waits for at most timeout until the login succeeded or, with
+// an mfaSelector, the site asks for a verification code, and returns which.
+// It fails as soon as the failure element of the recipe shows. Checks failing
+// while the page navigates are retried.
+func waitForLogin(ctx context.Context, recipe *config.LoginRecipe, mfaSelector string, timeout time.Duration) (string, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(selectorPollInterval)
+	defer ticker.Stop()
+	for {
+		var state loginState
+		err := chromedp.Run(waitCtx, chromedp.ActionFunc(func(ctx context.Context) error {
+			id, err := isolatedContext(ctx)
+			if err != nil {
+				return err
+			}
+			return callOnGlobal(ctx, id, loginStateFunction, &state, recipe.Success.URL, recipe.Success.Selector, recipe.FailureSelector, mfaSelector)
+		}))
+		if err == nil {
+			switch state.State {
+			case loginStateSuccess, loginStateMFA:
+				return state.State, nil
+			case loginStateFailure:
+				if state.Message == "" {
+					return "", fmt.Errorf("the site rejected the login")
+				}
+				return "", fmt.Errorf("the site rejected the login: %s", state.Message)
+			}
+		}
+		select {
+		case <-waitCtx.Done():
+			if !errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+				return "", waitCtx.Err()
+			}
+			return loginStatePending, fmt.Errorf("the login didn't succeed after %s", timeout)
+		case <-ticker.C:
+		}
+	}
+}
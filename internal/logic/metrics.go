@@ -0,0 +1,278 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"browser-tools-go/internal/models"
+)
+
+// Hooks called by logic functions so commands can report metrics; nil hooks
+// are skipped.
+var (
+	// OnNavigation is called after every successful Navigate.
+	OnNavigation func(result *models.NavigationResult)
+	// OnRetry is called every time FetchWithRetry retries a navigation.
+	OnRetry func(err error)
+)
+
+// metricsNamespace prefixes every exported metric.
+const metricsNamespace = "browser_tools"
+
+// durationBuckets are the histogram upper bounds, in seconds.
+var durationBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// netErrorPattern matches Chrome network error codes such as net::ERR_NAME_NOT_RESOLVED.
+var netErrorPattern = regexp.MustCompile(`net::(ERR_[A-Z0-9_]+)`)
+
+// MetricErrorCode classifies a command failure message for the failures
+// metric: Chrome's network error code when there is one, otherwise
+// tab_crashed, timeout, browser_unavailable, interrupted or other.
+func MetricErrorCode(message string) string {
+	if m := netErrorPattern.FindStringSubmatch(message); m != nil {
+		return m[1]
+	}
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "crashed"):
+		// What a crash causes, such as a timeout, is reported as the crash.
+		return "tab_crashed"
+	case strings.Contains(lower, "deadline exceeded"), strings.Contains(lower, "timed out"), strings.Contains(lower, "timeout"):
+		return "timeout"
+	case strings.Contains(lower, "failed to connect to browser"), strings.Contains(lower, "browser is not running"):
+		return "browser_unavailable"
+	case strings.Contains(lower, "interrupted"):
+		return "interrupted"
+	}
+	return "other"
+}
+
+// histogram is a cumulative Prometheus histogram of durations in seconds.
+type histogram struct {
+	counts []uint64 // per bucket of durationBuckets, not cumulative
+	count  uint64
+	sum    float64
+}
+
+func newHistogram() *histogram {
+	return &histogram{counts: make([]uint64, len(durationBuckets))}
+}
+
+func (h *histogram) observe(seconds float64) {
+	for i, bound := range durationBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// write writes the histogram's series with the given extra labels.
+func (h *histogram) write(w io.Writer, name, labels string) {
+	var cumulative uint64
+	for i, bound := range durationBuckets {
+		cumulative += h.counts[i]
+		fmt.Fprintf(w, "%s_bucket{%sle=\"%g\"} %d\n", name, labelPrefix(labels), bound, cumulative)
+	}
+	fmt.Fprintf(w, "%s_bucket{%sle=\"+Inf\"} %d\n", name, labelPrefix(labels), h.count)
+	fmt.Fprintf(w, "%s_sum%s %g\n", name, braces(labels), h.sum)
+	fmt.Fprintf(w, "%s_count%s %d\n", name, braces(labels), h.count)
+}
+
+// commandKey identifies a commands_total series.
+type commandKey struct {
+	command string
+	status  string
+}
+
+// MetricsRegistry aggregates the events reported by commands.
+type MetricsRegistry struct {
+	mu               sync.Mutex
+	commands         map[commandKey]uint64
+	commandDurations map[string]*histogram
+	failures         map[string]uint64
+	navigations      *histogram
+	retries          uint64
+}
+
+// NewMetricsRegistry returns an empty registry.
+func NewMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{
+		commands:         map[commandKey]uint64{},
+		commandDurations: map[string]*histogram{},
+		failures:         map[string]uint64{},
+		navigations:      newHistogram(),
+	}
+}
+
+// Observe records an event.
+func (r *MetricsRegistry) Observe(event models.MetricEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	seconds := event.DurationMs / 1000
+	switch event.Type {
+	case models.MetricCommand:
+		status := "success"
+		if !event.OK {
+			status = "failure"
+			code := event.ErrorCode
+			if code == "" {
+				code = "other"
+			}
+			r.failures[code]++
+		}
+		r.commands[commandKey{event.Command, status}]++
+		h, ok := r.commandDurations[event.Command]
+		if !ok {
+			h = newHistogram()
+			r.commandDurations[event.Command] = h
+		}
+		h.observe(seconds)
+	case models.MetricNavigation:
+		r.navigations.observe(seconds)
+	case models.MetricRetry:
+		r.retries++
+	}
+}
+
+// BrowserStatus is the state of the browser session at scrape time.
+type BrowserStatus struct {
+	Up       bool
+	OpenTabs int
+}
+
+// Write writes the metrics in the Prometheus text exposition format.
+func (r *MetricsRegistry) Write(w io.Writer, browser BrowserStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := metricsNamespace + "_commands_total"
+	fmt.Fprintf(w, "# HELP %s Commands executed, by command and status.\n# TYPE %s counter\n", name, name)
+	keys := make([]commandKey, 0, len(r.commands))
+	for key := range r.commands {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].command != keys[j].command {
+			return keys[i].command < keys[j].command
+		}
+		return keys[i].status < keys[j].status
+	})
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s{command=%s,status=%s} %d\n", name, quoteLabel(key.command), quoteLabel(key.status), r.commands[key])
+	}
+
+	name = metricsNamespace + "_command_duration_seconds"
+	fmt.Fprintf(w, "# HELP %s Command run time.\n# TYPE %s histogram\n", name, name)
+	for _, command := range sortedKeys(r.commandDurations) {
+		r.commandDurations[command].write(w, name, "command="+quoteLabel(command))
+	}
+
+	name = metricsNamespace + "_failures_total"
+	fmt.Fprintf(w, "# HELP %s Failed commands, by error code.\n# TYPE %s counter\n", name, name)
+	for _, code := range sortedKeys(r.failures) {
+		fmt.Fprintf(w, "%s{code=%s} %d\n", name, quoteLabel(code), r.failures[code])
+	}
+
+	name = metricsNamespace + "_navigation_duration_seconds"
+	fmt.Fprintf(w, "# HELP %s Time until navigations completed.\n# TYPE %s histogram\n", name, name)
+	r.navigations.write(w, name, "")
+
+	name = metricsNamespace + "_retries_total"
+	fmt.Fprintf(w, "# HELP %s Retried navigations.\n# TYPE %s counter\n%s %d\n", name, name, name, r.retries)
+
+	up := 0
+	if browser.Up {
+		up = 1
+	}
+	name = metricsNamespace + "_browser_up"
+	fmt.Fprintf(w, "# HELP %s Whether the browser session answers.\n# TYPE %s gauge\n%s %d\n", name, name, name, up)
+	name = metricsNamespace + "_open_tabs"
+	fmt.Fprintf(w, "# HELP %s Tabs open in the browser session.\n# TYPE %s gauge\n%s %d\n", name, name, name, browser.OpenTabs)
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// quoteLabel quotes a label value as the exposition format expects.
+func quoteLabel(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+// labelPrefix returns labels followed by a comma, or nothing.
+func labelPrefix(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return labels + ","
+}
+
+// braces returns labels in braces, or nothing.
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+// CountOpenTabs asks the browser's DevTools HTTP endpoint, derived from the
+// session's WebSocket URL, how many tabs are open.
+func CountOpenTabs(ctx context.Context, wsURL string) (int, error) {
+	u, err := url.Parse(wsURL)
+	if err != nil || u.Host == "" {
+		return 0, fmt.Errorf("invalid DevTools URL %q", wsURL)
+	}
+	scheme := "http"
+	if u.Scheme == "wss" {
+		scheme = "https"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, scheme+"://"+u.Host+"/json/list", nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("DevTools endpoint returned %s", resp.Status)
+	}
+
+	var targets []struct {
+		Type string `json:"type"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&targets); err != nil {
+		return 0, err
+	}
+	tabs := 0
+	for _, t := range targets {
+		if t.Type == "page" {
+			tabs++
+		}
+	}
+	return tabs, nil
+}
@@ -0,0 +1,92 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"browser-tools-go/internal/models"
+)
+
+func TestMetricErrorCode(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"✗ Failed to navigate: page load error net::ERR_NAME_NOT_RESOLVED", "ERR_NAME_NOT_RESOLVED"},
+		{"✗ failed waiting for \"main\": context deadline exceeded", "timeout"},
+		{"✗ Operation timed out", "timeout"},
+		{"✗ failed to connect to browser: dial tcp: connection refused", "browser_unavailable"},
+		{"✗ tab 1A2B crashed (oom): Failed to navigate: context deadline exceeded", "tab_crashed"},
+		{"✗ Failed to extract content", "other"},
+	}
+	for _, tt := range tests {
+		if got := MetricErrorCode(tt.message); got != tt.want {
+			t.Errorf("MetricErrorCode(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestMetricsRegistry_Write(t *testing.T) {
+	r := NewMetricsRegistry()
+	r.Observe(models.MetricEvent{Type: models.MetricCommand, Command: "navigate", OK: true, DurationMs: 300})
+	r.Observe(models.MetricEvent{Type: models.MetricCommand, Command: "navigate", OK: true, DurationMs: 1200})
+	r.Observe(models.MetricEvent{Type: models.MetricCommand, Command: "navigate", ErrorCode: "ERR_NAME_NOT_RESOLVED", DurationMs: 50})
+	r.Observe(models.MetricEvent{Type: models.MetricCommand, Command: `odd"name`, ErrorCode: ""})
+	r.Observe(models.MetricEvent{Type: models.MetricNavigation, DurationMs: 800})
+	r.Observe(models.MetricEvent{Type: models.MetricRetry})
+	r.Observe(models.MetricEvent{Type: models.MetricRetry})
+
+	var buf bytes.Buffer
+	r.Write(&buf, BrowserStatus{Up: true, OpenTabs: 3})
+	out := buf.String()
+
+	for _, want := range []string{
+		"# TYPE browser_tools_commands_total counter\n",
+		`browser_tools_commands_total{command="navigate",status="success"} 2` + "\n",
+		`browser_tools_commands_total{command="navigate",status="failure"} 1` + "\n",
+		`browser_tools_commands_total{command="odd\"name",status="failure"} 1` + "\n",
+		`browser_tools_command_duration_seconds_bucket{command="navigate",le="0.1"} 1` + "\n",
+		`browser_tools_command_duration_seconds_bucket{command="navigate",le="0.5"} 2` + "\n",
+		`browser_tools_command_duration_seconds_bucket{command="navigate",le="+Inf"} 3` + "\n",
+		`browser_tools_command_duration_seconds_count{command="navigate"} 3` + "\n",
+		`browser_tools_failures_total{code="ERR_NAME_NOT_RESOLVED"} 1` + "\n",
+		`browser_tools_failures_total{code="other"} 1` + "\n",
+		`browser_tools_navigation_duration_seconds_bucket{le="1"} 1` + "\n",
+		"browser_tools_navigation_duration_seconds_sum 0.8\n",
+		"browser_tools_retries_total 2\n",
+		"browser_tools_browser_up 1\n",
+		"browser_tools_open_tabs 3\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestCountOpenTabs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/list" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[{"type":"page"},{"type":"service_worker"},{"type":"page"}]`))
+	}))
+	defer server.Close()
+
+	wsURL := "ws://" + strings.TrimPrefix(server.URL, "http://") + "/devtools/browser/abc"
+	tabs, err := CountOpenTabs(context.Background(), wsURL)
+	if err != nil {
+		t.Fatalf("CountOpenTabs() error = %v", err)
+	}
+	if tabs != 2 {
+		t.Errorf("CountOpenTabs() = %d, want 2", tabs)
+	}
+
+	if _, err := CountOpenTabs(context.Background(), "not a url"); err == nil {
+		t.Error("Expected error for invalid URL")
+	}
+}
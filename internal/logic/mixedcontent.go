@@ -0,0 +1,169 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/audits"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/security"
+	"github.com/chromedp/chromedp"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Mixed content resolutions reported in MixedContentRequest.Resolution.
+const (
+	MixedContentLoaded   = "loaded"
+	MixedContentBlocked  = "blocked"
+	MixedContentUpgraded = "upgraded"
+)
+
+// mixedContentResolutions maps the resolutions of Chrome's mixed content issues.
+var mixedContentResolutions = map[audits.MixedContentResolutionStatus]string{
+	audits.MixedContentResolutionStatusMixedContentWarning:               MixedContentLoaded,
+	audits.MixedContentResolutionStatusMixedContentBlocked:               MixedContentBlocked,
+	audits.MixedContentResolutionStatusMixedContentAutomaticallyUpgraded: MixedContentUpgraded,
+}
+
+// AuditMixedContent loads the HTTPS page url and reports the HTTP subresources
+// it requested, whether Chrome loaded them, blocked them or upgraded them to
+// HTTPS, and the security state Chrome shows for the page.
+func AuditMixedContent(ctx context.Context, url string, waitUntil string) (*models.MixedContentAudit, error) {
+	ctx, span := startSpan(ctx, "audit.mixed_content", attribute.String("url.full", url))
+	audit, err := runMixedContentAudit(ctx, url, waitUntil)
+	if audit != nil {
+		span.SetAttributes(attribute.Int("browser_tools.mixed_content", len(audit.Requests)))
+	}
+	endSpan(span, err)
+	return audit, err
+}
+
+// runMixedContentAudit implements AuditMixedContent.
+func runMixedContentAudit(ctx context.Context, pageURL string, waitUntil string) (*models.MixedContentAudit, error) {
+	collector := newMixedContentCollector()
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chromedp.ListenTarget(listenCtx, collector.handle)
+
+	if err := chromedp.Run(ctx, audits.Enable(), security.Enable()); err != nil {
+		return nil, fmt.Errorf("failed to watch security events: %w", err)
+	}
+	defer func() {
+		_ = chromedp.Run(ctx, audits.Disable(), security.Disable())
+	}()
+
+	result, err := runNavigation(ctx, pageURL, NavigateOptions{WaitUntil: waitUntil})
+	if err != nil {
+		return nil, err
+	}
+	if u, err := url.Parse(result.FinalURL); err != nil || u.Scheme != "https" {
+		return nil, fmt.Errorf("%s isn't served over HTTPS, so it can't have mixed content", result.FinalURL)
+	}
+	audit := collector.audit()
+	audit.URL = pageURL
+	audit.FinalURL = result.FinalURL
+	return audit, nil
+}
+
+// mixedContentCollector collects the mixed content requests of a page load,
+// from the mixed content type of network requests and the mixed content
+// issues Chrome reports.
+type mixedContentCollector struct {
+	mu       sync.Mutex
+	requests map[string]*models.MixedContentRequest // by insecure URL
+	order    []string
+	ids      map[network.RequestID]string // URL of the mixed content requests
+	state    *security.VisibleSecurityState
+}
+
+func newMixedContentCollector() *mixedContentCollector {
+	return &mixedContentCollector{
+		requests: map[string]*models.MixedContentRequest{},
+		ids:      map[network.RequestID]string{},
+	}
+}
+
+// request returns the entry of an insecure URL, added as loaded.
+func (c *mixedContentCollector) request(insecureURL string) *models.MixedContentRequest {
+	req, ok := c.requests[insecureURL]
+	if !ok {
+		req = &models.MixedContentRequest{URL: insecureURL, Resolution: MixedContentLoaded}
+		c.requests[insecureURL] = req
+		c.order = append(c.order, insecureURL)
+	}
+	return req
+}
+
+// handle processes a single CDP event.
+func (c *mixedContentCollector) handle(ev interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch ev := ev.(type) {
+	case *network.EventRequestWillBeSent:
+		mixed := ev.Request.MixedContentType
+		if mixed == "" || mixed == security.MixedContentTypeNone || !strings.HasPrefix(ev.Request.URL, "http:") {
+			return
+		}
+		req := c.request(ev.Request.URL)
+		req.ResourceType = ev.Type.String()
+		if ev.DocumentURL != "" {
+			req.Initiator = ev.DocumentURL
+		}
+		c.ids[ev.RequestID] = ev.Request.URL
+	case *network.EventLoadingFailed:
+		if u, ok := c.ids[ev.RequestID]; ok && ev.BlockedReason == network.BlockedReasonMixedContent {
+			c.requests[u].Resolution = MixedContentBlocked
+		}
+	case *audits.EventIssueAdded:
+		if ev.Issue == nil || ev.Issue.Code != audits.InspectorIssueCodeMixedContentIssue || ev.Issue.Details == nil {
+			return
+		}
+		details := ev.Issue.Details.MixedContentIssueDetails
+		if details == nil || details.InsecureURL == "" {
+			return
+		}
+		req := c.request(details.InsecureURL)
+		if resolution, ok := mixedContentResolutions[details.ResolutionStatus]; ok {
+			req.Resolution = resolution
+		}
+		if details.ResourceType != "" {
+			req.ResourceType = details.ResourceType.String()
+		}
+		if details.MainResourceURL != "" {
+			req.Initiator = details.MainResourceURL
+		}
+	case *security.EventVisibleSecurityStateChanged:
+		c.state = ev.VisibleSecurityState
+	}
+}
+
+// audit returns the collected requests, in the order they were first seen.
+func (c *mixedContentCollector) audit() *models.MixedContentAudit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	audit := &models.MixedContentAudit{Requests: []models.MixedContentRequest{}}
+	for _, u := range c.order {
+		req := *c.requests[u]
+		switch req.Resolution {
+		case MixedContentLoaded:
+			audit.Loaded++
+		case MixedContentBlocked:
+			audit.Blocked++
+		case MixedContentUpgraded:
+			audit.Upgraded++
+		}
+		audit.Requests = append(audit.Requests, req)
+	}
+	if c.state != nil {
+		audit.SecurityState = c.state.SecurityState.String()
+		audit.SecurityIssues = c.state.SecurityStateIssueIDs
+	}
+	return audit
+}
@@ -0,0 +1,57 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/chromedp/cdproto/audits"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/security"
+)
+
+func TestMixedContentCollector(t *testing.T) {
+	c := newMixedContentCollector()
+	request := func(id network.RequestID, url string, mixed security.MixedContentType) *network.EventRequestWillBeSent {
+		return &network.EventRequestWillBeSent{
+			RequestID: id, Type: network.ResourceTypeImage, DocumentURL: "https://a.test/",
+			Request: &network.Request{URL: url, MixedContentType: mixed},
+		}
+	}
+	c.handle(request("1", "https://a.test/logo.png", security.MixedContentTypeNone))
+	c.handle(request("2", "http://cdn.test/photo.jpg", security.MixedContentTypeOptionallyBlockable))
+	c.handle(request("3", "http://cdn.test/app.js", security.MixedContentTypeBlockable))
+	c.handle(&network.EventLoadingFailed{RequestID: "3", BlockedReason: network.BlockedReasonMixedContent})
+	// Upgraded requests are only known from the issue Chrome reports.
+	c.handle(&audits.EventIssueAdded{Issue: &audits.InspectorIssue{
+		Code: audits.InspectorIssueCodeMixedContentIssue,
+		Details: &audits.InspectorIssueDetails{MixedContentIssueDetails: &audits.MixedContentIssueDetails{
+			ResourceType:     audits.MixedContentResourceTypeAudio,
+			ResolutionStatus: audits.MixedContentResolutionStatusMixedContentAutomaticallyUpgraded,
+			InsecureURL:      "http://cdn.test/song.mp3",
+			MainResourceURL:  "https://a.test/",
+		}},
+	}})
+	c.handle(&security.EventVisibleSecurityStateChanged{VisibleSecurityState: &security.VisibleSecurityState{
+		SecurityState: security.StateNeutral, SecurityStateIssueIDs: []string{"ran-mixed-content"},
+	}})
+
+	audit := c.audit()
+	want := []struct{ url, resolution string }{
+		{"http://cdn.test/photo.jpg", MixedContentLoaded},
+		{"http://cdn.test/app.js", MixedContentBlocked},
+		{"http://cdn.test/song.mp3", MixedContentUpgraded},
+	}
+	if len(audit.Requests) != len(want) {
+		t.Fatalf("got %d requests, want %d: %+v", len(audit.Requests), len(want), audit.Requests)
+	}
+	for i, w := range want {
+		if got := audit.Requests[i]; got.URL != w.url || got.Resolution != w.resolution || got.Initiator != "https://a.test/" {
+			t.Errorf("request %d = %+v, want %s %s", i, got, w.url, w.resolution)
+		}
+	}
+	if audit.Loaded != 1 || audit.Blocked != 1 || audit.Upgraded != 1 {
+		t.Errorf("counts = %d loaded, %d blocked, %d upgraded", audit.Loaded, audit.Blocked, audit.Upgraded)
+	}
+	if audit.SecurityState != "neutral" || len(audit.SecurityIssues) != 1 {
+		t.Errorf("security state = %q %v", audit.SecurityState, audit.SecurityIssues)
+	}
+}
@@ -0,0 +1,195 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/store"
+	"browser-tools-go/internal/utils"
+)
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	URL      string
+	Selector string
+	Interval time.Duration
+	// NotifyCmd is run with the change event as JSON on stdin whenever the content changes.
+	NotifyCmd string
+	// Webhook receives the change event as a JSON POST whenever the content changes.
+	Webhook Webhook
+	// Once performs a single check instead of polling.
+	Once bool
+	// Dedupe, when set, suppresses notifications for content already notified
+	// in a previous run (e.g. a page flipping back and forth between two states).
+	Dedupe *store.DedupeStore
+}
+
+// diffContextLines is the number of unchanged lines shown around each change.
+const diffContextLines = 3
+
+// CheckWatch extracts the watched region, compares it with the stored snapshot
+// and saves the new snapshot.
+func CheckWatch(ctx context.Context, opts WatchOptions) (*models.WatchEvent, error) {
+	result, err := GetContent(ctx, opts.URL, ContentOptions{
+		Format:   "text",
+		Selector: opts.Selector,
+		Strip:    DefaultStripSelectors,
+	})
+	if err != nil {
+		return nil, err
+	}
+	content, _ := result["content"].(string)
+
+	sum := sha256.Sum256([]byte(content))
+	event := &models.WatchEvent{
+		URL:       opts.URL,
+		Selector:  opts.Selector,
+		CheckedAt: time.Now().UTC(),
+		Hash:      hex.EncodeToString(sum[:]),
+		Content:   content,
+	}
+
+	previous, err := config.LoadWatchSnapshot(opts.URL, opts.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load previous snapshot: %w", err)
+	}
+	if previous == nil {
+		event.First = true
+	} else if previous.Hash != event.Hash {
+		event.Changed = true
+		event.PreviousHash = previous.Hash
+		event.Diff = utils.UnifiedDiff(
+			previous.CapturedAt.Format(time.RFC3339), event.CheckedAt.Format(time.RFC3339),
+			previous.Content, content, diffContextLines)
+		event.Added, event.Removed = utils.DiffStats(utils.DiffLines(utils.SplitLines(previous.Content), utils.SplitLines(content)))
+	}
+
+	err = config.SaveWatchSnapshot(&config.WatchSnapshot{
+		URL:        opts.URL,
+		Selector:   opts.Selector,
+		Hash:       event.Hash,
+		Content:    content,
+		CapturedAt: event.CheckedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return event, nil
+}
+
+// Watch checks the watched region every Interval until ctx is canceled, calling
+// onEvent after each successful check and notifying on changes. Failed checks
+// are logged and retried on the next tick.
+func Watch(ctx context.Context, opts WatchOptions, onEvent func(*models.WatchEvent)) error {
+	if !opts.Once && opts.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	for {
+		event, err := CheckWatch(ctx, opts)
+		if err != nil {
+			if opts.Once {
+				return err
+			}
+			i18n.Printf("Warning: watch check failed: %v", err)
+		} else {
+			onEvent(event)
+			if event.Changed && !alreadyNotified(opts, event) {
+				notifyWatchChange(ctx, opts, event)
+			}
+		}
+
+		if opts.Once {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+// WatchEval calls evaluate every interval until ctx is done, and onChange
+// with each result that differs from the previous one, the first included.
+// A failed evaluation is reported as a warning and doesn't stop the watch,
+// so that a page reloading under load doesn't end it.
+func WatchEval(ctx context.Context, interval time.Duration, evaluate func(context.Context) (interface{}, error), onChange func(*models.EvalWatchEvent)) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last []byte
+	for {
+		value, err := evaluate(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			i18n.Printf("Warning: evaluation failed: %v", err)
+		} else {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("failed to encode result: %w", err)
+			}
+			if last == nil || string(encoded) != string(last) {
+				last = encoded
+				onChange(&models.EvalWatchEvent{CheckedAt: time.Now().UTC(), Value: value})
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// alreadyNotified reports whether the event's content was already seen according
+// to the dedupe store, recording it otherwise.
+func alreadyNotified(opts WatchOptions, event *models.WatchEvent) bool {
+	if opts.Dedupe == nil {
+		return false
+	}
+	fresh, err := opts.Dedupe.FilterUnseen("watch:"+opts.URL+"\n"+opts.Selector, []string{event.Hash})
+	if err != nil {
+		i18n.Printf("Warning: %v", err)
+		return false
+	}
+	if len(fresh) == 0 {
+		i18n.Println("⏭️ Content matches a previously notified state, skipping notification.")
+		return true
+	}
+	return false
+}
+
+// notifyWatchChange delivers a change event to the configured command and webhook.
+func notifyWatchChange(ctx context.Context, opts WatchOptions, event *models.WatchEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		i18n.Printf("Warning: could not encode change event: %v", err)
+		return
+	}
+
+	if opts.NotifyCmd != "" {
+		env := map[string]string{"BT_WATCH_URL": event.URL, "BT_WATCH_HASH": event.Hash}
+		if err := RunNotifyCommand(ctx, opts.NotifyCmd, payload, env); err != nil {
+			i18n.Printf("Warning: %v", err)
+		}
+	}
+	if opts.Webhook.URL != "" {
+		if err := SendWebhookBatch(ctx, opts.Webhook, "watch", []*models.WatchEvent{event}); err != nil {
+			i18n.Printf("Warning: %v", err)
+		}
+	}
+}
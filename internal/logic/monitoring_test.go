@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"browser-tools-go/internal/models"
+)
+
+func TestWatchEval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := []interface{}{1.0, 1.0, errors.New("reloading"), 2.0, map[string]interface{}{"n": 2.0}}
+	calls := 0
+	evaluate := func(context.Context) (interface{}, error) {
+		result := results[calls]
+		calls++
+		if calls == len(results) {
+			cancel()
+		}
+		if err, ok := result.(error); ok {
+			return nil, err
+		}
+		return result, nil
+	}
+	var values []interface{}
+	err := WatchEval(ctx, time.Millisecond, evaluate, func(event *models.EvalWatchEvent) {
+		values = append(values, event.Value)
+	})
+	if err != nil {
+		t.Fatalf("WatchEval: %v", err)
+	}
+	// The repeated 1 and the failure are not reported; the last result comes
+	// after cancellation and is dropped.
+	if len(values) != 2 || values[0] != 1.0 || values[1] != 2.0 {
+		t.Errorf("values: got %v", values)
+	}
+
+	if err := WatchEval(context.Background(), 0, evaluate, nil); err == nil {
+		t.Error("zero interval: expected an error")
+	}
+}
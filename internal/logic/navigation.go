@@ -2,32 +2,447 @@ package logic
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"time"
 
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/models"
 	"browser-tools-go/internal/utils"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/runtime"
 	"github.com/chromedp/chromedp"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-// Navigate navigates the browser to a specific URL.
-func Navigate(ctx context.Context, url string) error {
-	if err := chromedp.Run(ctx, chromedp.Navigate(url)); err != nil {
-		return fmt.Errorf("failed to navigate: %w", err)
+// Conditions that complete a navigation, for NavigateOptions.WaitUntil.
+const (
+	WaitUntilLoad             = "load"
+	WaitUntilDOMContentLoaded = "domcontentloaded"
+	WaitUntilNetworkIdle      = "networkidle"
+)
+
+// waitUntilEvents maps WaitUntil values to Page.lifecycleEvent names.
+var waitUntilEvents = map[string]string{
+	WaitUntilLoad:             "load",
+	WaitUntilDOMContentLoaded: "DOMContentLoaded",
+	WaitUntilNetworkIdle:      "networkIdle",
+}
+
+// NavigateOptions controls how Navigate loads a page.
+type NavigateOptions struct {
+	// BypassServiceWorker loads the page from the network even when a service
+	// worker controls its scope. The setting stays active for the tab.
+	BypassServiceWorker bool
+	// WaitUntil is the condition that completes the navigation: load (the
+	// default), domcontentloaded or networkidle.
+	WaitUntil string
+	// Referrer is sent as the Referer of the navigation request.
+	Referrer string
+	// Transition is the navigation's transition type as Chrome records it,
+	// e.g. typed (entered in the address bar) or link (a followed link).
+	Transition string
+	// CollectConsoleErrors reports the console errors and uncaught exceptions,
+	// including unhandled promise rejections, of the load in the result.
+	CollectConsoleErrors bool
+	// Budget checks the requests, transfer sizes and web vitals of the load
+	// against a performance budget and reports them in the result.
+	Budget *models.Budget
+	// Stats reports the load timings and resource counts the page records
+	// with the Navigation and Resource Timing APIs in the result.
+	Stats bool
+}
+
+// navigationTransitions are the transition types accepted for a top-level navigation.
+var navigationTransitions = map[page.TransitionType]bool{
+	page.TransitionTypeLink:             true,
+	page.TransitionTypeTyped:            true,
+	page.TransitionTypeAddressBar:       true,
+	page.TransitionTypeAutoBookmark:     true,
+	page.TransitionTypeGenerated:        true,
+	page.TransitionTypeAutoToplevel:     true,
+	page.TransitionTypeFormSubmit:       true,
+	page.TransitionTypeReload:           true,
+	page.TransitionTypeKeyword:          true,
+	page.TransitionTypeKeywordGenerated: true,
+	page.TransitionTypeOther:            true,
+}
+
+// Validate checks the options; an empty WaitUntil means the default.
+func (o NavigateOptions) Validate() error {
+	if o.WaitUntil != "" {
+		if err := ValidateWaitUntil(o.WaitUntil); err != nil {
+			return err
+		}
+	}
+	if o.Transition != "" && !navigationTransitions[page.TransitionType(o.Transition)] {
+		return fmt.Errorf("invalid transition type %q (e.g. typed or link)", o.Transition)
+	}
+	if o.Referrer != "" {
+		u, err := neturl.Parse(o.Referrer)
+		if err != nil || !u.IsAbs() {
+			return fmt.Errorf("invalid referrer %q: must be an absolute URL", o.Referrer)
+		}
+	}
+	if o.Budget != nil {
+		return ValidateBudget(o.Budget)
 	}
 	return nil
 }
 
+// ValidateWaitUntil checks a NavigateOptions.WaitUntil value.
+func ValidateWaitUntil(waitUntil string) error {
+	if _, ok := waitUntilEvents[waitUntil]; !ok {
+		return fmt.Errorf("invalid wait condition %q (expected load, domcontentloaded or networkidle)", waitUntil)
+	}
+	return nil
+}
+
+// Navigate navigates the browser to a specific URL and reports the main
+// document's response: final URL, status, redirect chain, headers and timing.
+// HTTP error statuses are reported in the result, not as errors.
+func Navigate(ctx context.Context, url string, opts NavigateOptions) (*models.NavigationResult, error) {
+	ctx, span := startSpan(ctx, "navigate", attribute.String("url.full", url))
+	result, err := runNavigation(ctx, url, opts)
+	if result != nil {
+		span.SetAttributes(
+			attribute.String("browser_tools.final_url", result.FinalURL),
+			attribute.Int("http.response.status_code", int(result.Status)),
+			attribute.Int("browser_tools.redirects", len(result.Redirects)),
+		)
+	}
+	endSpan(span, err)
+	return result, err
+}
+
+// runNavigation implements Navigate.
+func runNavigation(ctx context.Context, url string, opts NavigateOptions) (result *models.NavigationResult, err error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.WaitUntil == "" {
+		opts.WaitUntil = WaitUntilLoad
+	}
+	if opts.CollectConsoleErrors {
+		watcher := WatchConsole(ctx)
+		defer func() {
+			consoleErrors := watcher.Stop()
+			if result != nil {
+				result.ConsoleErrors = consoleErrors
+			}
+		}()
+	}
+
+	tracker := newNavigationTracker()
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chromedp.ListenTarget(listenCtx, tracker.handle)
+	var resources *resourceCollector
+	if opts.Budget != nil {
+		resources = newResourceCollector()
+		chromedp.ListenTarget(listenCtx, resources.handle)
+	}
+
+	tasks := chromedp.Tasks{network.Enable(), page.SetLifecycleEventsEnabled(true)}
+	if opts.BypassServiceWorker {
+		tasks = append(tasks, network.SetBypassServiceWorker(true))
+	}
+	var loaderID cdp.LoaderID
+	var errorText string
+	tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
+		tree, err := page.GetFrameTree().Do(ctx)
+		if err != nil {
+			return err
+		}
+		tracker.setFrame(tree.Frame.ID)
+		navigate := page.Navigate(url)
+		if opts.Referrer != "" {
+			navigate = navigate.WithReferrer(opts.Referrer)
+		}
+		if opts.Transition != "" {
+			navigate = navigate.WithTransitionType(page.TransitionType(opts.Transition))
+		}
+		_, loaderID, errorText, err = navigate.Do(ctx)
+		return err
+	}))
+	if err := chromedp.Run(ctx, tasks); err != nil {
+		return nil, fmt.Errorf("failed to navigate: %w", err)
+	}
+
+	if errorText != "" {
+		// Chrome fails navigations to empty HTTP error responses; those still
+		// have a status worth reporting.
+		if result := tracker.result(url, loaderID, opts.WaitUntil); result.Status > 0 {
+			return result, nil
+		}
+		return nil, fmt.Errorf("failed to navigate: %s", errorText)
+	}
+	if loaderID == "" {
+		// Same-document navigation (e.g. a fragment change): nothing is loaded.
+		result := tracker.result(url, loaderID, opts.WaitUntil)
+		result.FinalURL = url
+		return result, nil
+	}
+
+	if err := tracker.wait(ctx, loaderID, waitUntilEvents[opts.WaitUntil]); err != nil {
+		return nil, fmt.Errorf("failed to navigate: %w", err)
+	}
+	result = tracker.result(url, loaderID, opts.WaitUntil)
+	if resources != nil {
+		if result.Budget, err = measureBudget(ctx, opts.Budget, resources.list()); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Stats {
+		if result.Stats, err = pageLoadStats(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if OnNavigation != nil {
+		OnNavigation(result)
+	}
+	return result, nil
+}
+
+// pageLoadStatsScript reads the load timings of the page from its navigation
+// entry and counts the resources it fetched. A resource's initiatorType tells
+// how it was requested: link, script, img, css, fetch, xmlhttprequest...
+const pageLoadStatsScript = `(() => {
+	const nav = performance.getEntriesByType('navigation')[0];
+	if (!nav) return null;
+	const span = (start, end) => start > 0 && end > start ? end - start : 0;
+	const resources = performance.getEntriesByType('resource');
+	const byType = {};
+	let transfer = nav.transferSize || 0;
+	for (const r of resources) {
+		byType[r.initiatorType] = (byType[r.initiatorType] || 0) + 1;
+		transfer += r.transferSize || 0;
+	}
+	return {
+		redirectMs: span(nav.redirectStart, nav.redirectEnd),
+		dnsMs: span(nav.domainLookupStart, nav.domainLookupEnd),
+		connectMs: span(nav.connectStart, nav.connectEnd),
+		tlsMs: span(nav.secureConnectionStart, nav.connectEnd),
+		ttfbMs: nav.responseStart,
+		downloadMs: span(nav.responseStart, nav.responseEnd),
+		domInteractiveMs: nav.domInteractive,
+		domContentLoadedMs: nav.domContentLoadedEventStart,
+		loadMs: nav.loadEventStart,
+		resources: resources.length,
+		resourcesByType: byType,
+		transferBytes: transfer,
+	};
+})()`
+
+// pageLoadStats reads the load statistics of the page in the tab of ctx.
+func pageLoadStats(ctx context.Context) (*models.PageLoadStats, error) {
+	var stats *models.PageLoadStats
+	if err := chromedp.Run(ctx, EvaluateIsolated(pageLoadStatsScript, &stats)); err != nil {
+		return nil, fmt.Errorf("failed to read the load timings: %w", err)
+	}
+	if stats == nil {
+		return nil, fmt.Errorf("the page has no navigation timing")
+	}
+	return stats, nil
+}
+
+// navigationTracker collects the main frame's document and lifecycle events,
+// keyed by loader, as they may arrive before Page.navigate returns.
+type navigationTracker struct {
+	mu        sync.Mutex
+	start     time.Time
+	frameID   cdp.FrameID
+	documents map[cdp.LoaderID]*models.NavigationResult
+	events    map[cdp.LoaderID]map[string]float64
+	changed   chan struct{}
+}
+
+func newNavigationTracker() *navigationTracker {
+	return &navigationTracker{
+		start:     time.Now(),
+		documents: map[cdp.LoaderID]*models.NavigationResult{},
+		events:    map[cdp.LoaderID]map[string]float64{},
+		changed:   make(chan struct{}, 1),
+	}
+}
+
+func (t *navigationTracker) setFrame(id cdp.FrameID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.frameID = id
+}
+
+// elapsed returns the milliseconds since the navigation started.
+func (t *navigationTracker) elapsed() float64 {
+	return float64(time.Since(t.start).Microseconds()) / 1000
+}
+
+// document returns the result being built for a loader.
+func (t *navigationTracker) document(id cdp.LoaderID) *models.NavigationResult {
+	doc, ok := t.documents[id]
+	if !ok {
+		doc = &models.NavigationResult{Redirects: []models.Redirect{}, Headers: map[string]string{}}
+		t.documents[id] = doc
+	}
+	return doc
+}
+
+// handle processes a single CDP event.
+func (t *navigationTracker) handle(ev interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch ev := ev.(type) {
+	case *network.EventRequestWillBeSent:
+		if ev.Type != network.ResourceTypeDocument || ev.FrameID != t.frameID {
+			return
+		}
+		doc := t.document(ev.LoaderID)
+		if ev.RedirectResponse != nil {
+			doc.Redirects = append(doc.Redirects, models.Redirect{
+				URL:      ev.RedirectResponse.URL,
+				Status:   ev.RedirectResponse.Status,
+				Location: ev.Request.URL,
+			})
+		}
+		doc.FinalURL = ev.Request.URL
+	case *network.EventResponseReceived:
+		if ev.Type != network.ResourceTypeDocument || ev.FrameID != t.frameID {
+			return
+		}
+		doc := t.document(ev.LoaderID)
+		doc.FinalURL = ev.Response.URL
+		doc.Status = ev.Response.Status
+		doc.StatusText = ev.Response.StatusText
+		if doc.StatusText == "" {
+			doc.StatusText = http.StatusText(int(ev.Response.Status))
+		}
+		doc.MimeType = ev.Response.MimeType
+		doc.Headers = flattenHeaders(ev.Response.Headers)
+		doc.Timing.ResponseMs = t.elapsed()
+	case *page.EventLifecycleEvent:
+		if ev.FrameID != t.frameID {
+			return
+		}
+		events, ok := t.events[ev.LoaderID]
+		if !ok {
+			events = map[string]float64{}
+			t.events[ev.LoaderID] = events
+		}
+		if _, seen := events[ev.Name]; !seen {
+			events[ev.Name] = t.elapsed()
+		}
+		select {
+		case t.changed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// wait blocks until the lifecycle event has fired for the loader.
+func (t *navigationTracker) wait(ctx context.Context, id cdp.LoaderID, event string) error {
+	for {
+		t.mu.Lock()
+		_, done := t.events[id][event]
+		t.mu.Unlock()
+		if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.changed:
+		}
+	}
+}
+
+// result returns the collected result for a loader.
+func (t *navigationTracker) result(url string, id cdp.LoaderID, waitUntil string) *models.NavigationResult {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	doc := *t.document(id)
+	doc.URL = url
+	doc.WaitUntil = waitUntil
+	events := t.events[id]
+	doc.Timing.DOMContentLoadedMs = events["DOMContentLoaded"]
+	doc.Timing.LoadMs = events["load"]
+	doc.Timing.NetworkIdleMs = events["networkIdle"]
+	doc.Timing.TotalMs = t.elapsed()
+	if ms, ok := events[waitUntilEvents[waitUntil]]; ok {
+		doc.Timing.TotalMs = ms
+	}
+	return &doc
+}
+
+// ScreenshotOptions configures Screenshot.
+type ScreenshotOptions struct {
+	// FullPage captures the whole page instead of the viewport.
+	FullPage bool
+	// Stitch captures the whole page by scrolling through it and stitching
+	// viewport captures, for pages that only render what is scrolled into view.
+	Stitch bool
+	// Annotate lists selectors whose elements are outlined and labelled on the image.
+	Annotate []string
+	// Scale is the device scale factor emulated during the capture, e.g. 2 for
+	// high-DPI images; 0 keeps the page's own.
+	Scale float64
+	// Selector captures only the first visible element matching it.
+	Selector string
+	// NoAutoScroll captures the element of Selector where it is instead of
+	// scrolling it into view and waiting for it to settle first.
+	NoAutoScroll bool
+}
+
 // Screenshot captures a screenshot of the current page.
 // filePathが空の場合、カレントディレクトリに"screenshot.png"を作成します。
 // filePathは検証され、不正なパス操作は拒否されます。
-func Screenshot(ctx context.Context, targetURL, filePath string, fullPage bool) (string, error) {
+func Screenshot(ctx context.Context, targetURL, filePath string, opts ScreenshotOptions) (string, error) {
+	ctx, span := startSpan(ctx, "screenshot", attribute.Bool("browser_tools.full_page", opts.FullPage || opts.Stitch))
+	path, err := takeScreenshot(ctx, targetURL, filePath, opts)
+	endSpan(span, err)
+	return path, err
+}
+
+// takeScreenshot implements Screenshot.
+func takeScreenshot(ctx context.Context, targetURL, filePath string, opts ScreenshotOptions) (string, error) {
 	tasks := make(chromedp.Tasks, 0)
 	if targetURL != "" {
 		tasks = append(tasks, chromedp.Navigate(targetURL))
 	}
 
+	if opts.Scale > 0 {
+		restore, err := SetDeviceScale(ctx, opts.Scale)
+		if err != nil {
+			return "", err
+		}
+		defer func() {
+			if err := restore(); err != nil {
+				i18n.Printf("Warning: failed to restore the device metrics: %v", err)
+			}
+		}()
+	}
+
 	var buf []byte
-	if fullPage {
+	if opts.Selector != "" {
+		tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
+			var err error
+			buf, err = captureElement(ctx, opts.Selector, !opts.NoAutoScroll)
+			return err
+		}))
+	} else if opts.Stitch {
+		tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
+			var err error
+			buf, err = stitchScreenshot(ctx)
+			return err
+		}))
+	} else if opts.FullPage {
 		tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
 			var err error
 			buf, err = page.CaptureScreenshot().WithFormat(page.CaptureScreenshotFormatPng).WithCaptureBeyondViewport(true).Do(ctx)
@@ -44,6 +459,19 @@ func Screenshot(ctx context.Context, targetURL, filePath string, fullPage bool)
 		return "", fmt.Errorf("failed to take screenshot: %w", err)
 	}
 
+	if len(opts.Annotate) > 0 {
+		annotations, scale, err := collectAnnotations(ctx, opts.Annotate, opts.FullPage || opts.Stitch)
+		if err != nil {
+			return "", err
+		}
+		if len(annotations) == 0 {
+			i18n.Printf("Warning: no visible elements matched %s", strings.Join(opts.Annotate, ", "))
+		}
+		if buf, err = AnnotateImage(buf, annotations, scale); err != nil {
+			return "", err
+		}
+	}
+
 	// セキュリティ強化：ファイルパスの検証
 	validatedPath, err := utils.ValidateScreenshotPath(filePath, ".")
 	if err != nil {
@@ -61,3 +489,58 @@ func Screenshot(ctx context.Context, targetURL, filePath string, fullPage bool)
 
 	return validatedPath, nil
 }
+
+// elementClipFunction returns the rect of the element it is called on in page
+// coordinates, as needed for a screenshot clip.
+const elementClipFunction = `function() {
+	const rect = this.getBoundingClientRect();
+	return {x: rect.left + window.scrollX, y: rect.top + window.scrollY, width: rect.width, height: rect.height};
+}`
+
+// captureElement captures the first visible element matching selector,
+// waiting for it at most elementTimeout. With autoScroll, the element is
+// scrolled into view and allowed to settle first, so that lazy content and
+// sticky headers are laid out as they are when it is shown.
+func captureElement(ctx context.Context, selector string, autoScroll bool) ([]byte, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, elementTimeout)
+	defer cancel()
+	var buf []byte
+	err := chromedp.QueryAfter(selector, func(ctx context.Context, _ runtime.ExecutionContextID, nodes ...*cdp.Node) error {
+		if autoScroll {
+			if err := scrollIntoViewStable(ctx, nodes[0].NodeID); err != nil {
+				return err
+			}
+		}
+		contextID, err := isolatedContext(ctx)
+		if err != nil {
+			return err
+		}
+		obj, err := resolveNodeIsolated(ctx, nodes[0].NodeID, contextID)
+		if err != nil {
+			return err
+		}
+		var clip page.Viewport
+		err = chromedp.CallFunctionOn(elementClipFunction, &clip, func(p *runtime.CallFunctionOnParams) *runtime.CallFunctionOnParams {
+			return p.WithObjectID(obj.ObjectID)
+		}).Do(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to measure element: %w", err)
+		}
+		if clip.Width <= 0 || clip.Height <= 0 {
+			return fmt.Errorf("element %q has no size", selector)
+		}
+		clip.Scale = 1
+		buf, err = page.CaptureScreenshot().WithFormat(page.CaptureScreenshotFormatPng).WithCaptureBeyondViewport(true).WithClip(&clip).Do(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to capture element screenshot: %w", err)
+		}
+		return nil
+	}, chromedp.ByQuery, chromedp.NodeVisible).Do(waitCtx)
+	if err != nil {
+		if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("no visible match for %q after %s", selector, elementTimeout)
+		}
+		return nil, err
+	}
+	return buf, nil
+}
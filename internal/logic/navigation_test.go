@@ -0,0 +1,101 @@
+package logic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+)
+
+func TestValidateWaitUntil(t *testing.T) {
+	for _, valid := range []string{"load", "domcontentloaded", "networkidle"} {
+		if err := ValidateWaitUntil(valid); err != nil {
+			t.Errorf("ValidateWaitUntil(%q) returned error: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"", "idle", "Load"} {
+		if err := ValidateWaitUntil(invalid); err == nil {
+			t.Errorf("ValidateWaitUntil(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestNavigateOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    NavigateOptions
+		wantErr bool
+	}{
+		{"defaults", NavigateOptions{}, false},
+		{"typed with referrer", NavigateOptions{Transition: "typed", Referrer: "https://www.google.com/"}, false},
+		{"link", NavigateOptions{Transition: "link", WaitUntil: "networkidle"}, false},
+		{"subframe transition", NavigateOptions{Transition: "auto_subframe"}, true},
+		{"unknown transition", NavigateOptions{Transition: "clicked"}, true},
+		{"relative referrer", NavigateOptions{Referrer: "/search"}, true},
+		{"bad wait condition", NavigateOptions{WaitUntil: "idle"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNavigationTracker(t *testing.T) {
+	tracker := newNavigationTracker()
+	tracker.setFrame("main")
+
+	const loader = cdp.LoaderID("L1")
+	tracker.handle(&network.EventRequestWillBeSent{
+		Type: network.ResourceTypeDocument, FrameID: "main", LoaderID: loader,
+		Request: &network.Request{URL: "http://example.com/"},
+	})
+	tracker.handle(&network.EventRequestWillBeSent{
+		Type: network.ResourceTypeDocument, FrameID: "main", LoaderID: loader,
+		Request:          &network.Request{URL: "https://example.com/"},
+		RedirectResponse: &network.Response{URL: "http://example.com/", Status: 301},
+	})
+	// Subresources and other frames are ignored.
+	tracker.handle(&network.EventResponseReceived{
+		Type: network.ResourceTypeImage, FrameID: "main", LoaderID: loader,
+		Response: &network.Response{URL: "https://example.com/a.png", Status: 200},
+	})
+	tracker.handle(&network.EventResponseReceived{
+		Type: network.ResourceTypeDocument, FrameID: "main", LoaderID: loader,
+		Response: &network.Response{
+			URL:     "https://example.com/",
+			Status:  404,
+			Headers: network.Headers{"content-type": "text/html"},
+		},
+	})
+	tracker.handle(&page.EventLifecycleEvent{FrameID: "main", LoaderID: loader, Name: "DOMContentLoaded"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := tracker.wait(ctx, loader, "DOMContentLoaded"); err != nil {
+		t.Fatalf("wait for a fired event returned error: %v", err)
+	}
+	if err := tracker.wait(ctx, loader, "load"); err == nil {
+		t.Error("wait for an event that never fired should time out")
+	}
+
+	result := tracker.result("http://example.com/", loader, WaitUntilDOMContentLoaded)
+	if result.FinalURL != "https://example.com/" || result.Status != 404 || result.StatusText != "Not Found" {
+		t.Errorf("unexpected response: %+v", result)
+	}
+	if len(result.Redirects) != 1 || result.Redirects[0].Status != 301 || result.Redirects[0].Location != "https://example.com/" {
+		t.Errorf("unexpected redirects: %+v", result.Redirects)
+	}
+	if result.Headers["content-type"] != "text/html" {
+		t.Errorf("unexpected headers: %v", result.Headers)
+	}
+	if result.Timing.LoadMs != 0 || result.Timing.TotalMs != result.Timing.DOMContentLoadedMs {
+		t.Errorf("unexpected timing: %+v", result.Timing)
+	}
+}
@@ -0,0 +1,259 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// Response sources reported in NetworkEntry.Source.
+const (
+	SourceNetwork       = "network"
+	SourceMemoryCache   = "memory-cache"
+	SourceDiskCache     = "disk-cache"
+	SourcePrefetchCache = "prefetch-cache"
+	SourceServiceWorker = "service-worker"
+)
+
+// NetworkCaptureOptions configures CaptureNetwork.
+type NetworkCaptureOptions struct {
+	// URL is navigated to after capturing starts; empty captures the current page's traffic.
+	URL string
+	// Wait is how long to keep capturing after navigation (or from the start when URL is empty).
+	Wait time.Duration
+	// Filter keeps only requests whose URL contains it, or matches it as a glob when it contains '*'.
+	Filter string
+}
+
+// SetCacheDisabled turns the browser cache off or on for the connected tab.
+func SetCacheDisabled(ctx context.Context, disabled bool) error {
+	if err := chromedp.Run(ctx, network.Enable(), network.SetCacheDisabled(disabled)); err != nil {
+		return fmt.Errorf("failed to set cache state: %w", err)
+	}
+	return nil
+}
+
+// CaptureNetwork records the requests made by the page and calls onEntry for each
+// finished or failed request. When onSSE is not nil, it is called for every
+// server-sent event received by an EventSource while capturing. The callbacks
+// are never called concurrently.
+func CaptureNetwork(ctx context.Context, opts NetworkCaptureOptions, onEntry func(*models.NetworkEntry), onSSE func(*models.SSEEvent)) error {
+	capture := newNetworkCapture(opts.Filter, onEntry)
+	capture.onSSE = onSSE
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chromedp.ListenTarget(listenCtx, capture.handle)
+
+	tasks := chromedp.Tasks{network.Enable()}
+	if opts.URL != "" {
+		tasks = append(tasks, chromedp.Navigate(opts.URL))
+	}
+	if err := chromedp.Run(ctx, tasks); err != nil {
+		return fmt.Errorf("failed to capture network traffic: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(opts.Wait):
+	}
+	return nil
+}
+
+// networkCapture correlates network events into NetworkEntry values.
+type networkCapture struct {
+	mu      sync.Mutex
+	filter  string
+	entries map[network.RequestID]*models.NetworkEntry
+	// extraHeaders holds wire headers (including Cookie) that arrived before their request.
+	extraHeaders map[network.RequestID]network.Headers
+	// filtered holds the requests not matching the filter until they end, so
+	// that their late wire headers aren't kept in extraHeaders.
+	filtered map[network.RequestID]bool
+	onEntry  func(*models.NetworkEntry)
+	onSSE    func(*models.SSEEvent)
+}
+
+func newNetworkCapture(filter string, onEntry func(*models.NetworkEntry)) *networkCapture {
+	return &networkCapture{
+		filter:       filter,
+		entries:      map[network.RequestID]*models.NetworkEntry{},
+		extraHeaders: map[network.RequestID]network.Headers{},
+		filtered:     map[network.RequestID]bool{},
+		onEntry:      onEntry,
+	}
+}
+
+// handle processes a single CDP event.
+func (c *networkCapture) handle(ev interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch ev := ev.(type) {
+	case *network.EventRequestWillBeSent:
+		// A redirect reuses the request ID; the previous hop ends with the redirect response.
+		if previous, ok := c.entries[ev.RequestID]; ok && ev.RedirectResponse != nil {
+			applyResponse(previous, ev.RedirectResponse)
+			c.emit(ev.RequestID)
+		}
+		if !matchesURLFilter(ev.Request.URL, c.filter) {
+			delete(c.extraHeaders, ev.RequestID)
+			c.filtered[ev.RequestID] = true
+			return
+		}
+		delete(c.filtered, ev.RequestID)
+		entry := &models.NetworkEntry{
+			Kind:           "request",
+			RequestID:      string(ev.RequestID),
+			URL:            ev.Request.URL,
+			Method:         ev.Request.Method,
+			ResourceType:   string(ev.Type),
+			RequestHeaders: flattenHeaders(ev.Request.Headers),
+			PostData:       ev.Request.PostData,
+			StartedAt:      time.Now().UTC(),
+		}
+		if ev.WallTime != nil {
+			entry.StartedAt = ev.WallTime.Time().UTC()
+		}
+		if extra, ok := c.extraHeaders[ev.RequestID]; ok {
+			mergeHeaders(entry, extra)
+			delete(c.extraHeaders, ev.RequestID)
+		}
+		c.entries[ev.RequestID] = entry
+	case *network.EventRequestWillBeSentExtraInfo:
+		if entry, ok := c.entries[ev.RequestID]; ok {
+			mergeHeaders(entry, ev.Headers)
+		} else if !c.filtered[ev.RequestID] {
+			c.extraHeaders[ev.RequestID] = ev.Headers
+		}
+	case *network.EventRequestServedFromCache:
+		if entry, ok := c.entries[ev.RequestID]; ok {
+			entry.Source = SourceMemoryCache
+			entry.FromCache = true
+		}
+	case *network.EventResponseReceived:
+		if entry, ok := c.entries[ev.RequestID]; ok {
+			applyResponse(entry, ev.Response)
+		}
+	case *network.EventEventSourceMessageReceived:
+		if entry, ok := c.entries[ev.RequestID]; ok && c.onSSE != nil {
+			c.onSSE(newSSEEvent(entry.URL, ev))
+		}
+	case *network.EventLoadingFinished:
+		delete(c.filtered, ev.RequestID)
+		if entry, ok := c.entries[ev.RequestID]; ok {
+			entry.EncodedBytes = int64(ev.EncodedDataLength)
+			c.emit(ev.RequestID)
+		}
+	case *network.EventLoadingFailed:
+		delete(c.filtered, ev.RequestID)
+		if entry, ok := c.entries[ev.RequestID]; ok {
+			entry.Error = ev.ErrorText
+			if ev.BlockedReason != "" {
+				entry.Error += " (" + ev.BlockedReason.String() + ")"
+			}
+			c.emit(ev.RequestID)
+		}
+	}
+}
+
+// emit reports and forgets an entry.
+func (c *networkCapture) emit(id network.RequestID) {
+	entry := c.entries[id]
+	delete(c.entries, id)
+	delete(c.extraHeaders, id)
+	if entry.Source == "" && entry.Error == "" {
+		entry.Source = SourceNetwork
+	}
+	c.onEntry(entry)
+}
+
+// newSSEEvent converts an EventSource message, decoding its data when it is JSON.
+func newSSEEvent(url string, ev *network.EventEventSourceMessageReceived) *models.SSEEvent {
+	event := &models.SSEEvent{
+		Kind:       "sse",
+		RequestID:  string(ev.RequestID),
+		URL:        url,
+		Event:      ev.EventName,
+		ID:         ev.EventID,
+		Data:       ev.Data,
+		ReceivedAt: time.Now().UTC(),
+	}
+	var decoded interface{}
+	if json.Unmarshal([]byte(ev.Data), &decoded) == nil {
+		event.JSON = decoded
+	}
+	return event
+}
+
+// applyResponse copies response details onto an entry and classifies where it was served from.
+func applyResponse(entry *models.NetworkEntry, resp *network.Response) {
+	entry.Status = resp.Status
+	entry.MimeType = resp.MimeType
+	entry.ResponseHeaders = flattenHeaders(resp.Headers)
+	if entry.Source == SourceMemoryCache {
+		return
+	}
+	entry.Source = responseSource(resp)
+	entry.FromCache = entry.Source == SourceDiskCache || entry.Source == SourcePrefetchCache
+}
+
+// responseSource classifies where a response was served from.
+func responseSource(resp *network.Response) string {
+	switch {
+	case resp.FromServiceWorker:
+		return SourceServiceWorker
+	case resp.FromPrefetchCache:
+		return SourcePrefetchCache
+	case resp.FromDiskCache:
+		return SourceDiskCache
+	default:
+		return SourceNetwork
+	}
+}
+
+// mergeHeaders adds the raw wire headers of a request, which include cookies and
+// other headers the renderer does not see, to the entry's request headers.
+func mergeHeaders(entry *models.NetworkEntry, headers network.Headers) {
+	if entry.RequestHeaders == nil {
+		entry.RequestHeaders = map[string]string{}
+	}
+	for name, value := range flattenHeaders(headers) {
+		entry.RequestHeaders[name] = value
+	}
+}
+
+// flattenHeaders converts CDP headers into a string map.
+func flattenHeaders(headers network.Headers) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	flat := make(map[string]string, len(headers))
+	for name, value := range headers {
+		flat[name] = fmt.Sprint(value)
+	}
+	return flat
+}
+
+// matchesURLFilter reports whether url passes filter. A filter containing '*' is
+// matched as a glob against the whole URL; otherwise it is a substring match.
+func matchesURLFilter(url, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	if !strings.Contains(filter, "*") {
+		return strings.Contains(url, filter)
+	}
+	// path.Match treats '/' specially, so match against a separator-free form of both.
+	ok, err := path.Match(strings.ReplaceAll(filter, "/", "\x00"), strings.ReplaceAll(url, "/", "\x00"))
+	return err == nil && ok
+}
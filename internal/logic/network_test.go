@@ -0,0 +1,134 @@
+package logic
+
+import (
+	"testing"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestMatchesURLFilter(t *testing.T) {
+	tests := []struct {
+		url, filter string
+		want        bool
+	}{
+		{"https://example.com/api/v1/products?page=2", "", true},
+		{"https://example.com/api/v1/products?page=2", "/api/", true},
+		{"https://example.com/static/app.js", "/api/", false},
+		{"https://example.com/api/v1/products?page=2", "*/api/v1/products*", true},
+		{"https://example.com/api/v2/products", "*/api/v1/products*", false},
+		{"https://cdn.example.com/img/logo.png", "*.png", true},
+	}
+	for _, tt := range tests {
+		if got := matchesURLFilter(tt.url, tt.filter); got != tt.want {
+			t.Errorf("matchesURLFilter(%q, %q) = %v, want %v", tt.url, tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestNetworkCapture_Sources(t *testing.T) {
+	var entries []*models.NetworkEntry
+	capture := newNetworkCapture("", func(e *models.NetworkEntry) { entries = append(entries, e) })
+
+	send := func(id network.RequestID, url string) {
+		capture.handle(&network.EventRequestWillBeSent{RequestID: id, Request: &network.Request{URL: url, Method: "GET"}})
+	}
+
+	send("1", "https://example.com/")
+	capture.handle(&network.EventResponseReceived{RequestID: "1", Response: &network.Response{Status: 200}})
+	capture.handle(&network.EventLoadingFinished{RequestID: "1", EncodedDataLength: 512})
+
+	send("2", "https://example.com/app.js")
+	capture.handle(&network.EventRequestServedFromCache{RequestID: "2"})
+	capture.handle(&network.EventResponseReceived{RequestID: "2", Response: &network.Response{Status: 200}})
+	capture.handle(&network.EventLoadingFinished{RequestID: "2"})
+
+	send("3", "https://example.com/logo.png")
+	capture.handle(&network.EventResponseReceived{RequestID: "3", Response: &network.Response{Status: 200, FromDiskCache: true}})
+	capture.handle(&network.EventLoadingFinished{RequestID: "3"})
+
+	send("4", "https://example.com/broken")
+	capture.handle(&network.EventLoadingFailed{RequestID: "4", ErrorText: "net::ERR_FAILED"})
+
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(entries))
+	}
+	want := []struct {
+		source    string
+		fromCache bool
+	}{
+		{SourceNetwork, false},
+		{SourceMemoryCache, true},
+		{SourceDiskCache, true},
+		{"", false},
+	}
+	for i, w := range want {
+		if entries[i].Source != w.source || entries[i].FromCache != w.fromCache {
+			t.Errorf("entry %d: got source %q fromCache %v, want %q %v", i, entries[i].Source, entries[i].FromCache, w.source, w.fromCache)
+		}
+	}
+	if entries[0].EncodedBytes != 512 || entries[3].Error != "net::ERR_FAILED" {
+		t.Errorf("unexpected entries: %+v %+v", entries[0], entries[3])
+	}
+}
+
+func TestNetworkCapture_Redirect(t *testing.T) {
+	var entries []*models.NetworkEntry
+	capture := newNetworkCapture("", func(e *models.NetworkEntry) { entries = append(entries, e) })
+
+	capture.handle(&network.EventRequestWillBeSent{RequestID: "1", Request: &network.Request{URL: "http://example.com/", Method: "GET"}})
+	capture.handle(&network.EventRequestWillBeSent{
+		RequestID:        "1",
+		Request:          &network.Request{URL: "https://example.com/", Method: "GET"},
+		RedirectResponse: &network.Response{Status: 301},
+	})
+	capture.handle(&network.EventResponseReceived{RequestID: "1", Response: &network.Response{Status: 200}})
+	capture.handle(&network.EventLoadingFinished{RequestID: "1"})
+
+	if len(entries) != 2 || entries[0].Status != 301 || entries[1].URL != "https://example.com/" || entries[1].Status != 200 {
+		t.Errorf("unexpected redirect entries: %+v", entries)
+	}
+}
+
+func TestNetworkCapture_FilteredExtraInfo(t *testing.T) {
+	capture := newNetworkCapture("/api", func(*models.NetworkEntry) {})
+
+	// Wire headers before and after a request that fails the filter.
+	capture.handle(&network.EventRequestWillBeSentExtraInfo{RequestID: "1", Headers: network.Headers{"Cookie": "a=1"}})
+	capture.handle(&network.EventRequestWillBeSent{RequestID: "1", Request: &network.Request{URL: "https://example.com/logo.png", Method: "GET"}})
+	capture.handle(&network.EventRequestWillBeSentExtraInfo{RequestID: "1", Headers: network.Headers{"Cookie": "a=1"}})
+	if len(capture.extraHeaders) != 0 {
+		t.Errorf("expected no headers kept for a filtered request, got %v", capture.extraHeaders)
+	}
+
+	capture.handle(&network.EventLoadingFinished{RequestID: "1"})
+	if len(capture.filtered) != 0 {
+		t.Errorf("expected the finished request to be forgotten, got %v", capture.filtered)
+	}
+}
+
+func TestNetworkCapture_SSE(t *testing.T) {
+	var events []*models.SSEEvent
+	capture := newNetworkCapture("/stream", func(*models.NetworkEntry) {})
+	capture.onSSE = func(e *models.SSEEvent) { events = append(events, e) }
+
+	capture.handle(&network.EventRequestWillBeSent{RequestID: "1", Type: network.ResourceTypeEventSource, Request: &network.Request{URL: "https://example.com/stream", Method: "GET"}})
+	capture.handle(&network.EventRequestWillBeSent{RequestID: "2", Request: &network.Request{URL: "https://example.com/other", Method: "GET"}})
+	capture.handle(&network.EventEventSourceMessageReceived{RequestID: "1", EventName: "price", EventID: "7", Data: `{"value": 42}`})
+	capture.handle(&network.EventEventSourceMessageReceived{RequestID: "1", EventName: "message", Data: "plain text"})
+	capture.handle(&network.EventEventSourceMessageReceived{RequestID: "2", EventName: "message", Data: "filtered out"})
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", events)
+	}
+	if events[0].Kind != "sse" || events[0].URL != "https://example.com/stream" || events[0].Event != "price" || events[0].ID != "7" {
+		t.Errorf("unexpected first event: %+v", events[0])
+	}
+	if decoded, ok := events[0].JSON.(map[string]interface{}); !ok || decoded["value"] != float64(42) {
+		t.Errorf("expected JSON data to be decoded, got %#v", events[0].JSON)
+	}
+	if events[1].JSON != nil || events[1].Data != "plain text" {
+		t.Errorf("expected plain data to be kept as-is, got %+v", events[1])
+	}
+}
@@ -0,0 +1,101 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"browser-tools-go/internal/models"
+)
+
+// webhookTimeout bounds a single webhook delivery.
+const webhookTimeout = 10 * time.Second
+
+// RunNotifyCommand runs command with the JSON payload on stdin. The command line
+// is split on whitespace; extra environment variables are appended to the
+// current environment.
+func RunNotifyCommand(ctx context.Context, command string, payload []byte, env map[string]string) error {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return fmt.Errorf("empty notify command")
+	}
+
+	cmd := exec.CommandContext(ctx, fields[0], fields[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	for key, value := range env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("notify command '%s' failed: %w", command, err)
+	}
+	return nil
+}
+
+// WebhookSignatureHeader carries the HMAC-SHA256 signature of the request body.
+const WebhookSignatureHeader = "X-Signature-256"
+
+// Webhook is a destination for result batches.
+type Webhook struct {
+	URL string
+	// Secret, when set, signs each body with HMAC-SHA256 in WebhookSignatureHeader.
+	Secret string
+}
+
+// SignWebhookPayload returns the "sha256=<hex>" HMAC signature of payload.
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SendWebhookBatch wraps results in a WebhookBatch envelope and POSTs it.
+func SendWebhookBatch(ctx context.Context, hook Webhook, source string, results interface{}) error {
+	payload, err := json.Marshal(models.WebhookBatch{
+		Source:  source,
+		SentAt:  time.Now().UTC(),
+		Results: results,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	return PostWebhook(ctx, hook, payload)
+}
+
+// PostWebhook POSTs the JSON payload to the webhook and fails on a non-2xx response.
+func PostWebhook(ctx context.Context, hook Webhook, payload []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if hook.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(hook.Secret, payload))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %s", resp.Status)
+	}
+	return nil
+}
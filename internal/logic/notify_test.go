@@ -0,0 +1,66 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"browser-tools-go/internal/models"
+)
+
+func TestSendWebhookBatch(t *testing.T) {
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(WebhookSignatureHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	results := []models.SearchResult{{Title: "Example", Link: "https://example.com"}}
+	err := SendWebhookBatch(context.Background(), Webhook{URL: server.URL, Secret: "s3cret"}, "search", results)
+	if err != nil {
+		t.Fatalf("SendWebhookBatch failed: %v", err)
+	}
+
+	var batch struct {
+		Source  string                `json:"source"`
+		Results []models.SearchResult `json:"results"`
+	}
+	if err := json.Unmarshal(body, &batch); err != nil {
+		t.Fatalf("webhook body is not valid JSON: %v", err)
+	}
+	if batch.Source != "search" || len(batch.Results) != 1 || batch.Results[0].Title != "Example" {
+		t.Errorf("unexpected webhook batch: %+v", batch)
+	}
+	if signature != SignWebhookPayload("s3cret", body) {
+		t.Errorf("signature %q does not match body", signature)
+	}
+}
+
+func TestPostWebhook_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(WebhookSignatureHeader) != "" {
+			t.Error("unsigned webhook should not carry a signature header")
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := PostWebhook(context.Background(), Webhook{URL: server.URL}, []byte(`{}`)); err == nil {
+		t.Error("expected error for a non-2xx response")
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// Well-known HMAC-SHA256 example vector
+	got := SignWebhookPayload("key", []byte("The quick brown fox jumps over the lazy dog"))
+	expected := "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
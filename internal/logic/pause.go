@@ -0,0 +1,74 @@
+package logic
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"browser-tools-go/internal/browser"
+	"browser-tools-go/internal/i18n"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultPauseMessage is shown by Pause when no message is given.
+const DefaultPauseMessage = "Press Enter to continue"
+
+// Pause hands the tab of ctx over to an operator, e.g. to solve a CAPTCHA:
+// it brings the browser window to the front, shows message on stderr and
+// waits until a line is read from in, for at most timeout unless it is 0.
+// It fails when in is closed first, so that an unattended run doesn't go on
+// as if someone had confirmed.
+func Pause(ctx context.Context, message string, in io.Reader, timeout time.Duration) error {
+	ctx, span := startSpan(ctx, "pause", attribute.String("browser_tools.message", message))
+	err := runPause(ctx, message, in, timeout)
+	endSpan(span, err)
+	return err
+}
+
+func runPause(ctx context.Context, message string, in io.Reader, timeout time.Duration) error {
+	if err := browser.FocusTab(ctx); err != nil {
+		return err
+	}
+	if message == "" {
+		message = i18n.T(DefaultPauseMessage)
+	}
+	fmt.Fprintf(os.Stderr, "⏸️  %s ", message)
+	start := time.Now()
+	if err := waitForConfirmation(ctx, in, timeout); err != nil {
+		fmt.Fprintln(os.Stderr)
+		return err
+	}
+	i18n.Printf("▶️ Resumed after %s", time.Since(start).Round(time.Second))
+	return nil
+}
+
+// waitForConfirmation waits until a line is read from in, for at most
+// timeout unless it is 0.
+func waitForConfirmation(ctx context.Context, in io.Reader, timeout time.Duration) error {
+	confirmed := make(chan error, 1)
+	go func() {
+		_, err := bufio.NewReader(in).ReadString('\n')
+		confirmed <- err
+	}()
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case err := <-confirmed:
+		if err != nil {
+			return fmt.Errorf("no confirmation: %w", err)
+		}
+		return nil
+	case <-expired:
+		return fmt.Errorf("no confirmation after %s", timeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,31 @@
+package logic
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForConfirmation(t *testing.T) {
+	ctx := context.Background()
+	if err := waitForConfirmation(ctx, strings.NewReader("\n"), 0); err != nil {
+		t.Errorf("Enter: %v", err)
+	}
+	// A closed stdin is not a confirmation.
+	if err := waitForConfirmation(ctx, strings.NewReader(""), 0); err == nil {
+		t.Error("EOF: expected an error")
+	}
+
+	r, w := io.Pipe()
+	defer w.Close()
+	if err := waitForConfirmation(ctx, r, 10*time.Millisecond); err == nil || !strings.Contains(err.Error(), "after 10ms") {
+		t.Errorf("timeout: got %v", err)
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := waitForConfirmation(cancelled, r, 0); err != context.Canceled {
+		t.Errorf("cancelled: got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/color"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// Point is a position in CSS pixels.
+type Point struct {
+	X, Y float64
+}
+
+// ParsePoint parses "x,y".
+func ParsePoint(value string) (Point, error) {
+	xs, ys, ok := strings.Cut(value, ",")
+	if !ok {
+		return Point{}, fmt.Errorf("invalid point %q: expected x,y", value)
+	}
+	x, errX := strconv.ParseFloat(strings.TrimSpace(xs), 64)
+	y, errY := strconv.ParseFloat(strings.TrimSpace(ys), 64)
+	if errX != nil || errY != nil {
+		return Point{}, fmt.Errorf("invalid point %q: expected x,y", value)
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+// elementPointFunction returns the viewport position of the element's top-left
+// corner plus offset, or of its center when offset is null.
+const elementPointFunction = `function(selector, offset) {
+	const el = document.querySelector(selector);
+	if (!el) {
+		return null;
+	}
+	el.scrollIntoView({block: 'center', inline: 'center'});
+	const r = el.getBoundingClientRect();
+	return offset ? {x: r.left + offset.x, y: r.top + offset.y} : {x: r.left + r.width / 2, y: r.top + r.height / 2};
+}`
+
+// ElementPoint returns the viewport point of the first element matching
+// selector: its top-left corner moved by offset, or its center when offset is nil.
+// The element is scrolled into view first.
+func ElementPoint(ctx context.Context, selector string, offset *Point) (Point, error) {
+	var point *Point
+	var arg interface{}
+	if offset != nil {
+		arg = map[string]float64{"x": offset.X, "y": offset.Y}
+	}
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		id, err := isolatedContext(ctx)
+		if err != nil {
+			return err
+		}
+		var raw *struct {
+			X float64 `json:"x"`
+			Y float64 `json:"y"`
+		}
+		if err := callOnGlobal(ctx, id, elementPointFunction, &raw, selector, arg); err != nil {
+			return err
+		}
+		if raw != nil {
+			point = &Point{X: raw.X, Y: raw.Y}
+		}
+		return nil
+	}))
+	if err != nil {
+		return Point{}, fmt.Errorf("failed to locate '%s': %w", selector, err)
+	}
+	if point == nil {
+		return Point{}, fmt.Errorf("no element matched '%s'", selector)
+	}
+	return *point, nil
+}
+
+// SamplePixel returns the rendered color at a viewport point.
+func SamplePixel(ctx context.Context, point Point) (*models.PixelColor, error) {
+	var buf []byte
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		_, _, _, layout, _, _, err := page.GetLayoutMetrics().Do(ctx)
+		if err != nil {
+			return err
+		}
+		// Clips are in document coordinates.
+		buf, err = page.CaptureScreenshot().
+			WithFormat(page.CaptureScreenshotFormatPng).
+			WithClip(&page.Viewport{X: point.X + float64(layout.PageX), Y: point.Y + float64(layout.PageY), Width: 1, Height: 1, Scale: 1}).
+			Do(ctx)
+		return err
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture pixel at %g,%g: %w", point.X, point.Y, err)
+	}
+	c, err := firstPixel(buf)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PixelColor{
+		X:   point.X,
+		Y:   point.Y,
+		Hex: fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B),
+		R:   c.R,
+		G:   c.G,
+		B:   c.B,
+	}, nil
+}
+
+// firstPixel returns the top-left pixel of a PNG image.
+func firstPixel(data []byte) (color.RGBA, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("failed to decode pixel capture: %w", err)
+	}
+	if img.Bounds().Empty() {
+		return color.RGBA{}, fmt.Errorf("pixel capture is empty")
+	}
+	min := img.Bounds().Min
+	return color.RGBAModel.Convert(img.At(min.X, min.Y)).(color.RGBA), nil
+}
@@ -0,0 +1,55 @@
+package logic
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestParsePoint(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Point
+		wantErr bool
+	}{
+		{"10,20", Point{10, 20}, false},
+		{" 10.5 , -3 ", Point{10.5, -3}, false},
+		{"10", Point{}, true},
+		{"a,b", Point{}, true},
+		{"1,2,3", Point{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePoint(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePoint(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePoint(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFirstPixel(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff})
+	img.Set(1, 1, color.RGBA{R: 0xff, A: 0xff})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := firstPixel(buf.Bytes())
+	if err != nil {
+		t.Fatalf("firstPixel() error = %v", err)
+	}
+	if want := (color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff}); got != want {
+		t.Errorf("firstPixel() = %v, want %v", got, want)
+	}
+
+	if _, err := firstPixel([]byte("not a png")); err == nil {
+		t.Error("expected an error for invalid image data")
+	}
+}
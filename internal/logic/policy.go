@@ -0,0 +1,94 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"browser-tools-go/internal/browser"
+	"browser-tools-go/internal/config"
+
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+type policyKey struct{}
+
+// WithPolicy returns a copy of ctx carrying policy: ApplyHostPolicy enforces
+// its hosts on tabs, crawls follow them and EvaluateJS refuses inline
+// JavaScript when it restricts eval.
+func WithPolicy(ctx context.Context, policy *config.Policy) context.Context {
+	return context.WithValue(ctx, policyKey{}, policy)
+}
+
+// PolicyFrom returns the policy of ctx, nil when it has none.
+func PolicyFrom(ctx context.Context) *config.Policy {
+	policy, _ := ctx.Value(policyKey{}).(*config.Policy)
+	return policy
+}
+
+// CheckEvalPolicy returns an error when the policy of ctx restricts eval to
+// approved scripts, which inline JavaScript isn't.
+func CheckEvalPolicy(ctx context.Context) error {
+	if policy := PolicyFrom(ctx); policy.RestrictsEval() {
+		return fmt.Errorf("%w: inline JavaScript isn't allowed, only the approved scripts of %s", config.ErrEvalRefused, policy.EvalScriptDir)
+	}
+	return nil
+}
+
+// CheckHostPolicy returns an error when the policy of ctx doesn't allow
+// navigating to url.
+func CheckHostPolicy(ctx context.Context, url string) error {
+	return PolicyFrom(ctx).Allows(url)
+}
+
+// ApplyHostPolicy makes the tab of ctx fail the navigations its policy
+// doesn't allow, for as long as ctx is connected. The document requests of
+// the tab are intercepted before they are sent, so redirects, links followed
+// and navigations started by the page are covered as well. It does nothing
+// when ctx has no policy.
+func ApplyHostPolicy(ctx context.Context) error {
+	policy := PolicyFrom(ctx)
+	if policy.AllowsAllHosts() {
+		return nil
+	}
+	err := interceptRequests(ctx, requestRule{
+		pattern: &fetch.RequestPattern{URLPattern: "*", ResourceType: network.ResourceTypeDocument},
+		paused: func(ctx context.Context, ev *fetch.EventRequestPaused) bool {
+			if policy.Allows(ev.Request.URL) == nil {
+				return false
+			}
+			_ = chromedp.Run(ctx, fetch.FailRequest(ev.RequestID, network.ErrorReasonBlockedByClient))
+			return true
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply the host policy: %w", err)
+	}
+	return nil
+}
+
+// allowedLinks returns the links the host policy of ctx allows navigating to.
+func allowedLinks(ctx context.Context, links []string) []string {
+	policy := PolicyFrom(ctx)
+	if policy.AllowsAllHosts() {
+		return links
+	}
+	var allowed []string
+	for _, link := range links {
+		if policy.Allows(link) == nil {
+			allowed = append(allowed, link)
+		}
+	}
+	return allowed
+}
+
+// NewTabPool returns a pool of tabs of the browser of ctx, each following the
+// host policy of ctx.
+func NewTabPool(ctx context.Context, size int) *browser.TabPool {
+	pool := browser.NewTabPool(ctx, size)
+	if !PolicyFrom(ctx).AllowsAllHosts() {
+		pool.Setup = ApplyHostPolicy
+	}
+	return pool
+}
@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"browser-tools-go/internal/config"
+)
+
+func TestAllowedLinks(t *testing.T) {
+	links := []string{"https://example.com/a", "https://ads.example.net/b", "https://example.com/c"}
+	if got := allowedLinks(context.Background(), links); len(got) != 3 {
+		t.Errorf("no policy: got %v", got)
+	}
+
+	ctx := WithPolicy(context.Background(), &config.Policy{BlockedHosts: []string{"*.example.net"}})
+	got := allowedLinks(ctx, links)
+	if len(got) != 2 || got[0] != links[0] || got[1] != links[2] {
+		t.Errorf("got %v", got)
+	}
+	if err := CheckHostPolicy(ctx, "https://ads.example.net/"); err == nil {
+		t.Error("blocked host: expected an error")
+	}
+	// Without a policy nothing is intercepted, not even a browser needed.
+	if err := ApplyHostPolicy(context.Background()); err != nil {
+		t.Errorf("no policy: got %v", err)
+	}
+}
+
+func TestCheckEvalPolicy(t *testing.T) {
+	if err := CheckEvalPolicy(context.Background()); err != nil {
+		t.Errorf("no policy: got %v", err)
+	}
+	ctx := WithPolicy(context.Background(), &config.Policy{EvalScriptDir: "/opt/scripts"})
+	if err := CheckEvalPolicy(ctx); !errors.Is(err, config.ErrEvalRefused) {
+		t.Errorf("restricted: got %v", err)
+	}
+	if _, err := EvaluateJS(ctx, "document.title", EvalOptions{}); !errors.Is(err, config.ErrEvalRefused) {
+		t.Errorf("EvaluateJS: got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrReadOnly is matched by the errors of actions refused in read-only mode.
+var ErrReadOnly = errors.New("refused by --read-only")
+
+type readOnlyKey struct{}
+
+// WithReadOnly returns a copy of ctx in which the actions that change the
+// page or the site are refused: clicking and typing, JavaScript that looks
+// like it modifies the page, requests other than GET, HEAD and OPTIONS, and
+// GraphQL mutations.
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// IsReadOnly reports whether ctx was made read-only by WithReadOnly.
+func IsReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
+
+// checkReadOnly refuses action when ctx is read-only.
+func checkReadOnly(ctx context.Context, action string) error {
+	if IsReadOnly(ctx) {
+		return fmt.Errorf("%w: %s", ErrReadOnly, action)
+	}
+	return nil
+}
+
+// checkReadOnlyJS refuses JavaScript that looks like it changes the page when
+// ctx is read-only.
+func checkReadOnlyJS(ctx context.Context, js string) error {
+	if !IsReadOnly(ctx) {
+		return nil
+	}
+	if reason, ok := MutatingJS(js); ok {
+		return fmt.Errorf("%w: the JavaScript looks like it changes the page (%s)", ErrReadOnly, reason)
+	}
+	return nil
+}
+
+// checkReadOnlyMethod refuses requests that may change the site when ctx is
+// read-only.
+func checkReadOnlyMethod(ctx context.Context, method string) error {
+	switch strings.ToUpper(method) {
+	case "", "GET", "HEAD", "OPTIONS":
+		return nil
+	}
+	return checkReadOnly(ctx, fmt.Sprintf("%s requests may change the site", strings.ToUpper(method)))
+}
+
+// mutatingJSPatterns are what MutatingJS looks for, with the reason given.
+// A property is a member, ".name", or an index, "name[...]".
+var mutatingJSPatterns = []struct {
+	pattern *regexp.Regexp
+	reason  string
+}{
+	{regexp.MustCompile(`(\.\s*[A-Za-z_$][\w$]*|[\w$)\]]\[[^\[\]]*\])\s*(\*\*|<<|>>>?|&&|\|\||\?\?|[-+*/%&|^])?=($|[^=>])`), "assignment to a property"},
+	{regexp.MustCompile(`(^|[^\w$.])location\s*=($|[^=>])`), "assignment to location"},
+	{regexp.MustCompile(`(\+\+|--)\s*[\w$.]*(\.\s*[A-Za-z_$][\w$]*|[\w$)\]]\[[^\[\]]*\])|(\.\s*[A-Za-z_$][\w$]*|[\w$)\]]\[[^\[\]]*\])\s*(\+\+|--)`), "increment of a property"},
+	{regexp.MustCompile(`(^|[^\w$.])delete\s`), "delete"},
+	{regexp.MustCompile(`\.\s*(click|submit|requestSubmit|reset|remove|removeChild|append|appendChild|prepend|insertBefore|insertAdjacentHTML|insertAdjacentElement|insertAdjacentText|replaceWith|replaceChild|replaceChildren|setAttribute|setAttributeNS|removeAttribute|toggleAttribute|dispatchEvent|setItem|removeItem|write|writeln|execCommand|pushState|replaceState|reload|showModal|deleteDatabase|setProperty)\s*\(`), "call of a mutating method"},
+}
+
+// mutatingFetchMethod matches the method of a request that may change the
+// site, looked for in string literals.
+var mutatingFetchMethod = regexp.MustCompile(`(?i)method\s*:\s*["'` + "`" + `](POST|PUT|PATCH|DELETE)`)
+
+// MutatingJS reports whether js looks like it changes the page, and why:
+// it assigns to or deletes properties, such as element.value or
+// document.cookie, calls DOM methods such as click, remove or setAttribute,
+// writes to storage or sends a request with a method other than GET. It is a
+// heuristic, looking at the source with its string literals and comments
+// blanked out: assignments to local variables aren't flagged, and code that
+// hides what it does can get past it.
+func MutatingJS(js string) (string, bool) {
+	code := blankJSLiterals(js)
+	for _, p := range mutatingJSPatterns {
+		if p.pattern.MatchString(code) {
+			return p.reason, true
+		}
+	}
+	if mutatingFetchMethod.MatchString(js) {
+		return "request with a mutating method", true
+	}
+	return "", false
+}
+
+// blankJSLiterals returns js with the contents of its string and template
+// literals and its comments removed, keeping the quotes.
+func blankJSLiterals(js string) string {
+	var b strings.Builder
+	for i := 0; i < len(js); i++ {
+		c := js[i]
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			b.WriteByte(c)
+			for i++; i < len(js) && js[i] != c; i++ {
+				if js[i] == '\\' {
+					i++
+				}
+			}
+			if i < len(js) {
+				b.WriteByte(c)
+			}
+		case c == '/' && i+1 < len(js) && js[i+1] == '/':
+			for i < len(js) && js[i] != '\n' {
+				i++
+			}
+			b.WriteByte('\n')
+		case c == '/' && i+1 < len(js) && js[i+1] == '*':
+			end := strings.Index(js[i+2:], "*/")
+			if end < 0 {
+				return b.String()
+			}
+			i += end + 3
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// graphQLMutation matches a query defining a mutation operation.
+var graphQLMutation = regexp.MustCompile(`(^|[^\w])mutation\b\s*[\w({@]`)
+
+// checkReadOnlyGraphQL refuses GraphQL mutations when ctx is read-only.
+func checkReadOnlyGraphQL(ctx context.Context, query string) error {
+	if IsReadOnly(ctx) && graphQLMutation.MatchString(blankGraphQLLiterals(query)) {
+		return checkReadOnly(ctx, "the GraphQL query defines a mutation")
+	}
+	return nil
+}
+
+// graphQLString matches a GraphQL string literal.
+var graphQLString = regexp.MustCompile(`"(\\.|[^"\\])*"`)
+
+// blankGraphQLLiterals returns query with its strings and comments removed.
+func blankGraphQLLiterals(query string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(query, "\n") {
+		line = graphQLString.ReplaceAllString(line, `""`)
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
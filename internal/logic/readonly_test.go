@@ -0,0 +1,77 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMutatingJS(t *testing.T) {
+	mutating := []string{
+		`document.querySelector("#q").value = "x"`,
+		`document.cookie = "a=b"`,
+		`el.dataset.count += 1`,
+		`items[0] = null`,
+		`counter.n++`,
+		`location = "/logout"`,
+		`delete window.config`,
+		`document.querySelector("button").click()`,
+		`document.body.appendChild(document.createElement("div"))`,
+		`localStorage.setItem("k", "v")`,
+		`fetch("/api/items", {method: "POST"})`,
+		`el.value ||= "x"`,
+	}
+	for _, js := range mutating {
+		if _, ok := MutatingJS(js); !ok {
+			t.Errorf("%s: expected to be flagged", js)
+		}
+	}
+
+	readOnly := []string{
+		`document.title`,
+		`document.querySelector("#q").value === "x"`,
+		`const [a, b] = [1, 2]; a + b`,
+		`let n = 0; for (let i = 0; i < 3; i++) n += i; n`,
+		`[...document.querySelectorAll("a")].map(a => a.href)`,
+		`el.scrollTop >= el.scrollHeight`,
+		`"a.b = c"`,
+		`// x.y = 1` + "\n" + `1`,
+		`Object.assign({}, window.config)`,
+		`fetch("/api/items").then(r => r.json())`,
+		`new Map([[1, 2]]).delete(1)`,
+	}
+	for _, js := range readOnly {
+		if reason, ok := MutatingJS(js); ok {
+			t.Errorf("%s: unexpectedly flagged (%s)", js, reason)
+		}
+	}
+}
+
+func TestReadOnlyChecks(t *testing.T) {
+	ctx := context.Background()
+	if err := checkReadOnlyJS(ctx, `el.value = 1`); err != nil {
+		t.Errorf("not read-only: got %v", err)
+	}
+
+	ctx = WithReadOnly(ctx)
+	if !IsReadOnly(ctx) {
+		t.Fatal("expected a read-only context")
+	}
+	if err := checkReadOnlyJS(ctx, `el.value = 1`); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("assignment: got %v", err)
+	}
+	for method, allowed := range map[string]bool{"": true, "get": true, "HEAD": true, "POST": false, "delete": false} {
+		if err := checkReadOnlyMethod(ctx, method); (err == nil) != allowed {
+			t.Errorf("method %q: got %v", method, err)
+		}
+	}
+	if err := checkReadOnlyGraphQL(ctx, `mutation AddItem($name: String!) { addItem(name: $name) { id } }`); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("mutation: got %v", err)
+	}
+	if err := checkReadOnlyGraphQL(ctx, `query { items(filter: "mutation {") { id } } # mutation {`); err != nil {
+		t.Errorf("query: got %v", err)
+	}
+	if err := Click(ctx, "button", false); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("click: got %v", err)
+	}
+}
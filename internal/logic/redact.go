@@ -0,0 +1,261 @@
+package logic
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"browser-tools-go/internal/config"
+	"browser-tools-go/internal/models"
+)
+
+// Redacted replaces the values masked by a Redactor.
+const Redacted = "[REDACTED]"
+
+// Redactor masks the secrets of logs, network captures and curl commands, as
+// configured by the redaction of the policy. A nil Redactor masks nothing.
+type Redactor struct {
+	fields   map[string]bool // lower-cased field names
+	patterns []*regexp.Regexp
+	// jsonField, headerField and paramField match the values of the fields
+	// in free text: `"name": value`, "Name: value" and "name=value".
+	jsonField, headerField, paramField *regexp.Regexp
+}
+
+// NewRedactor returns a Redactor masking the fields and patterns of
+// redaction.
+func NewRedactor(redaction config.Redaction) (*Redactor, error) {
+	r := &Redactor{fields: map[string]bool{}}
+	var names []string
+	for _, field := range redaction.RedactedFields() {
+		r.fields[strings.ToLower(field)] = true
+		names = append(names, regexp.QuoteMeta(field))
+	}
+	for _, pattern := range redaction.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("redaction: invalid pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	if len(names) > 0 {
+		alternatives := strings.Join(names, "|")
+		r.jsonField = regexp.MustCompile(`(?i)("(?:` + alternatives + `)"\s*:\s*)("(?:[^"\\]|\\.)*"|-?\d[\d.eE+-]*|true|false|null)`)
+		name := `(?i)(^|[^\w-])(` + alternatives + `)`
+		r.headerField = regexp.MustCompile(name + `(\s*:[ \t]*)[^\r\n"']+`)
+		r.paramField = regexp.MustCompile(name + `(=)[^\s&;,"']+`)
+	}
+	return r, nil
+}
+
+// String masks the fields and patterns found in s, a log message or a
+// request body: JSON members, header lines and query or form parameters
+// named after the fields.
+func (r *Redactor) String(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	if r.jsonField != nil {
+		s = r.jsonField.ReplaceAllString(s, `${1}"`+Redacted+`"`)
+		s = r.headerField.ReplaceAllString(s, "${1}${2}${3}"+Redacted)
+		s = r.paramField.ReplaceAllString(s, "${1}${2}${3}"+Redacted)
+	}
+	return r.mask(s)
+}
+
+// mask masks the patterns found in s.
+func (r *Redactor) mask(s string) string {
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// URL masks the password and the query parameters named after the fields of
+// rawURL.
+func (r *Redactor) URL(rawURL string) string {
+	if r == nil {
+		return rawURL
+	}
+	if u, err := url.Parse(rawURL); err == nil && u.User != nil {
+		rawURL = u.Redacted()
+	}
+	return r.String(rawURL)
+}
+
+// Headers returns a copy of headers with the values of the fields masked.
+// The scheme of credentials and the names and attributes of cookies are
+// kept, as they help debugging without revealing anything.
+func (r *Redactor) Headers(headers map[string]string) map[string]string {
+	if r == nil || headers == nil {
+		return headers
+	}
+	masked := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if r.fields[strings.ToLower(name)] {
+			value = redactHeader(name, value)
+		}
+		masked[name] = r.mask(value)
+	}
+	return masked
+}
+
+// redactHeader masks the value of the header name.
+func redactHeader(name, value string) string {
+	switch strings.ToLower(name) {
+	case "authorization", "proxy-authorization":
+		if scheme, _, ok := strings.Cut(value, " "); ok {
+			return scheme + " " + Redacted
+		}
+	case "cookie":
+		pairs := strings.Split(value, ";")
+		for i, pair := range pairs {
+			pairs[i] = redactCookie(pair)
+		}
+		return strings.Join(pairs, ";")
+	case "set-cookie":
+		// Several Set-Cookie headers are joined by newlines.
+		lines := strings.Split(value, "\n")
+		for i, line := range lines {
+			cookie, attributes, ok := strings.Cut(line, ";")
+			lines[i] = redactCookie(cookie)
+			if ok {
+				lines[i] += ";" + attributes
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+	return Redacted
+}
+
+// redactCookie masks the value of a "name=value" cookie.
+func redactCookie(pair string) string {
+	name, _, ok := strings.Cut(pair, "=")
+	if !ok {
+		return Redacted
+	}
+	return name + "=" + Redacted
+}
+
+// JSON returns a copy of the decoded JSON v with the members named after the
+// fields masked, and the strings masked like String does.
+func (r *Redactor) JSON(v interface{}) interface{} {
+	if r == nil {
+		return v
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for name, value := range v {
+			if r.fields[strings.ToLower(name)] {
+				masked[name] = Redacted
+			} else {
+				masked[name] = r.JSON(value)
+			}
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, value := range v {
+			masked[i] = r.JSON(value)
+		}
+		return masked
+	case string:
+		return r.String(v)
+	}
+	return v
+}
+
+// NetworkEntry returns a copy of entry with its URL, headers and body
+// masked.
+func (r *Redactor) NetworkEntry(entry *models.NetworkEntry) *models.NetworkEntry {
+	if r == nil {
+		return entry
+	}
+	masked := *entry
+	masked.URL = r.URL(entry.URL)
+	masked.RequestHeaders = r.Headers(entry.RequestHeaders)
+	masked.ResponseHeaders = r.Headers(entry.ResponseHeaders)
+	masked.PostData = r.String(entry.PostData)
+	return &masked
+}
+
+// ExchangeHeaders returns a copy of entry with its request and response
+// headers masked, for archives that must keep the URL and bodies intact.
+func (r *Redactor) ExchangeHeaders(entry *models.NetworkEntry) *models.NetworkEntry {
+	if r == nil {
+		return entry
+	}
+	masked := *entry
+	masked.RequestHeaders = r.Headers(entry.RequestHeaders)
+	masked.ResponseHeaders = r.Headers(entry.ResponseHeaders)
+	return &masked
+}
+
+// SSEEvent returns a copy of event with its URL and data masked.
+func (r *Redactor) SSEEvent(event *models.SSEEvent) *models.SSEEvent {
+	if r == nil {
+		return event
+	}
+	masked := *event
+	masked.URL = r.URL(event.URL)
+	masked.Data = r.String(event.Data)
+	masked.JSON = r.JSON(event.JSON)
+	return &masked
+}
+
+// CapturedResponse returns a copy of response with its URL and body masked.
+func (r *Redactor) CapturedResponse(response *models.CapturedResponse) *models.CapturedResponse {
+	if r == nil {
+		return response
+	}
+	masked := *response
+	masked.URL = r.URL(response.URL)
+	masked.JSON = r.JSON(response.JSON)
+	masked.Body = r.String(response.Body)
+	return &masked
+}
+
+// CurlRequest returns a copy of req with its URL, headers and body masked.
+func (r *Redactor) CurlRequest(req CurlRequest) CurlRequest {
+	if r == nil {
+		return req
+	}
+	req.URL = r.URL(req.URL)
+	req.Headers = r.Headers(req.Headers)
+	req.Body = r.String(req.Body)
+	return req
+}
+
+// ConsoleMessage returns a copy of message with its text and URL masked.
+func (r *Redactor) ConsoleMessage(message models.ConsoleMessage) models.ConsoleMessage {
+	if r == nil {
+		return message
+	}
+	message.Text = r.String(message.Text)
+	message.URL = r.URL(message.URL)
+	return message
+}
+
+// Writer returns a writer masking what it writes to w. The log writes each
+// message with a single Write, so secrets are never split between writes.
+func (r *Redactor) Writer(w io.Writer) io.Writer {
+	if r == nil {
+		return w
+	}
+	return redactingWriter{r: r, w: w}
+}
+
+type redactingWriter struct {
+	r *Redactor
+	w io.Writer
+}
+
+func (w redactingWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.w, w.r.String(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
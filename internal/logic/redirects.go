@@ -0,0 +1,180 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// Redirect hop kinds reported in RedirectHop.Kind.
+const (
+	HopHTTP   = "http"
+	HopClient = "client"
+	HopFinal  = "final"
+)
+
+// maxRedirectHops stops tracing pages that redirect in a loop.
+const maxRedirectHops = 30
+
+// RedirectTraceOptions configures TraceRedirects.
+type RedirectTraceOptions struct {
+	// Settle is how long the page must stay without a new main-frame
+	// navigation before its URL is taken as the final destination.
+	Settle time.Duration
+}
+
+// TraceRedirects loads url and reports every hop until the final destination.
+// Hops are taken from the main frame's document requests, so script and meta
+// refresh redirects are included alongside HTTP redirects.
+func TraceRedirects(ctx context.Context, url string, opts RedirectTraceOptions) (*models.RedirectTrace, error) {
+	tracer := newRedirectTracer()
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chromedp.ListenTarget(listenCtx, tracer.handle)
+
+	err := chromedp.Run(ctx,
+		network.Enable(),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			tree, err := page.GetFrameTree().Do(ctx)
+			if err != nil {
+				return err
+			}
+			tracer.setFrame(tree.Frame.ID)
+			return nil
+		}),
+		chromedp.Navigate(url),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", url, err)
+	}
+
+	// Wait for client-side redirects until the page stays put.
+	for {
+		quiet := opts.Settle - time.Since(tracer.lastActivity())
+		if quiet <= 0 || tracer.hopCount() >= maxRedirectHops {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(quiet):
+		}
+	}
+	return tracer.trace(url), nil
+}
+
+// redirectTracer collects main-frame document hops from network events.
+type redirectTracer struct {
+	mu      sync.Mutex
+	start   time.Time
+	last    time.Time
+	frameID cdp.FrameID
+	hops    []*models.RedirectHop
+	// byRequest lists the hops of each request ID (HTTP redirects reuse it);
+	// extraSeen counts the ExtraInfo events already matched to them.
+	byRequest map[network.RequestID][]*models.RedirectHop
+	extraSeen map[network.RequestID]int
+}
+
+func newRedirectTracer() *redirectTracer {
+	now := time.Now()
+	return &redirectTracer{
+		start:     now,
+		last:      now,
+		byRequest: map[network.RequestID][]*models.RedirectHop{},
+		extraSeen: map[network.RequestID]int{},
+	}
+}
+
+func (t *redirectTracer) setFrame(id cdp.FrameID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.frameID = id
+}
+
+func (t *redirectTracer) lastActivity() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.last
+}
+
+func (t *redirectTracer) hopCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.hops)
+}
+
+// handle processes a single CDP event.
+func (t *redirectTracer) handle(ev interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch ev := ev.(type) {
+	case *network.EventRequestWillBeSent:
+		if ev.Type != network.ResourceTypeDocument || ev.FrameID != t.frameID {
+			return
+		}
+		if len(t.hops) > 0 {
+			previous := t.hops[len(t.hops)-1]
+			if ev.RedirectResponse != nil {
+				previous.Kind = HopHTTP
+				previous.Status = ev.RedirectResponse.Status
+				previous.ElapsedMs = t.elapsed()
+			} else {
+				previous.Kind = HopClient
+			}
+			previous.Location = ev.Request.URL
+		}
+		hop := &models.RedirectHop{URL: ev.Request.URL, Kind: HopFinal}
+		t.hops = append(t.hops, hop)
+		t.byRequest[ev.RequestID] = append(t.byRequest[ev.RequestID], hop)
+		t.last = time.Now()
+	case *network.EventResponseReceived:
+		hops := t.byRequest[ev.RequestID]
+		if len(hops) == 0 {
+			return
+		}
+		hop := hops[len(hops)-1]
+		hop.Status = ev.Response.Status
+		hop.ElapsedMs = t.elapsed()
+	case *network.EventResponseReceivedExtraInfo:
+		hops := t.byRequest[ev.RequestID]
+		i := t.extraSeen[ev.RequestID]
+		if i >= len(hops) {
+			return
+		}
+		t.extraSeen[ev.RequestID] = i + 1
+		if cookies, ok := lookupHeader(flattenHeaders(ev.Headers), "set-cookie"); ok {
+			hops[i].SetCookies = strings.Split(cookies, "\n")
+		}
+	}
+}
+
+// elapsed returns the milliseconds since the trace started.
+func (t *redirectTracer) elapsed() float64 {
+	return float64(time.Since(t.start).Microseconds()) / 1000
+}
+
+// trace returns the collected hops.
+func (t *redirectTracer) trace(url string) *models.RedirectTrace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := &models.RedirectTrace{URL: url, FinalURL: url, Hops: []models.RedirectHop{}}
+	for _, hop := range t.hops {
+		result.Hops = append(result.Hops, *hop)
+	}
+	if n := len(t.hops); n > 0 {
+		result.FinalURL = t.hops[n-1].URL
+	}
+	return result
+}
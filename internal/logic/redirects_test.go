@@ -0,0 +1,63 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestRedirectTracer(t *testing.T) {
+	tracer := newRedirectTracer()
+	tracer.setFrame("main")
+
+	document := func(id network.RequestID, url string, redirect *network.Response) *network.EventRequestWillBeSent {
+		return &network.EventRequestWillBeSent{
+			RequestID: id, Type: network.ResourceTypeDocument, FrameID: "main",
+			Request: &network.Request{URL: url}, RedirectResponse: redirect,
+		}
+	}
+
+	tracer.handle(document("1", "http://a.test/", nil))
+	tracer.handle(&network.EventResponseReceivedExtraInfo{
+		RequestID: "1", StatusCode: 301,
+		Headers: network.Headers{"Set-Cookie": "sid=1; Path=/\ntrack=x"},
+	})
+	tracer.handle(document("1", "https://a.test/", &network.Response{URL: "http://a.test/", Status: 301}))
+	tracer.handle(&network.EventResponseReceived{RequestID: "1", Response: &network.Response{URL: "https://a.test/", Status: 200}})
+	// A subresource of another frame is not a hop.
+	tracer.handle(&network.EventRequestWillBeSent{
+		RequestID: "2", Type: network.ResourceTypeDocument, FrameID: "child",
+		Request: &network.Request{URL: "https://ads.test/"},
+	})
+	// Meta refresh / location.href assignment.
+	tracer.handle(document("3", "https://a.test/home", nil))
+	tracer.handle(&network.EventResponseReceived{RequestID: "3", Response: &network.Response{URL: "https://a.test/home", Status: 200}})
+
+	trace := tracer.trace("http://a.test/")
+	if trace.FinalURL != "https://a.test/home" {
+		t.Errorf("FinalURL = %q", trace.FinalURL)
+	}
+	want := []struct {
+		url, kind, location string
+		status              int64
+	}{
+		{"http://a.test/", HopHTTP, "https://a.test/", 301},
+		{"https://a.test/", HopClient, "https://a.test/home", 200},
+		{"https://a.test/home", HopFinal, "", 200},
+	}
+	if len(trace.Hops) != len(want) {
+		t.Fatalf("got %d hops, want %d: %+v", len(trace.Hops), len(want), trace.Hops)
+	}
+	for i, w := range want {
+		hop := trace.Hops[i]
+		if hop.URL != w.url || hop.Kind != w.kind || hop.Location != w.location || hop.Status != w.status {
+			t.Errorf("hop %d = %+v, want %+v", i, hop, w)
+		}
+	}
+	if got := trace.Hops[0].SetCookies; len(got) != 2 || got[0] != "sid=1; Path=/" {
+		t.Errorf("SetCookies = %v", got)
+	}
+	if len(trace.Hops[1].SetCookies) != 0 {
+		t.Errorf("unexpected cookies on second hop: %v", trace.Hops[1].SetCookies)
+	}
+}
@@ -0,0 +1,68 @@
+package logic
+
+import (
+	"sync"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+// resourceCollector collects the requests of a page load with the bytes they
+// transferred and decoded, in the order they were made.
+type resourceCollector struct {
+	mu        sync.Mutex
+	resources map[network.RequestID]*models.Resource
+	order     []network.RequestID
+}
+
+func newResourceCollector() *resourceCollector {
+	return &resourceCollector{resources: map[network.RequestID]*models.Resource{}}
+}
+
+// handle processes a single CDP event.
+func (c *resourceCollector) handle(ev interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch ev := ev.(type) {
+	case *network.EventRequestWillBeSent:
+		res, ok := c.resources[ev.RequestID]
+		if !ok {
+			res = &models.Resource{}
+			c.resources[ev.RequestID] = res
+			c.order = append(c.order, ev.RequestID)
+		}
+		// A redirect reuses the request ID; the resource is its last hop.
+		res.URL = ev.Request.URL
+		res.Type = ev.Type.String()
+	case *network.EventResponseReceived:
+		if res, ok := c.resources[ev.RequestID]; ok {
+			res.Status = ev.Response.Status
+		}
+	case *network.EventDataReceived:
+		if res, ok := c.resources[ev.RequestID]; ok {
+			res.DecodedBytes += ev.DataLength
+		}
+	case *network.EventLoadingFinished:
+		if res, ok := c.resources[ev.RequestID]; ok {
+			res.TransferBytes = int64(ev.EncodedDataLength)
+		}
+	case *network.EventLoadingFailed:
+		if res, ok := c.resources[ev.RequestID]; ok {
+			res.Failed = true
+		}
+	}
+}
+
+// list returns the collected resources.
+func (c *resourceCollector) list() []models.Resource {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	list := make([]models.Resource, 0, len(c.order))
+	for _, id := range c.order {
+		list = append(list, *c.resources[id])
+	}
+	return list
+}
@@ -0,0 +1,241 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/utils"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// savePageResourceDir is the directory, relative to the saved page, that
+// holds its subresources.
+const savePageResourceDir = "resources"
+
+// savedResourceTypes are the subresources stored by SavePage.
+var savedResourceTypes = map[network.ResourceType]bool{
+	network.ResourceTypeStylesheet: true,
+	network.ResourceTypeScript:     true,
+	network.ResourceTypeImage:      true,
+	network.ResourceTypeFont:       true,
+}
+
+// cssURLPattern matches url(...) references in stylesheets.
+var cssURLPattern = regexp.MustCompile(`url\(\s*(['"]?)([^'")]+)(['"]?)\s*\)`)
+
+// unsafeFileChars matches characters not kept in resource file names.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// serializeOfflineFunction serializes a copy of the rendered document with
+// references to saved resources replaced by their local paths. Rewritten
+// elements lose integrity/crossorigin, which would block loading from disk.
+const serializeOfflineFunction = `function(mapping) {
+	const local = (value) => {
+		try {
+			const u = new URL(value.trim(), document.baseURI);
+			u.hash = '';
+			return mapping[u.href];
+		} catch (e) {
+			return undefined;
+		}
+	};
+	const rewriteCSS = (text) => text.replace(/url\(\s*(['"]?)([^'")]+)\1\s*\)/g,
+		(match, quote, value) => { const p = local(value); return p ? 'url("' + p + '")' : match; });
+	const root = document.documentElement.cloneNode(true);
+	root.querySelectorAll('base').forEach((el) => el.remove());
+	for (const el of root.querySelectorAll('*')) {
+		let rewritten = false;
+		for (const attr of ['src', 'href', 'poster']) {
+			const value = el.getAttribute(attr);
+			const p = value && local(value);
+			if (p) {
+				el.setAttribute(attr, p);
+				rewritten = true;
+			}
+		}
+		const srcset = el.getAttribute('srcset');
+		if (srcset) {
+			el.setAttribute('srcset', srcset.split(',').map((candidate) => {
+				const parts = candidate.trim().split(/\s+/);
+				const p = parts[0] && local(parts[0]);
+				if (p) {
+					parts[0] = p;
+					rewritten = true;
+				}
+				return parts.join(' ');
+			}).join(', '));
+		}
+		const style = el.getAttribute('style');
+		if (style && style.includes('url(')) {
+			el.setAttribute('style', rewriteCSS(style));
+		}
+		if (el.tagName === 'STYLE') {
+			el.textContent = rewriteCSS(el.textContent);
+		}
+		if (rewritten) {
+			el.removeAttribute('integrity');
+			el.removeAttribute('crossorigin');
+		}
+	}
+	const doctype = document.doctype ? new XMLSerializer().serializeToString(document.doctype) + '\n' : '';
+	return doctype + root.outerHTML;
+}`
+
+// SavePage writes an offline copy of the rendered page into dir: index.html,
+// with links rewritten to the stylesheets, scripts, images and fonts stored
+// under dir/resources. Resource contents come from the browser session, so
+// pages behind a login are saved as the user sees them. dir is validated like
+// other output paths.
+func SavePage(ctx context.Context, targetURL, dir string) (*models.SavedPage, error) {
+	validatedDir, err := utils.ValidateFilePath(dir, false, ".")
+	if err != nil {
+		return nil, fmt.Errorf("invalid output directory: %w", err)
+	}
+
+	if targetURL != "" {
+		if err := chromedp.Run(ctx, chromedp.Navigate(targetURL)); err != nil {
+			return nil, fmt.Errorf("failed to navigate to %s: %w", targetURL, err)
+		}
+	}
+
+	var tree *page.FrameResourceTree
+	if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		tree, err = page.GetResourceTree().Do(ctx)
+		return err
+	})); err != nil {
+		return nil, fmt.Errorf("failed to list page resources: %w", err)
+	}
+
+	result := &models.SavedPage{URL: tree.Frame.URL, Resources: []models.SavedResource{}}
+	contents := map[string][]byte{}
+	mapping := map[string]string{}
+	for _, res := range tree.Resources {
+		key := stripFragment(res.URL)
+		if !savedResourceTypes[res.Type] || res.Failed || res.Canceled || mapping[key] != "" {
+			continue
+		}
+		if !strings.HasPrefix(key, "http://") && !strings.HasPrefix(key, "https://") {
+			continue
+		}
+		content, err := resourceContent(ctx, tree.Frame.ID, res.URL)
+		if err != nil {
+			result.Skipped = append(result.Skipped, res.URL)
+			continue
+		}
+		localPath := path.Join(savePageResourceDir, resourceFileName(key, res.MimeType))
+		mapping[key] = localPath
+		contents[localPath] = content
+		result.Resources = append(result.Resources, models.SavedResource{
+			URL:  res.URL,
+			Type: string(res.Type),
+			Path: localPath,
+		})
+	}
+
+	for _, res := range result.Resources {
+		content := contents[res.Path]
+		if res.Type == string(network.ResourceTypeStylesheet) {
+			content = rewriteStylesheet(content, res.URL, mapping)
+		}
+		if err := utils.SecureWriteFile(filepath.Join(validatedDir, filepath.FromSlash(res.Path)), content, 0644, validatedDir); err != nil {
+			return nil, fmt.Errorf("failed to save %s: %w", res.URL, err)
+		}
+	}
+
+	var html string
+	if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		id, err := isolatedContext(ctx)
+		if err != nil {
+			return err
+		}
+		return callOnGlobal(ctx, id, serializeOfflineFunction, &html, mapping)
+	})); err != nil {
+		return nil, fmt.Errorf("failed to serialize page: %w", err)
+	}
+
+	result.Index = filepath.Join(validatedDir, "index.html")
+	if err := utils.SecureWriteFile(result.Index, []byte(html), 0644, validatedDir); err != nil {
+		return nil, fmt.Errorf("failed to save %s: %w", result.Index, err)
+	}
+	return result, nil
+}
+
+// resourceContent returns the decoded content of a frame resource.
+func resourceContent(ctx context.Context, frameID cdp.FrameID, resourceURL string) ([]byte, error) {
+	var content []byte
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		content, err = page.GetResourceContent(frameID, resourceURL).Do(ctx)
+		return err
+	}))
+	return content, err
+}
+
+// resourceFileName returns a unique, filesystem-safe file name for a resource,
+// keeping its base name and adding an extension from the MIME type when the
+// URL has none.
+func resourceFileName(resourceURL, mimeType string) string {
+	sum := sha1.Sum([]byte(resourceURL))
+	prefix := hex.EncodeToString(sum[:4])
+
+	base := ""
+	if u, err := url.Parse(resourceURL); err == nil {
+		base = path.Base(u.Path)
+	}
+	base = strings.Trim(unsafeFileChars.ReplaceAllString(base, "_"), "._")
+	if len(base) > 60 {
+		base = base[len(base)-60:]
+	}
+	if path.Ext(base) == "" {
+		if exts, err := mime.ExtensionsByType(mimeType); err == nil && len(exts) > 0 {
+			base += exts[0]
+		}
+	}
+	if base == "" {
+		return prefix
+	}
+	return prefix + "-" + base
+}
+
+// rewriteStylesheet points url(...) references of a saved stylesheet at the
+// local copies, which live in the same directory.
+func rewriteStylesheet(css []byte, sheetURL string, mapping map[string]string) []byte {
+	base, err := url.Parse(sheetURL)
+	if err != nil {
+		return css
+	}
+	return cssURLPattern.ReplaceAllFunc(css, func(match []byte) []byte {
+		ref := cssURLPattern.FindSubmatch(match)[2]
+		target, err := base.Parse(strings.TrimSpace(string(ref)))
+		if err != nil {
+			return match
+		}
+		localPath, ok := mapping[stripFragment(target.String())]
+		if !ok {
+			return match
+		}
+		return []byte(`url("` + path.Base(localPath) + `")`)
+	})
+}
+
+// stripFragment removes the #fragment of a URL.
+func stripFragment(rawURL string) string {
+	if i := strings.IndexByte(rawURL, '#'); i >= 0 {
+		return rawURL[:i]
+	}
+	return rawURL
+}
@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResourceFileName(t *testing.T) {
+	tests := []struct {
+		url, mimeType, suffix string
+	}{
+		{"https://example.com/css/site.css?v=3", "text/css", "-site.css"},
+		{"https://example.com/img/logo", "image/png", "-logo.png"},
+		{"https://example.com/fonts/a%20b.woff2", "font/woff2", "-a_b.woff2"},
+		{"https://example.com/", "", ""},
+	}
+	for _, tt := range tests {
+		got := resourceFileName(tt.url, tt.mimeType)
+		if len(got) < 8 || !strings.HasSuffix(got, tt.suffix) {
+			t.Errorf("resourceFileName(%q) = %q, want hash prefix and suffix %q", tt.url, got, tt.suffix)
+		}
+		if strings.ContainsAny(got, `/\?%`) {
+			t.Errorf("resourceFileName(%q) = %q contains unsafe characters", tt.url, got)
+		}
+	}
+	if resourceFileName("https://a.test/x.css", "") == resourceFileName("https://b.test/x.css", "") {
+		t.Error("expected different names for different URLs")
+	}
+}
+
+func TestRewriteStylesheet(t *testing.T) {
+	mapping := map[string]string{
+		"https://example.com/fonts/a.woff2": "resources/1234-a.woff2",
+		"https://example.com/img/bg.png":    "resources/5678-bg.png",
+	}
+	css := `@font-face { src: url('../fonts/a.woff2#iefix') } body { background: url( /img/bg.png ) } .x { background: url(other.png) }`
+	got := string(rewriteStylesheet([]byte(css), "https://example.com/css/site.css", mapping))
+
+	for _, want := range []string{`url("1234-a.woff2")`, `url("5678-bg.png")`, `url(other.png)`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rewritten CSS missing %q: %s", want, got)
+		}
+	}
+}
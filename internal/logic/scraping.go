@@ -2,154 +2,404 @@ package logic
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"log"
-	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"browser-tools-go/internal/i18n"
 	"browser-tools-go/internal/models"
+	"browser-tools-go/internal/utils"
 
 	md "github.com/JohannesKaufmann/html-to-markdown"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/chromedp/chromedp"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-// Search performs a Google search and returns the results.
-func Search(ctx context.Context, query string, numResults int, fetchContent bool) ([]models.SearchResult, error) {
-	searchURL := fmt.Sprintf("https://www.google.com/search?q=%s", url.QueryEscape(query))
+// DefaultContentConcurrency is the number of tabs search fetches result content on in parallel.
+const DefaultContentConcurrency = 3
 
-	err := chromedp.Run(ctx,
-		chromedp.Navigate(searchURL),
-		chromedp.WaitVisible("div#search"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to navigate to google and wait for results: %w", err)
-	}
-
-	var searchResultsJSON string
-	script := `
-		(() => {
-			const results = [];
-			const items = document.querySelectorAll('div#search div.g');
-			for (let i = 0; i < items.length; i++) {
-				const item = items[i];
-				const titleEl = item.querySelector('h3');
-				const linkEl = item.querySelector('a');
-				const snippetEl = item.querySelector('div.VwiC3b');
-				if (titleEl && linkEl && snippetEl) {
-					results.push({
-						title: titleEl.innerText,
-						link: linkEl.href,
-						snippet: snippetEl.innerText
-					});
-				}
-			}
-			return JSON.stringify(results);
-		})();
-	`
-	err = chromedp.Run(ctx, chromedp.Evaluate(script, &searchResultsJSON))
+// Search performs a Google search and returns up to numResults web results,
+// followed by up to numResults results of each vertical of include (see
+// SearchVerticals). With fetchContent, the content of the results is fetched
+// in parallel on tabs of their own.
+func Search(ctx context.Context, query string, numResults int, fetchContent bool, include []string) ([]models.SearchResult, error) {
+	if err := CheckSearchVerticals(include); err != nil {
+		return nil, err
+	}
+	results, err := searchPage(ctx, searchURL(query, ""), "div#search", searchResultsScript, numResults)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract search results with script: %w", err)
+		return nil, err
 	}
-
-	var rawResults []map[string]string
-	if err := json.Unmarshal([]byte(searchResultsJSON), &rawResults); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal search results: %w", err)
+	for _, name := range include {
+		vertical := searchVerticals[name]
+		verticalResults, err := searchPage(ctx, searchURL(query, vertical.tbm), vertical.wait, vertical.script, numResults)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		results = append(results, verticalResults...)
 	}
 
-	minLen := len(rawResults)
-	if numResults > 0 && numResults < minLen {
-		minLen = numResults
+	if fetchContent {
+		results, _ = fetchContentForResults(ctx, results, DefaultContentConcurrency)
 	}
 
-	results := make([]models.SearchResult, minLen)
-	for i := 0; i < minLen; i++ {
-		results[i] = models.SearchResult{
-			Title:   rawResults[i]["title"],
-			Link:    rawResults[i]["link"],
-			Snippet: rawResults[i]["snippet"],
+	return results, nil
+}
+
+// ContentOptions controls how GetContent extracts and formats a page.
+type ContentOptions struct {
+	Format string
+	// Selector limits extraction to the first element matching this CSS selector.
+	// When it matches nothing, the content fallbacks from Selectors are tried in order.
+	Selector  string
+	Selectors *utils.SelectorConfig
+	// Strip lists CSS selectors removed from the extracted HTML before formatting.
+	Strip []string
+	// MaxChars and MaxTokens cap the formatted content; zero means unlimited.
+	MaxChars  int
+	MaxTokens int
+	// FollowNext merges paginated articles by following rel=next / "next page" links.
+	FollowNext bool
+	// MaxPages caps the number of pages merged by FollowNext; zero means DefaultMaxPages.
+	MaxPages int
+}
+
+// DefaultMaxPages is the page limit applied to FollowNext when MaxPages is unset.
+const DefaultMaxPages = 10
+
+// DefaultStripSelectors are the boilerplate elements removed from content by default.
+var DefaultStripSelectors = []string{"script", "style", "noscript", "nav", "footer", "aside"}
+
+// charsPerToken is the rough characters-per-token ratio used for --max-tokens.
+const charsPerToken = 4
+
+// GetContent extracts content from a URL or the current page.
+func GetContent(ctx context.Context, targetURL string, opts ContentOptions) (map[string]interface{}, error) {
+	ctx, span := startSpan(ctx, "extract content", attribute.String("browser_tools.format", opts.Format))
+	result, err := getContent(ctx, targetURL, opts)
+	endSpan(span, err)
+	return result, err
+}
+
+// getContent implements GetContent.
+func getContent(ctx context.Context, targetURL string, opts ContentOptions) (map[string]interface{}, error) {
+	if targetURL != "" {
+		if err := navigateAndWait(ctx, targetURL); err != nil {
+			return nil, err
 		}
 	}
 
+	page, err := extractPageContent(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
 
-	if fetchContent {
-		for i := range results {
-			var content string
-			err := chromedp.Run(ctx,
-				chromedp.Navigate(results[i].Link),
-				chromedp.WaitVisible("body"),
-				chromedp.Evaluate("document.body.innerText", &content),
-			)
-			if err != nil {
-				log.Printf("Warning: could not fetch content for %s: %v\n", results[i].Link, err)
-				continue
-			}
-			if len(content) > 2000 {
-				content = content[:2000] + "..."
-			}
-			results[i].Content = content
-		}
+	if targetURL == "" {
+		targetURL = page.url
 	}
 
-	return results, nil
+	processedContent := page.content
+	pages := []string{page.url}
+	if opts.FollowNext {
+		processedContent = pageMarker(opts.Format, 1, page.url) + processedContent
+		processedContent += followNextPages(ctx, opts, &pages)
+	}
+	processedContent, truncated := truncateContent(processedContent, opts.MaxChars, opts.MaxTokens)
+
+	result := map[string]interface{}{
+		"title":     page.title,
+		"content":   processedContent,
+		"format":    opts.Format,
+		"url":       targetURL,
+		"truncated": truncated,
+	}
+	if page.language != "" {
+		result["language"] = page.language
+	}
+	if page.charset != "" {
+		result["charset"] = page.charset
+	}
+	if opts.Selector != "" {
+		result["selector"] = page.selector
+	}
+	if opts.FollowNext {
+		result["pages"] = pages
+	}
+	return result, nil
 }
 
-// GetContent extracts content from a URL or the current page.
-func GetContent(ctx context.Context, targetURL, format string) (map[string]interface{}, error) {
-	if targetURL != "" {
-		err := chromedp.Run(ctx,
-			chromedp.Navigate(targetURL),
-			chromedp.WaitVisible("body"),
-		)
+// pageContent is the formatted content of a single page.
+type pageContent struct {
+	title    string
+	url      string
+	selector string
+	content  string
+	language string
+	charset  string
+}
+
+// documentInfoScript collects the declared language, the charset and a text sample of the page.
+const documentInfoScript = `
+	(() => {
+		const meta = document.querySelector('meta[http-equiv="content-language" i]');
+		return {
+			lang: document.documentElement.lang || (meta ? meta.content : ''),
+			charset: document.characterSet || '',
+			sample: document.body ? document.body.innerText.slice(0, 5000) : '',
+		};
+	})();
+`
+
+// documentInfo is the result of documentInfoScript.
+type documentInfo struct {
+	Lang    string `json:"lang"`
+	Charset string `json:"charset"`
+	Sample  string `json:"sample"`
+}
+
+// navigateAndWait navigates to targetURL and waits for the body to render.
+func navigateAndWait(ctx context.Context, targetURL string) (err error) {
+	ctx, span := startSpan(ctx, "navigate", attribute.String("url.full", targetURL))
+	defer func() { endSpan(span, err) }()
+
+	err = chromedp.Run(ctx,
+		chromedp.Navigate(targetURL),
+		chromedp.WaitVisible("body"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to navigate to '%s': %w", targetURL, err)
+	}
+	return nil
+}
+
+// extractPageContent extracts, strips and formats the content of the current page.
+func extractPageContent(ctx context.Context, opts ContentOptions) (*pageContent, error) {
+	root := "body"
+	if opts.Selector != "" {
+		candidates := []string{opts.Selector}
+		if opts.Selectors != nil && opts.Selectors.Content != nil {
+			candidates = append(candidates, opts.Selectors.Content.Main...)
+		}
+		matched, err := firstExistingSelector(ctx, candidates)
 		if err != nil {
-			return nil, fmt.Errorf("failed to navigate to '%s': %w", targetURL, err)
+			return nil, err
+		}
+		if matched != opts.Selector {
+			i18n.Printf("Selector '%s' matched nothing, falling back to '%s'", opts.Selector, matched)
 		}
+		root = matched
 	}
 
 	var content, title, currentURL string
+	var info documentInfo
 	err := chromedp.Run(ctx,
-		chromedp.InnerHTML("body", &content),
+		chromedp.InnerHTML(root, &content, chromedp.ByQuery),
 		chromedp.Title(&title),
 		chromedp.Location(&currentURL),
+		EvaluateIsolated(documentInfoScript, &info),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract page content: %w", err)
 	}
 
-	if targetURL == "" {
-		targetURL = currentURL
+	// The browser has already decoded the page; a UTF-8 document served with a
+	// legacy charset label shows up as mojibake, which is reversed here.
+	if !isUTF8Charset(info.Charset) {
+		if repaired, ok := repairMislabeledText(content, info.Charset); ok {
+			i18n.Printf("Repaired UTF-8 content mislabeled as %s", info.Charset)
+			content = repaired
+			title, _ = repairMislabeledText(title, info.Charset)
+			info.Sample, _ = repairMislabeledText(info.Sample, info.Charset)
+		}
+	}
+	content = strings.ToValidUTF8(content, "�")
+
+	if len(opts.Strip) > 0 {
+		content, err = stripElements(content, opts.Strip)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	processedContent, err := formatContent(content, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pageContent{
+		title:    title,
+		url:      currentURL,
+		selector: root,
+		content:  processedContent,
+		language: detectLanguage(info.Lang, info.Sample),
+		charset:  info.Charset,
+	}, nil
+}
+
+// findNextPageScript returns the URL of the next page of a paginated document, or an empty string.
+const findNextPageScript = `
+	(() => {
+		const rel = document.querySelector('link[rel~="next"][href], a[rel~="next"][href]');
+		if (rel) return rel.href;
+		const patterns = [
+			/^next(\s+page)?\s*[›»>→]*$/i,
+			/^[›»→]$/,
+			/^次(のページ)?へ?\s*[›»>→]*$/,
+		];
+		for (const a of document.querySelectorAll('a[href]')) {
+			const text = (a.innerText || a.getAttribute('aria-label') || '').trim();
+			if (patterns.some(p => p.test(text))) return a.href;
+		}
+		return '';
+	})();
+`
+
+// followNextPages follows next-page links from the current page until none remain,
+// a page repeats, or MaxPages is reached. It returns the merged content of the
+// additional pages and appends their URLs to pages.
+func followNextPages(ctx context.Context, opts ContentOptions, pages *[]string) string {
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+
+	visited := map[string]bool{(*pages)[0]: true}
+	var merged strings.Builder
+	for len(*pages) < maxPages {
+		var next string
+		if err := chromedp.Run(ctx, EvaluateIsolated(findNextPageScript, &next)); err != nil {
+			i18n.Printf("Warning: could not look for a next page: %v", err)
+			break
+		}
+		if next == "" || visited[next] {
+			break
+		}
+		visited[next] = true
+
+		i18n.Printf("📄 Following next page: %s", next)
+		if err := navigateAndWait(ctx, next); err != nil {
+			i18n.Printf("Warning: %v", err)
+			break
+		}
+		page, err := extractPageContent(ctx, opts)
+		if err != nil {
+			i18n.Printf("Warning: could not extract content from %s: %v", next, err)
+			break
+		}
+
+		*pages = append(*pages, page.url)
+		merged.WriteString(pageMarker(opts.Format, len(*pages), page.url))
+		merged.WriteString(page.content)
+	}
+	return merged.String()
+}
+
+// pageMarker returns the separator placed before each page of a merged document.
+func pageMarker(format string, page int, pageURL string) string {
+	prefix := ""
+	if page > 1 {
+		prefix = "\n\n"
+	}
+	switch format {
+	case "text":
+		return fmt.Sprintf("%s----- Page %d: %s -----\n\n", prefix, page, pageURL)
+	default:
+		return fmt.Sprintf("%s<!-- page %d: %s -->\n\n", prefix, page, pageURL)
 	}
+}
 
-	var processedContent string
+// formatContent converts an HTML fragment into the requested output format.
+func formatContent(content, format string) (string, error) {
 	switch format {
 	case "text":
 		doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse html: %w", err)
+			return "", fmt.Errorf("failed to parse html: %w", err)
 		}
-		processedContent = strings.TrimSpace(doc.Find("body").Text())
+		return strings.TrimSpace(doc.Find("body").Text()), nil
 	case "markdown":
 		converter := md.NewConverter("", true, nil)
-		processedContent, err = converter.ConvertString(content)
+		markdown, err := converter.ConvertString(content)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert to markdown: %w", err)
+			return "", fmt.Errorf("failed to convert to markdown: %w", err)
 		}
+		return markdown, nil
 	case "html":
-		processedContent = content
+		return content, nil
 	default:
-		return nil, fmt.Errorf("unsupported format: %s", format)
+		return "", fmt.Errorf("unsupported format: %s", format)
 	}
+}
 
-	result := map[string]interface{}{
-		"title":   title,
-		"content": processedContent,
-		"format":  format,
-		"url":     targetURL,
+// stripElements removes every element matching the given selectors from an HTML fragment.
+func stripElements(content string, selectors []string) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse html: %w", err)
 	}
-	return result, nil
+	for _, selector := range selectors {
+		selector = strings.TrimSpace(selector)
+		if selector == "" {
+			continue
+		}
+		doc.Find(selector).Remove()
+	}
+	stripped, err := doc.Find("body").Html()
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize html: %w", err)
+	}
+	return stripped, nil
+}
+
+// truncateContent cuts content to the tighter of maxChars and maxTokens (estimated
+// at charsPerToken characters per token) and reports whether anything was removed.
+func truncateContent(content string, maxChars, maxTokens int) (string, bool) {
+	limit := maxChars
+	if maxTokens > 0 && (limit <= 0 || maxTokens*charsPerToken < limit) {
+		limit = maxTokens * charsPerToken
+	}
+	if limit <= 0 {
+		return content, false
+	}
+
+	runes := []rune(content)
+	if len(runes) <= limit {
+		return content, false
+	}
+	return string(runes[:limit]), true
+}
+
+// selectorExistsFunction reports whether an element matches the selector it
+// is called with.
+const selectorExistsFunction = `function(selector) {
+	return document.querySelector(selector) !== null;
+}`
+
+// firstExistingSelector returns the first candidate that matches an element on the current page.
+func firstExistingSelector(ctx context.Context, candidates []string) (string, error) {
+	for _, selector := range candidates {
+		if selector == "" {
+			continue
+		}
+		var exists bool
+		err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+			id, err := isolatedContext(ctx)
+			if err != nil {
+				return err
+			}
+			return callOnGlobal(ctx, id, selectorExistsFunction, &exists, selector)
+		}))
+		if err != nil {
+			i18n.Printf("Selector '%s' could not be evaluated: %v", selector, err)
+			continue
+		}
+		if exists {
+			return selector, nil
+		}
+	}
+	return "", fmt.Errorf("no element matched selectors: %s", strings.Join(candidates, ", "))
 }
 
 // HnScraper scrapes top stories from Hacker News.
@@ -165,12 +415,12 @@ func HnScraper(ctx context.Context, limit int) ([]models.HnSubmission, error) {
 
 	var titles, urls, scoreTexts, authorTexts, timeTexts, commentTexts []string
 	err = chromedp.Run(ctx,
-		chromedp.Evaluate(`Array.from(document.querySelectorAll('span.titleline > a')).map(a => a.textContent)`, &titles),
-		chromedp.Evaluate(`Array.from(document.querySelectorAll('span.titleline > a')).map(a => a.href)`, &urls),
-		chromedp.Evaluate(`Array.from(document.querySelectorAll('.score')).map(el => el.textContent)`, &scoreTexts),
-		chromedp.Evaluate(`Array.from(document.querySelectorAll('.hnuser')).map(el => el.textContent)`, &authorTexts),
-		chromedp.Evaluate(`Array.from(document.querySelectorAll('span.age a')).map(el => el.title || el.textContent)`, &timeTexts),
-		chromedp.Evaluate(`Array.from(document.querySelectorAll('td.subtext > a')).filter(a => a.textContent.includes('comment')).map(a => a.textContent)`, &commentTexts),
+		EvaluateIsolated(`Array.from(document.querySelectorAll('span.titleline > a')).map(a => a.textContent)`, &titles),
+		EvaluateIsolated(`Array.from(document.querySelectorAll('span.titleline > a')).map(a => a.href)`, &urls),
+		EvaluateIsolated(`Array.from(document.querySelectorAll('.score')).map(el => el.textContent)`, &scoreTexts),
+		EvaluateIsolated(`Array.from(document.querySelectorAll('.hnuser')).map(el => el.textContent)`, &authorTexts),
+		EvaluateIsolated(`Array.from(document.querySelectorAll('span.age a')).map(el => el.title || el.textContent)`, &timeTexts),
+		EvaluateIsolated(`Array.from(document.querySelectorAll('td.subtext > a')).filter(a => a.textContent.includes('comment')).map(a => a.textContent)`, &commentTexts),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract data from hacker news: %w", err)
@@ -2,19 +2,22 @@ package logic
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"log"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"browser-tools-go/internal/i18n"
 	"browser-tools-go/internal/models"
 	"browser-tools-go/internal/utils"
 
-	md "github.com/JohannesKaufmann/html-to-markdown"
-	"github.com/PuerkitoBio/goquery"
 	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/runtime"
 	"github.com/chromedp/chromedp"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // FetchWithRetry はリトライ機能付きでWebページをフェッチします
@@ -41,12 +44,22 @@ func FetchWithRetry(ctx context.Context, targetURL string, maxRetries int) error
 			return false
 		},
 		OnRetry: func(attempt int, err error) {
-			log.Printf("Retry %d/3 for %s: %v", attempt, targetURL, err)
+			i18n.Printf("Retry %d/3 for %s: %v", attempt, targetURL, err)
+			if OnRetry != nil {
+				OnRetry(err)
+			}
 		},
 	}
 
+	attempt := 0
 	fetchFn := func() error {
-		return chromedp.Run(ctx, chromedp.Navigate(targetURL))
+		attempt++
+		attemptCtx, span := startSpan(ctx, "navigate attempt",
+			attribute.String("url.full", targetURL),
+			attribute.Int("browser_tools.attempt", attempt))
+		err := chromedp.Run(attemptCtx, chromedp.Navigate(targetURL))
+		endSpan(span, err)
+		return err
 	}
 
 	return utils.Retry(ctx, fetchFn, retryConfig)
@@ -71,7 +84,7 @@ func EnhancedSearch(ctx context.Context, query string, numResults int, fetchCont
 		if err == nil {
 			break
 		}
-		log.Printf("Fallback selector failed: %s", selector)
+		i18n.Printf("Fallback selector failed: %s", selector)
 	}
 
 	// 検索結果抽出
@@ -89,7 +102,7 @@ func EnhancedSearch(ctx context.Context, query string, numResults int, fetchCont
 
 	// コンテンツ取得
 	if fetchContent {
-		resultsWithContent, err := fetchContentForResults(ctx, results, 3)
+		resultsWithContent, err := fetchContentForResults(ctx, results, DefaultContentConcurrency)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch content: %w", err)
 		}
@@ -109,12 +122,12 @@ func extractSearchResults(ctx context.Context, selectors *utils.GoogleSearchSele
 			for _, snippetSelector := range selectors.Snippet {
 				results, err := tryExtractOneStrategy(ctx, itemSelector, titleSelector, snippetSelector)
 				if err == nil && len(results) > 0 {
-					log.Printf("Successfully extracted %d results with selectors: item=%s, title=%s, snippet=%s",
+					i18n.Printf("Successfully extracted %d results with selectors: item=%s, title=%s, snippet=%s",
 						len(results), itemSelector, titleSelector, snippetSelector)
 					return results, nil
 				}
 				lastErr = err
-				log.Printf("Selector strategy failed: item=%s, title=%s, snippet=%s: %v",
+				i18n.Printf("Selector strategy failed: item=%s, title=%s, snippet=%s: %v",
 					itemSelector, titleSelector, snippetSelector, err)
 			}
 		}
@@ -132,35 +145,46 @@ func tryExtractOneStrategy(ctx context.Context, itemSel, titleSel, snippetSel st
 	}
 
 	results := make([]models.SearchResult, 0, len(items))
-	// セレクタエスケープ
-	escapedTitleSel := utils.FormatSelectorForJS(titleSel)
-	escapedSnippetSel := utils.FormatSelectorForJS(snippetSel)
+	// JavaScriptによる要素抽出（セレクタは引数として渡す）
+	extractScript := `
+		function(titleSel, snippetSel) {
+			const item = this;
+			const titleEl = item.querySelector(titleSel);
+			const linkEls = item.querySelectorAll('a');
+			const snippetEl = item.querySelector(snippetSel);
+
+			const title = titleEl ? titleEl.innerText : '';
+			const snippet = snippetEl ? snippetEl.innerText : '';
+			const link = linkEls[0] ? linkEls[0].href : '';
+
+			return {title, snippet, link};
+		}
+	`
+
+	var contextID runtime.ExecutionContextID
+	if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) (err error) {
+		contextID, err = isolatedContext(ctx)
+		return err
+	})); err != nil {
+		return nil, err
+	}
 
 	for i, item := range items {
-		// JavaScriptによる要素抽出
-		var titleText, snippetText, linkHref string
-
-		extractScript := fmt.Sprintf(`
-			(() => {
-				const item = this;
-				const titleEl = item.querySelector('%s');
-				const linkEls = item.querySelectorAll('a');
-				const snippetEl = item.querySelector('%s');
-
-				const title = titleEl ? titleEl.innerText : '';
-				const snippet = snippetEl ? snippetEl.innerText : '';
-				const link = linkEls[0] ? linkEls[0].href : '';
-
-				return {title, snippet, link};
-			}).call(this);
-		`, escapedTitleSel, escapedSnippetSel)
-
 		var extractResult map[string]string
-		err := chromedp.Run(ctx, chromedp.Evaluate(extractScript, &extractResult, func(p *cdproto.RuntimeEvaluateParams) *cdproto.RuntimeEvaluateParams {
-			return p.WithObjectID(item.ObjectID)
+		err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+			remoteObject, err := resolveNodeIsolated(ctx, item.NodeID, contextID)
+			if err != nil {
+				return err
+			}
+			return chromedp.CallFunctionOn(extractScript, &extractResult,
+				func(p *runtime.CallFunctionOnParams) *runtime.CallFunctionOnParams {
+					return p.WithObjectID(remoteObject.ObjectID)
+				},
+				titleSel, snippetSel,
+			).Do(ctx)
 		}))
 		if err != nil {
-			log.Printf("Failed to extract from item %d: %v", i, err)
+			i18n.Printf("Failed to extract from item %d: %v", i, err)
 			continue
 		}
 
@@ -169,6 +193,7 @@ func tryExtractOneStrategy(ctx context.Context, itemSel, titleSel, snippetSel st
 				Title:   extractResult["title"],
 				Link:    extractResult["link"],
 				Snippet: extractResult["snippet"],
+				Type:    SearchTypeOrganic,
 			})
 		}
 	}
@@ -180,41 +205,55 @@ func tryExtractOneStrategy(ctx context.Context, itemSel, titleSel, snippetSel st
 	return results, nil
 }
 
-// fetchContentForResults は検索結果のコンテンツを取得します
+// fetchContentForResults は検索結果のコンテンツを、最大maxConcurrent個の専用タブで並列に取得します
 func fetchContentForResults(ctx context.Context, results []models.SearchResult, maxConcurrent int) ([]models.SearchResult, error) {
-	// 並列度を制限したコンテンツ取得
-	semaphore := make(chan struct{}, maxConcurrent)
+	// 各ゴルーチンはプールから借りたタブだけを操作するため、タブを共有しません
+	pool := NewTabPool(ctx, maxConcurrent)
+	defer pool.Close()
 
+	var wg sync.WaitGroup
 	for i := range results {
-		semaphore <- struct{}{}
+		wg.Add(1)
 		go func(idx int) {
-			defer func() { <-semaphore }()
-
-			var content string
-			err := chromedp.Run(ctx,
-				chromedp.Navigate(results[idx].Link),
-				chromedp.WaitVisible("body", chromedp.BySearch),
-				chromedp.Evaluate("document.body.innerText", &content, chromedp.EvalIgnoreExceptions),
-			)
+			defer wg.Done()
+			err := pool.Do(ctx, func(tabCtx context.Context) error {
+				content, err := fetchResultContent(tabCtx, results[idx].Link)
+				if err != nil {
+					return err
+				}
+				results[idx].Content = content
+				return nil
+			})
 			if err != nil {
-				log.Printf("Warning: could not fetch content for %s: %v", results[idx].Link, err)
-				return
-			}
-
-			// コンテンツの切り詰め
-			if len(content) > 2000 {
-				content = content[:2000] + "..."
+				i18n.Printf("Warning: could not fetch content for %s: %v", results[idx].Link, err)
 			}
-			results[idx].Content = content
 		}(i)
 	}
+	wg.Wait()
 
-	// すべてのゴルーチンが完了するまで待機
-	for i := 0; i < cap(semaphore); i++ {
-		semaphore <- struct{}{}
+	return results, nil
+}
+
+// fetchResultContent はリンク先に移動し、切り詰めた本文テキストを返します
+func fetchResultContent(ctx context.Context, link string) (string, error) {
+	if err := CheckHostPolicy(ctx, link); err != nil {
+		return "", err
+	}
+	var content string
+	err := chromedp.Run(ctx,
+		chromedp.Navigate(link),
+		chromedp.WaitVisible("body", chromedp.BySearch),
+		EvaluateIsolated("document.body.innerText", &content, chromedp.EvalIgnoreExceptions),
+	)
+	if err != nil {
+		return "", err
 	}
 
-	return results, nil
+	// コンテンツの切り詰め
+	if len(content) > 2000 {
+		content = content[:2000] + "..."
+	}
+	return content, nil
 }
 
 // EnhancedHnScraper は強化版Hacker Newsスクレイパーです
@@ -252,9 +291,9 @@ func EnhancedHnScraper(ctx context.Context, limit int, config *utils.SelectorCon
 func extractHnData(ctx context.Context, limit int, selectors *utils.HackerNewsSelectors) ([]models.HnSubmission, error) {
 	var titles, urls, scoreTexts, authorTexts, timeTexts, commentTexts []string
 
-	// 改良版抽出ロジック
-	extractScript := fmt.Sprintf(`
-		(() => {
+	// 改良版抽出ロジック（セレクタは引数として渡す）
+	extractScript := `
+		function(titleSel, scoreSel, authorSel, timeSel) {
 			const titles = [];
 			const urls = [];
 			const scores = [];
@@ -263,28 +302,28 @@ func extractHnData(ctx context.Context, limit int, selectors *utils.HackerNewsSe
 			const comments = [];
 
 			// タイトルとURLの抽出
-			const titleLinks = document.querySelectorAll('%s');
+			const titleLinks = document.querySelectorAll(titleSel);
 			titleLinks.forEach(el => {
 				titles.push(el.textContent.trim());
 				urls.push(el.href);
 			});
 
 			// スコアの抽出
-			const scoreEls = document.querySelectorAll('%s');
+			const scoreEls = document.querySelectorAll(scoreSel);
 			scoreEls.forEach(el => scores.push(el.textContent));
 
 			// 著者の抽出
-			const authorEls = document.querySelectorAll('%s');
+			const authorEls = document.querySelectorAll(authorSel);
 			authorEls.forEach(el => authors.push(el.textContent));
 
 			// 時間の抽出
-			const timeEls = document.querySelectorAll('%s');
+			const timeEls = document.querySelectorAll(timeSel);
 			timeEls.forEach(el => times.push(el.textContent || el.title));
 
 			// コメント数の抽出
 			const commentEls = document.querySelectorAll('td.subtext > a');
 			commentEls.forEach(el => {
-				if (el.textContent.includes('comment') || el.textContent.match(/\\d+\\s*comments?/i)) {
+				if (el.textContent.includes('comment') || el.textContent.match(/\d+\s*comments?/i)) {
 					comments.push(el.textContent);
 				}
 			});
@@ -292,28 +331,34 @@ func extractHnData(ctx context.Context, limit int, selectors *utils.HackerNewsSe
 			return {
 				titles, urls, scores, authors, times, comments
 			};
-		})();
-	`,
-		utils.JoinSelectors(selectors.TitleLink),
-		utils.JoinSelectors(selectors.Score),
-		utils.JoinSelectors(selectors.Author),
-		utils.JoinSelectors(selectors.Time),
-	)
+		}
+	`
 
-	err := chromedp.Run(ctx, chromedp.Evaluate(extractScript, &map[string]interface{}{
-		"titles":   &titles,
-		"urls":     &urls,
-		"scores":   &scoreTexts,
-		"authors":  &authorTexts,
-		"times":    &timeTexts,
-		"comments": &commentTexts,
-	}, chromedp.EvalIgnoreExceptions))
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		contextID, err := isolatedContext(ctx)
+		if err != nil {
+			return err
+		}
+		return callOnGlobal(ctx, contextID, extractScript, &map[string]interface{}{
+			"titles":   &titles,
+			"urls":     &urls,
+			"scores":   &scoreTexts,
+			"authors":  &authorTexts,
+			"times":    &timeTexts,
+			"comments": &commentTexts,
+		},
+			utils.JoinSelectors(selectors.TitleLink),
+			utils.JoinSelectors(selectors.Score),
+			utils.JoinSelectors(selectors.Author),
+			utils.JoinSelectors(selectors.Time),
+		)
+	}))
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract hacker news data: %w", err)
 	}
 
 	// 結果の構築
-	minLen := utils.Min(len(titles), limit)
+	minLen := Min(len(titles), limit)
 	if limit <= 0 || limit > len(titles) {
 		minLen = len(titles)
 	}
@@ -59,3 +59,92 @@ func TestGetContentFormatting(t *testing.T) {
 		}
 	})
 }
+
+func TestFormatContent(t *testing.T) {
+	fragment := `<h1>Title</h1><p>Body <a href="https://example.com">link</a></p>`
+
+	t.Run("text", func(t *testing.T) {
+		got, err := formatContent(fragment, "text")
+		if err != nil {
+			t.Fatalf("formatContent failed: %v", err)
+		}
+		if got != "TitleBody link" {
+			t.Errorf("unexpected text output: %q", got)
+		}
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		got, err := formatContent(fragment, "markdown")
+		if err != nil {
+			t.Fatalf("formatContent failed: %v", err)
+		}
+		if !strings.Contains(got, "# Title") || !strings.Contains(got, "[link](https://example.com)") {
+			t.Errorf("unexpected markdown output: %q", got)
+		}
+	})
+
+	t.Run("html", func(t *testing.T) {
+		got, err := formatContent(fragment, "html")
+		if err != nil {
+			t.Fatalf("formatContent failed: %v", err)
+		}
+		if got != fragment {
+			t.Errorf("expected html to be unchanged, got %q", got)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		if _, err := formatContent(fragment, "pdf"); err == nil {
+			t.Error("expected error for unsupported format")
+		}
+	})
+}
+
+func TestStripElements(t *testing.T) {
+	fragment := `<nav>Menu</nav><div class="ads">Buy now</div><p>Article</p><script>track()</script>`
+
+	got, err := stripElements(fragment, []string{"nav", ".ads", "script", ""})
+	if err != nil {
+		t.Fatalf("stripElements failed: %v", err)
+	}
+	if got != "<p>Article</p>" {
+		t.Errorf("unexpected stripped html: %q", got)
+	}
+}
+
+func TestTruncateContent(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		maxChars      int
+		maxTokens     int
+		expected      string
+		wantTruncated bool
+	}{
+		{"unlimited", "hello world", 0, 0, "hello world", false},
+		{"under limit", "hello", 10, 0, "hello", false},
+		{"max chars", "hello world", 5, 0, "hello", true},
+		{"max tokens", "hello world", 0, 2, "hello wo", true},
+		{"tighter limit wins", "hello world", 3, 2, "hel", true},
+		{"multibyte", "こんにちは世界", 5, 0, "こんにちは", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := truncateContent(tt.content, tt.maxChars, tt.maxTokens)
+			if got != tt.expected || truncated != tt.wantTruncated {
+				t.Errorf("truncateContent(%q, %d, %d) = (%q, %t), want (%q, %t)",
+					tt.content, tt.maxChars, tt.maxTokens, got, truncated, tt.expected, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestPageMarker(t *testing.T) {
+	if got := pageMarker("markdown", 1, "https://example.com/a"); got != "<!-- page 1: https://example.com/a -->\n\n" {
+		t.Errorf("unexpected first markdown marker: %q", got)
+	}
+	if got := pageMarker("text", 2, "https://example.com/b"); got != "\n\n----- Page 2: https://example.com/b -----\n\n" {
+		t.Errorf("unexpected text marker: %q", got)
+	}
+}
@@ -0,0 +1,263 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"browser-tools-go/internal/i18n"
+
+	"github.com/dop251/goja"
+)
+
+// defaultScriptWait is the waitFor timeout when a script doesn't give one.
+const defaultScriptWait = 30 * time.Second
+
+// scriptNavigateOptions are the options of browser.navigate in scripts.
+type scriptNavigateOptions struct {
+	WaitUntil          string `json:"waitUntil"`
+	Referrer           string `json:"referrer"`
+	Transition         string `json:"transition"`
+	BypassSW           bool   `json:"bypassServiceWorker"`
+	FailOnConsoleError bool   `json:"failOnConsoleError"`
+}
+
+// scriptExtractOptions are the options of browser.extract in scripts.
+type scriptExtractOptions struct {
+	URL      string `json:"url"`
+	Format   string `json:"format"`
+	Selector string `json:"selector"`
+}
+
+// scriptScreenshotOptions are the options of browser.screenshot in scripts.
+type scriptScreenshotOptions struct {
+	URL      string `json:"url"`
+	FullPage bool   `json:"fullPage"`
+}
+
+// scriptWaitOptions are the options of browser.waitFor in scripts.
+type scriptWaitOptions struct {
+	Timeout int64 `json:"timeout"` // milliseconds
+}
+
+// scriptAPI implements the browser object available to automation scripts.
+type scriptAPI struct {
+	ctx context.Context
+	vm  *goja.Runtime
+	// autoScroll scrolls elements into view before pick and click.
+	autoScroll bool
+}
+
+// RunScript runs an automation script written in JavaScript (ES5.1 with most
+// of ES6) on the tab in ctx. Unlike eval, the script runs in an interpreter
+// embedded in this process and drives the browser through the "browser"
+// object, so it can navigate between pages, loop and branch on what it finds.
+// args is exposed to the script as the "args" array. The value of the last
+// statement is returned; undefined and null return nil. autoScroll is passed
+// on to PickElements and Click.
+func RunScript(ctx context.Context, source, sourceName string, args []string, autoScroll bool) (interface{}, error) {
+	vm := goja.New()
+	vm.SetFieldNameMapper(goja.TagFieldNameMapper("json", true))
+	api := &scriptAPI{ctx: ctx, vm: vm, autoScroll: autoScroll}
+	if err := api.install(args); err != nil {
+		return nil, err
+	}
+
+	stop := context.AfterFunc(ctx, func() { vm.Interrupt(ctx.Err()) })
+	defer stop()
+
+	value, err := vm.RunScript(sourceName, source)
+	if err != nil {
+		return nil, scriptError(err)
+	}
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return nil, nil
+	}
+	return value.Export(), nil
+}
+
+// scriptError turns a goja error into a one-line error with the script position.
+func scriptError(err error) error {
+	switch e := err.(type) {
+	case *goja.Exception:
+		return fmt.Errorf("%s", strings.TrimSpace(e.Error()))
+	case *goja.InterruptedError:
+		return fmt.Errorf("script interrupted: %v", e.Value())
+	}
+	return err
+}
+
+// install defines the globals available to scripts.
+func (s *scriptAPI) install(args []string) error {
+	if args == nil {
+		args = []string{}
+	}
+	browser := s.vm.NewObject()
+	functions := map[string]interface{}{
+		"navigate":   s.navigate,
+		"pick":       s.pick,
+		"pickAll":    s.pickAll,
+		"click":      s.click,
+		"type":       s.typeText,
+		"waitFor":    s.waitFor,
+		"extract":    s.extract,
+		"screenshot": s.screenshot,
+		"eval":       s.eval,
+		"sleep":      s.sleep,
+		"pause":      s.pause,
+	}
+	for name, fn := range functions {
+		if err := browser.Set(name, fn); err != nil {
+			return err
+		}
+	}
+
+	console := s.vm.NewObject()
+	for _, name := range []string{"log", "info", "warn", "error"} {
+		if err := console.Set(name, s.log); err != nil {
+			return err
+		}
+	}
+
+	globals := map[string]interface{}{
+		"browser": browser,
+		"console": console,
+		"print":   s.log,
+		"args":    args,
+	}
+	for name, value := range globals {
+		if err := s.vm.Set(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toJS converts a Go result into plain script values (objects keyed by the
+// JSON field names), so scripts can inspect and JSON.stringify them.
+func (s *scriptAPI) toJS(v interface{}) (goja.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var plain interface{}
+	if err := json.Unmarshal(data, &plain); err != nil {
+		return nil, err
+	}
+	return s.vm.ToValue(plain), nil
+}
+
+func (s *scriptAPI) navigate(url string, opts scriptNavigateOptions) (goja.Value, error) {
+	result, err := Navigate(s.ctx, url, NavigateOptions{
+		BypassServiceWorker:  opts.BypassSW,
+		WaitUntil:            opts.WaitUntil,
+		Referrer:             opts.Referrer,
+		Transition:           opts.Transition,
+		CollectConsoleErrors: opts.FailOnConsoleError,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if n := len(result.ConsoleErrors); n > 0 {
+		texts := make([]string, n)
+		for i, msg := range result.ConsoleErrors {
+			texts[i] = msg.Text
+		}
+		return nil, fmt.Errorf("%d console error(s) during the load of %s: %s", n, url, strings.Join(texts, "; "))
+	}
+	return s.toJS(result)
+}
+
+func (s *scriptAPI) pick(selector string) (goja.Value, error) {
+	infos, err := PickElements(s.ctx, selector, false, s.autoScroll)
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return goja.Null(), nil
+	}
+	return s.toJS(infos[0])
+}
+
+func (s *scriptAPI) pickAll(selector string) (goja.Value, error) {
+	infos, err := PickElements(s.ctx, selector, true, s.autoScroll)
+	if err != nil {
+		return nil, err
+	}
+	return s.toJS(infos)
+}
+
+func (s *scriptAPI) click(selector string) error {
+	return Click(s.ctx, selector, s.autoScroll)
+}
+
+func (s *scriptAPI) typeText(selector, text string) error {
+	return TypeText(s.ctx, selector, text)
+}
+
+func (s *scriptAPI) waitFor(selector string, opts scriptWaitOptions) error {
+	timeout := defaultScriptWait
+	if opts.Timeout > 0 {
+		timeout = time.Duration(opts.Timeout) * time.Millisecond
+	}
+	return WaitForSelector(s.ctx, selector, timeout)
+}
+
+func (s *scriptAPI) pause(message string) error {
+	return Pause(s.ctx, message, os.Stdin, 0)
+}
+
+func (s *scriptAPI) extract(opts scriptExtractOptions) (goja.Value, error) {
+	contentOpts := ContentOptions{
+		Format:   opts.Format,
+		Selector: opts.Selector,
+		Strip:    DefaultStripSelectors,
+	}
+	if contentOpts.Format == "" {
+		contentOpts.Format = "markdown"
+	}
+	result, err := GetContent(s.ctx, opts.URL, contentOpts)
+	if err != nil {
+		return nil, err
+	}
+	return s.toJS(result)
+}
+
+func (s *scriptAPI) screenshot(path string, opts scriptScreenshotOptions) (string, error) {
+	return Screenshot(s.ctx, opts.URL, path, ScreenshotOptions{FullPage: opts.FullPage})
+}
+
+func (s *scriptAPI) eval(expression string) (goja.Value, error) {
+	result, err := EvaluateJS(s.ctx, expression, EvalOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return s.toJS(result)
+}
+
+func (s *scriptAPI) sleep(ms int64) error {
+	select {
+	case <-time.After(time.Duration(ms) * time.Millisecond):
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+// log writes its arguments to the log, objects as JSON.
+func (s *scriptAPI) log(call goja.FunctionCall) goja.Value {
+	parts := make([]string, len(call.Arguments))
+	for i, arg := range call.Arguments {
+		parts[i] = arg.String()
+		if _, ok := arg.(*goja.Object); ok {
+			if data, err := json.Marshal(arg.Export()); err == nil {
+				parts[i] = string(data)
+			}
+		}
+	}
+	i18n.Printf("📜 %s", strings.Join(parts, " "))
+	return goja.Undefined()
+}
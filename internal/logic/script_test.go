@@ -0,0 +1,79 @@
+package logic
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunScript(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		args   []string
+		want   interface{}
+	}{
+		{"last value", "1 + 2", nil, int64(3)},
+		{"undefined", "var x = 1;", nil, nil},
+		{"args", "args.join('+')", []string{"a", "b"}, "a+b"},
+		{"no args", "args.length", nil, int64(0)},
+		{"loop", "var n = 0; for (var i = 0; i < 3; i++) { browser.sleep(1); n++ } n", nil, int64(3)},
+		{"object", "console.log('items', {a: 1}); ({count: 2})", nil, map[string]interface{}{"count": int64(2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RunScript(context.Background(), tt.source, "test.js", tt.args, true)
+			if err != nil {
+				t.Fatalf("RunScript() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RunScript() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunScript_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr string
+	}{
+		{"thrown", "\nthrow new Error('no results')", "test.js:2"},
+		{"syntax", "if (", "test.js"},
+		// Browser calls fail without a tab; the error is catchable by the script.
+		{"browser error", "try { browser.click('a') } catch (e) { throw new Error('caught: ' + e.message) }", "caught: failed to click"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RunScript(context.Background(), tt.source, "test.js", nil, true)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRunScript_OptionalArguments(t *testing.T) {
+	// Options objects may be omitted; the call reaches the browser and fails there.
+	_, err := RunScript(context.Background(), "browser.waitFor('main')", "test.js", nil, true)
+	if err == nil || !strings.Contains(err.Error(), "failed waiting for") {
+		t.Errorf("Expected browser error, got %v", err)
+	}
+	_, err = RunScript(context.Background(), "browser.waitFor('main', {timeout: 10})", "test.js", nil, true)
+	if err == nil || !strings.Contains(err.Error(), "failed waiting for") {
+		t.Errorf("Expected browser error, got %v", err)
+	}
+}
+
+func TestRunScript_Canceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := RunScript(ctx, "for (;;) {}", "test.js", nil, true)
+	if err == nil || !strings.Contains(err.Error(), "interrupted") {
+		t.Errorf("Expected interrupted error, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// maxSettleFrames bounds how many animation frames scrollIntoViewStable waits
+// for an element to stop moving, about a second at 60 frames per second.
+const maxSettleFrames = 60
+
+// settleElementFunction scrolls the element it is called on into the middle
+// of the viewport unless it is already fully visible, then resolves once two
+// consecutive animation frames report the same rect, so that smooth scrolling,
+// sticky headers and transitions have settled, or after maxFrames frames.
+const settleElementFunction = `function(maxFrames) {
+	const rect = this.getBoundingClientRect();
+	const inView = rect.top >= 0 && rect.left >= 0 &&
+		rect.bottom <= window.innerHeight && rect.right <= window.innerWidth;
+	if (!inView) {
+		this.scrollIntoView({block: 'center', inline: 'center', behavior: 'instant'});
+	}
+	const same = (a, b) => a.x === b.x && a.y === b.y && a.width === b.width && a.height === b.height;
+	return new Promise(resolve => {
+		let previous = this.getBoundingClientRect();
+		let frames = 0;
+		const check = () => {
+			const current = this.getBoundingClientRect();
+			if (same(previous, current) || ++frames >= maxFrames) {
+				resolve();
+				return;
+			}
+			previous = current;
+			requestAnimationFrame(check);
+		};
+		requestAnimationFrame(check);
+	});
+}`
+
+// scrollIntoViewStable scrolls the node into view when it isn't fully visible
+// and waits until it stops moving. An element that keeps moving, such as one
+// in a running animation, is left as it is after maxSettleFrames frames.
+func scrollIntoViewStable(ctx context.Context, nodeID cdp.NodeID) error {
+	contextID, err := isolatedContext(ctx)
+	if err != nil {
+		return err
+	}
+	obj, err := resolveNodeIsolated(ctx, nodeID, contextID)
+	if err != nil {
+		return err
+	}
+	err = chromedp.CallFunctionOn(settleElementFunction, nil, func(p *runtime.CallFunctionOnParams) *runtime.CallFunctionOnParams {
+		return p.WithObjectID(obj.ObjectID).WithAwaitPromise(true)
+	}, maxSettleFrames).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to scroll element into view: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Types of search results.
+const (
+	SearchTypeOrganic = "organic"
+	SearchTypeVideo   = "video"
+	SearchTypeNews    = "news"
+	SearchTypeImage   = "image"
+)
+
+// searchVertical is a Google search vertical search --include adds.
+type searchVertical struct {
+	tbm    string // the tbm parameter selecting the vertical
+	wait   string // selector of the results, waited for
+	script string // extracts the results as JSON
+}
+
+// searchVerticals are the verticals of search --include, by name.
+var searchVerticals = map[string]searchVertical{
+	"news":   {tbm: "nws", wait: "div#search", script: newsResultsScript},
+	"images": {tbm: "isch", wait: "div#islrg, div#search", script: imageResultsScript},
+}
+
+// SearchVerticals returns the names of the verticals search can include.
+func SearchVerticals() []string {
+	names := make([]string, 0, len(searchVerticals))
+	for name := range searchVerticals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckSearchVerticals returns an error for the first of include that isn't a
+// vertical search can include.
+func CheckSearchVerticals(include []string) error {
+	for _, name := range include {
+		if _, ok := searchVerticals[name]; !ok {
+			return fmt.Errorf("unknown search vertical %q: expected one of %s", name, strings.Join(SearchVerticals(), ", "))
+		}
+	}
+	return nil
+}
+
+// searchResultsScript extracts the web results of a Google search. Results
+// with a video player or linking to a video site are of type video.
+const searchResultsScript = `
+	(() => {
+		const results = [];
+		const items = document.querySelectorAll('div#search div.g');
+		for (let i = 0; i < items.length; i++) {
+			const item = items[i];
+			const titleEl = item.querySelector('h3');
+			const linkEl = item.querySelector('a');
+			const snippetEl = item.querySelector('div.VwiC3b');
+			if (titleEl && linkEl && snippetEl) {
+				const dateEl = item.querySelector('span.LEwnzc, span.YrbPuc');
+				const iconEl = item.querySelector('img.XNo5Ab');
+				const video = item.querySelector('[data-vid], g-video, video-voyager') ||
+					/youtube\.com\/watch|youtu\.be\/|vimeo\.com\/\d/.test(linkEl.href);
+				results.push({
+					title: titleEl.innerText,
+					link: linkEl.href,
+					snippet: snippetEl.innerText,
+					date: dateEl ? dateEl.innerText : '',
+					favicon: iconEl ? iconEl.src : '',
+					type: video ? 'video' : 'organic'
+				});
+			}
+		}
+		return JSON.stringify(results);
+	})();
+`
+
+// newsResultsScript extracts the results of a Google News search.
+const newsResultsScript = `
+	(() => {
+		const results = [];
+		const items = document.querySelectorAll('div#search div.SoaBEf, div#search g-card');
+		for (let i = 0; i < items.length; i++) {
+			const item = items[i];
+			const titleEl = item.querySelector('[role="heading"], h3');
+			const linkEl = item.querySelector('a[href]');
+			if (titleEl && linkEl) {
+				const snippetEl = item.querySelector('.GI74Re, .Y3v8qd');
+				const dateEl = item.querySelector('.OSrXXb span, .rbYSKb span, .OSrXXb, time');
+				const iconEl = item.querySelector('.MgUUmf img, img.XNo5Ab');
+				results.push({
+					title: titleEl.innerText,
+					link: linkEl.href,
+					snippet: snippetEl ? snippetEl.innerText : '',
+					date: dateEl ? dateEl.innerText : '',
+					favicon: iconEl ? iconEl.src : '',
+					type: 'news'
+				});
+			}
+		}
+		return JSON.stringify(results);
+	})();
+`
+
+// imageResultsScript extracts the results of a Google Images search: the
+// page of each image, its title and its thumbnail.
+const imageResultsScript = `
+	(() => {
+		const results = [];
+		const items = document.querySelectorAll('div[data-lpage]');
+		for (let i = 0; i < items.length; i++) {
+			const item = items[i];
+			const imageEl = item.querySelector('img');
+			const titleEl = item.querySelector('h3');
+			const title = titleEl ? titleEl.innerText : (imageEl ? imageEl.alt : '');
+			if (title) {
+				const thumbnail = imageEl ? (imageEl.src || imageEl.dataset.src || '') : '';
+				results.push({
+					title: title,
+					link: item.dataset.lpage,
+					snippet: '',
+					thumbnail: thumbnail,
+					type: 'image'
+				});
+			}
+		}
+		return JSON.stringify(results);
+	})();
+`
+
+// rawSearchResult is a result as the search scripts extract it.
+type rawSearchResult struct {
+	Title     string `json:"title"`
+	Link      string `json:"link"`
+	Snippet   string `json:"snippet"`
+	Date      string `json:"date"`
+	Favicon   string `json:"favicon"`
+	Thumbnail string `json:"thumbnail"`
+	Type      string `json:"type"`
+}
+
+// searchURL returns the URL of the Google search for query, in the vertical
+// selected by tbm when it isn't empty.
+func searchURL(query, tbm string) string {
+	searchURL := fmt.Sprintf("https://www.google.com/search?q=%s", url.QueryEscape(query))
+	if tbm != "" {
+		searchURL += "&tbm=" + tbm
+	}
+	return searchURL
+}
+
+// searchPage loads the Google search at searchURL, waits for wait and
+// returns up to numResults results extracted by script; numResults 0 means
+// all.
+func searchPage(ctx context.Context, searchURL, wait, script string, numResults int) ([]models.SearchResult, error) {
+	err := chromedp.Run(ctx,
+		chromedp.Navigate(searchURL),
+		chromedp.WaitVisible(wait, chromedp.ByQuery),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to navigate to google and wait for results: %w", err)
+	}
+
+	var searchResultsJSON string
+	if err := chromedp.Run(ctx, EvaluateIsolated(script, &searchResultsJSON)); err != nil {
+		return nil, fmt.Errorf("failed to extract search results with script: %w", err)
+	}
+	var rawResults []rawSearchResult
+	if err := json.Unmarshal([]byte(searchResultsJSON), &rawResults); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal search results: %w", err)
+	}
+	if numResults > 0 && numResults < len(rawResults) {
+		rawResults = rawResults[:numResults]
+	}
+
+	results := make([]models.SearchResult, len(rawResults))
+	for i, raw := range rawResults {
+		results[i] = raw.result()
+	}
+	return results, nil
+}
+
+// result returns the search result of r. The displayed date is trimmed of
+// the dash separating it from the snippet; the favicon is kept as Google
+// shows it, which may be a data URL.
+func (r rawSearchResult) result() models.SearchResult {
+	return models.SearchResult{
+		Title:     r.Title,
+		Link:      r.Link,
+		Snippet:   r.Snippet,
+		Date:      strings.TrimSpace(strings.TrimRight(strings.TrimSpace(r.Date), "—–-·")),
+		Favicon:   r.Favicon,
+		Thumbnail: r.Thumbnail,
+		Type:      r.Type,
+	}
+}
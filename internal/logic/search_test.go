@@ -0,0 +1,58 @@
+package logic
+
+import "testing"
+
+func TestCheckSearchVerticals(t *testing.T) {
+	if err := CheckSearchVerticals([]string{"news", "images"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := CheckSearchVerticals([]string{"videos"}); err == nil {
+		t.Error("expected an error for an unknown vertical")
+	}
+}
+
+func TestSearchURL(t *testing.T) {
+	if got, want := searchURL("go & jq", ""), "https://www.google.com/search?q=go+%26+jq"; got != want {
+		t.Errorf("searchURL = %s, want %s", got, want)
+	}
+	if got, want := searchURL("go", searchVerticals["news"].tbm), "https://www.google.com/search?q=go&tbm=nws"; got != want {
+		t.Errorf("searchURL = %s, want %s", got, want)
+	}
+}
+
+func TestRawSearchResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        rawSearchResult
+		date, icon string
+		resultType string
+	}{
+		{
+			name:       "date separator and inlined favicon",
+			raw:        rawSearchResult{Link: "https://go.dev/blog/", Date: "3 days ago — ", Favicon: "data:image/png;base64,AAAA", Type: SearchTypeOrganic},
+			date:       "3 days ago",
+			icon:       "data:image/png;base64,AAAA",
+			resultType: SearchTypeOrganic,
+		},
+		{
+			name:       "favicon URL kept",
+			raw:        rawSearchResult{Link: "https://news.example.com/a", Date: "Oct 1, 2026", Favicon: "https://encrypted-tbn0.gstatic.com/favicon?x", Type: SearchTypeNews},
+			date:       "Oct 1, 2026",
+			icon:       "https://encrypted-tbn0.gstatic.com/favicon?x",
+			resultType: SearchTypeNews,
+		},
+		{
+			name:       "no link",
+			raw:        rawSearchResult{Type: SearchTypeImage},
+			resultType: SearchTypeImage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.raw.result()
+			if result.Date != tt.date || result.Favicon != tt.icon || result.Type != tt.resultType {
+				t.Errorf("result = %+v, want date %q, favicon %q, type %q", result, tt.date, tt.icon, tt.resultType)
+			}
+		})
+	}
+}
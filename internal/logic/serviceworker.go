@@ -0,0 +1,139 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/serviceworker"
+	"github.com/chromedp/chromedp"
+)
+
+// swEventWait is how long to wait for the registration events Chrome sends after ServiceWorker.enable.
+const swEventWait = 500 * time.Millisecond
+
+// ListServiceWorkers returns the service worker registrations known to the browser.
+// A non-empty origin limits the list to registrations whose scope belongs to it.
+func ListServiceWorkers(ctx context.Context, origin string) ([]models.ServiceWorker, error) {
+	var mu sync.Mutex
+	registrations := map[serviceworker.RegistrationID]*serviceworker.Registration{}
+	versions := map[string]*serviceworker.Version{}
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chromedp.ListenTarget(listenCtx, func(ev interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch ev := ev.(type) {
+		case *serviceworker.EventWorkerRegistrationUpdated:
+			for _, r := range ev.Registrations {
+				registrations[r.RegistrationID] = r
+			}
+		case *serviceworker.EventWorkerVersionUpdated:
+			for _, v := range ev.Versions {
+				versions[v.VersionID] = v
+			}
+		}
+	})
+
+	err := chromedp.Run(ctx,
+		serviceworker.Enable(),
+		chromedp.Sleep(swEventWait),
+		serviceworker.Disable(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service workers: %w", err)
+	}
+	cancel()
+
+	mu.Lock()
+	defer mu.Unlock()
+	return collectServiceWorkers(registrations, versions, strings.TrimSuffix(origin, "/")), nil
+}
+
+// collectServiceWorkers groups versions under their live registrations, filtered by origin.
+func collectServiceWorkers(registrations map[serviceworker.RegistrationID]*serviceworker.Registration, versions map[string]*serviceworker.Version, origin string) []models.ServiceWorker {
+	workers := []models.ServiceWorker{}
+	for _, r := range registrations {
+		if r.IsDeleted || (origin != "" && !strings.HasPrefix(r.ScopeURL, origin+"/")) {
+			continue
+		}
+		worker := models.ServiceWorker{
+			RegistrationID: string(r.RegistrationID),
+			ScopeURL:       r.ScopeURL,
+			Versions:       []models.ServiceWorkerVersion{},
+		}
+		for _, v := range versions {
+			if v.RegistrationID != r.RegistrationID || v.Status == serviceworker.VersionStatusRedundant {
+				continue
+			}
+			worker.Versions = append(worker.Versions, models.ServiceWorkerVersion{
+				VersionID:     v.VersionID,
+				ScriptURL:     v.ScriptURL,
+				RunningStatus: v.RunningStatus.String(),
+				Status:        v.Status.String(),
+			})
+		}
+		sort.Slice(worker.Versions, func(i, j int) bool { return worker.Versions[i].VersionID < worker.Versions[j].VersionID })
+		workers = append(workers, worker)
+	}
+	sort.Slice(workers, func(i, j int) bool { return workers[i].ScopeURL < workers[j].ScopeURL })
+	return workers
+}
+
+// UnregisterServiceWorkers unregisters every service worker of an origin (the
+// current page's origin when empty) and returns the affected scopes.
+func UnregisterServiceWorkers(ctx context.Context, origin string) ([]string, error) {
+	return forEachServiceWorker(ctx, origin, func(ctx context.Context, scope string) error {
+		return serviceworker.Unregister(scope).Do(ctx)
+	})
+}
+
+// UpdateServiceWorkers asks every service worker of an origin (the current page's
+// origin when empty) to check for a new script version and returns the affected scopes.
+func UpdateServiceWorkers(ctx context.Context, origin string) ([]string, error) {
+	return forEachServiceWorker(ctx, origin, func(ctx context.Context, scope string) error {
+		return serviceworker.UpdateRegistration(scope).Do(ctx)
+	})
+}
+
+// forEachServiceWorker runs fn for the scope of each service worker registered for origin.
+func forEachServiceWorker(ctx context.Context, origin string, fn func(ctx context.Context, scope string) error) ([]string, error) {
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		origin, err = resolveOrigin(ctx, origin)
+		return err
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	workers, err := ListServiceWorkers(ctx, origin)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := make([]string, 0, len(workers))
+	err = chromedp.Run(ctx,
+		serviceworker.Enable(),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			for _, w := range workers {
+				if err := fn(ctx, w.ScopeURL); err != nil {
+					return fmt.Errorf("%s: %w", w.ScopeURL, err)
+				}
+				scopes = append(scopes, w.ScopeURL)
+			}
+			return nil
+		}),
+		serviceworker.Disable(),
+	)
+	if err != nil {
+		return scopes, fmt.Errorf("failed to update service workers: %w", err)
+	}
+	return scopes, nil
+}
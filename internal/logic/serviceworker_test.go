@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/chromedp/cdproto/serviceworker"
+)
+
+func TestCollectServiceWorkers(t *testing.T) {
+	registrations := map[serviceworker.RegistrationID]*serviceworker.Registration{
+		"1": {RegistrationID: "1", ScopeURL: "https://example.com/"},
+		"2": {RegistrationID: "2", ScopeURL: "https://example.com/app/"},
+		"3": {RegistrationID: "3", ScopeURL: "https://other.example.org/"},
+		"4": {RegistrationID: "4", ScopeURL: "https://example.com/old/", IsDeleted: true},
+	}
+	versions := map[string]*serviceworker.Version{
+		"10": {VersionID: "10", RegistrationID: "1", ScriptURL: "https://example.com/sw.js", Status: serviceworker.VersionStatusActivated, RunningStatus: serviceworker.VersionRunningStatusRunning},
+		"11": {VersionID: "11", RegistrationID: "1", ScriptURL: "https://example.com/sw.js", Status: serviceworker.VersionStatusRedundant},
+		"20": {VersionID: "20", RegistrationID: "2", ScriptURL: "https://example.com/app/sw.js", Status: serviceworker.VersionStatusInstalled},
+	}
+
+	all := collectServiceWorkers(registrations, versions, "")
+	if len(all) != 3 {
+		t.Fatalf("expected 3 live registrations, got %+v", all)
+	}
+
+	workers := collectServiceWorkers(registrations, versions, "https://example.com")
+	if len(workers) != 2 {
+		t.Fatalf("expected 2 registrations for the origin, got %+v", workers)
+	}
+	if workers[0].ScopeURL != "https://example.com/" || len(workers[0].Versions) != 1 {
+		t.Errorf("expected redundant version to be dropped, got %+v", workers[0])
+	}
+	if workers[0].Versions[0].RunningStatus != "running" || workers[0].Versions[0].Status != "activated" {
+		t.Errorf("unexpected version status: %+v", workers[0].Versions[0])
+	}
+	if workers[1].ScopeURL != "https://example.com/app/" {
+		t.Errorf("expected registrations sorted by scope, got %+v", workers)
+	}
+}
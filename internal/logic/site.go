@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SiteAuth is what a site profile adds to the requests to its origin: the
+// headers sent with each of them and the credentials answering HTTP
+// authentication challenges. The values are resolved secrets.
+type SiteAuth struct {
+	Origin   string
+	Headers  map[string]string
+	Username string
+	Password string
+}
+
+// ApplySiteAuth intercepts the requests of the tab of ctx to auth.Origin, for
+// as long as ctx is connected: it adds auth.Headers to them, replacing any
+// header of the same name, and answers their authentication challenges with
+// the credentials once; a challenge repeated for the same request means they
+// were rejected and is cancelled. Other origins are left alone, so that the
+// secrets never leave the site.
+func ApplySiteAuth(ctx context.Context, auth SiteAuth) error {
+	ctx, span := startSpan(ctx, "site_auth", attribute.String("browser_tools.origin", auth.Origin))
+	err := runApplySiteAuth(ctx, auth)
+	endSpan(span, err)
+	return err
+}
+
+func runApplySiteAuth(ctx context.Context, auth SiteAuth) error {
+	withCredentials := auth.Username != "" || auth.Password != ""
+	if len(auth.Headers) == 0 && !withCredentials {
+		return nil
+	}
+	if auth.Origin == "" {
+		return fmt.Errorf("no origin to apply the site to")
+	}
+
+	prefix := strings.TrimSuffix(auth.Origin, "/") + "/"
+	var mu sync.Mutex
+	answered := map[fetch.RequestID]bool{}
+	rule := requestRule{
+		pattern: &fetch.RequestPattern{URLPattern: prefix + "*"},
+		paused: func(ctx context.Context, ev *fetch.EventRequestPaused) bool {
+			if !strings.HasPrefix(ev.Request.URL, prefix) {
+				return false
+			}
+			headers := siteRequestHeaders(ev.Request.Headers, auth.Headers)
+			_ = chromedp.Run(ctx, fetch.ContinueRequest(ev.RequestID).WithHeaders(headers))
+			return true
+		},
+	}
+	if withCredentials {
+		rule.authRequired = func(ctx context.Context, ev *fetch.EventAuthRequired) bool {
+			if !strings.HasPrefix(ev.Request.URL, prefix) {
+				return false
+			}
+			mu.Lock()
+			response := &fetch.AuthChallengeResponse{Response: fetch.AuthChallengeResponseResponseCancelAuth}
+			if !answered[ev.RequestID] {
+				answered[ev.RequestID] = true
+				response = &fetch.AuthChallengeResponse{
+					Response: fetch.AuthChallengeResponseResponseProvideCredentials,
+					Username: auth.Username,
+					Password: auth.Password,
+				}
+			}
+			mu.Unlock()
+			_ = chromedp.Run(ctx, fetch.ContinueWithAuth(ev.RequestID, response))
+			return true
+		}
+	}
+	if err := interceptRequests(ctx, rule); err != nil {
+		return fmt.Errorf("failed to intercept the requests to %s: %w", auth.Origin, err)
+	}
+	return nil
+}
+
+// siteRequestHeaders returns the headers of a request with the site's headers
+// added; they replace request headers of the same name, whatever their case.
+func siteRequestHeaders(request network.Headers, site map[string]string) []*fetch.HeaderEntry {
+	headers := make([]*fetch.HeaderEntry, 0, len(request)+len(site))
+	for name, value := range request {
+		if _, ok := lookupHeader(site, name); ok {
+			continue
+		}
+		headers = append(headers, &fetch.HeaderEntry{Name: name, Value: fmt.Sprint(value)})
+	}
+	for name, value := range site {
+		headers = append(headers, &fetch.HeaderEntry{Name: name, Value: value})
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+	return headers
+}
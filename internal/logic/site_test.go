@@ -0,0 +1,31 @@
+package logic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestSiteRequestHeaders(t *testing.T) {
+	request := network.Headers{"Accept": "text/html", "x-api-key": "stale"}
+	headers := siteRequestHeaders(request, map[string]string{"X-Api-Key": "s3cret"})
+	if len(headers) != 2 {
+		t.Fatalf("headers: got %d, want 2", len(headers))
+	}
+	// The site's header replaces the request's whatever its case.
+	if headers[0].Name != "Accept" || headers[1].Name != "X-Api-Key" || headers[1].Value != "s3cret" {
+		t.Errorf("headers: got %s=%s, %s=%s", headers[0].Name, headers[0].Value, headers[1].Name, headers[1].Value)
+	}
+}
+
+func TestApplySiteAuth_Nothing(t *testing.T) {
+	// Without headers or credentials there is nothing to intercept, not even
+	// a browser needed.
+	if err := ApplySiteAuth(context.Background(), SiteAuth{Origin: "https://example.com"}); err != nil {
+		t.Errorf("got %v", err)
+	}
+	if err := ApplySiteAuth(context.Background(), SiteAuth{Username: "alice"}); err == nil {
+		t.Error("no origin: expected an error")
+	}
+}
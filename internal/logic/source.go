@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// renderedSourceScript serializes the current DOM, including the doctype.
+const renderedSourceScript = `(() => {
+	const doctype = document.doctype ? new XMLSerializer().serializeToString(document.doctype) + '\n' : '';
+	return doctype + document.documentElement.outerHTML;
+})()`
+
+// RawSource returns the HTML of the page as sent by the server, before any
+// script ran. With targetURL, the page is loaded and its document response
+// body is returned; otherwise the current page's document is read from the
+// browser's resource cache.
+func RawSource(ctx context.Context, targetURL string) (string, error) {
+	if targetURL == "" {
+		var content []byte
+		err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+			tree, err := page.GetFrameTree().Do(ctx)
+			if err != nil {
+				return err
+			}
+			content, err = page.GetResourceContent(tree.Frame.ID, tree.Frame.URL).Do(ctx)
+			return err
+		}))
+		if err != nil {
+			return "", fmt.Errorf("failed to read raw source: %w", err)
+		}
+		return string(content), nil
+	}
+
+	var mu sync.Mutex
+	var documentID network.RequestID
+	var mainFrame string
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chromedp.ListenTarget(listenCtx, func(ev interface{}) {
+		if ev, ok := ev.(*network.EventResponseReceived); ok && ev.Type == network.ResourceTypeDocument {
+			mu.Lock()
+			defer mu.Unlock()
+			if string(ev.FrameID) == mainFrame {
+				documentID = ev.RequestID
+			}
+		}
+	})
+
+	err := chromedp.Run(ctx,
+		network.Enable(),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			tree, err := page.GetFrameTree().Do(ctx)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			mainFrame = string(tree.Frame.ID)
+			mu.Unlock()
+			return nil
+		}),
+		chromedp.Navigate(targetURL),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to load %s: %w", targetURL, err)
+	}
+
+	mu.Lock()
+	id := documentID
+	mu.Unlock()
+	if id == "" {
+		return "", fmt.Errorf("no document response was received for %s", targetURL)
+	}
+	var body []byte
+	if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		body, err = network.GetResponseBody(id).Do(ctx)
+		return err
+	})); err != nil {
+		return "", fmt.Errorf("failed to read document response body: %w", err)
+	}
+	return string(body), nil
+}
+
+// RenderedSource returns the serialized DOM after scripts ran, navigating to
+// targetURL first when it is not empty.
+func RenderedSource(ctx context.Context, targetURL string) (string, error) {
+	tasks := make(chromedp.Tasks, 0)
+	if targetURL != "" {
+		tasks = append(tasks, chromedp.Navigate(targetURL))
+	}
+	var html string
+	tasks = append(tasks, EvaluateIsolated(renderedSourceScript, &html))
+	if err := chromedp.Run(ctx, tasks); err != nil {
+		return "", fmt.Errorf("failed to serialize rendered source: %w", err)
+	}
+	return html, nil
+}
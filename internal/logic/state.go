@@ -0,0 +1,189 @@
+package logic
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"browser-tools-go/internal/i18n"
+
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// StorageState is a snapshot of cookies and per-origin web storage, in the same
+// shape as Playwright's storageState so files can be shared between the tools.
+type StorageState struct {
+	Cookies []*network.Cookie `json:"cookies"`
+	Origins []OriginState     `json:"origins"`
+}
+
+// OriginState holds the web storage of one origin.
+type OriginState struct {
+	Origin       string        `json:"origin"`
+	LocalStorage []StorageItem `json:"localStorage"`
+	// SessionStorage is only captured for the origin of the current page.
+	SessionStorage []StorageItem `json:"sessionStorage,omitempty"`
+}
+
+// StorageItem is a single web storage entry.
+type StorageItem struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// readStorageScript returns the entries of localStorage or sessionStorage.
+const readStorageScript = `
+	(() => {
+		const s = window[%q];
+		const items = [];
+		for (let i = 0; i < s.length; i++) {
+			const name = s.key(i);
+			items.push({ name, value: s.getItem(name) });
+		}
+		return items;
+	})();
+`
+
+// writeStorageScript stores the given entries in localStorage or sessionStorage.
+const writeStorageScript = `
+	(() => {
+		const s = window[%q];
+		for (const item of %s) s.setItem(item.name, item.value);
+		return true;
+	})();
+`
+
+// blankPageBody is served for every request made by the helper tab used to reach an origin.
+var blankPageBody = base64.StdEncoding.EncodeToString([]byte("<!DOCTYPE html><html><head></head><body></body></html>"))
+
+// SaveStorageState captures all cookies and the web storage of the current page's
+// origin and of the given extra origins.
+func SaveStorageState(ctx context.Context, origins []string) (*StorageState, error) {
+	cookies, err := GetCookies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state := &StorageState{Cookies: cookies, Origins: []OriginState{}}
+
+	var pageOrigin string
+	if err := chromedp.Run(ctx, EvaluateIsolated("location.origin", &pageOrigin)); err != nil {
+		return nil, fmt.Errorf("failed to read the current origin: %w", err)
+	}
+
+	seen := map[string]bool{}
+	if strings.HasPrefix(pageOrigin, "http") {
+		origin := OriginState{Origin: pageOrigin}
+		err := chromedp.Run(ctx,
+			EvaluateIsolated(fmt.Sprintf(readStorageScript, "localStorage"), &origin.LocalStorage),
+			EvaluateIsolated(fmt.Sprintf(readStorageScript, "sessionStorage"), &origin.SessionStorage),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read storage of %s: %w", pageOrigin, err)
+		}
+		state.Origins = append(state.Origins, origin)
+		seen[pageOrigin] = true
+	}
+
+	for _, o := range origins {
+		o = strings.TrimSuffix(o, "/")
+		if seen[o] {
+			continue
+		}
+		seen[o] = true
+
+		origin := OriginState{Origin: o}
+		err := withOriginPage(ctx, o, func(ctx context.Context) error {
+			return EvaluateIsolated(fmt.Sprintf(readStorageScript, "localStorage"), &origin.LocalStorage).Do(ctx)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read storage of %s: %w", o, err)
+		}
+		state.Origins = append(state.Origins, origin)
+	}
+	return state, nil
+}
+
+// LoadStorageState restores a state file written by SaveStorageState or by
+// Playwright. Session storage is tab-scoped and is only restored for the origin
+// of the current page.
+func LoadStorageState(ctx context.Context, data []byte) error {
+	var raw struct {
+		Cookies json.RawMessage `json:"cookies"`
+		Origins []OriginState   `json:"origins"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to parse state file: %w", err)
+	}
+
+	if len(raw.Cookies) > 0 {
+		cookies, err := parseJSONCookies(raw.Cookies)
+		if err != nil {
+			return err
+		}
+		if err := ImportCookies(ctx, cookies); err != nil {
+			return err
+		}
+	}
+
+	var pageOrigin string
+	if err := chromedp.Run(ctx, EvaluateIsolated("location.origin", &pageOrigin)); err != nil {
+		return fmt.Errorf("failed to read the current origin: %w", err)
+	}
+
+	for _, origin := range raw.Origins {
+		origin.Origin = strings.TrimSuffix(origin.Origin, "/")
+		local, err := json.Marshal(origin.LocalStorage)
+		if err != nil {
+			return err
+		}
+		writeLocal := EvaluateIsolated(fmt.Sprintf(writeStorageScript, "localStorage", local), nil)
+
+		if origin.Origin == pageOrigin {
+			session, err := json.Marshal(origin.SessionStorage)
+			if err != nil {
+				return err
+			}
+			writeSession := EvaluateIsolated(fmt.Sprintf(writeStorageScript, "sessionStorage", session), nil)
+			if err := chromedp.Run(ctx, writeLocal, writeSession); err != nil {
+				return fmt.Errorf("failed to restore storage of %s: %w", origin.Origin, err)
+			}
+			continue
+		}
+
+		if len(origin.SessionStorage) > 0 {
+			i18n.Printf("Warning: session storage of %s is only restored while a page of that origin is open", origin.Origin)
+		}
+		if len(origin.LocalStorage) == 0 {
+			continue
+		}
+		if err := withOriginPage(ctx, origin.Origin, writeLocal.Do); err != nil {
+			return fmt.Errorf("failed to restore storage of %s: %w", origin.Origin, err)
+		}
+	}
+	return nil
+}
+
+// withOriginPage runs fn in a temporary tab showing a blank page on origin. All
+// requests of the tab are answered locally, so the origin's server is never contacted.
+func withOriginPage(ctx context.Context, origin string, fn func(ctx context.Context) error) error {
+	tabCtx, cancel := chromedp.NewContext(ctx)
+	defer cancel()
+
+	chromedp.ListenTarget(tabCtx, func(ev interface{}) {
+		if ev, ok := ev.(*fetch.EventRequestPaused); ok {
+			go chromedp.Run(tabCtx, fetch.FulfillRequest(ev.RequestID, 200).
+				WithResponseHeaders([]*fetch.HeaderEntry{{Name: "Content-Type", Value: "text/html"}}).
+				WithBody(blankPageBody))
+		}
+	})
+
+	return chromedp.Run(tabCtx,
+		fetch.Enable(),
+		chromedp.Navigate(origin+"/"),
+		chromedp.ActionFunc(fn),
+	)
+}
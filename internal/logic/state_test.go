@@ -0,0 +1,78 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+func TestLoadStorageState_Playwright(t *testing.T) {
+	if _, err := exec.LookPath("google-chrome"); err != nil {
+		t.Skip("google-chrome not found, skipping test")
+	}
+
+	// A state file as written by Playwright's context.storageState().
+	data, err := os.ReadFile("testdata/storage_state.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	ctx, cancel := chromedp.NewContext(context.Background())
+	defer cancel()
+
+	if err := LoadStorageState(ctx, data); err != nil {
+		t.Fatalf("LoadStorageState returned error: %v", err)
+	}
+
+	var cookies []*network.Cookie
+	err = chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		cookies, err = network.GetCookies().WithUrls([]string{"https://example.com/"}).Do(ctx)
+		return err
+	}))
+	if err != nil {
+		t.Fatalf("failed to read cookies: %v", err)
+	}
+	if len(cookies) != 1 || cookies[0].Name != "sid" || !cookies[0].Session || cookies[0].SameSite != network.CookieSameSiteLax {
+		t.Errorf("unexpected cookies: %+v", cookies)
+	}
+
+	var items []StorageItem
+	err = withOriginPage(ctx, "https://example.com", func(ctx context.Context) error {
+		return EvaluateIsolated(fmt.Sprintf(readStorageScript, "localStorage"), &items).Do(ctx)
+	})
+	if err != nil {
+		t.Fatalf("failed to read local storage: %v", err)
+	}
+	if len(items) != 1 || items[0] != (StorageItem{Name: "token", Value: "xyz"}) {
+		t.Errorf("unexpected local storage: %+v", items)
+	}
+}
+
+func TestLoadStorageState_InvalidFile(t *testing.T) {
+	if err := LoadStorageState(context.Background(), []byte("not json")); err == nil {
+		t.Error("expected an error for a malformed state file")
+	}
+}
+
+func TestStorageState_Keys(t *testing.T) {
+	// Saved states use the same top-level keys as Playwright's.
+	saved, err := json.Marshal(StorageState{})
+	if err != nil {
+		t.Fatalf("failed to marshal state: %v", err)
+	}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(saved, &keys); err != nil {
+		t.Fatalf("failed to unmarshal saved state: %v", err)
+	}
+	for _, key := range []string{"cookies", "origins"} {
+		if _, ok := keys[key]; !ok {
+			t.Errorf("expected key %q in saved state %s", key, saved)
+		}
+	}
+}
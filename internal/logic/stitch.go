@@ -0,0 +1,127 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"math"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// stitchDelay gives lazily rendered content time to appear after each scroll.
+const stitchDelay = 300 * time.Millisecond
+
+// maxStitchSegments bounds the captures of pages that keep growing while they
+// are scrolled (infinite scroll).
+const maxStitchSegments = 50
+
+// scrollStateScript reads the document scroll position and sizes in CSS pixels.
+const scrollStateScript = `({
+	scrollY: scrollY,
+	viewportHeight: innerHeight,
+	scrollHeight: document.scrollingElement ? document.scrollingElement.scrollHeight : document.documentElement.scrollHeight,
+	ratio: devicePixelRatio,
+})`
+
+type scrollState struct {
+	ScrollY        float64 `json:"scrollY"`
+	ViewportHeight float64 `json:"viewportHeight"`
+	ScrollHeight   float64 `json:"scrollHeight"`
+	Ratio          float64 `json:"ratio"`
+}
+
+// stitchSegment is a viewport capture placed Offset image pixels below the top of the page.
+type stitchSegment struct {
+	Offset int
+	PNG    []byte
+}
+
+// stitchScreenshot scrolls through the document in viewport-sized steps,
+// captures each step and stitches the captures into one PNG. Unlike
+// captureBeyondViewport, this lets virtualized and lazily rendered pages render
+// the content of every step. The scroll position is restored afterwards.
+func stitchScreenshot(ctx context.Context) ([]byte, error) {
+	var segments []stitchSegment
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var start scrollState
+		if err := EvaluateIsolated(scrollStateScript, &start).Do(ctx); err != nil {
+			return err
+		}
+		defer EvaluateIsolated(fmt.Sprintf("window.scrollTo(0, %g)", start.ScrollY), nil).Do(ctx)
+
+		target, previous := 0.0, -1.0
+		for i := 0; i < maxStitchSegments; i++ {
+			if err := EvaluateIsolated(fmt.Sprintf("window.scrollTo(0, %g)", target), nil).Do(ctx); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(stitchDelay):
+			}
+
+			var state scrollState
+			if err := EvaluateIsolated(scrollStateScript, &state).Do(ctx); err != nil {
+				return err
+			}
+			if state.ScrollY <= previous {
+				break // the page did not scroll any further
+			}
+			var buf []byte
+			if err := chromedp.CaptureScreenshot(&buf).Do(ctx); err != nil {
+				return err
+			}
+			segments = append(segments, stitchSegment{Offset: int(math.Round(state.ScrollY * state.Ratio)), PNG: buf})
+
+			if state.ScrollY+state.ViewportHeight >= state.ScrollHeight-1 {
+				break
+			}
+			previous, target = state.ScrollY, state.ScrollY+state.ViewportHeight
+		}
+		return nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture page for stitching: %w", err)
+	}
+	return stitchImages(segments)
+}
+
+// stitchImages draws the segments below each other at their offsets; where
+// segments overlap, the later one wins.
+func stitchImages(segments []stitchSegment) ([]byte, error) {
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("nothing to stitch")
+	}
+	images := make([]image.Image, len(segments))
+	width, height := 0, 0
+	for i, segment := range segments {
+		img, err := png.Decode(bytes.NewReader(segment.PNG))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode capture %d: %w", i+1, err)
+		}
+		images[i] = img
+		if w := img.Bounds().Dx(); w > width {
+			width = w
+		}
+		if h := segment.Offset + img.Bounds().Dy(); h > height {
+			height = h
+		}
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i, img := range images {
+		dst := image.Rect(0, segments[i].Offset, img.Bounds().Dx(), segments[i].Offset+img.Bounds().Dy())
+		draw.Draw(canvas, dst, img, img.Bounds().Min, draw.Src)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas); err != nil {
+		return nil, fmt.Errorf("failed to encode stitched screenshot: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,64 @@
+package logic
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"testing"
+)
+
+func solidPNG(t *testing.T, width, height int, c color.Color) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestStitchImages(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	// The last capture overlaps the first one, as happens at the bottom of a page.
+	segments := []stitchSegment{
+		{Offset: 0, PNG: solidPNG(t, 40, 30, red)},
+		{Offset: 20, PNG: solidPNG(t, 40, 30, blue)},
+	}
+
+	out, err := stitchImages(segments)
+	if err != nil {
+		t.Fatalf("stitchImages() error = %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("result is not a PNG: %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(40, 50) {
+		t.Errorf("stitched size = %v, want (40,50)", got)
+	}
+	for _, tt := range []struct {
+		y    int
+		want color.RGBA
+	}{
+		{5, red},
+		{25, blue}, // overlap: the later segment wins
+		{49, blue},
+	} {
+		if got := color.RGBAModel.Convert(img.At(10, tt.y)).(color.RGBA); got != tt.want {
+			t.Errorf("pixel at y=%d = %v, want %v", tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestStitchImages_Errors(t *testing.T) {
+	if _, err := stitchImages(nil); err == nil {
+		t.Error("expected an error without segments")
+	}
+	if _, err := stitchImages([]stitchSegment{{PNG: []byte("broken")}}); err == nil {
+		t.Error("expected an error for an invalid capture")
+	}
+}
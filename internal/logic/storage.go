@@ -0,0 +1,90 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/storage"
+	"github.com/chromedp/chromedp"
+)
+
+// ClearDataOptions selects the kinds of data removed by ClearData.
+// When nothing is selected, everything is cleared.
+type ClearDataOptions struct {
+	Cookies        bool
+	Cache          bool
+	Storage        bool
+	ServiceWorkers bool
+}
+
+// all reports whether no specific kind was selected.
+func (o ClearDataOptions) all() bool {
+	return !o.Cookies && !o.Cache && !o.Storage && !o.ServiceWorkers
+}
+
+// storageTypes returns the Storage.clearDataForOrigin types for the selected kinds.
+func (o ClearDataOptions) storageTypes() []storage.Type {
+	var types []storage.Type
+	if o.all() || o.Cookies {
+		types = append(types, storage.TypeCookies)
+	}
+	if o.all() || o.Cache {
+		types = append(types, storage.TypeCacheStorage)
+	}
+	if o.all() || o.Storage {
+		types = append(types, storage.TypeLocalStorage, storage.TypeIndexeddb, storage.TypeWebsql, storage.TypeFileSystems)
+	}
+	if o.all() || o.ServiceWorkers {
+		types = append(types, storage.TypeServiceWorkers)
+	}
+	return types
+}
+
+// ClearData removes the selected data for an origin (the current page's origin when
+// empty). Clearing the cache also empties the browser's HTTP cache, which is shared
+// by all origins. Session storage is cleared too when the current page belongs to
+// the origin, since it is only reachable from the page itself. It returns the
+// cleared storage types.
+func ClearData(ctx context.Context, origin string, opts ClearDataOptions) ([]string, error) {
+	var cleared []string
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		if origin, err = resolveOrigin(ctx, origin); err != nil {
+			return err
+		}
+
+		types := opts.storageTypes()
+		names := make([]string, len(types))
+		for i, t := range types {
+			names[i] = t.String()
+		}
+		if err := storage.ClearDataForOrigin(origin, strings.Join(names, ",")).Do(ctx); err != nil {
+			return err
+		}
+		cleared = append(cleared, names...)
+
+		if opts.all() || opts.Cache {
+			if err := network.ClearBrowserCache().Do(ctx); err != nil {
+				return err
+			}
+			cleared = append(cleared, "http_cache")
+		}
+
+		if opts.all() || opts.Storage {
+			var pageOrigin string
+			if err := EvaluateIsolated("location.origin", &pageOrigin).Do(ctx); err == nil && pageOrigin == origin {
+				if err := EvaluateIsolated("sessionStorage.clear()", nil).Do(ctx); err != nil {
+					return err
+				}
+				cleared = append(cleared, "session_storage")
+			}
+		}
+		return nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear data for '%s': %w", origin, err)
+	}
+	return cleared, nil
+}
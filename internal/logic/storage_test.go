@@ -0,0 +1,43 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chromedp/cdproto/storage"
+)
+
+func TestClearDataOptions_StorageTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ClearDataOptions
+		want []storage.Type
+	}{
+		{
+			name: "nothing selected clears everything",
+			opts: ClearDataOptions{},
+			want: []storage.Type{
+				storage.TypeCookies, storage.TypeCacheStorage,
+				storage.TypeLocalStorage, storage.TypeIndexeddb, storage.TypeWebsql, storage.TypeFileSystems,
+				storage.TypeServiceWorkers,
+			},
+		},
+		{
+			name: "cookies only",
+			opts: ClearDataOptions{Cookies: true},
+			want: []storage.Type{storage.TypeCookies},
+		},
+		{
+			name: "cache and service workers",
+			opts: ClearDataOptions{Cache: true, ServiceWorkers: true},
+			want: []storage.Type{storage.TypeCacheStorage, storage.TypeServiceWorkers},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.storageTypes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("storageTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"sort"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/net/publicsuffix"
+)
+
+// scriptTimingScript resolves to the scripts that ran in the long animation
+// frames of the page, with how long each ran.
+const scriptTimingScript = `new Promise(resolve => {
+	const scripts = [];
+	try {
+		new PerformanceObserver(list => {
+			for (const frame of list.getEntries()) {
+				for (const script of frame.scripts || []) {
+					if (script.sourceURL) scripts.push({url: script.sourceURL, durationMs: script.duration});
+				}
+			}
+		}).observe({type: 'long-animation-frame', buffered: true});
+	} catch (e) {}
+	setTimeout(() => resolve(scripts), 50);
+})`
+
+// ScriptTiming is how long a script ran in a long animation frame.
+type ScriptTiming struct {
+	URL        string  `json:"url"`
+	DurationMs float64 `json:"durationMs"`
+}
+
+// AuditThirdParties loads url with the browser cache disabled and sums its
+// requests, transferred bytes and main-thread blocking time by site, the
+// first party, the site of the page, apart.
+func AuditThirdParties(ctx context.Context, url string, waitUntil string) (*models.ThirdPartyAudit, error) {
+	ctx, span := startSpan(ctx, "audit.third_party", attribute.String("url.full", url))
+	audit, err := runThirdPartyAudit(ctx, url, waitUntil)
+	if audit != nil {
+		span.SetAttributes(attribute.Int("browser_tools.third_parties", len(audit.ThirdParties)))
+	}
+	endSpan(span, err)
+	return audit, err
+}
+
+// runThirdPartyAudit implements AuditThirdParties.
+func runThirdPartyAudit(ctx context.Context, url string, waitUntil string) (*models.ThirdPartyAudit, error) {
+	if err := SetCacheDisabled(ctx, true); err != nil {
+		return nil, err
+	}
+	resources := newResourceCollector()
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chromedp.ListenTarget(listenCtx, resources.handle)
+
+	result, err := runNavigation(ctx, url, NavigateOptions{WaitUntil: waitUntil})
+	if err != nil {
+		return nil, err
+	}
+	var scripts []ScriptTiming
+	err = chromedp.Run(ctx, EvaluateIsolated(scriptTimingScript, &scripts, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+		return p.WithAwaitPromise(true)
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script timings: %w", err)
+	}
+	audit := SummarizeThirdParties(result.FinalURL, resources.list(), scripts)
+	audit.URL = url
+	return audit, nil
+}
+
+// SummarizeThirdParties groups resources and script timings by site, the site
+// of pageURL being the first party. Requests that don't go to a host, such
+// as data: URLs, aren't counted.
+func SummarizeThirdParties(pageURL string, resources []models.Resource, scripts []ScriptTiming) *models.ThirdPartyAudit {
+	firstParty := siteOf(pageURL)
+	sites := map[string]*models.ThirdPartyUsage{}
+	usage := func(rawURL string) *models.ThirdPartyUsage {
+		site := siteOf(rawURL)
+		if site == "" {
+			return nil
+		}
+		u, ok := sites[site]
+		if !ok {
+			u = &models.ThirdPartyUsage{Domain: site, Hosts: []string{}}
+			sites[site] = u
+		}
+		if parsed, err := url.Parse(rawURL); err == nil && !slices.Contains(u.Hosts, parsed.Hostname()) {
+			u.Hosts = append(u.Hosts, parsed.Hostname())
+		}
+		return u
+	}
+	for _, res := range resources {
+		if u := usage(res.URL); u != nil {
+			u.Requests++
+			u.TransferBytes += res.TransferBytes
+		}
+	}
+	for _, script := range scripts {
+		if u := usage(script.URL); u != nil {
+			u.BlockingMs += script.DurationMs
+		}
+	}
+
+	audit := &models.ThirdPartyAudit{FinalURL: pageURL, FirstParty: models.ThirdPartyUsage{Domain: firstParty, Hosts: []string{}}, ThirdParties: []models.ThirdPartyUsage{}}
+	for site, u := range sites {
+		sort.Strings(u.Hosts)
+		if site == firstParty {
+			audit.FirstParty = *u
+		} else {
+			audit.ThirdParties = append(audit.ThirdParties, *u)
+		}
+	}
+	sort.Slice(audit.ThirdParties, func(i, j int) bool {
+		a, b := audit.ThirdParties[i], audit.ThirdParties[j]
+		if a.BlockingMs != b.BlockingMs {
+			return a.BlockingMs > b.BlockingMs
+		}
+		if a.TransferBytes != b.TransferBytes {
+			return a.TransferBytes > b.TransferBytes
+		}
+		return a.Domain < b.Domain
+	})
+	return audit
+}
+
+// siteOf returns the registrable domain (eTLD+1) of a URL, e.g. example.co.uk
+// for https://cdn.example.co.uk/, or its host when it has none, such as
+// localhost or an IP address. It is empty for URLs without a host.
+func siteOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	if net.ParseIP(u.Hostname()) != nil {
+		return u.Hostname()
+	}
+	site, err := publicsuffix.EffectiveTLDPlusOne(u.Hostname())
+	if err != nil {
+		return u.Hostname()
+	}
+	return site
+}
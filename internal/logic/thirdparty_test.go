@@ -0,0 +1,70 @@
+package logic
+
+import (
+	"testing"
+
+	"browser-tools-go/internal/models"
+)
+
+func TestSiteOf(t *testing.T) {
+	tests := map[string]string{
+		"https://www.example.com/":        "example.com",
+		"https://cdn.example.co.uk/a.js":  "example.co.uk",
+		"https://user.github.io/":         "user.github.io",
+		"http://localhost:8080/":          "localhost",
+		"http://127.0.0.1:3000/api":       "127.0.0.1",
+		"data:image/png;base64,AAAA":      "",
+		"blob:https://example.com/1234-5": "",
+	}
+	for rawURL, want := range tests {
+		if got := siteOf(rawURL); got != want {
+			t.Errorf("siteOf(%q) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
+
+func TestSummarizeThirdParties(t *testing.T) {
+	resources := []models.Resource{
+		{URL: "https://www.shop.test/", Type: "Document", TransferBytes: 20000},
+		{URL: "https://static.shop.test/app.js", Type: "Script", TransferBytes: 100000},
+		{URL: "https://www.googletagmanager.com/gtm.js", Type: "Script", TransferBytes: 90000},
+		{URL: "https://www.google-analytics.com/g/collect", Type: "Ping"},
+		{URL: "https://cdn.ads.test/ad.js", Type: "Script", TransferBytes: 150000},
+		{URL: "https://img.ads.test/banner.png", Type: "Image", TransferBytes: 30000},
+		{URL: "data:image/gif;base64,R0lGOD", Type: "Image"},
+	}
+	scripts := []ScriptTiming{
+		{URL: "https://www.googletagmanager.com/gtm.js", DurationMs: 120},
+		{URL: "https://static.shop.test/app.js", DurationMs: 80},
+		{URL: "https://www.googletagmanager.com/gtm.js", DurationMs: 30},
+	}
+	audit := SummarizeThirdParties("https://www.shop.test/", resources, scripts)
+
+	first := audit.FirstParty
+	if first.Domain != "shop.test" || first.Requests != 2 || first.TransferBytes != 120000 || first.BlockingMs != 80 {
+		t.Errorf("FirstParty = %+v", first)
+	}
+	if len(first.Hosts) != 2 || first.Hosts[0] != "static.shop.test" {
+		t.Errorf("FirstParty.Hosts = %v", first.Hosts)
+	}
+	var domains []string
+	for _, tp := range audit.ThirdParties {
+		domains = append(domains, tp.Domain)
+	}
+	want := []string{"googletagmanager.com", "ads.test", "google-analytics.com"}
+	if len(domains) != len(want) {
+		t.Fatalf("ThirdParties = %v, want %v", domains, want)
+	}
+	for i := range want {
+		if domains[i] != want[i] {
+			t.Errorf("ThirdParties = %v, want %v", domains, want)
+			break
+		}
+	}
+	if gtm := audit.ThirdParties[0]; gtm.BlockingMs != 150 || gtm.Requests != 1 {
+		t.Errorf("googletagmanager.com = %+v", gtm)
+	}
+	if ads := audit.ThirdParties[1]; ads.Requests != 2 || ads.TransferBytes != 180000 || len(ads.Hosts) != 2 {
+		t.Errorf("ads.test = %+v", ads)
+	}
+}
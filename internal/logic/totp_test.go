@@ -0,0 +1,58 @@
+package logic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTOTP(t *testing.T) {
+	// Test vectors of RFC 6238, appendix B.
+	const (
+		sha1Secret   = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+		sha256Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZA"
+		sha512Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNA"
+	)
+	tests := []struct {
+		secret string
+		unix   int64
+		want   string
+	}{
+		{"otpauth://totp/test?digits=8&secret=" + sha1Secret, 59, "94287082"},
+		{"otpauth://totp/test?digits=8&algorithm=SHA256&secret=" + sha256Secret, 59, "46119246"},
+		{"otpauth://totp/test?digits=8&algorithm=SHA512&secret=" + sha512Secret, 59, "90693936"},
+		{"otpauth://totp/test?digits=8&secret=" + sha1Secret, 1111111109, "07081804"},
+		{"otpauth://totp/test?digits=8&secret=" + sha1Secret, 20000000000, "65353130"},
+		// 6 digits by default; spaces and lower case are accepted.
+		{"gezd gnbv gy3t qojq gezd gnbv gy3t qojq", 59, "287082"},
+	}
+	for _, tt := range tests {
+		code, _, err := TOTP(tt.secret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Errorf("TOTP(%q, %d) failed: %v", tt.secret, tt.unix, err)
+			continue
+		}
+		if code != tt.want {
+			t.Errorf("TOTP(%q, %d) = %s, want %s", tt.secret, tt.unix, code, tt.want)
+		}
+	}
+
+	_, validFor, _ := TOTP(sha1Secret, time.Unix(59, 0))
+	if validFor != time.Second {
+		t.Errorf("validFor = %s, want 1s", validFor)
+	}
+}
+
+func TestTOTP_Invalid(t *testing.T) {
+	for _, secret := range []string{
+		"",
+		"not base32!",
+		"otpauth://hotp/test?secret=GEZDGNBV",
+		"otpauth://totp/test?secret=GEZDGNBV&digits=4",
+		"otpauth://totp/test?secret=GEZDGNBV&algorithm=MD5",
+		"otpauth://totp/test",
+	} {
+		if _, _, err := TOTP(secret, time.Now()); err == nil {
+			t.Errorf("TOTP(%q): expected an error", secret)
+		}
+	}
+}
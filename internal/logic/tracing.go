@@ -0,0 +1,29 @@
+package logic
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans of the logic layer. It is a no-op until a tracer
+// provider is installed (see the OTEL_* environment variables in the README).
+var tracer = otel.Tracer("browser-tools-go/internal/logic")
+
+// startSpan starts a span as a child of the span in ctx. The returned context
+// still carries the browser tab of ctx.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan marks span as failed when err is not nil and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
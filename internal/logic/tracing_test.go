@@ -0,0 +1,65 @@
+package logic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chromedp/chromedp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestLogicSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer provider.Shutdown(context.Background())
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "command")
+	// Without a reachable browser the calls fail, which must show on their spans.
+	allocCtx, cancelAlloc := chromedp.NewRemoteAllocator(ctx, "ws://127.0.0.1:1/")
+	defer cancelAlloc()
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	defer cancel()
+	if _, err := Navigate(ctx, "https://example.com/", NavigateOptions{}); err == nil {
+		t.Fatal("Expected Navigate to fail without a browser")
+	}
+	if err := FetchWithRetry(ctx, "https://example.com/", 2); err == nil {
+		t.Fatal("Expected FetchWithRetry to fail without a browser")
+	}
+	parent.End()
+
+	// navigate, one span per retry attempt, and the command span.
+	spans := recorder.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("Expected 4 spans, got %d", len(spans))
+	}
+	for _, span := range spans[:3] {
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("Span %q is not a child of the command span", span.Name())
+		}
+		if span.Status().Code != codes.Error {
+			t.Errorf("Expected span %q to be marked failed", span.Name())
+		}
+	}
+	if spans[0].Name() != "navigate" || !hasAttribute(spans[0].Attributes(), attribute.String("url.full", "https://example.com/")) {
+		t.Errorf("Unexpected navigate span %q %v", spans[0].Name(), spans[0].Attributes())
+	}
+	for i, span := range spans[1:3] {
+		if span.Name() != "navigate attempt" || !hasAttribute(span.Attributes(), attribute.Int("browser_tools.attempt", i+1)) {
+			t.Errorf("Unexpected retry span %q %v", span.Name(), span.Attributes())
+		}
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,280 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Location is a parsed s3://bucket/prefix destination.
+type S3Location struct {
+	Bucket string
+	Prefix string
+}
+
+// ParseS3URL parses an s3://bucket[/prefix] URL.
+func ParseS3URL(raw string) (*S3Location, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "s3" || u.Host == "" {
+		return nil, fmt.Errorf("invalid upload destination '%s': expected s3://bucket/prefix", raw)
+	}
+	return &S3Location{Bucket: u.Host, Prefix: strings.Trim(u.Path, "/")}, nil
+}
+
+// Key returns the object key for a file name under the location's prefix.
+func (l *S3Location) Key(name string) string {
+	if l.Prefix == "" {
+		return name
+	}
+	return l.Prefix + "/" + name
+}
+
+// AWSCredentials are the credentials and endpoint settings read from the standard AWS environment variables.
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Region          string
+	// Endpoint overrides the S3 endpoint (e.g. MinIO); path-style addressing is used when set.
+	Endpoint string
+}
+
+// AWSCredentialsFromEnv reads AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY,
+// AWS_SESSION_TOKEN, AWS_REGION / AWS_DEFAULT_REGION and AWS_ENDPOINT_URL_S3 / AWS_ENDPOINT_URL.
+func AWSCredentialsFromEnv() (*AWSCredentials, error) {
+	creds := &AWSCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		Region:          firstNonEmpty(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"), "us-east-1"),
+		Endpoint:        firstNonEmpty(os.Getenv("AWS_ENDPOINT_URL_S3"), os.Getenv("AWS_ENDPOINT_URL")),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return nil, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+	}
+	return creds, nil
+}
+
+// UploadFile uploads a local file to the S3 destination and returns its s3:// URL.
+func UploadFile(ctx context.Context, destination, filePath string) (string, error) {
+	loc, err := ParseS3URL(destination)
+	if err != nil {
+		return "", err
+	}
+	creds, err := AWSCredentialsFromEnv()
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	key := loc.Key(filepath.Base(filePath))
+	if err := putS3Object(ctx, creds, loc.Bucket, key, data, contentTypeFor(filePath)); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("s3://%s/%s", loc.Bucket, key), nil
+}
+
+// UploadDir uploads the files below the local directory dir to the S3
+// destination, keyed by their paths below the name of dir, and returns the
+// s3:// URL of the directory.
+func UploadDir(ctx context.Context, destination, dir string) (string, error) {
+	loc, err := ParseS3URL(destination)
+	if err != nil {
+		return "", err
+	}
+	creds, err := AWSCredentialsFromEnv()
+	if err != nil {
+		return "", err
+	}
+	base := filepath.Base(filepath.Clean(dir))
+	err = filepath.WalkDir(dir, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		key := loc.Key(path.Join(base, filepath.ToSlash(rel)))
+		return putS3Object(ctx, creds, loc.Bucket, key, data, contentTypeFor(filePath))
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("s3://%s/%s", loc.Bucket, loc.Key(base)), nil
+}
+
+// putS3Object PUTs data as bucket/key using a SigV4-signed request.
+func putS3Object(ctx context.Context, creds *AWSCredentials, bucket, key string, data []byte, contentType string) error {
+	var objectURL string
+	if creds.Endpoint != "" {
+		objectURL = strings.TrimRight(creds.Endpoint, "/") + "/" + bucket + "/" + key
+	} else {
+		objectURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, creds.Region, key)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	// Send the path exactly as it is canonicalized for signing.
+	req.URL.RawPath = awsURIEncode(req.URL.Path, false)
+	req.Header.Set("Content-Type", contentType)
+	signS3Request(req, creds, sha256Hex(data), time.Now().UTC())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload to s3://%s/%s: %w", bucket, key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("upload to s3://%s/%s failed with status %s", bucket, key, resp.Status)
+	}
+	return nil
+}
+
+// signS3Request adds AWS Signature Version 4 headers for the s3 service to req,
+// signing the host and every header already set on the request.
+func signS3Request(req *http.Request, creds *AWSCredentials, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		awsURIEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + creds.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, creds.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes query parameters sorted by key as required by SigV4.
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, awsURIEncode(k, true)+"="+awsURIEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsURIEncode percent-encodes everything except unreserved characters; '/'
+// is kept as-is unless encodeSlash is set.
+func awsURIEncode(s string, encodeSlash bool) string {
+	if s == "" {
+		return "/"
+	}
+	var sb strings.Builder
+	for _, b := range []byte(s) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			sb.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			sb.WriteByte(b)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", b)
+		}
+	}
+	return sb.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// contentTypeFor guesses the Content-Type of an artifact from its extension.
+func contentTypeFor(filePath string) string {
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".har":
+		return "application/json"
+	case ".mhtml":
+		return "multipart/related"
+	case ".gz":
+		return "application/gzip"
+	}
+	if ct := mime.TypeByExtension(filepath.Ext(filePath)); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,31 @@
+package logic
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/andybalholm/cascadia"
+)
+
+// ValidateSelector checks that selector is a valid CSS selector (group), as
+// the commands taking one query the page with it. It can't tell whether a
+// browser supports every pseudo-class the selector uses.
+func ValidateSelector(selector string) error {
+	if _, err := cascadia.ParseGroup(selector); err != nil {
+		return fmt.Errorf("invalid selector %q: %w", selector, err)
+	}
+	return nil
+}
+
+// ValidateAbsoluteURL checks that rawURL is an absolute URL, as pages to visit
+// and endpoints to send results to must be.
+func ValidateAbsoluteURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if !u.IsAbs() {
+		return fmt.Errorf("invalid URL %q: scheme missing (e.g. https://)", rawURL)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package logic
+
+import "testing"
+
+func TestValidateSelector(t *testing.T) {
+	for _, selector := range []string{"main", "#id > .a, .b", "a[href^='https']", "li:nth-child(2n+1)", "div:has(img)"} {
+		if err := ValidateSelector(selector); err != nil {
+			t.Errorf("ValidateSelector(%q) failed: %v", selector, err)
+		}
+	}
+	for _, selector := range []string{"", "div[", "a >", "##x"} {
+		if err := ValidateSelector(selector); err == nil {
+			t.Errorf("ValidateSelector(%q) succeeded, want error", selector)
+		}
+	}
+}
+
+func TestValidateAbsoluteURL(t *testing.T) {
+	for _, u := range []string{"https://example.com", "http://localhost:8080/a?b=c", "about:blank", "file:///tmp/a.html"} {
+		if err := ValidateAbsoluteURL(u); err != nil {
+			t.Errorf("ValidateAbsoluteURL(%q) failed: %v", u, err)
+		}
+	}
+	for _, u := range []string{"example.com", "/path", "http://[::1", ""} {
+		if err := ValidateAbsoluteURL(u); err == nil {
+			t.Errorf("ValidateAbsoluteURL(%q) succeeded, want error", u)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package logic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"browser-tools-go/internal/i18n"
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// Buffer sizes for response bodies kept by the browser while recording, so
+// bodies are still available when the writer gets to them.
+const (
+	warcMaxTotalBuffer    = 100 << 20
+	warcMaxResourceBuffer = 20 << 20
+)
+
+// warcQueueSize bounds the number of finished requests waiting for their
+// bodies to be fetched and written.
+const warcQueueSize = 4096
+
+// warcHopHeaders are response headers that describe the wire encoding. Bodies
+// are recorded decoded, so these are dropped and Content-Length is rewritten.
+var warcHopHeaders = map[string]bool{
+	"content-encoding":  true,
+	"transfer-encoding": true,
+	"content-length":    true,
+}
+
+// WARCWriter writes WARC/1.1 records. When compressing, every record is its
+// own gzip member, as expected by replay tools, so files can be appended to.
+type WARCWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	compress bool
+}
+
+// NewWARCWriter returns a writer emitting records to w.
+func NewWARCWriter(w io.Writer, compress bool) *WARCWriter {
+	return &WARCWriter{w: w, compress: compress}
+}
+
+// warcField is a single WARC header line.
+type warcField struct {
+	name, value string
+}
+
+// WriteWarcinfo writes the warcinfo record describing the capturing software.
+func (w *WARCWriter) WriteWarcinfo(software string) error {
+	block := fmt.Sprintf("software: %s\r\nformat: WARC File Format 1.1\r\n", software)
+	return w.writeRecord([]warcField{
+		{"WARC-Type", "warcinfo"},
+		{"WARC-Record-ID", newWARCRecordID()},
+		{"WARC-Date", warcDate(time.Now())},
+		{"Content-Type", "application/warc-fields"},
+	}, []byte(block))
+}
+
+// WriteExchange writes the response record for entry followed by its
+// concurrent request record.
+func (w *WARCWriter) WriteExchange(entry *models.NetworkEntry, body []byte) error {
+	date := warcDate(entry.StartedAt)
+	responseID := newWARCRecordID()
+	response := httpResponseBlock(entry, body)
+	if err := w.writeRecord([]warcField{
+		{"WARC-Type", "response"},
+		{"WARC-Record-ID", responseID},
+		{"WARC-Date", date},
+		{"WARC-Target-URI", entry.URL},
+		{"WARC-Payload-Digest", warcDigest(body)},
+		{"Content-Type", "application/http;msgtype=response"},
+	}, response); err != nil {
+		return err
+	}
+	return w.writeRecord([]warcField{
+		{"WARC-Type", "request"},
+		{"WARC-Record-ID", newWARCRecordID()},
+		{"WARC-Date", date},
+		{"WARC-Target-URI", entry.URL},
+		{"WARC-Concurrent-To", responseID},
+		{"Content-Type", "application/http;msgtype=request"},
+	}, httpRequestBlock(entry))
+}
+
+// writeRecord serializes a record with its block digest and length.
+func (w *WARCWriter) writeRecord(fields []warcField, block []byte) error {
+	var buf bytes.Buffer
+	buf.WriteString("WARC/1.1\r\n")
+	for _, f := range fields {
+		fmt.Fprintf(&buf, "%s: %s\r\n", f.name, f.value)
+	}
+	fmt.Fprintf(&buf, "WARC-Block-Digest: %s\r\n", warcDigest(block))
+	fmt.Fprintf(&buf, "Content-Length: %d\r\n\r\n", len(block))
+	buf.Write(block)
+	buf.WriteString("\r\n\r\n")
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.compress {
+		_, err := w.w.Write(buf.Bytes())
+		return err
+	}
+	gz := gzip.NewWriter(w.w)
+	if _, err := gz.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// httpRequestBlock rebuilds the HTTP/1.1 request message of an entry.
+func httpRequestBlock(entry *models.NetworkEntry) []byte {
+	target := entry.URL
+	host := ""
+	if u, err := url.Parse(entry.URL); err == nil {
+		target = u.RequestURI()
+		host = u.Host
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s HTTP/1.1\r\n", entry.Method, target)
+	if _, ok := lookupHeader(entry.RequestHeaders, "host"); !ok && host != "" {
+		fmt.Fprintf(&buf, "Host: %s\r\n", host)
+	}
+	writeHTTPHeaders(&buf, entry.RequestHeaders, nil)
+	buf.WriteString("\r\n")
+	buf.WriteString(entry.PostData)
+	return buf.Bytes()
+}
+
+// httpResponseBlock rebuilds the HTTP/1.1 response message of an entry with
+// its decoded body.
+func httpResponseBlock(entry *models.NetworkEntry, body []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "HTTP/1.1 %d %s\r\n", entry.Status, http.StatusText(int(entry.Status)))
+	writeHTTPHeaders(&buf, entry.ResponseHeaders, warcHopHeaders)
+	fmt.Fprintf(&buf, "Content-Length: %d\r\n\r\n", len(body))
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+// writeHTTPHeaders writes headers in a stable order, skipping HTTP/2
+// pseudo-headers and the names in skip. Headers folded into one value by
+// Chrome are split back into separate lines.
+func writeHTTPHeaders(buf *bytes.Buffer, headers map[string]string, skip map[string]bool) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		if strings.HasPrefix(name, ":") || skip[strings.ToLower(name)] {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range strings.Split(headers[name], "\n") {
+			fmt.Fprintf(buf, "%s: %s\r\n", name, value)
+		}
+	}
+}
+
+// lookupHeader finds a header case-insensitively.
+func lookupHeader(headers map[string]string, name string) (string, bool) {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// warcDigest returns the WARC digest of data (base32 SHA-1).
+func warcDigest(data []byte) string {
+	sum := sha1.Sum(data)
+	return "sha1:" + base32.StdEncoding.EncodeToString(sum[:])
+}
+
+// warcDate formats t as a WARC-Date.
+func warcDate(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// newWARCRecordID returns a random UUID record ID.
+func newWARCRecordID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("<urn:uuid:%x-%x-%x-%x-%x>", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// isWARCRecordable reports whether a finished request can be archived.
+func isWARCRecordable(entry *models.NetworkEntry) bool {
+	if entry.Error != "" || entry.Status == 0 {
+		return false
+	}
+	return strings.HasPrefix(entry.URL, "http://") || strings.HasPrefix(entry.URL, "https://")
+}
+
+// WARCRecorder archives the requests made by a tab while it runs.
+type WARCRecorder struct {
+	writer   *WARCWriter
+	redactor *Redactor
+	cancel   context.CancelFunc
+	done     chan struct{}
+
+	mu      sync.Mutex
+	queue   chan *models.NetworkEntry
+	closed  bool
+	dropped int
+	records int
+}
+
+// StartWARCRecorder starts archiving every finished HTTP(S) request of the
+// tab in ctx to writer, with the request and response headers masked by
+// redactor. URLs and bodies are archived as they are, so that the archive
+// replays. Call Stop to flush the pending records.
+func StartWARCRecorder(ctx context.Context, writer *WARCWriter, redactor *Redactor) (*WARCRecorder, error) {
+	listenCtx, cancel := context.WithCancel(ctx)
+	r := &WARCRecorder{
+		writer:   writer,
+		redactor: redactor,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		queue:    make(chan *models.NetworkEntry, warcQueueSize),
+	}
+	capture := newNetworkCapture("", r.enqueue)
+	chromedp.ListenTarget(listenCtx, capture.handle)
+	enable := network.Enable().
+		WithMaxTotalBufferSize(warcMaxTotalBuffer).
+		WithMaxResourceBufferSize(warcMaxResourceBuffer)
+	if err := chromedp.Run(ctx, enable); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to enable network recording: %w", err)
+	}
+	go r.run(ctx)
+	return r, nil
+}
+
+// enqueue hands an entry to the writer goroutine. It runs on the event
+// listener, which must not block on CDP calls, so a full queue drops entries.
+func (r *WARCRecorder) enqueue(entry *models.NetworkEntry) {
+	if !isWARCRecordable(entry) {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	select {
+	case r.queue <- entry:
+	default:
+		r.dropped++
+	}
+}
+
+// run fetches bodies and writes records until the queue is closed.
+func (r *WARCRecorder) run(ctx context.Context) {
+	defer close(r.done)
+	for entry := range r.queue {
+		body := r.responseBody(ctx, entry)
+		if err := r.writer.WriteExchange(r.redactor.ExchangeHeaders(entry), body); err != nil {
+			i18n.Printf("⚠️ Failed to write WARC record for %s: %v", entry.URL, err)
+			continue
+		}
+		r.records++
+	}
+}
+
+// responseBody returns the decoded body of a response; redirects and bodies
+// evicted from the browser are recorded empty.
+func (r *WARCRecorder) responseBody(ctx context.Context, entry *models.NetworkEntry) []byte {
+	if entry.Status >= 300 && entry.Status < 400 {
+		return nil
+	}
+	var body []byte
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		body, err = network.GetResponseBody(network.RequestID(entry.RequestID)).Do(ctx)
+		return err
+	}))
+	if err != nil {
+		return nil
+	}
+	return body
+}
+
+// Stop stops recording, waits for the queued records to be written and
+// returns the number of archived requests.
+func (r *WARCRecorder) Stop() int {
+	r.cancel()
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.queue)
+	}
+	r.mu.Unlock()
+	<-r.done
+	if r.dropped > 0 {
+		i18n.Printf("⚠️ %d requests were not archived because the WARC writer fell behind", r.dropped)
+	}
+	return r.records
+}
+
+// warcSoftware identifies this tool in warcinfo records.
+const warcSoftware = "browser-tools-go"
+
+// OpenWARC starts a recorder writing to w, beginning with a warcinfo record.
+// Paths ending in .gz should be written compressed. See StartWARCRecorder for
+// redactor.
+func OpenWARC(ctx context.Context, w io.Writer, compress bool, redactor *Redactor) (*WARCRecorder, error) {
+	writer := NewWARCWriter(w, compress)
+	if err := writer.WriteWarcinfo(warcSoftware); err != nil {
+		return nil, fmt.Errorf("failed to write WARC header: %w", err)
+	}
+	return StartWARCRecorder(ctx, writer, redactor)
+}
+
+// IsCompressedWARCPath reports whether a WARC path should be gzip-compressed.
+func IsCompressedWARCPath(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), ".gz")
+}
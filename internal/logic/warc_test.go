@@ -0,0 +1,148 @@
+package logic
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"browser-tools-go/internal/models"
+)
+
+func testWARCEntry() *models.NetworkEntry {
+	return &models.NetworkEntry{
+		RequestID: "1",
+		URL:       "https://example.com/search?q=go",
+		Method:    "GET",
+		Status:    200,
+		RequestHeaders: map[string]string{
+			"Accept": "text/html",
+		},
+		ResponseHeaders: map[string]string{
+			":status":          "200",
+			"Content-Type":     "text/html",
+			"Content-Encoding": "gzip",
+			"Content-Length":   "999",
+			"Set-Cookie":       "a=1\nb=2",
+		},
+		StartedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWARCWriter_WriteExchange(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWARCWriter(&buf, false)
+	if err := w.WriteExchange(testWARCEntry(), []byte("<html></html>")); err != nil {
+		t.Fatalf("WriteExchange failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"WARC-Type: response\r\n",
+		"WARC-Type: request\r\n",
+		"WARC-Date: 2024-05-01T12:00:00Z\r\n",
+		"WARC-Target-URI: https://example.com/search?q=go\r\n",
+		"HTTP/1.1 200 OK\r\n",
+		"Content-Length: 13\r\n\r\n<html></html>",
+		"Set-Cookie: a=1\r\nSet-Cookie: b=2\r\n",
+		"GET /search?q=go HTTP/1.1\r\nHost: example.com\r\n",
+		"WARC-Payload-Digest: " + warcDigest([]byte("<html></html>")),
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"Content-Encoding", ":status", "Content-Length: 999"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("output should not contain %q", unwanted)
+		}
+	}
+	if strings.Count(out, "WARC/1.1\r\n") != 2 {
+		t.Errorf("expected 2 records, got %d", strings.Count(out, "WARC/1.1\r\n"))
+	}
+}
+
+func TestWARCWriter_CompressedMembers(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWARCWriter(&buf, true)
+	if err := w.WriteWarcinfo("test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteExchange(testWARCEntry(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	members := 0
+	for {
+		zr.Multistream(false)
+		data, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(data), "WARC/1.1\r\n") {
+			t.Errorf("member %d does not start a record", members)
+		}
+		members++
+		if err := zr.Reset(&buf); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if members != 3 {
+		t.Errorf("expected 3 gzip members (one per record), got %d", members)
+	}
+}
+
+func TestWARCRecordBlockLength(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWARCWriter(&buf, false)
+	if err := w.WriteWarcinfo("test"); err != nil {
+		t.Fatal(err)
+	}
+	r := bufio.NewReader(&buf)
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line == "\r\n" {
+			break
+		}
+		if strings.HasPrefix(line, "Content-Length: ") {
+			if _, err := fmt.Sscan(strings.TrimSpace(strings.TrimPrefix(line, "Content-Length: ")), &length); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	rest, _ := io.ReadAll(r)
+	if len(rest) != length+4 || !strings.HasSuffix(string(rest), "\r\n\r\n") {
+		t.Errorf("block length %d does not match Content-Length %d", len(rest)-4, length)
+	}
+}
+
+func TestIsWARCRecordable(t *testing.T) {
+	tests := []struct {
+		entry models.NetworkEntry
+		want  bool
+	}{
+		{models.NetworkEntry{URL: "https://a.test/", Status: 200}, true},
+		{models.NetworkEntry{URL: "http://a.test/", Status: 301}, true},
+		{models.NetworkEntry{URL: "data:text/plain,hi", Status: 200}, false},
+		{models.NetworkEntry{URL: "https://a.test/", Error: "net::ERR_FAILED"}, false},
+	}
+	for _, tt := range tests {
+		if got := isWARCRecordable(&tt.entry); got != tt.want {
+			t.Errorf("isWARCRecordable(%+v) = %v, want %v", tt.entry, got, tt.want)
+		}
+	}
+}
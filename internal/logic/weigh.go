@@ -0,0 +1,120 @@
+package logic
+
+import (
+	"context"
+	"net/url"
+	"sort"
+
+	"browser-tools-go/internal/models"
+
+	"github.com/chromedp/chromedp"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WeighOptions configures Weigh.
+type WeighOptions struct {
+	Top       int    // number of largest resources listed
+	WaitUntil string // as in NavigateOptions
+}
+
+// Weigh loads url with the browser cache disabled, so that every resource
+// comes from the network, and sums the bytes its requests transferred and
+// decoded by resource type and by domain, with the largest resources.
+func Weigh(ctx context.Context, url string, opts WeighOptions) (*models.PageWeight, error) {
+	ctx, span := startSpan(ctx, "weigh", attribute.String("url.full", url))
+	weight, err := weigh(ctx, url, opts)
+	if weight != nil {
+		span.SetAttributes(attribute.Int64("browser_tools.transfer_bytes", weight.TransferBytes))
+	}
+	endSpan(span, err)
+	return weight, err
+}
+
+// weigh implements Weigh.
+func weigh(ctx context.Context, url string, opts WeighOptions) (*models.PageWeight, error) {
+	if err := SetCacheDisabled(ctx, true); err != nil {
+		return nil, err
+	}
+	resources := newResourceCollector()
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chromedp.ListenTarget(listenCtx, resources.handle)
+
+	result, err := runNavigation(ctx, url, NavigateOptions{WaitUntil: opts.WaitUntil})
+	if err != nil {
+		return nil, err
+	}
+	weight := SummarizeWeight(resources.list(), opts.Top)
+	weight.URL = url
+	weight.FinalURL = result.FinalURL
+	return weight, nil
+}
+
+// SummarizeWeight sums resources by type and by domain and lists the top
+// largest by transferred bytes.
+func SummarizeWeight(resources []models.Resource, top int) *models.PageWeight {
+	weight := &models.PageWeight{Requests: len(resources)}
+	byType := map[string]*models.WeightGroup{}
+	byDomain := map[string]*models.WeightGroup{}
+	add := func(groups map[string]*models.WeightGroup, name string, res models.Resource) {
+		group, ok := groups[name]
+		if !ok {
+			group = &models.WeightGroup{Name: name}
+			groups[name] = group
+		}
+		group.Requests++
+		group.TransferBytes += res.TransferBytes
+		group.DecodedBytes += res.DecodedBytes
+	}
+	for _, res := range resources {
+		weight.TransferBytes += res.TransferBytes
+		weight.DecodedBytes += res.DecodedBytes
+		add(byType, res.Type, res)
+		add(byDomain, resourceDomain(res.URL), res)
+	}
+	weight.ByType = heaviestGroups(byType)
+	weight.ByDomain = heaviestGroups(byDomain)
+
+	largest := append([]models.Resource(nil), resources...)
+	sort.SliceStable(largest, func(i, j int) bool {
+		return heavier(largest[i].TransferBytes, largest[i].DecodedBytes, largest[j].TransferBytes, largest[j].DecodedBytes)
+	})
+	weight.Largest = largest[:min(top, len(largest))]
+	return weight
+}
+
+// resourceDomain returns the host of a resource URL, or its scheme, such as
+// data:, when it has none.
+func resourceDomain(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	if u.Hostname() == "" {
+		return u.Scheme + ":"
+	}
+	return u.Hostname()
+}
+
+// heaviestGroups returns groups, heaviest first.
+func heaviestGroups(groups map[string]*models.WeightGroup) []models.WeightGroup {
+	list := make([]models.WeightGroup, 0, len(groups))
+	for _, group := range groups {
+		list = append(list, *group)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].TransferBytes == list[j].TransferBytes && list[i].DecodedBytes == list[j].DecodedBytes {
+			return list[i].Name < list[j].Name
+		}
+		return heavier(list[i].TransferBytes, list[i].DecodedBytes, list[j].TransferBytes, list[j].DecodedBytes)
+	})
+	return list
+}
+
+// heavier orders by transferred bytes, then by decoded bytes.
+func heavier(transferA, decodedA, transferB, decodedB int64) bool {
+	if transferA != transferB {
+		return transferA > transferB
+	}
+	return decodedA > decodedB
+}
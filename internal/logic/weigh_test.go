@@ -0,0 +1,49 @@
+package logic
+
+import (
+	"testing"
+
+	"browser-tools-go/internal/models"
+)
+
+func TestSummarizeWeight(t *testing.T) {
+	resources := []models.Resource{
+		{URL: "https://a.test/", Type: "Document", TransferBytes: 20000, DecodedBytes: 80000},
+		{URL: "https://a.test/app.js", Type: "Script", TransferBytes: 300000, DecodedBytes: 900000},
+		{URL: "https://cdn.test/vendor.js", Type: "Script", TransferBytes: 250000, DecodedBytes: 700000},
+		{URL: "https://cdn.test/hero.jpg", Type: "Image", TransferBytes: 400000, DecodedBytes: 400000},
+		{URL: "data:image/png;base64,AAAA", Type: "Image", DecodedBytes: 3},
+	}
+	weight := SummarizeWeight(resources, 2)
+
+	if weight.Requests != 5 || weight.TransferBytes != 970000 || weight.DecodedBytes != 2080003 {
+		t.Errorf("totals = %d requests, %d transferred, %d decoded", weight.Requests, weight.TransferBytes, weight.DecodedBytes)
+	}
+	wantTypes := []models.WeightGroup{
+		{Name: "Script", Requests: 2, TransferBytes: 550000, DecodedBytes: 1600000},
+		{Name: "Image", Requests: 2, TransferBytes: 400000, DecodedBytes: 400003},
+		{Name: "Document", Requests: 1, TransferBytes: 20000, DecodedBytes: 80000},
+	}
+	if len(weight.ByType) != len(wantTypes) {
+		t.Fatalf("ByType = %+v", weight.ByType)
+	}
+	for i, want := range wantTypes {
+		if weight.ByType[i] != want {
+			t.Errorf("ByType[%d] = %+v, want %+v", i, weight.ByType[i], want)
+		}
+	}
+	var domains []string
+	for _, group := range weight.ByDomain {
+		domains = append(domains, group.Name)
+	}
+	if len(domains) != 3 || domains[0] != "cdn.test" || domains[1] != "a.test" || domains[2] != "data:" {
+		t.Errorf("ByDomain = %v", domains)
+	}
+	if len(weight.Largest) != 2 || weight.Largest[0].URL != "https://cdn.test/hero.jpg" || weight.Largest[1].URL != "https://a.test/app.js" {
+		t.Errorf("Largest = %+v", weight.Largest)
+	}
+
+	if weight := SummarizeWeight(nil, 10); len(weight.Largest) != 0 || weight.ByType == nil {
+		t.Errorf("empty weight = %+v", weight)
+	}
+}
@@ -0,0 +1,131 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/dom"
+	"github.com/chromedp/cdproto/inspector"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// isolatedWorldName names the isolated world in which the tool's helper scripts
+// run. It shares the DOM with the page but not its JavaScript globals, so page
+// scripts can't break the helpers (e.g. by overriding Array.from or fetch) and
+// the helpers leave no traces on the page.
+const isolatedWorldName = "browser-tools-go"
+
+// worldCache holds the isolated worlds created in the frames of a tab, so
+// that helpers don't create a new world on every call. A frame's world goes
+// away when the frame navigates, and its entry with it.
+type worldCache struct {
+	mu     sync.Mutex
+	worlds map[cdp.FrameID]runtime.ExecutionContextID
+}
+
+// worldCaches maps each *chromedp.Target to its *worldCache.
+var worldCaches sync.Map
+
+// worldCacheOf returns the cache of the tab of ctx, or nil when ctx isn't
+// running commands on the tab it is attached to.
+func worldCacheOf(ctx context.Context) *worldCache {
+	c := chromedp.FromContext(ctx)
+	if c == nil || c.Target == nil || cdp.ExecutorFromContext(ctx) != cdp.Executor(c.Target) {
+		return nil
+	}
+	if cache, ok := worldCaches.Load(c.Target); ok {
+		return cache.(*worldCache)
+	}
+	cache, loaded := worldCaches.LoadOrStore(c.Target, &worldCache{worlds: map[cdp.FrameID]runtime.ExecutionContextID{}})
+	if !loaded {
+		// The cache lives as long as the tab, not as the command that
+		// happened to create it first.
+		t := c.Target
+		chromedp.ListenTarget(context.WithoutCancel(ctx), cache.(*worldCache).invalidate(func() { worldCaches.Delete(t) }))
+	}
+	return cache.(*worldCache)
+}
+
+// invalidate returns the event listener dropping the worlds that navigations
+// and reloads destroy; forget is called when the tab goes away.
+func (w *worldCache) invalidate(forget func()) func(ev interface{}) {
+	return func(ev interface{}) {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		switch ev := ev.(type) {
+		case *page.EventFrameNavigated:
+			delete(w.worlds, ev.Frame.ID)
+		case *runtime.EventExecutionContextDestroyed:
+			for frame, id := range w.worlds {
+				if id == ev.ExecutionContextID {
+					delete(w.worlds, frame)
+				}
+			}
+		case *runtime.EventExecutionContextsCleared:
+			clear(w.worlds)
+		case *inspector.EventDetached, *inspector.EventTargetCrashed:
+			clear(w.worlds)
+			forget()
+		}
+	}
+}
+
+// isolatedContext returns the execution context of the isolated world in the
+// main frame, creating the world the first time and after each navigation.
+func isolatedContext(ctx context.Context) (runtime.ExecutionContextID, error) {
+	tree, err := page.GetFrameTree().Do(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get frame tree: %w", err)
+	}
+	frame := tree.Frame.ID
+	cache := worldCacheOf(ctx)
+	if cache != nil {
+		cache.mu.Lock()
+		id, ok := cache.worlds[frame]
+		cache.mu.Unlock()
+		if ok {
+			return id, nil
+		}
+	}
+	id, err := page.CreateIsolatedWorld(frame).WithWorldName(isolatedWorldName).Do(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create isolated world: %w", err)
+	}
+	if cache != nil {
+		cache.mu.Lock()
+		cache.worlds[frame] = id
+		cache.mu.Unlock()
+	}
+	return id, nil
+}
+
+// EvaluateIsolated is chromedp.Evaluate run in the isolated world of the main
+// frame.
+func EvaluateIsolated(expression string, res interface{}, opts ...chromedp.EvaluateOption) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		id, err := isolatedContext(ctx)
+		if err != nil {
+			return err
+		}
+		evalOpts := append(append([]chromedp.EvaluateOption{}, opts...), func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+			return p.WithContextID(id)
+		})
+		return chromedp.Evaluate(expression, res, evalOpts...).Do(ctx)
+	})
+}
+
+// resolveNodeIsolated resolves a DOM node to an object in the isolated world id.
+func resolveNodeIsolated(ctx context.Context, nodeID cdp.NodeID, id runtime.ExecutionContextID) (*runtime.RemoteObject, error) {
+	obj, err := dom.ResolveNode().WithNodeID(nodeID).WithExecutionContextID(id).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve node: %w", err)
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("resolved node object is nil")
+	}
+	return obj, nil
+}
@@ -0,0 +1,37 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/inspector"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/runtime"
+)
+
+func TestWorldCacheInvalidate(t *testing.T) {
+	forgotten := false
+	cache := &worldCache{worlds: map[cdp.FrameID]runtime.ExecutionContextID{"main": 1, "child": 2}}
+	handle := cache.invalidate(func() { forgotten = true })
+
+	handle(&runtime.EventExecutionContextDestroyed{ExecutionContextID: 2})
+	if _, ok := cache.worlds["child"]; ok || len(cache.worlds) != 1 {
+		t.Errorf("expected the destroyed world to be dropped, got %v", cache.worlds)
+	}
+
+	handle(&page.EventFrameNavigated{Frame: &cdp.Frame{ID: "main"}})
+	if len(cache.worlds) != 0 {
+		t.Errorf("expected the world of the navigated frame to be dropped, got %v", cache.worlds)
+	}
+
+	cache.worlds["main"] = 3
+	handle(&runtime.EventExecutionContextsCleared{})
+	if len(cache.worlds) != 0 || forgotten {
+		t.Errorf("expected a reload to clear the worlds only, got %v", cache.worlds)
+	}
+
+	handle(&inspector.EventDetached{})
+	if !forgotten {
+		t.Error("expected the cache to be forgotten when the tab goes away")
+	}
+}
@@ -1,10 +1,19 @@
 package models
 
+import "time"
+
 // SearchResult represents a single search engine result.
 type SearchResult struct {
 	Title   string `json:"title"`
 	Link    string `json:"link"`
 	Snippet string `json:"snippet"`
+	// Date is the date Google displays for the result, as it displays it,
+	// e.g. "3 days ago" or "Oct 1, 2026".
+	Date      string `json:"date,omitempty"`
+	Favicon   string `json:"favicon,omitempty"`
+	Thumbnail string `json:"thumbnail,omitempty"` // image results only
+	// Type is organic, video, news or image.
+	Type    string `json:"type,omitempty"`
 	Content string `json:"content,omitempty"`
 }
 
@@ -28,3 +37,677 @@ type ElementInfo struct {
 	Rect     map[string]interface{} `json:"rect"`
 	Children []ElementInfo          `json:"children"`
 }
+
+// WatchEvent reports the outcome of a single check of a watched page region.
+type WatchEvent struct {
+	URL          string    `json:"url"`
+	Selector     string    `json:"selector,omitempty"`
+	CheckedAt    time.Time `json:"checkedAt"`
+	Changed      bool      `json:"changed"`
+	First        bool      `json:"first,omitempty"`
+	PreviousHash string    `json:"previousHash,omitempty"`
+	Hash         string    `json:"hash"`
+	Added        int       `json:"added"`
+	Removed      int       `json:"removed"`
+	Diff         string    `json:"diff,omitempty"`
+	Content      string    `json:"content"`
+}
+
+// EvalWatchEvent is a result of an expression watched by eval --watch,
+// reported whenever it differs from the previous one.
+type EvalWatchEvent struct {
+	CheckedAt time.Time   `json:"checkedAt"`
+	Value     interface{} `json:"value"`
+}
+
+// FieldChange is a single difference between two JSON documents.
+type FieldChange struct {
+	Path string      `json:"path"`
+	Kind string      `json:"kind"` // added, removed or changed
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// SnapshotDiff is the structural and textual difference between two snapshots.
+type SnapshotDiff struct {
+	Changed  bool          `json:"changed"`
+	Changes  []FieldChange `json:"changes"`
+	TextDiff string        `json:"textDiff,omitempty"`
+	Added    int           `json:"added"`
+	Removed  int           `json:"removed"`
+}
+
+// WebhookBatch is the envelope POSTed to result webhooks.
+type WebhookBatch struct {
+	Source  string      `json:"source"`
+	SentAt  time.Time   `json:"sentAt"`
+	Results interface{} `json:"results"`
+}
+
+// CrawlPage is a single page visited by a crawl.
+type CrawlPage struct {
+	URL       string    `json:"url"`
+	Depth     int       `json:"depth"`
+	Title     string    `json:"title,omitempty"`
+	Content   string    `json:"content,omitempty"`
+	Links     int       `json:"links"`
+	Error     string    `json:"error,omitempty"`
+	CrawledAt time.Time `json:"crawledAt"`
+}
+
+// ExportedDoc is a page written as a file by content --out-dir or crawl
+// --out-dir.
+type ExportedDoc struct {
+	Path  string `json:"path"`
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+}
+
+// IDBDatabase describes an IndexedDB database and its object stores.
+type IDBDatabase struct {
+	Name         string           `json:"name"`
+	Version      float64          `json:"version"`
+	ObjectStores []IDBObjectStore `json:"objectStores"`
+}
+
+// IDBObjectStore describes an IndexedDB object store.
+type IDBObjectStore struct {
+	Name          string   `json:"name"`
+	KeyPath       string   `json:"keyPath,omitempty"`
+	AutoIncrement bool     `json:"autoIncrement"`
+	Indexes       []string `json:"indexes"`
+	Entries       int64    `json:"entries"`
+}
+
+// IDBRecord is a single record of an IndexedDB object store.
+type IDBRecord struct {
+	Key   interface{} `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// IDBStoreDump holds all records of one IndexedDB object store.
+type IDBStoreDump struct {
+	Database string      `json:"database"`
+	Store    string      `json:"store"`
+	Records  []IDBRecord `json:"records"`
+}
+
+// ServiceWorker is a service worker registration with its versions.
+type ServiceWorker struct {
+	RegistrationID string                 `json:"registrationId"`
+	ScopeURL       string                 `json:"scopeUrl"`
+	Versions       []ServiceWorkerVersion `json:"versions"`
+}
+
+// ServiceWorkerVersion is one version of a registered service worker script.
+type ServiceWorkerVersion struct {
+	VersionID     string `json:"versionId"`
+	ScriptURL     string `json:"scriptUrl"`
+	RunningStatus string `json:"runningStatus"`
+	Status        string `json:"status"`
+}
+
+// NetworkEntry is a single request captured by the network log.
+type NetworkEntry struct {
+	Kind         string `json:"kind"` // always "request"
+	RequestID    string `json:"requestId"`
+	URL          string `json:"url"`
+	Method       string `json:"method"`
+	ResourceType string `json:"resourceType,omitempty"`
+	Status       int64  `json:"status,omitempty"`
+	MimeType     string `json:"mimeType,omitempty"`
+	// Source is where the response came from: network, memory-cache, disk-cache,
+	// prefetch-cache or service-worker.
+	Source          string            `json:"source,omitempty"`
+	FromCache       bool              `json:"fromCache"`
+	EncodedBytes    int64             `json:"encodedBytes"`
+	RequestHeaders  map[string]string `json:"requestHeaders,omitempty"`
+	ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+	PostData        string            `json:"postData,omitempty"`
+	Error           string            `json:"error,omitempty"`
+	StartedAt       time.Time         `json:"startedAt"`
+}
+
+// SSEEvent is a single server-sent event received by an EventSource.
+type SSEEvent struct {
+	Kind       string      `json:"kind"` // always "sse"
+	RequestID  string      `json:"requestId"`
+	URL        string      `json:"url"`
+	Event      string      `json:"event"`
+	ID         string      `json:"id,omitempty"`
+	Data       string      `json:"data"`
+	JSON       interface{} `json:"json,omitempty"` // Data decoded, when it is valid JSON
+	ReceivedAt time.Time   `json:"receivedAt"`
+}
+
+// FetchResult is the response of a fetch executed inside the page.
+type FetchResult struct {
+	URL        string            `json:"url"`
+	Status     int               `json:"status"`
+	StatusText string            `json:"statusText"`
+	OK         bool              `json:"ok"`
+	Redirected bool              `json:"redirected"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+	JSON       interface{}       `json:"json,omitempty"` // Body decoded, when the response is JSON
+}
+
+// DownloadResult describes a file saved by the download command.
+type DownloadResult struct {
+	URL         string `json:"url"`
+	FinalURL    string `json:"finalUrl"`
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Path        string `json:"path"`
+	Bytes       int    `json:"bytes"`
+	SHA256      string `json:"sha256"`
+}
+
+// GraphQLResponse is the result of a GraphQL request.
+type GraphQLResponse struct {
+	Data       interface{}   `json:"data"`
+	Errors     []interface{} `json:"errors,omitempty"`
+	Extensions interface{}   `json:"extensions,omitempty"`
+}
+
+// CapturedResponse is an XHR/fetch response captured by capture-api.
+type CapturedResponse struct {
+	URL      string      `json:"url"`
+	Method   string      `json:"method"`
+	Status   int64       `json:"status"`
+	MimeType string      `json:"mimeType"`
+	JSON     interface{} `json:"json,omitempty"`
+	Body     string      `json:"body,omitempty"` // set instead of JSON when the body is not JSON
+}
+
+// BindingCall is a call of a page binding registered by the bind command.
+type BindingCall struct {
+	Kind     string      `json:"kind"` // always "binding"
+	Name     string      `json:"name"`
+	Payload  string      `json:"payload"`
+	JSON     interface{} `json:"json,omitempty"` // Payload decoded, when it is valid JSON
+	CalledAt time.Time   `json:"calledAt"`
+}
+
+// DecodedCode is a QR code or barcode decoded from a page capture.
+type DecodedCode struct {
+	Format string `json:"format"`
+	Text   string `json:"text"`
+}
+
+// PixelColor is the rendered color at a point of the page, in viewport CSS pixels.
+type PixelColor struct {
+	X   float64 `json:"x"`
+	Y   float64 `json:"y"`
+	Hex string  `json:"hex"`
+	R   uint8   `json:"r"`
+	G   uint8   `json:"g"`
+	B   uint8   `json:"b"`
+}
+
+// SavedPage is an offline copy of a page written by save-page.
+type SavedPage struct {
+	URL       string          `json:"url"`
+	Index     string          `json:"index"` // path of the rewritten HTML file
+	Resources []SavedResource `json:"resources"`
+	Skipped   []string        `json:"skipped,omitempty"` // resources whose content was unavailable
+}
+
+// SavedResource is a subresource stored next to a saved page.
+type SavedResource struct {
+	URL  string `json:"url"`
+	Type string `json:"type"`
+	Path string `json:"path"` // relative to the saved page's directory
+}
+
+// NavigationResult describes the main document response of a navigation.
+type NavigationResult struct {
+	URL        string            `json:"url"`      // requested URL
+	FinalURL   string            `json:"finalUrl"` // URL after redirects
+	Status     int64             `json:"status"`
+	StatusText string            `json:"statusText"`
+	MimeType   string            `json:"mimeType,omitempty"`
+	Redirects  []Redirect        `json:"redirects"`
+	Headers    map[string]string `json:"headers"`
+	WaitUntil  string            `json:"waitUntil"`
+	Timing     NavigationTiming  `json:"timing"`
+	// ConsoleErrors are the errors reported during the load, when collected.
+	ConsoleErrors []ConsoleMessage `json:"consoleErrors,omitempty"`
+	// Budget is the load measured against a performance budget, when given.
+	Budget *BudgetReport `json:"budget,omitempty"`
+	// Stats are the load timings and resources the page reports, when asked for.
+	Stats *PageLoadStats `json:"stats,omitempty"`
+}
+
+// PageLoadStats are the timings of a load from the Navigation Timing API, in
+// milliseconds, and the resources it fetched from the Resource Timing API.
+// TTFBMs and the event timings count from the start of the navigation, the
+// others are durations; phases that didn't happen, such as DNS for a reused
+// connection, are 0.
+type PageLoadStats struct {
+	RedirectMs         float64        `json:"redirectMs"`
+	DNSMs              float64        `json:"dnsMs"`
+	ConnectMs          float64        `json:"connectMs"`
+	TLSMs              float64        `json:"tlsMs"`
+	TTFBMs             float64        `json:"ttfbMs"`
+	DownloadMs         float64        `json:"downloadMs"`
+	DOMInteractiveMs   float64        `json:"domInteractiveMs"`
+	DOMContentLoadedMs float64        `json:"domContentLoadedMs"`
+	LoadMs             float64        `json:"loadMs"`
+	Resources          int            `json:"resources"`
+	ResourcesByType    map[string]int `json:"resourcesByType"`
+	TransferBytes      int64          `json:"transferBytes"` // document and resources, 0 for cached or cross-origin ones without Timing-Allow-Origin
+}
+
+// Redirect is one hop of a redirect chain.
+type Redirect struct {
+	URL      string `json:"url"`
+	Status   int64  `json:"status"`
+	Location string `json:"location"`
+}
+
+// NavigationTiming holds milliseconds elapsed since the navigation started.
+type NavigationTiming struct {
+	ResponseMs         float64 `json:"responseMs"`                   // main document response headers received
+	DOMContentLoadedMs float64 `json:"domContentLoadedMs,omitempty"` // DOMContentLoaded fired
+	LoadMs             float64 `json:"loadMs,omitempty"`             // load fired
+	NetworkIdleMs      float64 `json:"networkIdleMs,omitempty"`      // network became idle
+	TotalMs            float64 `json:"totalMs"`                      // --wait-until condition reached
+}
+
+// RedirectTrace is the chain of hops traced by trace-redirects.
+type RedirectTrace struct {
+	URL      string        `json:"url"`
+	FinalURL string        `json:"finalUrl"`
+	Hops     []RedirectHop `json:"hops"`
+}
+
+// RedirectHop is one document load in a redirect chain.
+type RedirectHop struct {
+	URL        string   `json:"url"`
+	Status     int64    `json:"status"`
+	Kind       string   `json:"kind"`               // "http" (3xx), "client" (script or meta refresh) or "final"
+	Location   string   `json:"location,omitempty"` // URL of the next hop
+	SetCookies []string `json:"setCookies,omitempty"`
+	ElapsedMs  float64  `json:"elapsedMs"` // response received, since the trace started
+}
+
+// TabResult is the outcome of running a command in one tab with --all-tabs.
+type TabResult struct {
+	TabID  string      `json:"tabId"`
+	URL    string      `json:"url"`
+	Title  string      `json:"title"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// BatchReport is the combined result of a batch run.
+type BatchReport struct {
+	Steps      []BatchStep `json:"steps"`
+	Succeeded  int         `json:"succeeded"`
+	Failed     int         `json:"failed"`
+	Skipped    int         `json:"skipped"` // commands not run after a failure with --on-error stop
+	DurationMs float64     `json:"durationMs"`
+}
+
+// BatchStep is the result of one command of a batch.
+type BatchStep struct {
+	Line       int         `json:"line"`
+	Command    string      `json:"command"`
+	OK         bool        `json:"ok"`
+	Output     interface{} `json:"output,omitempty"` // stdout, parsed as JSON when possible
+	Error      string      `json:"error,omitempty"`
+	DurationMs float64     `json:"durationMs"`
+}
+
+// PluginInfo describes a discovered plugin.
+type PluginInfo struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Shadowed bool   `json:"shadowed,omitempty"` // a built-in command has the same name
+}
+
+// Types of MetricEvent.
+const (
+	MetricCommand    = "command"
+	MetricNavigation = "navigation"
+	MetricRetry      = "retry"
+)
+
+// MetricEvent is reported by a command to the metrics server.
+type MetricEvent struct {
+	Type       string  `json:"type"`
+	Command    string  `json:"command,omitempty"`
+	OK         bool    `json:"ok,omitempty"`
+	ErrorCode  string  `json:"errorCode,omitempty"` // failed commands, see logic.MetricErrorCode
+	DurationMs float64 `json:"durationMs,omitempty"`
+}
+
+// VersionInfo describes the tool and the browser it is connected to.
+type VersionInfo struct {
+	Version      string       `json:"version"`
+	GoVersion    string       `json:"goVersion"`
+	Platform     string       `json:"platform"`
+	Browser      *BrowserInfo `json:"browser,omitempty"`
+	BrowserError string       `json:"browserError,omitempty"` // why Browser is missing
+}
+
+// BrowserInfo is the version of a browser, as reported by Browser.getVersion.
+type BrowserInfo struct {
+	Product         string `json:"product"`
+	Revision        string `json:"revision"`
+	ProtocolVersion string `json:"protocolVersion"`
+	UserAgent       string `json:"userAgent"`
+	JSVersion       string `json:"jsVersion"`
+}
+
+// PingResult is the outcome of a health check of the session's browser.
+type PingResult struct {
+	OK        bool      `json:"ok"`
+	Error     string    `json:"error,omitempty"`
+	Product   string    `json:"product,omitempty"`
+	ConnectMs float64   `json:"connectMs"` // connecting to the DevTools WebSocket
+	LatencyMs float64   `json:"latencyMs"` // round trip of a Browser.getVersion call
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// DryRunPlan is what a command would do, as printed by --dry-run.
+type DryRunPlan struct {
+	Command  string         `json:"command"`
+	Args     []string       `json:"args"`
+	Browser  *DryRunBrowser `json:"browser,omitempty"` // nil for commands not using the session
+	Steps    []DryRunStep   `json:"steps"`
+	Problems []string       `json:"problems,omitempty"`
+	OK       bool           `json:"ok"`
+}
+
+// DryRunBrowser is the state of the browser session checked by --dry-run.
+type DryRunBrowser struct {
+	Connected bool   `json:"connected"`
+	OpenTabs  int    `json:"openTabs,omitempty"`
+	Tab       string `json:"tab,omitempty"` // URL of the tab selected by --target
+	Error     string `json:"error,omitempty"`
+}
+
+// DryRunStep is one action of a dry-run plan, e.g. a URL to visit or a file
+// to write.
+type DryRunStep struct {
+	Line   int    `json:"line,omitempty"` // line of the batch file or argument of pipe-line
+	Action string `json:"action"`         // visit, request, select, read, write, send or upload
+	Target string `json:"target"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// BenchSample is the timing of one page load of a benchmark, in milliseconds
+// since the navigation started. Paint timings are zero when the page didn't
+// report them.
+type BenchSample struct {
+	Run                      int     `json:"run"`
+	Status                   int64   `json:"status"`
+	ResponseMs               float64 `json:"responseMs"`
+	DOMContentLoadedMs       float64 `json:"domContentLoadedMs"`
+	LoadMs                   float64 `json:"loadMs"`
+	FirstPaintMs             float64 `json:"firstPaintMs,omitempty"`
+	FirstContentfulPaintMs   float64 `json:"firstContentfulPaintMs,omitempty"`
+	LargestContentfulPaintMs float64 `json:"largestContentfulPaintMs,omitempty"`
+	TotalMs                  float64 `json:"totalMs"`
+}
+
+// BenchStats summarizes a metric over the runs of a benchmark.
+type BenchStats struct {
+	Samples int     `json:"samples"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Mean    float64 `json:"mean"`
+	StdDev  float64 `json:"stdDev"`
+	P50     float64 `json:"p50"`
+	P95     float64 `json:"p95"`
+}
+
+// BenchComparison compares the median of a metric with a baseline.
+type BenchComparison struct {
+	Metric     string  `json:"metric"`
+	Baseline   float64 `json:"baselineP50"`
+	Current    float64 `json:"currentP50"`
+	ChangePct  float64 `json:"changePct"`
+	Regression bool    `json:"regression"`
+}
+
+// BenchReport is the result of the bench command. It is also the format of
+// stored baselines.
+type BenchReport struct {
+	URL          string                `json:"url"`
+	Mode         string                `json:"mode"` // cold or warm
+	Runs         int                   `json:"runs"`
+	Samples      []BenchSample         `json:"samples"`
+	Metrics      map[string]BenchStats `json:"metrics"`
+	Baseline     string                `json:"baseline,omitempty"` // file compared against
+	ThresholdPct float64               `json:"thresholdPct,omitempty"`
+	Comparison   []BenchComparison     `json:"comparison,omitempty"`
+	Regressions  int                   `json:"regressions,omitempty"`
+}
+
+// ConsoleMessage is a message reported by a page: a console call, an uncaught
+// exception or an entry of the browser log.
+type ConsoleMessage struct {
+	Source string `json:"source"`          // console, exception or browser
+	Level  string `json:"level,omitempty"` // e.g. log, warning or error
+	Text   string `json:"text"`
+	URL    string `json:"url,omitempty"`
+	Line   int64  `json:"line,omitempty"` // 1-based
+}
+
+// CompareSide is one of the two pages of a compare report.
+type CompareSide struct {
+	URL           string             `json:"url"`
+	FinalURL      string             `json:"finalUrl"`
+	Status        int64              `json:"status"`
+	Title         string             `json:"title"`
+	Metrics       map[string]float64 `json:"metrics"` // median of each BenchStats metric
+	ConsoleErrors []ConsoleMessage   `json:"consoleErrors"`
+	Screenshot    string             `json:"screenshot,omitempty"`
+}
+
+// MetricDelta compares a performance metric of the two pages of a compare
+// report; positive deltas mean page b is slower.
+type MetricDelta struct {
+	Metric   string  `json:"metric"`
+	A        float64 `json:"a"`
+	B        float64 `json:"b"`
+	DeltaMs  float64 `json:"deltaMs"`
+	DeltaPct float64 `json:"deltaPct"`
+}
+
+// CompareReport is the side-by-side report of the compare command.
+type CompareReport struct {
+	A                 CompareSide      `json:"a"`
+	B                 CompareSide      `json:"b"`
+	Performance       []MetricDelta    `json:"performance"`
+	Content           *SnapshotDiff    `json:"content"`
+	NewConsoleErrors  []ConsoleMessage `json:"newConsoleErrors"` // errors of b that a doesn't report
+	ScreenshotDiffPct *float64         `json:"screenshotDiffPct,omitempty"`
+}
+
+// FailureReport describes a failed command and the page it left behind, as
+// saved by --on-failure-artifacts. The artifact fields name the saved files.
+type FailureReport struct {
+	Command    string           `json:"command"`
+	Error      string           `json:"error"`
+	Time       time.Time        `json:"time"`
+	URL        string           `json:"url,omitempty"`
+	Title      string           `json:"title,omitempty"`
+	Screenshot string           `json:"screenshot,omitempty"`
+	HTML       string           `json:"html,omitempty"`
+	Console    []ConsoleMessage `json:"console"`            // latest messages, oldest first
+	Problems   []string         `json:"problems,omitempty"` // artifacts that couldn't be saved
+}
+
+// HeaderAudit scores the security headers of a page's main document response.
+type HeaderAudit struct {
+	URL      string        `json:"url"`
+	FinalURL string        `json:"finalUrl"`
+	Status   int64         `json:"status"`
+	Score    int           `json:"score"`
+	MaxScore int           `json:"maxScore"`
+	Grade    string        `json:"grade"` // A to F
+	Checks   []HeaderCheck `json:"checks"`
+}
+
+// HeaderCheck is the score of one security header.
+type HeaderCheck struct {
+	Header   string   `json:"header"`
+	Present  bool     `json:"present"`
+	Value    string   `json:"value,omitempty"`
+	Score    int      `json:"score"`
+	MaxScore int      `json:"maxScore"`
+	Notes    []string `json:"notes,omitempty"` // why points were withheld
+}
+
+// MixedContentAudit lists the insecure (HTTP) subresources of an HTTPS page.
+type MixedContentAudit struct {
+	URL      string `json:"url"`
+	FinalURL string `json:"finalUrl"`
+	// SecurityState is the state Chrome shows for the page, e.g. secure or
+	// neutral, and SecurityIssues the reasons it isn't secure, such as
+	// ran-mixed-content.
+	SecurityState  string                `json:"securityState,omitempty"`
+	SecurityIssues []string              `json:"securityIssues,omitempty"`
+	Loaded         int                   `json:"loaded"`
+	Blocked        int                   `json:"blocked"`
+	Upgraded       int                   `json:"upgraded"`
+	Requests       []MixedContentRequest `json:"requests"`
+}
+
+// MixedContentRequest is an insecure subresource request of an HTTPS page.
+type MixedContentRequest struct {
+	URL          string `json:"url"`
+	ResourceType string `json:"resourceType,omitempty"`
+	// Resolution is what Chrome did with it: loaded (over HTTP), blocked or
+	// upgraded (to HTTPS).
+	Resolution string `json:"resolution"`
+	Initiator  string `json:"initiator,omitempty"` // URL of the document that requested it
+}
+
+// CertificateInfo describes the TLS connection and certificate of a page.
+type CertificateInfo struct {
+	URL              string    `json:"url"`
+	FinalURL         string    `json:"finalUrl"`
+	Subject          string    `json:"subject"`
+	Issuer           string    `json:"issuer"`
+	SANs             []string  `json:"sans"`
+	ValidFrom        time.Time `json:"validFrom"`
+	ValidTo          time.Time `json:"validTo"`
+	DaysUntilExpiry  int       `json:"daysUntilExpiry"` // negative once expired
+	Expired          bool      `json:"expired"`
+	Protocol         string    `json:"protocol"`              // e.g. TLS 1.3 or QUIC
+	Cipher           string    `json:"cipher"`                // e.g. AES_128_GCM
+	KeyExchange      string    `json:"keyExchange,omitempty"` // empty for TLS 1.3
+	KeyExchangeGroup string    `json:"keyExchangeGroup,omitempty"`
+	// CertificateTransparency is Chrome's verdict on the certificate's
+	// compliance with the Certificate Transparency policy.
+	CertificateTransparency string `json:"certificateTransparency"`
+}
+
+// AssetAudit lists the missing or broken assets of a page, grouped by
+// resource type such as Image, Script or Stylesheet.
+type AssetAudit struct {
+	URL      string                   `json:"url"`
+	FinalURL string                   `json:"finalUrl"`
+	Requests int                      `json:"requests"` // subresource requests made
+	Broken   int                      `json:"broken"`
+	ByType   map[string][]BrokenAsset `json:"byType"`
+}
+
+// BrokenAsset is a subresource that failed: an HTTP error status, a failed
+// load such as a DNS error, or an image the browser couldn't decode.
+type BrokenAsset struct {
+	URL    string `json:"url"`
+	Status int64  `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Resource is a request made by a page load, with the bytes it took.
+type Resource struct {
+	URL           string `json:"url"`
+	Type          string `json:"type"` // resource type, e.g. Script or Image
+	Status        int64  `json:"status,omitempty"`
+	TransferBytes int64  `json:"transferBytes"` // received over the network, headers included
+	DecodedBytes  int64  `json:"decodedBytes"`  // body size after decompression
+	Failed        bool   `json:"failed,omitempty"`
+}
+
+// Budget is a performance budget for a page load. Zero limits aren't checked.
+type Budget struct {
+	MaxRequests int `json:"maxRequests,omitempty"`
+	// MaxTransferBytes limits the bytes transferred by lowercase resource
+	// type, e.g. script or image, and in "total".
+	MaxTransferBytes map[string]int64 `json:"maxTransferBytes,omitempty"`
+	MaxLCPMs         float64          `json:"maxLcpMs,omitempty"`
+	MaxCLS           float64          `json:"maxCls,omitempty"`
+}
+
+// BudgetReport is what a page load measured against a Budget.
+type BudgetReport struct {
+	Requests      int               `json:"requests"`
+	TransferBytes map[string]int64  `json:"transferBytes"` // by lowercase resource type, and "total"
+	LCPMs         float64           `json:"lcpMs"`
+	CLS           float64           `json:"cls"`
+	Violations    []BudgetViolation `json:"violations"`
+}
+
+// BudgetViolation is a budget a page load exceeded.
+type BudgetViolation struct {
+	Metric string  `json:"metric"` // e.g. requests, transferBytes.script, lcpMs or cls
+	Limit  float64 `json:"limit"`
+	Actual float64 `json:"actual"`
+}
+
+// PageWeight breaks down the bytes a page load took.
+type PageWeight struct {
+	URL           string        `json:"url"`
+	FinalURL      string        `json:"finalUrl"`
+	Requests      int           `json:"requests"`
+	TransferBytes int64         `json:"transferBytes"`
+	DecodedBytes  int64         `json:"decodedBytes"`
+	ByType        []WeightGroup `json:"byType"`   // heaviest first
+	ByDomain      []WeightGroup `json:"byDomain"` // heaviest first
+	Largest       []Resource    `json:"largest"`  // heaviest resources first
+}
+
+// WeightGroup sums the resources of a type or a domain.
+type WeightGroup struct {
+	Name          string `json:"name"`
+	Requests      int    `json:"requests"`
+	TransferBytes int64  `json:"transferBytes"`
+	DecodedBytes  int64  `json:"decodedBytes"`
+}
+
+// ThirdPartyAudit splits the requests of a page load between its own site
+// (first party) and the other sites it loads from.
+type ThirdPartyAudit struct {
+	URL          string            `json:"url"`
+	FinalURL     string            `json:"finalUrl"`
+	FirstParty   ThirdPartyUsage   `json:"firstParty"`
+	ThirdParties []ThirdPartyUsage `json:"thirdParties"` // most blocking first
+}
+
+// ThirdPartyUsage sums the requests of a site, identified by its registrable
+// domain (eTLD+1).
+type ThirdPartyUsage struct {
+	Domain        string   `json:"domain"`
+	Hosts         []string `json:"hosts"`
+	Requests      int      `json:"requests"`
+	TransferBytes int64    `json:"transferBytes"`
+	// BlockingMs is the main-thread time its scripts ran in long animation
+	// frames, those that kept the page from responding for over 50 ms.
+	BlockingMs float64 `json:"blockingMs"`
+}
+
+// LoginResult reports a login run from a recipe.
+type LoginResult struct {
+	Site     string `json:"site"`
+	FinalURL string `json:"finalUrl"`
+	// MFA is true when the site asked for a verification code.
+	MFA        bool    `json:"mfa"`
+	DurationMs float64 `json:"durationMs"`
+}
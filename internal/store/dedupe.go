@@ -0,0 +1,148 @@
+// Package store provides persistent state shared across runs of the tool.
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// DedupeStore remembers keys (URLs, item IDs, content hashes) seen in previous
+// runs so they can be skipped. Keys are grouped in namespaces and expire after TTL.
+type DedupeStore struct {
+	db  *bolt.DB
+	ttl time.Duration
+	now func() time.Time
+}
+
+// OpenDedupeStore opens (creating if needed) the store at path. A zero ttl keeps
+// keys forever; otherwise keys older than ttl are treated as unseen and purged.
+func OpenDedupeStore(path string, ttl time.Duration) (*DedupeStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dedupe store %s: %w", path, err)
+	}
+	s := &DedupeStore{db: db, ttl: ttl, now: time.Now}
+	if err := s.purgeExpired(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close closes the underlying database.
+func (s *DedupeStore) Close() error {
+	return s.db.Close()
+}
+
+// Seen reports whether key was marked in namespace and has not expired.
+func (s *DedupeStore) Seen(namespace, key string) (bool, error) {
+	seen := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(namespace))
+		if bucket == nil {
+			return nil
+		}
+		if value := bucket.Get([]byte(key)); value != nil {
+			seen = !s.expired(value)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to read dedupe store: %w", err)
+	}
+	return seen, nil
+}
+
+// Mark records keys in namespace with the current time.
+func (s *DedupeStore) Mark(namespace string, keys ...string) error {
+	stamp := make([]byte, 8)
+	binary.BigEndian.PutUint64(stamp, uint64(s.now().Unix()))
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(namespace))
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := bucket.Put([]byte(key), stamp); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write dedupe store: %w", err)
+	}
+	return nil
+}
+
+// FilterUnseen returns the indexes of keys not yet seen in namespace and marks
+// them as seen, so a later run skips them.
+func (s *DedupeStore) FilterUnseen(namespace string, keys []string) ([]int, error) {
+	var fresh []int
+	var freshKeys []string
+	batch := map[string]bool{}
+	for i, key := range keys {
+		if batch[key] {
+			continue
+		}
+		seen, err := s.Seen(namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		if !seen {
+			fresh = append(fresh, i)
+			freshKeys = append(freshKeys, key)
+			batch[key] = true
+		}
+	}
+	if len(freshKeys) > 0 {
+		if err := s.Mark(namespace, freshKeys...); err != nil {
+			return nil, err
+		}
+	}
+	return fresh, nil
+}
+
+// expired reports whether a stored timestamp is older than the TTL.
+func (s *DedupeStore) expired(value []byte) bool {
+	if s.ttl <= 0 || len(value) != 8 {
+		return false
+	}
+	marked := time.Unix(int64(binary.BigEndian.Uint64(value)), 0)
+	return s.now().Sub(marked) > s.ttl
+}
+
+// purgeExpired deletes every expired key.
+func (s *DedupeStore) purgeExpired() error {
+	if s.ttl <= 0 {
+		return nil
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			var stale [][]byte
+			err := bucket.ForEach(func(k, v []byte) error {
+				if s.expired(v) {
+					stale = append(stale, append([]byte(nil), k...))
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, k := range stale {
+				if err := bucket.Delete(k); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to purge expired dedupe entries: %w", err)
+	}
+	return nil
+}
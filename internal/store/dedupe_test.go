@@ -0,0 +1,78 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T, path string, ttl time.Duration) *DedupeStore {
+	t.Helper()
+	s, err := OpenDedupeStore(path, ttl)
+	if err != nil {
+		t.Fatalf("OpenDedupeStore failed: %v", err)
+	}
+	return s
+}
+
+// TestDedupeStore_FilterUnseen は既出キーが次回以降スキップされることをテストします。
+func TestDedupeStore_FilterUnseen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dedupe.db")
+
+	s := openTestStore(t, path, 0)
+	fresh, err := s.FilterUnseen("search", []string{"a", "b", "a"})
+	if err != nil {
+		t.Fatalf("FilterUnseen failed: %v", err)
+	}
+	if len(fresh) != 2 || fresh[0] != 0 || fresh[1] != 1 {
+		t.Errorf("Expected indexes [0 1], got %v", fresh)
+	}
+	s.Close()
+
+	// 別の実行でも記録が残っていること
+	s = openTestStore(t, path, 0)
+	defer s.Close()
+	fresh, err = s.FilterUnseen("search", []string{"a", "c"})
+	if err != nil {
+		t.Fatalf("FilterUnseen failed: %v", err)
+	}
+	if len(fresh) != 1 || fresh[0] != 1 {
+		t.Errorf("Expected only 'c' to be fresh, got %v", fresh)
+	}
+
+	// 名前空間は独立している
+	seen, err := s.Seen("hn-scraper", "a")
+	if err != nil || seen {
+		t.Errorf("Expected key to be unseen in another namespace, got seen=%t err=%v", seen, err)
+	}
+}
+
+// TestDedupeStore_TTL は期限切れのキーが未出扱いになることをテストします。
+func TestDedupeStore_TTL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dedupe.db")
+	s := openTestStore(t, path, time.Hour)
+	defer s.Close()
+
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	if err := s.Mark("watch", "hash"); err != nil {
+		t.Fatalf("Mark failed: %v", err)
+	}
+
+	if seen, _ := s.Seen("watch", "hash"); !seen {
+		t.Error("Expected key to be seen within TTL")
+	}
+
+	s.now = func() time.Time { return now.Add(2 * time.Hour) }
+	if seen, _ := s.Seen("watch", "hash"); seen {
+		t.Error("Expected key to expire after TTL")
+	}
+
+	if err := s.purgeExpired(); err != nil {
+		t.Fatalf("purgeExpired failed: %v", err)
+	}
+	s.now = func() time.Time { return now }
+	if seen, _ := s.Seen("watch", "hash"); seen {
+		t.Error("Expected expired key to be purged")
+	}
+}
@@ -0,0 +1,211 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiffOp is the kind of change a diff line represents.
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffDelete
+	DiffInsert
+)
+
+// DiffLine is a single line of a diff.
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// maxDiffCells caps the LCS table size; larger inputs are diffed as a full replacement.
+const maxDiffCells = 4_000_000
+
+// DiffLines computes a line-based edit script turning a into b.
+func DiffLines(a, b []string) []DiffLine {
+	// Trim the common prefix and suffix so the LCS table only covers the changed middle.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]DiffLine, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		result = append(result, DiffLine{Op: DiffEqual, Text: line})
+	}
+	result = append(result, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		result = append(result, DiffLine{Op: DiffEqual, Text: line})
+	}
+	return result
+}
+
+// diffMiddle diffs two slices with a longest-common-subsequence table.
+func diffMiddle(a, b []string) []DiffLine {
+	var result []DiffLine
+	if len(a)*len(b) > maxDiffCells {
+		for _, line := range a {
+			result = append(result, DiffLine{Op: DiffDelete, Text: line})
+		}
+		for _, line := range b {
+			result = append(result, DiffLine{Op: DiffInsert, Text: line})
+		}
+		return result
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, DiffLine{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, DiffLine{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			result = append(result, DiffLine{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		result = append(result, DiffLine{Op: DiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		result = append(result, DiffLine{Op: DiffInsert, Text: b[j]})
+	}
+	return result
+}
+
+// DiffStats returns the number of inserted and deleted lines.
+func DiffStats(lines []DiffLine) (added, removed int) {
+	for _, line := range lines {
+		switch line.Op {
+		case DiffInsert:
+			added++
+		case DiffDelete:
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// UnifiedDiff renders the difference between two texts in unified diff format
+// with the given number of context lines. It returns an empty string when the
+// texts are identical.
+func UnifiedDiff(fromName, toName, a, b string, context int) string {
+	lines := DiffLines(SplitLines(a), SplitLines(b))
+	if added, removed := DiffStats(lines); added == 0 && removed == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	// Walk the edit script, emitting a hunk for each run of changes plus context.
+	for start := 0; start < len(lines); {
+		first := start
+		for first < len(lines) && lines[first].Op == DiffEqual {
+			first++
+		}
+		if first == len(lines) {
+			break
+		}
+
+		hunkStart := first - context
+		if hunkStart < start {
+			hunkStart = start
+		}
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+
+		// Extend the hunk while the gap between changes is within 2*context lines.
+		hunkEnd := first
+		for hunkEnd < len(lines) {
+			if lines[hunkEnd].Op != DiffEqual {
+				hunkEnd++
+				continue
+			}
+			gap := hunkEnd
+			for gap < len(lines) && lines[gap].Op == DiffEqual {
+				gap++
+			}
+			if gap == len(lines) || gap-hunkEnd > 2*context {
+				hunkEnd += minInt(context, gap-hunkEnd)
+				break
+			}
+			hunkEnd = gap
+		}
+
+		fromLine, toLine := lineNumbers(lines[:hunkStart])
+		fromCount, toCount := lineNumbers(lines[hunkStart:hunkEnd])
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", fromLine+1, fromCount, toLine+1, toCount)
+		for _, line := range lines[hunkStart:hunkEnd] {
+			switch line.Op {
+			case DiffEqual:
+				sb.WriteString(" ")
+			case DiffDelete:
+				sb.WriteString("-")
+			case DiffInsert:
+				sb.WriteString("+")
+			}
+			sb.WriteString(line.Text)
+			sb.WriteString("\n")
+		}
+		start = hunkEnd
+	}
+
+	return sb.String()
+}
+
+// lineNumbers counts how many lines of the old and new text the given diff lines span.
+func lineNumbers(lines []DiffLine) (from, to int) {
+	for _, line := range lines {
+		if line.Op != DiffInsert {
+			from++
+		}
+		if line.Op != DiffDelete {
+			to++
+		}
+	}
+	return from, to
+}
+
+// SplitLines splits text into lines without a trailing empty element.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
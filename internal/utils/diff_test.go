@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDiffLines は行単位の差分計算をテストします
+func TestDiffLines(t *testing.T) {
+	a := []string{"a", "b", "c", "d"}
+	b := []string{"a", "x", "c", "d", "e"}
+
+	lines := DiffLines(a, b)
+	added, removed := DiffStats(lines)
+	if added != 2 || removed != 1 {
+		t.Errorf("Expected 2 added and 1 removed, got %d added and %d removed", added, removed)
+	}
+
+	var rebuilt []string
+	for _, line := range lines {
+		if line.Op != DiffDelete {
+			rebuilt = append(rebuilt, line.Text)
+		}
+	}
+	if strings.Join(rebuilt, ",") != strings.Join(b, ",") {
+		t.Errorf("Applying the diff should yield %v, got %v", b, rebuilt)
+	}
+}
+
+// TestDiffLines_Identical は同一入力で変更がないことをテストします
+func TestDiffLines_Identical(t *testing.T) {
+	lines := DiffLines([]string{"same", "text"}, []string{"same", "text"})
+	if added, removed := DiffStats(lines); added != 0 || removed != 0 {
+		t.Errorf("Expected no changes, got %d added and %d removed", added, removed)
+	}
+}
+
+// TestUnifiedDiff はunified diff形式の出力をテストします
+func TestUnifiedDiff(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	b := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n"
+
+	got := UnifiedDiff("old", "new", a, b, 1)
+	expected := "--- old\n+++ new\n@@ -4,3 +4,3 @@\n 4\n-5\n+five\n 6\n"
+	if got != expected {
+		t.Errorf("Unexpected unified diff:\n%s\nwant:\n%s", got, expected)
+	}
+
+	if UnifiedDiff("old", "new", a, a, 3) != "" {
+		t.Error("Expected empty diff for identical texts")
+	}
+}
+
+// TestUnifiedDiff_SeparateHunks は離れた変更が別のハンクになることをテストします
+func TestUnifiedDiff_SeparateHunks(t *testing.T) {
+	a := "a\nb\nc\nd\ne\nf\ng\nh\n"
+	b := "A\nb\nc\nd\ne\nf\ng\nH\n"
+
+	got := UnifiedDiff("old", "new", a, b, 1)
+	if strings.Count(got, "@@ -") != 2 {
+		t.Errorf("Expected two hunks, got:\n%s", got)
+	}
+}
@@ -54,8 +54,8 @@ func ValidateFilePath(path string, allowAbsolute bool, baseDir string) (string,
 	// 4. クリーンなパスに変換
 	cleanPath := filepath.Clean(path)
 
-	// 5. 親ディレクトリ参照の検出
-	if hasPathTraversal(cleanPath) {
+	// 5. 親ディレクトリ参照の検出（許可された絶対パスは除く）
+	if hasPathTraversal(cleanPath) && !(allowAbsolute && filepath.IsAbs(cleanPath)) {
 		return "", ErrPathTraversal
 	}
 
@@ -71,7 +71,7 @@ func TestValidateFilePath_ValidRelativePath(t *testing.T) {
 	}{
 		{"test.txt", "."},
 		{"subdir/test.txt", "."},
-		{"../test.txt", ".."},
+		{"./test.txt", "."},
 		{"dir/subdir/file.txt", "."},
 	}
 
@@ -89,23 +89,29 @@ func TestValidateFilePath_ValidRelativePath(t *testing.T) {
 }
 
 // TestValidateFilePath_BaseDirSecurity はベースディレクトリ外へのアクセスを防ぐことをテストします。
+// パスは作業ディレクトリからの相対パスとして解決されます。
 func TestValidateFilePath_BaseDirSecurity(t *testing.T) {
-	tmpDir := t.TempDir()
-	baseDir := filepath.Join(tmpDir, "safe")
+	t.Chdir(t.TempDir())
+	baseDir := "safe"
 
 	// ベースディレクトリ外へのパス
-	_, err := ValidateFilePath("../escape.txt", false, baseDir)
+	_, err := ValidateFilePath("other/escape.txt", false, baseDir)
 	if err != ErrOutsideWorkingDir {
 		t.Errorf("Expected ErrOutsideWorkingDir, got %v", err)
 	}
 
+	// 親ディレクトリ参照はベースディレクトリの検証より先に拒否される
+	if _, err := ValidateFilePath("../escape.txt", false, baseDir); err != ErrPathTraversal {
+		t.Errorf("Expected ErrPathTraversal, got %v", err)
+	}
+
 	// ベースディレクトリ内へのパス
-	result, err := ValidateFilePath("safe.txt", false, baseDir)
+	result, err := ValidateFilePath("safe/safe.txt", false, baseDir)
 	if err != nil {
 		t.Errorf("Expected no error for safe path, got %v", err)
 	}
-	if result != "safe.txt" {
-		t.Errorf("Expected 'safe.txt', got %s", result)
+	if result != filepath.Join("safe", "safe.txt") {
+		t.Errorf("Expected 'safe/safe.txt', got %s", result)
 	}
 }
 
@@ -169,10 +175,11 @@ func TestValidateScreenshotPath_PathTraversal(t *testing.T) {
 // TestSecureWriteFile_Success はSecureWriteFileが正常に動作することをテストします。
 func TestSecureWriteFile_Success(t *testing.T) {
 	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
 	filePath := "testfile.txt"
 	data := []byte("test data")
 
-	err := SecureWriteFile(filePath, data, 0644, tmpDir)
+	err := SecureWriteFile(filePath, data, 0644, ".")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -192,12 +199,16 @@ func TestSecureWriteFile_Success(t *testing.T) {
 // TestSecureWriteFile_PathTraversal はSecureWriteFileがパストラバーサルを防ぐことをテストします。
 func TestSecureWriteFile_PathTraversal(t *testing.T) {
 	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
 	filePath := "../escape.txt"
 	data := []byte("malicious data")
 
-	err := SecureWriteFile(filePath, data, 0644, tmpDir)
-	if err != ErrOutsideWorkingDir {
-		t.Errorf("Expected ErrOutsideWorkingDir, got %v", err)
+	err := SecureWriteFile(filePath, data, 0644, ".")
+	if err != ErrPathTraversal {
+		t.Errorf("Expected ErrPathTraversal, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(tmpDir), "escape.txt")); !os.IsNotExist(err) {
+		t.Error("Expected no file to be written outside the working directory")
 	}
 }
 
@@ -245,9 +256,10 @@ func TestValidateFilePathLenient(t *testing.T) {
 // TestGetSafeAbsolutePath_Success はGetSafeAbsolutePathが正常に動作することをテストします。
 func TestGetSafeAbsolutePath_Success(t *testing.T) {
 	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
 	relPath := "test.txt"
 
-	absPath, err := GetSafeAbsolutePath(relPath, tmpDir)
+	absPath, err := GetSafeAbsolutePath(relPath, ".")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -261,11 +273,12 @@ func TestGetSafeAbsolutePath_Success(t *testing.T) {
 // TestGetSafeAbsolutePath_PathTraversal はGetSafeAbsolutePathがパストラバーサルを防ぐことをテストします。
 func TestGetSafeAbsolutePath_PathTraversal(t *testing.T) {
 	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
 	relPath := "../escape.txt"
 
-	_, err := GetSafeAbsolutePath(relPath, tmpDir)
-	if err != ErrOutsideWorkingDir {
-		t.Errorf("Expected ErrOutsideWorkingDir, got %v", err)
+	_, err := GetSafeAbsolutePath(relPath, ".")
+	if err != ErrPathTraversal {
+		t.Errorf("Expected ErrPathTraversal, got %v", err)
 	}
 }
 
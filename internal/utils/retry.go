@@ -3,9 +3,10 @@ package utils
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"time"
+
+	"browser-tools-go/internal/i18n"
 )
 
 // RetryableError はリトライ可能なエラーを示します
@@ -96,7 +97,7 @@ func DefaultIsRetryable(err error) bool {
 
 // DefaultOnRetry はデフォルトのリトライコールバックです
 func DefaultOnRetry(attempt int, err error) {
-	log.Printf("Retry attempt %d after error: %v", attempt, err)
+	i18n.Printf("Retry attempt %d after error: %v", attempt, err)
 }
 
 // Retry は指定された関数をリトライ設定に従って実行します
@@ -108,6 +109,13 @@ func Retry(ctx context.Context, fn func() error, config *RetryConfig) error {
 	var lastErr error
 
 	for attempt := 0; ; attempt++ {
+		// キャンセル済みのコンテキストでは実行しない
+		if attempt == 0 {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("retry canceled: %w", err)
+			}
+		}
+
 		// 関数実行
 		err := fn()
 		if err == nil {
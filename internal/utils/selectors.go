@@ -12,6 +12,7 @@ import (
 type SelectorConfig struct {
 	GoogleSearch *GoogleSearchSelectors `json:"google_search"`
 	HackerNews   *HackerNewsSelectors   `json:"hacker_news"`
+	Content      *ContentSelectors      `json:"content"`
 }
 
 // GoogleSearchSelectors はGoogle検索のセレクタ定義です
@@ -35,6 +36,11 @@ type HackerNewsSelectors struct {
 	FallbackWait    []string `json:"fallback_wait"`
 }
 
+// ContentSelectors はコンテンツ抽出対象領域のセレクタ定義です
+type ContentSelectors struct {
+	Main []string `json:"main"`
+}
+
 // DefaultSelectorConfig はデフォルトのセレクタ設定です
 func DefaultSelectorConfig() *SelectorConfig {
 	return &SelectorConfig{
@@ -55,6 +61,9 @@ func DefaultSelectorConfig() *SelectorConfig {
 			Comments:     []string{"td.subtext > a:last-child", "a[href*=\"item?id=\"]"},
 			FallbackWait: []string{"table.itemlist", "body"},
 		},
+		Content: &ContentSelectors{
+			Main: []string{"main", "article", "[role=\"main\"]", "#content"},
+		},
 	}
 }
 
@@ -131,6 +140,12 @@ func (c *SelectorConfig) mergeWithDefaults() {
 	} else {
 		c.HackerNews = mergeHackerNewsSelectors(c.HackerNews, defaults.HackerNews)
 	}
+
+	if c.Content == nil {
+		c.Content = defaults.Content
+	} else if len(c.Content.Main) == 0 {
+		c.Content.Main = defaults.Content.Main
+	}
 }
 
 func mergeGoogleSearchSelectors(current, defaults *GoogleSearchSelectors) *GoogleSearchSelectors {
@@ -209,18 +224,18 @@ func GenerateAlternativeSelectors(baseSelector string) []string {
 	alternatives := []string{baseSelector}
 
 	// セレクタを解析して代替案を生成
-	parts := strings.Split(baseSelector, " ")
+	parts := strings.Fields(baseSelector)
+	if len(parts) == 0 {
+		return alternatives
+	}
+	lastPart := parts[len(parts)-1]
 	if len(parts) > 1 {
 		// 最後の要素のみ
-		alternatives = append(alternatives, parts[len(parts)-1])
-		// クラス指定なし
-		lastPart := parts[len(parts)-1]
-		if strings.Contains(lastPart, ".") || strings.Contains(lastPart, "#") {
-			tag := strings.TrimRight(lastPart, ".#0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ_-")
-			if tag != "" {
-				alternatives = append(alternatives, tag)
-			}
-		}
+		alternatives = append(alternatives, lastPart)
+	}
+	// クラス・ID指定なし
+	if i := strings.IndexAny(lastPart, ".#"); i > 0 {
+		alternatives = append(alternatives, lastPart[:i])
 	}
 
 	return alternatives
@@ -247,9 +262,9 @@ func ValidateSelectorSyntax(selector string) error {
 
 // FormatSelectorForJS はセレクタをJavaScriptで安全に扱える形式にエスケープします
 func FormatSelectorForJS(selector string) string {
+	// バックスラッシュを先にエスケープ（クォートのエスケープを二重にしないため）
+	escaped := strings.ReplaceAll(selector, `\`, `\\`)
 	// シングルクォートをエスケープ
-	escaped := strings.ReplaceAll(selector, `'`, `\'`)
-	// バックスラッシュをエスケープ
-	escaped = strings.ReplaceAll(escaped, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, `'`, `\'`)
 	return escaped
 }
\ No newline at end of file
@@ -120,6 +120,24 @@ func TestMergeWithDefaults(t *testing.T) {
 	}
 }
 
+// TestMergeWithDefaults_Content はコンテンツセレクタのマージをテストします
+func TestMergeWithDefaults_Content(t *testing.T) {
+	config := &SelectorConfig{
+		Content: &ContentSelectors{Main: []string{"div.post"}},
+	}
+	config.mergeWithDefaults()
+
+	if len(config.Content.Main) != 1 || config.Content.Main[0] != "div.post" {
+		t.Errorf("Custom content selectors should be preserved, got %v", config.Content.Main)
+	}
+
+	empty := &SelectorConfig{}
+	empty.mergeWithDefaults()
+	if empty.Content == nil || len(empty.Content.Main) == 0 {
+		t.Error("Content selectors should be populated from defaults")
+	}
+}
+
 // TestFirstMatchingSelector はFirstMatchingSelector関数をテストします
 func TestFirstMatchingSelector(t *testing.T) {
 	tests := []struct {
@@ -324,7 +342,7 @@ func TestFormatSelectorForJS(t *testing.T) {
 		{
 			name:     "complex selector",
 			input:    `div[class='test' data-value='\test']`,
-			expected: `div[class=\\'test\\' data-value=\\'\\\\test\\']`,
+			expected: `div[class=\'test\' data-value=\'\\test\']`,
 		},
 	}
 